@@ -33,6 +33,8 @@ var (
 	domain     = flag.String("domain", "", "the domain name to verify")
 	token      = flag.String("token", "", "the challenge token to verify against")
 	key        = flag.String("key", "", "the challenge key to respond with")
+	tokenDir   = flag.String("token-dir", "", "a directory containing a file per challenge token, containing the key to respond with. "+
+		"If set, all tokens in the directory are served and --domain, --token and --key are ignored")
 )
 
 func main() {
@@ -46,6 +48,7 @@ func main() {
 		Domain:     *domain,
 		Token:      *token,
 		Key:        *key,
+		TokenDir:   *tokenDir,
 	}
 
 	if err := s.Listen(ctx); err != nil {
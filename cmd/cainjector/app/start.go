@@ -17,13 +17,15 @@ limitations under the License.
 package app
 
 import (
+	"context"
 	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
-	"k8s.io/klog"
+	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/jetstack/cert-manager/pkg/api"
@@ -32,9 +34,15 @@ import (
 )
 
 type InjectorControllerOptions struct {
-	Namespace               string
-	LeaderElect             bool
-	LeaderElectionNamespace string
+	Namespace                string
+	LeaderElect              bool
+	LeaderElectionNamespace  string
+	ClusterResourceNamespace string
+
+	// UnstructuredTargets are the kinds of resources, in the form
+	// 'Kind.version.group', that CA data can be injected into at the field
+	// named by the inject-ca-path annotation.
+	UnstructuredTargets []string
 
 	StdOut io.Writer
 	StdErr io.Writer
@@ -51,6 +59,27 @@ func (o *InjectorControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.LeaderElectionNamespace, "leader-election-namespace", "", ""+
 		"Namespace used to perform leader election (defaults to controller's namespace). "+
 		"Only used if leader election is enabled")
+	fs.StringVar(&o.ClusterResourceNamespace, "cluster-resource-namespace", "kube-system", ""+
+		"Namespace that resources owned by cluster scoped resources such as ClusterIssuer are stored in. "+
+		"Used to read the CA of ClusterIssuers referenced by the 'cert-manager.io/inject-ca-from-issuer' annotation.")
+	fs.StringSliceVar(&o.UnstructuredTargets, "unstructured-targets", nil, ""+
+		"Kinds of resources, in the form 'Kind.version.group', that CA data can be injected into. "+
+		"CA data is injected into the field of the resource named by the "+
+		"'cert-manager.io/inject-ca-path' annotation, for example '.spec.caBundle'. "+
+		"cainjector must be granted permission to get, list, watch and update these resources.")
+}
+
+// unstructuredKinds parses the UnstructuredTargets option.
+func (o InjectorControllerOptions) unstructuredKinds() ([]schema.GroupVersionKind, error) {
+	var kinds []schema.GroupVersionKind
+	for _, target := range o.UnstructuredTargets {
+		gvk, _ := schema.ParseKindArg(target)
+		if gvk == nil {
+			return nil, fmt.Errorf("invalid unstructured target %q, must be of the form 'Kind.version.group'", target)
+		}
+		kinds = append(kinds, *gvk)
+	}
+	return kinds, nil
 }
 
 func NewInjectorControllerOptions(out, errOut io.Writer) *InjectorControllerOptions {
@@ -71,7 +100,7 @@ func NewCommandStartInjectorController(out, errOut io.Writer, stopCh <-chan stru
 		Short: fmt.Sprintf("CA Injection Controller for Kubernetes (%s) (%s)", util.AppVersion, util.AppGitCommit),
 		Long: `
 cert-manager CA injector is a Kubernetes addon to automate the injection of CA data into
-webhooks and APIServices from cert-manager certificates.
+webhooks, APIServices, CRDs and ConfigMaps from cert-manager certificates.
 
 It will ensure that annotated webhooks and API services always have the correct
 CA data from the referenced certificates, which can then be used to serve API
@@ -91,20 +120,28 @@ servers and webhook servers.`,
 }
 
 func (o InjectorControllerOptions) RunInjectorController(stopCh <-chan struct{}) {
+	// the controller-runtime managers are stopped by cancelling a context
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
 	eitherStopCh := make(chan struct{})
 	go func() {
 		defer close(eitherStopCh)
-		o.runCertificateBasedInjector(stopCh)
+		o.runCertificateBasedInjector(ctx)
 	}()
 	go func() {
 		defer close(eitherStopCh)
-		o.runSecretBasedInjector(stopCh)
+		o.runSecretBasedInjector(ctx)
 	}()
 
 	<-eitherStopCh
 }
 
-func (o InjectorControllerOptions) runCertificateBasedInjector(stopCh <-chan struct{}) {
+func (o InjectorControllerOptions) runCertificateBasedInjector(ctx context.Context) {
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                  api.Scheme,
 		Namespace:               o.Namespace,
@@ -118,17 +155,22 @@ func (o InjectorControllerOptions) runCertificateBasedInjector(stopCh <-chan str
 		klog.Fatalf("error creating manager: %v", err)
 	}
 
+	unstructuredKinds, err := o.unstructuredKinds()
+	if err != nil {
+		klog.Fatalf("error parsing options: %v", err)
+	}
+
 	// TODO(directxman12): enabled controllers for separate injectors?
-	if err := cainjector.RegisterCertificateBased(mgr); err != nil {
+	if err := cainjector.RegisterCertificateBased(mgr, o.ClusterResourceNamespace, unstructuredKinds...); err != nil {
 		klog.Fatalf("error registering controllers: %v", err)
 	}
 
-	if err := mgr.Start(stopCh); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		klog.Fatalf("error running manager: %v", err)
 	}
 }
 
-func (o InjectorControllerOptions) runSecretBasedInjector(stopCh <-chan struct{}) {
+func (o InjectorControllerOptions) runSecretBasedInjector(ctx context.Context) {
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                  api.Scheme,
 		Namespace:               o.Namespace,
@@ -142,12 +184,17 @@ func (o InjectorControllerOptions) runSecretBasedInjector(stopCh <-chan struct{}
 		klog.Fatalf("error creating core-only manager: %v", err)
 	}
 
+	unstructuredKinds, err := o.unstructuredKinds()
+	if err != nil {
+		klog.Fatalf("error parsing options: %v", err)
+	}
+
 	// TODO(directxman12): enabled controllers for separate injectors?
-	if err := cainjector.RegisterSecretBased(mgr); err != nil {
+	if err := cainjector.RegisterSecretBased(mgr, unstructuredKinds...); err != nil {
 		klog.Fatalf("error registering core-only controllers: %v", err)
 	}
 
-	if err := mgr.Start(stopCh); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		klog.Fatalf("error running core-only manager: %v", err)
 	}
 }
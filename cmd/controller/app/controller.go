@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -36,7 +37,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/klog"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 
 	"github.com/jetstack/cert-manager/cmd/controller/app/options"
@@ -50,10 +51,12 @@ import (
 	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/issuing"
 	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/keymanager"
 	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/readiness"
+	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/replication"
 	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/requestmanager"
 	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/trigger"
 	"github.com/jetstack/cert-manager/pkg/feature"
 	dnsutil "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/jetstack/cert-manager/pkg/issuer/vault/tokencache"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/metrics"
 	"github.com/jetstack/cert-manager/pkg/util"
@@ -87,6 +90,7 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) {
 		keymanager.ControllerName,
 		requestmanager.ControllerName,
 		readiness.ControllerName,
+		replication.ControllerName,
 	}
 	enabledSet := sets.NewString(opts.EnabledControllers...)
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalCertificateControllers) {
@@ -187,6 +191,12 @@ func buildControllerContext(ctx context.Context, stopCh <-chan struct{}, opts *o
 		return nil, nil, fmt.Errorf("error creating kubernetes client: %s", err.Error())
 	}
 
+	// Create a Kubernetes dynamic client
+	dynamicCl, err := dynamic.NewForConfig(kubeCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating kubernetes dynamic client: %s", err.Error())
+	}
+
 	nameservers := opts.DNS01RecursiveNameservers
 	if len(nameservers) == 0 {
 		nameservers = dnsutil.RecursiveNameservers
@@ -227,6 +237,7 @@ func buildControllerContext(ctx context.Context, stopCh <-chan struct{}, opts *o
 	kubeSharedInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(cl, time.Second*30, kubeinformers.WithNamespace(opts.Namespace))
 
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
+	metricsRecorder := metrics.New(log)
 
 	return &controller.Context{
 		RootContext:               ctx,
@@ -234,18 +245,20 @@ func buildControllerContext(ctx context.Context, stopCh <-chan struct{}, opts *o
 		RESTConfig:                kubeCfg,
 		Client:                    cl,
 		CMClient:                  intcl,
+		DynamicClient:             dynamicCl,
 		Recorder:                  recorder,
 		KubeSharedInformerFactory: kubeSharedInformerFactory,
 		SharedInformerFactory:     sharedInformerFactory,
 		Namespace:                 opts.Namespace,
 		Clock:                     clock.RealClock{},
-		Metrics:                   metrics.New(log),
+		Metrics:                   metricsRecorder,
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverImage:                 opts.ACMEHTTP01SolverImage,
 			HTTP01SolverResourceRequestCPU:    HTTP01SolverResourceRequestCPU,
 			HTTP01SolverResourceRequestMemory: HTTP01SolverResourceRequestMemory,
 			HTTP01SolverResourceLimitsCPU:     HTTP01SolverResourceLimitsCPU,
 			HTTP01SolverResourceLimitsMemory:  HTTP01SolverResourceLimitsMemory,
+			HTTP01SolverShared:                opts.ACMEHTTP01SolverShared,
 			DNS01CheckAuthoritative:           !opts.DNS01RecursiveNameserversOnly,
 			DNS01Nameservers:                  nameservers,
 			AccountRegistry:                   acmeAccountRegistry,
@@ -255,6 +268,8 @@ func buildControllerContext(ctx context.Context, stopCh <-chan struct{}, opts *o
 			IssuerAmbientCredentials:        opts.IssuerAmbientCredentials,
 			ClusterResourceNamespace:        opts.ClusterResourceNamespace,
 			RenewBeforeExpiryDuration:       opts.RenewBeforeExpiryDuration,
+			VaultTokenCache:                 tokencache.New(clock.RealClock{}, metricsRecorder),
+			PKCS11AllowedModules:            opts.PKCS11AllowedModules,
 		},
 		IngressShimOptions: controller.IngressShimOptions{
 			DefaultIssuerName:                 opts.DefaultIssuerName,
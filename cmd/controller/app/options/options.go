@@ -19,6 +19,7 @@ package options
 import (
 	"fmt"
 	"net"
+	"path/filepath"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -27,8 +28,11 @@ import (
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	challengescontroller "github.com/jetstack/cert-manager/pkg/controller/acmechallenges"
 	orderscontroller "github.com/jetstack/cert-manager/pkg/controller/acmeorders"
+	bundlescontroller "github.com/jetstack/cert-manager/pkg/controller/bundles"
 	cracmecontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/acme"
 	crcacontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/ca"
+	crestcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/est"
+	crkubernetescsrcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/kubernetescsr"
 	crselfsignedcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/selfsigned"
 	crvaultcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/vault"
 	crvenaficontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/venafi"
@@ -59,11 +63,15 @@ type ControllerOptions struct {
 	ACMEHTTP01SolverResourceRequestMemory string
 	ACMEHTTP01SolverResourceLimitsCPU     string
 	ACMEHTTP01SolverResourceLimitsMemory  string
+	ACMEHTTP01SolverShared                bool
 
 	ClusterIssuerAmbientCredentials bool
 	IssuerAmbientCredentials        bool
 	RenewBeforeExpiryDuration       time.Duration
 
+	// Paths to the PKCS#11 modules that CA issuers may use.
+	PKCS11AllowedModules []string
+
 	// Default issuer/certificates details consumed by ingress-shim
 	DefaultIssuerName                 string
 	DefaultIssuerKind                 string
@@ -119,6 +127,7 @@ var (
 	defaultACMEHTTP01SolverResourceRequestMemory = "64Mi"
 	defaultACMEHTTP01SolverResourceLimitsCPU     = "100m"
 	defaultACMEHTTP01SolverResourceLimitsMemory  = "64Mi"
+	defaultACMEHTTP01SolverShared                = false
 
 	defaultAutoCertificateAnnotations = []string{"kubernetes.io/tls-acme"}
 
@@ -135,7 +144,10 @@ var (
 		crselfsignedcontroller.CRControllerName,
 		crvaultcontroller.CRControllerName,
 		crvenaficontroller.CRControllerName,
+		crestcontroller.CRControllerName,
+		crkubernetescsrcontroller.CRControllerName,
 		certificatescontroller.ControllerName,
+		bundlescontroller.ControllerName,
 	}
 )
 
@@ -214,6 +226,10 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.ACMEHTTP01SolverResourceLimitsMemory, "acme-http01-solver-resource-limits-memory", defaultACMEHTTP01SolverResourceLimitsMemory, ""+
 		"Defines the resource limits Memory size when spawning new ACME HTTP01 challenge solver pods.")
 
+	fs.BoolVar(&s.ACMEHTTP01SolverShared, "acme-http01-solver-shared", defaultACMEHTTP01SolverShared, ""+
+		"If true, a single long running ACME HTTP01 challenge solver Deployment is run in each namespace "+
+		"to serve the tokens of all pending challenges, instead of creating a new pod and service per challenge.")
+
 	fs.BoolVar(&s.ClusterIssuerAmbientCredentials, "cluster-issuer-ambient-credentials", defaultClusterIssuerAmbientCredentials, ""+
 		"Whether a cluster-issuer may make use of ambient credentials for issuers. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the ClusterIssuer API object. "+
 		"When this flag is enabled, the following sources for credentials are also used: "+
@@ -226,6 +242,10 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"The default 'renew before expiry' time for Certificates. "+
 		"Once a certificate is within this duration until expiry, a new Certificate "+
 		"will be attempted to be issued.")
+	fs.StringSliceVar(&s.PKCS11AllowedModules, "pkcs11-allowed-modules", []string{}, ""+
+		"A list of comma separated absolute paths to the PKCS#11 modules that CA issuers may use to "+
+		"sign with private keys stored in PKCS#11 tokens. If empty, CA issuers may not use PKCS#11 tokens. "+
+		"PKCS#11 requires cert-manager to be built with cgo, which the released images are not.")
 	fs.StringSliceVar(&s.DefaultAutoCertificateAnnotations, "auto-certificate-annotations", defaultAutoCertificateAnnotations, ""+
 		"The annotation consumed by the ingress-shim controller to indicate a ingress is requesting a certificate")
 
@@ -277,5 +297,11 @@ func (o *ControllerOptions) Validate() error {
 		}
 	}
 
+	for _, module := range o.PKCS11AllowedModules {
+		if !filepath.IsAbs(module) {
+			return fmt.Errorf("invalid PKCS#11 module path, must be an absolute path: %v", module)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,309 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmesolver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	extapi "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+
+	"github.com/jetstack/cert-manager/pkg/acme/webhook"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/conformance"
+	testserver "github.com/jetstack/cert-manager/pkg/acme/webhook/conformance/server"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns"
+	grpcslv "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/grpc"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/rfc2136"
+	webhookslv "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/webhook"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+var (
+	testLong = templates.LongDesc(i18n.T(`
+Run the DNS01 solver conformance test suite against a solver.
+
+The suite calls Present on the solver, checks that the challenge record can be
+resolved, and then calls CleanUp and checks that the record has been removed.
+Any Kubernetes resources the solver depends on, such as Secrets containing
+credentials, can be created in each test's namespace using --manifests.
+
+The solver config given using --config has the same format as the solver's
+stanza in an Issuer, e.g. the contents of 'dns01.webhook' for webhook solvers
+or 'dns01.cloudflare' for the built-in Cloudflare provider.`))
+
+	testExample = templates.Examples(i18n.T(`
+# Test a webhook solver against the 'example.com.' zone, checking records using 8.8.8.8.
+kubectl cert-manager acme-solver test --solver webhook --config webhook.yaml --resolved-zone example.com.
+
+# Test an out-of-process solver served over gRPC, creating the Secrets it needs first.
+kubectl cert-manager acme-solver test --solver grpc --config grpc.yaml --manifests ./testdata --resolved-zone example.com.
+
+# Test the built-in Cloudflare provider, creating the Secret containing its API token first.
+kubectl cert-manager acme-solver test --solver cloudflare --config cloudflare.yaml --manifests ./testdata --resolved-zone example.com.
+
+# Test the built-in RFC2136 solver using an in-process DNS server.
+kubectl cert-manager acme-solver test --solver rfc2136 --in-process-dns-server --resolved-zone example.com.`))
+)
+
+// solvers are the solvers that can be tested in addition to the built-in DNS
+// providers, keyed by the name used in the --solver flag.
+var solvers = map[string]func() webhook.Solver{
+	"grpc":    func() webhook.Solver { return grpcslv.New() },
+	"rfc2136": func() webhook.Solver { return rfc2136.New() },
+	"webhook": func() webhook.Solver { return &webhookslv.Webhook{} },
+}
+
+// newSolver returns the solver with the given name.
+func newSolver(name string) (webhook.Solver, error) {
+	if fn, ok := solvers[name]; ok {
+		return fn(), nil
+	}
+	return dns.NewBuiltinSolver(name)
+}
+
+// TestOptions is a struct to support the acme-solver test command
+type TestOptions struct {
+	RESTConfig *restclient.Config
+
+	// Solver is the name of the solver to test
+	Solver string
+	// ConfigFile is the path to a JSON or YAML file containing the solver
+	// config
+	ConfigFile string
+	// ManifestPath is a directory of manifests to create in each test's
+	// namespace
+	ManifestPath string
+
+	ResolvedZone            string
+	ResolvedFQDN            string
+	AllowAmbientCredentials bool
+
+	// DNSServer is the address of the DNS server used to check records
+	DNSServer string
+	// InProcessDNSServer runs an RFC2136 capable DNS server for ResolvedZone
+	// in-process, and checks records using it
+	InProcessDNSServer bool
+	UseAuthoritative   bool
+	Strict             bool
+
+	PollInterval     time.Duration
+	PropagationLimit time.Duration
+
+	genericclioptions.IOStreams
+}
+
+// NewTestOptions returns initialized TestOptions
+func NewTestOptions(ioStreams genericclioptions.IOStreams) *TestOptions {
+	return &TestOptions{
+		DNSServer:        "8.8.8.8:53",
+		UseAuthoritative: true,
+		PollInterval:     time.Second * 3,
+		PropagationLimit: time.Minute * 2,
+		IOStreams:        ioStreams,
+	}
+}
+
+// NewCmdTest returns a cobra command for running the DNS01 solver
+// conformance test suite
+func NewCmdTest(ioStreams genericclioptions.IOStreams, factory cmdutil.Factory) *cobra.Command {
+	o := NewTestOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:     "test",
+		Short:   "Run the DNS01 solver conformance test suite",
+		Long:    testLong,
+		Example: testExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Complete(factory))
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Solver, "solver", o.Solver, fmt.Sprintf("The solver to test, one of: %s.", strings.Join(solverNames(), ", ")))
+	cmd.Flags().StringVar(&o.ConfigFile, "config", o.ConfigFile, "Path to a JSON or YAML file containing the solver config.")
+	cmd.Flags().StringVar(&o.ManifestPath, "manifests", o.ManifestPath, "Path to a directory of manifests to create in the namespace of each test.")
+	cmd.Flags().StringVar(&o.ResolvedZone, "resolved-zone", o.ResolvedZone, "The DNS zone to present records in, e.g. 'example.com.'.")
+	cmd.Flags().StringVar(&o.ResolvedFQDN, "resolved-fqdn", o.ResolvedFQDN, "The name of the record to present. Defaults to 'cert-manager-dns01-tests.' followed by the resolved zone.")
+	cmd.Flags().BoolVar(&o.AllowAmbientCredentials, "allow-ambient-credentials", o.AllowAmbientCredentials, "Allow the solver to use ambient credentials.")
+	cmd.Flags().StringVar(&o.DNSServer, "dns-server", o.DNSServer, "The address of the DNS server used to check that records have been presented and cleaned up.")
+	cmd.Flags().BoolVar(&o.InProcessDNSServer, "in-process-dns-server", o.InProcessDNSServer, ""+
+		"Run a DNS server for the resolved zone that accepts RFC2136 updates in-process, and use it to check records. "+
+		"When testing the rfc2136 solver without --config, the solver is configured to update this server.")
+	cmd.Flags().BoolVar(&o.UseAuthoritative, "use-authoritative", o.UseAuthoritative, "Check records using the zone's authoritative nameservers, found using --dns-server.")
+	cmd.Flags().BoolVar(&o.Strict, "strict", o.Strict, "Run the extended tests that check multiple records with the same name are supported.")
+	cmd.Flags().DurationVar(&o.PollInterval, "poll-interval", o.PollInterval, "How often to check whether records have been updated.")
+	cmd.Flags().DurationVar(&o.PropagationLimit, "propagation-limit", o.PropagationLimit, "How long to wait for records to be updated before failing.")
+
+	return cmd
+}
+
+func solverNames() []string {
+	names := dns.BuiltinSolverNames()
+	for name := range solvers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate validates the provided options
+func (o *TestOptions) Validate() error {
+	if !sets.NewString(solverNames()...).Has(o.Solver) {
+		return fmt.Errorf("--solver must be one of: %s", strings.Join(solverNames(), ", "))
+	}
+
+	if len(o.ConfigFile) == 0 && !(o.Solver == "rfc2136" && o.InProcessDNSServer) {
+		return errors.New("--config must be specified")
+	}
+
+	if !strings.HasSuffix(o.ResolvedZone, ".") {
+		return errors.New("--resolved-zone must be specified and end with a '.'")
+	}
+
+	if len(o.ResolvedFQDN) > 0 && !strings.HasSuffix(o.ResolvedFQDN, ".") {
+		return errors.New("--resolved-fqdn must end with a '.'")
+	}
+
+	return nil
+}
+
+// Complete takes the factory and infers any remaining options.
+func (o *TestOptions) Complete(f cmdutil.Factory) error {
+	var err error
+	o.RESTConfig, err = f.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run executes the acme-solver test command
+func (o *TestOptions) Run() error {
+	solver, err := newSolver(o.Solver)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := kubernetes.NewForConfig(o.RESTConfig)
+	if err != nil {
+		return fmt.Errorf("error constructing clientset: %v", err)
+	}
+	applyManifest, err := conformance.NewManifestApplier(o.RESTConfig)
+	if err != nil {
+		return err
+	}
+
+	suite := &conformance.Suite{
+		Solver:                  solver,
+		Clientset:               clientset,
+		ApplyManifest:           applyManifest,
+		ResolvedFQDN:            o.ResolvedFQDN,
+		ResolvedZone:            o.ResolvedZone,
+		AllowAmbientCredentials: o.AllowAmbientCredentials,
+		Strict:                  o.Strict,
+		UseAuthoritative:        o.UseAuthoritative,
+		ManifestPath:            o.ManifestPath,
+		DNSServer:               o.DNSServer,
+		PollInterval:            o.PollInterval,
+		PropagationLimit:        o.PropagationLimit,
+	}
+	if len(suite.ResolvedFQDN) == 0 {
+		suite.ResolvedFQDN = "cert-manager-dns01-tests." + o.ResolvedZone
+	}
+
+	if o.InProcessDNSServer {
+		server := &testserver.BasicServer{
+			Zones: []string{o.ResolvedZone},
+		}
+		if err := server.Run(logf.NewContext(nil, nil, "acme-solver-test")); err != nil {
+			return fmt.Errorf("error starting in-process DNS server: %v", err)
+		}
+		defer server.Shutdown()
+
+		fmt.Fprintf(o.Out, "Started in-process DNS server on %s\n", server.ListenAddr())
+
+		// the in-process server is the only nameserver for the zone
+		suite.DNSServer, suite.UseAuthoritative = server.ListenAddr(), false
+
+		if len(o.ConfigFile) == 0 {
+			suite.Config, err = encodeConfig(cmacme.ACMEIssuerDNS01ProviderRFC2136{
+				Nameserver: server.ListenAddr(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(o.ConfigFile) > 0 {
+		cfg, err := readConfig(o.ConfigFile)
+		if err != nil {
+			return err
+		}
+		if suite.Config, err = encodeConfig(cfg); err != nil {
+			return err
+		}
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	if err := solver.Initialize(o.RESTConfig, stopCh); err != nil {
+		return fmt.Errorf("error initializing solver: %v", err)
+	}
+
+	return conformance.RunReport(o.Out, suite.RunConformance)
+}
+
+// encodeConfig encodes a solver config as JSON
+func encodeConfig(cfg interface{}) (*extapi.JSON, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding solver config: %v", err)
+	}
+	return &extapi.JSON{Raw: data}, nil
+}
+
+// readConfig reads a JSON or YAML solver config file
+func readConfig(path string) (interface{}, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading solver config: %v", err)
+	}
+
+	var cfg interface{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("error decoding solver config %q: %v", path, err)
+	}
+
+	return cfg, nil
+}
@@ -55,6 +55,10 @@ type WebhookOptions struct {
 	// MinTLSVersion is the minimum TLS version supported.
 	// Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants).
 	MinTLSVersion string
+
+	// PKCS11AllowedModules is the list of paths to the PKCS#11 modules that
+	// CA issuers may use.
+	PKCS11AllowedModules []string
 }
 
 func (o *WebhookOptions) AddFlags(fs *pflag.FlagSet) {
@@ -77,6 +81,10 @@ func (o *WebhookOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.MinTLSVersion, "tls-min-version", o.MinTLSVersion,
 		"Minimum TLS version supported. "+
 			"Possible values: "+strings.Join(tlsPossibleVersions, ", "))
+	fs.StringSliceVar(&o.PKCS11AllowedModules, "pkcs11-allowed-modules", []string{},
+		"A list of comma separated absolute paths to the PKCS#11 modules that CA issuers may use. "+
+			"If empty, Issuers and ClusterIssuers that use PKCS#11 tokens are rejected. "+
+			"This should match the --pkcs11-allowed-modules flag of the controller.")
 }
 
 func FileTLSSourceEnabled(o WebhookOptions) bool {
@@ -75,6 +75,11 @@ func NewServerWithOptions(log logr.Logger, opts options.WebhookOptions) (*server
 		log.Info("warning: serving insecurely as tls certificate data not provided")
 	}
 
+	if len(opts.PKCS11AllowedModules) > 0 {
+		log.Info("allowing CA issuers to use PKCS#11 modules", "modules", opts.PKCS11AllowedModules)
+	}
+	webhook.SetPKCS11AllowedModules(opts.PKCS11AllowedModules)
+
 	return &server.Server{
 		ListenAddr:        fmt.Sprintf(":%d", opts.ListenPort),
 		HealthzAddr:       fmt.Sprintf(":%d", opts.HealthzPort),
@@ -124,6 +124,7 @@ SKIPPED_DIRS = [
 # not having the headers. since they're just generated, ignore them
 IGNORE_HEADERS = [
     '// Code generated by go-bindata.',
+    '// Code generated by protoc-gen-go. DO NOT EDIT.',
     '// +skip_license_check',
     '# +skip_license_check',
 ]
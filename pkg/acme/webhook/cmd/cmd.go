@@ -21,7 +21,7 @@ import (
 	"os"
 	"runtime"
 
-	"k8s.io/klog"
+	"k8s.io/klog/v2"
 
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/component-base/logs"
@@ -49,7 +49,6 @@ func NewWebhookServerOptions(out, errOut io.Writer, groupName string, solvers ..
 		RecommendedOptions: genericoptions.NewRecommendedOptions(
 			defaultEtcdPathPrefix,
 			apiserver.Codecs.LegacyCodec(whapi.SchemeGroupVersion),
-			nil,
 		),
 
 		SolverGroup: groupName,
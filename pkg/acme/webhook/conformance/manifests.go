@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// NewManifestApplier returns a function that can be used as the
+// ApplyManifest function of a Suite. It creates the objects in a manifest
+// using the API of the cluster described by the given config, so that
+// kubectl is not required.
+func NewManifestApplier(restConfig *rest.Config) (func(namespace, path string) error, error) {
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error constructing dynamic client: %v", err)
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error constructing discovery client: %v", err)
+	}
+	restMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+
+	return func(namespace, path string) error {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		decoder := utilyaml.NewYAMLOrJSONDecoder(file, 4096)
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("error decoding %q: %v", path, err)
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+
+			gvk := obj.GroupVersionKind()
+			mapping, err := restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			if err != nil {
+				return fmt.Errorf("error finding resource for %v: %v", gvk, err)
+			}
+
+			var client dynamic.ResourceInterface = dynamicClient.Resource(mapping.Resource)
+			if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+				client = dynamicClient.Resource(mapping.Resource).Namespace(namespace)
+			}
+			if _, err := client.Create(context.TODO(), obj, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("error creating %v %q: %v", gvk, obj.GetName(), err)
+			}
+		}
+	}, nil
+}
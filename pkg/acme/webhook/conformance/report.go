@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// T is the subset of *testing.T used by the conformance suite.
+// It allows the suite to be run outside of 'go test', for example by the
+// 'acme-solver test' command of the cert-manager CLI.
+type T interface {
+	Logf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Skip(args ...interface{})
+	Run(name string, fn func(T)) bool
+}
+
+// RunReport runs fn outside of 'go test', writing a human readable report
+// of the results of the tests it runs to out, in a format similar to
+// 'go test -v'. An error is returned if any of the tests failed.
+func RunReport(out io.Writer, fn func(T)) error {
+	r := newReport(out)
+
+	// run the tests in their own goroutine, so that a failure outside of a
+	// test can stop them using runtime.Goexit
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+
+	r.summary()
+	if r.failed {
+		if r.failures == 0 {
+			return fmt.Errorf("error setting up conformance tests")
+		}
+		return fmt.Errorf("%d conformance test(s) failed", r.failures)
+	}
+	return nil
+}
+
+// report is a T that writes the progress and result of each test to an
+// io.Writer in a format similar to 'go test -v'.
+type report struct {
+	out    io.Writer
+	name   string
+	depth  int
+	parent *report
+
+	failed  bool
+	skipped bool
+
+	// counts of leaf tests, only maintained on the root report
+	passes, failures, skips int
+}
+
+var _ T = &report{}
+
+func newReport(out io.Writer) *report {
+	return &report{out: out}
+}
+
+func (r *report) Logf(format string, args ...interface{}) {
+	r.log(fmt.Sprintf(format, args...))
+}
+
+func (r *report) Errorf(format string, args ...interface{}) {
+	r.log(fmt.Sprintf(format, args...))
+	r.fail()
+}
+
+// Fatalf marks the test as failed and stops its execution.
+func (r *report) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+// Skip marks the test as skipped and stops its execution.
+func (r *report) Skip(args ...interface{}) {
+	r.log(fmt.Sprint(args...))
+	r.skipped = true
+	runtime.Goexit()
+}
+
+// Run runs fn as a subtest of r. Like testing.T, the subtest is run in its
+// own goroutine so that Fatalf and Skip can stop it using runtime.Goexit.
+func (r *report) Run(name string, fn func(T)) bool {
+	fullName := name
+	if r.name != "" {
+		fullName = r.name + "/" + name
+	}
+	child := &report{
+		out:    r.out,
+		name:   fullName,
+		depth:  r.depth + 1,
+		parent: r,
+	}
+
+	fmt.Fprintf(r.out, "=== RUN   %s\n", fullName)
+	start := time.Now()
+
+	done := make(chan struct{})
+	hasChildren := false
+	go func() {
+		defer close(done)
+		fn(&runTracker{report: child, ran: &hasChildren})
+	}()
+	<-done
+
+	result := "PASS"
+	switch {
+	case child.failed:
+		result = "FAIL"
+	case child.skipped:
+		result = "SKIP"
+	}
+	fmt.Fprintf(r.out, "%s--- %s: %s (%.2fs)\n", child.indent(), result, fullName, time.Since(start).Seconds())
+
+	if !hasChildren {
+		root := r.root()
+		switch result {
+		case "FAIL":
+			root.failures++
+		case "SKIP":
+			root.skips++
+		default:
+			root.passes++
+		}
+	}
+
+	return !child.failed
+}
+
+// runTracker records whether a test has any subtests, so that only leaf
+// tests are counted in the summary.
+type runTracker struct {
+	*report
+	ran *bool
+}
+
+func (t *runTracker) Run(name string, fn func(T)) bool {
+	*t.ran = true
+	return t.report.Run(name, fn)
+}
+
+func (r *report) log(msg string) {
+	indent := r.indent() + "    "
+	for _, line := range strings.Split(strings.TrimRight(msg, "\n"), "\n") {
+		fmt.Fprintf(r.out, "%s%s\n", indent, line)
+	}
+}
+
+func (r *report) fail() {
+	for p := r; p != nil; p = p.parent {
+		p.failed = true
+	}
+}
+
+func (r *report) root() *report {
+	p := r
+	for p.parent != nil {
+		p = p.parent
+	}
+	return p
+}
+
+func (r *report) indent() string {
+	if r.depth == 0 {
+		return ""
+	}
+	return strings.Repeat("    ", r.depth-1)
+}
+
+// summary writes the overall result of the run.
+func (r *report) summary() {
+	result := "PASS"
+	if r.failed {
+		result = "FAIL"
+	}
+	fmt.Fprintf(r.out, "%s\n%d passed, %d failed, %d skipped\n", result, r.passes, r.failures, r.skips)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r := newReport(buf)
+
+	afterFatal := false
+	r.Run("Suite", func(t T) {
+		t.Run("Passes", func(t T) {
+			t.Logf("some output")
+		})
+		t.Run("Fails", func(t T) {
+			t.Fatalf("failed")
+			afterFatal = true
+		})
+		t.Run("Skipped", func(t T) {
+			t.Skip("skipping")
+		})
+	})
+	r.summary()
+
+	if afterFatal {
+		t.Errorf("expected Fatalf to stop the test")
+	}
+	if !r.failed {
+		t.Errorf("expected failure to propagate to the root report")
+	}
+	if r.passes != 1 || r.failures != 1 || r.skips != 1 {
+		t.Errorf("unexpected counts, passes=%d failures=%d skips=%d", r.passes, r.failures, r.skips)
+	}
+
+	out := buf.String()
+	for _, s := range []string{
+		"=== RUN   Suite/Passes\n",
+		"        some output\n",
+		"    --- PASS: Suite/Passes",
+		"    --- FAIL: Suite/Fails",
+		"    --- SKIP: Suite/Skipped",
+		"--- FAIL: Suite",
+		"FAIL\n1 passed, 1 failed, 1 skipped\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected report to contain %q, got:\n%s", s, out)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package conformance implements the DNS01 solver conformance test suite.
+// It is run as part of 'go test' using the fixture in test/acme/dns, and
+// outside of it using RunReport, e.g. by the 'acme-solver test' command of
+// the cert-manager CLI.
+package conformance
+
+import (
+	"time"
+
+	extapi "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/jetstack/cert-manager/pkg/acme/webhook"
+)
+
+var (
+	defaultPollInterval     = time.Second * 3
+	defaultPropagationLimit = time.Minute * 2
+)
+
+// Suite runs the conformance tests against a solver.
+type Suite struct {
+	// Solver is the DNS solver under test. It must have been initialized.
+	Solver webhook.Solver
+	// Clientset is used to create a namespace for each test.
+	Clientset kubernetes.Interface
+	// ApplyManifest creates the objects in the manifest at path in the given
+	// namespace. It must be set if ManifestPath is set.
+	ApplyManifest func(namespace, path string) error
+
+	ResolvedFQDN            string
+	ResolvedZone            string
+	AllowAmbientCredentials bool
+	// Config is the solver config passed in each challenge request.
+	Config *extapi.JSON
+	// Strict enables the extended tests.
+	Strict bool
+	// UseAuthoritative checks records using the zone's authoritative
+	// nameservers, which are found using DNSServer.
+	UseAuthoritative bool
+	// ManifestPath is a directory of manifests that are created in the
+	// namespace of each test.
+	ManifestPath string
+
+	// DNSServer is the address:port of the DNS server to send requests to
+	// when validating that records are set as expected.
+	// Ideally, for fast tests, this should be set to a DNS server that does
+	// not cache queries.
+	DNSServer string
+
+	PollInterval     time.Duration
+	PropagationLimit time.Duration
+}
+
+// RunConformance will execute all conformance tests
+func (s *Suite) RunConformance(t T) {
+	t.Run("Conformance", func(t T) {
+		s.RunBasic(t)
+		s.RunExtended(t)
+	})
+}
+
+func (s *Suite) RunBasic(t T) {
+	t.Run("Basic", func(t T) {
+		t.Run("PresentRecord", s.TestBasicPresentRecord)
+	})
+}
+
+func (s *Suite) RunExtended(t T) {
+	t.Run("Extended", func(t T) {
+		t.Run("DeletingOneRecordRetainsOthers", s.TestExtendedDeletingOneRecordRetainsOthers)
+	})
+}
+
+// TestBasicPresentRecord will perform a basic validation that the Present
+// method works as expected.
+// It will call Present and then poll the configured DNS server until the
+// record has propagated.
+// Afterwards, it will call CleanUp to clean up the changes it has made.
+// If either Present or CleanUp fail to properly present and clean up the
+// challenge record, this test case will fail.
+func (s *Suite) TestBasicPresentRecord(t T) {
+	ns, cleanup := s.setupNamespace(t, "basic-present-record")
+	defer cleanup()
+	ch := s.buildChallengeRequest(t, ns)
+
+	t.Logf("Calling Present with ChallengeRequest: %#v", ch)
+	// present the record
+	if err := s.Solver.Present(ch); err != nil {
+		t.Errorf("expected Present to not error, but got: %v", err)
+		return
+	}
+	defer s.Solver.CleanUp(ch)
+
+	// wait until the record has propagated
+	if err := wait.PollUntil(s.getPollInterval(),
+		s.recordHasPropagatedCheck(ch.ResolvedFQDN, ch.Key),
+		closingStopCh(s.getPropagationLimit())); err != nil {
+		t.Errorf("error waiting for DNS record propagation: %v", err)
+		return
+	}
+
+	// clean up the presented record
+	if err := s.Solver.CleanUp(ch); err != nil {
+		t.Errorf("expected CleanUp to not error, but got: %v", err)
+	}
+
+	// wait until the record has been deleted
+	if err := wait.PollUntil(s.getPollInterval(),
+		s.recordHasBeenDeletedCheck(ch.ResolvedFQDN, ch.Key),
+		closingStopCh(s.getPropagationLimit())); err != nil {
+		t.Errorf("error waiting for record to be deleted: %v", err)
+		return
+	}
+}
+
+// TestExtendedDeletingOneRecordRetainsOthers validates that a DNS01 provider
+// supports setting multiple TXT records for the same DNS record name.
+// Adding a new record **must not** delete existing records with the same
+// record name from the DNS zone.
+func (s *Suite) TestExtendedDeletingOneRecordRetainsOthers(t T) {
+	if !s.Strict {
+		t.Skip("skipping test as strict mode is disabled, see: https://github.com/jetstack/cert-manager/pull/1354")
+	}
+
+	ns, cleanup := s.setupNamespace(t, "extended-supports-multiple-same-domain")
+	defer cleanup()
+	ch := s.buildChallengeRequest(t, ns)
+	ch2 := s.buildChallengeRequest(t, ns)
+	ch2.Key = "anothertestingkey"
+
+	// present the first record
+	if err := s.Solver.Present(ch); err != nil {
+		t.Errorf("expected Present to not error, but got: %v", err)
+		return
+	}
+	defer s.Solver.CleanUp(ch)
+
+	// present the second record
+	if err := s.Solver.Present(ch2); err != nil {
+		t.Errorf("expected Present to not error, but got: %v", err)
+		return
+	}
+	defer s.Solver.CleanUp(ch2)
+
+	// wait until all records have propagated
+	if err := wait.PollUntil(s.getPollInterval(),
+		allConditions(
+			s.recordHasPropagatedCheck(ch.ResolvedFQDN, ch.Key),
+			s.recordHasPropagatedCheck(ch2.ResolvedFQDN, ch2.Key),
+		),
+		closingStopCh(s.getPropagationLimit())); err != nil {
+		t.Errorf("error waiting for DNS record propagation: %v", err)
+		return
+	}
+
+	// clean up the second record
+	if err := s.Solver.CleanUp(ch2); err != nil {
+		t.Errorf("expected CleanUp to not error, but got: %v", err)
+	}
+
+	// wait until the second record has been deleted and the first one remains
+	if err := wait.PollUntil(s.getPollInterval(),
+		allConditions(
+			s.recordHasBeenDeletedCheck(ch2.ResolvedFQDN, ch2.Key),
+			s.recordHasPropagatedCheck(ch.ResolvedFQDN, ch.Key),
+		),
+		closingStopCh(s.getPropagationLimit())); err != nil {
+		t.Errorf("error waiting for DNS record propagation: %v", err)
+		return
+	}
+}
@@ -1,5 +1,5 @@
 /*
-Copyright 2019 The Jetstack cert-manager contributors.
+Copyright 2020 The Jetstack cert-manager contributors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -13,15 +13,13 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 See the License for the specific language governing permissions and
 limitations under the License.
 */
-
-package dns
+package conformance
 
 import (
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
-	"testing"
 	"time"
 
 	"github.com/miekg/dns"
@@ -33,13 +31,8 @@ import (
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
 )
 
-var (
-	defaultPollInterval     = time.Second * 3
-	defaultPropagationLimit = time.Minute * 2
-)
-
-func (f *fixture) setupNamespace(t *testing.T, name string) (string, func()) {
-	if _, err := f.clientset.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
+func (s *Suite) setupNamespace(t T, name string) (string, func()) {
+	if _, err := s.Clientset.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
 		},
@@ -47,8 +40,8 @@ func (f *fixture) setupNamespace(t *testing.T, name string) (string, func()) {
 		t.Fatalf("error creating test namespace %q: %v", name, err)
 	}
 
-	if f.kubectlManifestsPath != "" {
-		if err := filepath.Walk(f.kubectlManifestsPath, func(path string, info os.FileInfo, err error) error {
+	if s.ManifestPath != "" {
+		if err := filepath.Walk(s.ManifestPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
@@ -63,8 +56,7 @@ func (f *fixture) setupNamespace(t *testing.T, name string) (string, func()) {
 				return nil
 			}
 
-			_, _, err = f.kubectl.Run("apply", "--namespace", name, "-f", path)
-			if err != nil {
+			if err := s.ApplyManifest(name, path); err != nil {
 				return err
 			}
 
@@ -79,17 +71,17 @@ func (f *fixture) setupNamespace(t *testing.T, name string) (string, func()) {
 	}
 
 	return name, func() {
-		f.clientset.CoreV1().Namespaces().Delete(context.TODO(), name, metav1.DeleteOptions{})
+		s.Clientset.CoreV1().Namespaces().Delete(context.TODO(), name, metav1.DeleteOptions{})
 	}
 }
 
-func (f *fixture) buildChallengeRequest(t *testing.T, ns string) *whapi.ChallengeRequest {
+func (s *Suite) buildChallengeRequest(t T, ns string) *whapi.ChallengeRequest {
 	return &whapi.ChallengeRequest{
 		ResourceNamespace:       ns,
-		ResolvedFQDN:            f.resolvedFQDN,
-		ResolvedZone:            f.resolvedZone,
-		AllowAmbientCredentials: f.allowAmbientCredentials,
-		Config:                  f.jsonConfig,
+		ResolvedFQDN:            s.ResolvedFQDN,
+		ResolvedZone:            s.ResolvedZone,
+		AllowAmbientCredentials: s.AllowAmbientCredentials,
+		Config:                  s.Config,
 		// TODO
 		DNSName: "example.com",
 		Key:     "123d==",
@@ -117,15 +109,15 @@ func closingStopCh(t time.Duration) <-chan struct{} {
 	return stopCh
 }
 
-func (f *fixture) recordHasPropagatedCheck(fqdn, value string) func() (bool, error) {
+func (s *Suite) recordHasPropagatedCheck(fqdn, value string) func() (bool, error) {
 	return func() (bool, error) {
-		return util.PreCheckDNS(fqdn, value, []string{f.testDNSServer}, *f.useAuthoritative)
+		return util.PreCheckDNS(fqdn, value, []string{s.DNSServer}, s.UseAuthoritative)
 	}
 }
 
-func (f *fixture) recordHasBeenDeletedCheck(fqdn, value string) func() (bool, error) {
+func (s *Suite) recordHasBeenDeletedCheck(fqdn, value string) func() (bool, error) {
 	return func() (bool, error) {
-		msg, err := util.DNSQuery(fqdn, dns.TypeTXT, []string{f.testDNSServer}, *f.useAuthoritative)
+		msg, err := util.DNSQuery(fqdn, dns.TypeTXT, []string{s.DNSServer}, s.UseAuthoritative)
 		if err != nil {
 			return false, err
 		}
@@ -150,17 +142,17 @@ func (f *fixture) recordHasBeenDeletedCheck(fqdn, value string) func() (bool, er
 	}
 }
 
-func (f *fixture) getPollInterval() time.Duration {
-	if f.pollInterval != 0 {
-		return f.pollInterval
+func (s *Suite) getPollInterval() time.Duration {
+	if s.PollInterval != 0 {
+		return s.PollInterval
 	} else {
 		return defaultPollInterval
 	}
 }
 
-func (f *fixture) getPropagationLimit() time.Duration {
-	if f.propagationLimit != 0 {
-		return f.propagationLimit
+func (s *Suite) getPropagationLimit() time.Duration {
+	if s.PropagationLimit != 0 {
+		return s.PropagationLimit
 	} else {
 		return defaultPropagationLimit
 	}
@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcsolver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	restclient "k8s.io/client-go/rest"
+
+	"github.com/jetstack/cert-manager/pkg/acme/webhook"
+	whapi "github.com/jetstack/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/grpcsolver/v1alpha1"
+)
+
+const (
+	// unixPrefix is the prefix of endpoints that refer to a Unix domain socket.
+	unixPrefix = "unix://"
+
+	// defaultTimeout is the time after which calls to a solver are cancelled.
+	defaultTimeout = time.Minute
+)
+
+// Client is a webhook.Solver that forwards all calls to a solver served over
+// gRPC.
+type Client struct {
+	solverName string
+	conn       *grpc.ClientConn
+	client     v1alpha1.SolverClient
+}
+
+var _ webhook.Solver = &Client{}
+
+// NewClient returns a Client for the named solver served on conn.
+func NewClient(conn *grpc.ClientConn, solverName string) *Client {
+	return &Client{
+		solverName: solverName,
+		conn:       conn,
+		client:     v1alpha1.NewSolverClient(conn),
+	}
+}
+
+// Close closes the connection the client was created with.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Name returns the name of the remote solver.
+func (c *Client) Name() string {
+	return c.solverName
+}
+
+// Initialize checks that the remote endpoint serves the named solver.
+// The Kubernetes client configuration is not used, as out-of-process solvers
+// are responsible for configuring their own clients.
+func (c *Client) Initialize(_ *restclient.Config, _ <-chan struct{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	resp, err := c.client.Initialize(ctx, &v1alpha1.InitializeRequest{})
+	if err != nil {
+		return statusError(err)
+	}
+
+	for _, name := range resp.SolverNames {
+		if name == c.solverName {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("solver %q is not served by endpoint, available solvers: %s", c.solverName, strings.Join(resp.SolverNames, ", "))
+}
+
+// Present calls Present on the remote solver.
+func (c *Client) Present(ch *whapi.ChallengeRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	_, err := c.client.Present(ctx, challengeRequestToProto(c.solverName, ch))
+	return statusError(err)
+}
+
+// CleanUp calls CleanUp on the remote solver.
+func (c *Client) CleanUp(ch *whapi.ChallengeRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	_, err := c.client.CleanUp(ctx, challengeRequestToProto(c.solverName, ch))
+	return statusError(err)
+}
+
+// StatusError is returned when a call to a remote solver fails.
+// Its message is the message returned by the solver, and the gRPC status
+// code can be read using status.Code so that callers can tell apart, for
+// example, an unavailable endpoint from an invalid request.
+type StatusError struct {
+	status *status.Status
+}
+
+func (e *StatusError) Error() string {
+	return e.status.Message()
+}
+
+// Code returns the gRPC status code of the error.
+func (e *StatusError) Code() codes.Code {
+	return e.status.Code()
+}
+
+// GRPCStatus returns the gRPC status of the error.
+func (e *StatusError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// statusError converts a gRPC status error into a StatusError.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return &StatusError{status: st}
+}
+
+// Dial connects to the solver served at endpoint.
+// Endpoints of the form 'unix:///path/to/socket' are dialled over a Unix
+// domain socket without TLS. All other endpoints are dialled over TCP using
+// TLS, verifying the server's certificate using the PEM encoded caBundle, or
+// the system root certificates if caBundle is empty. If clientCert is not
+// nil, it is presented to the server to authenticate the connection.
+func Dial(ctx context.Context, endpoint string, caBundle []byte, clientCert *tls.Certificate) (*grpc.ClientConn, error) {
+	if IsUnixEndpoint(endpoint) {
+		path := strings.TrimPrefix(endpoint, unixPrefix)
+		dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		}
+		return grpc.DialContext(ctx, path, grpc.WithInsecure(), grpc.WithContextDialer(dialer))
+	}
+
+	tlsConfig := &tls.Config{}
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("failed to parse CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+
+	return grpc.DialContext(ctx, endpoint, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+}
+
+// IsUnixEndpoint returns true if the endpoint refers to a Unix domain socket.
+func IsUnixEndpoint(endpoint string) bool {
+	return strings.HasPrefix(endpoint, unixPrefix)
+}
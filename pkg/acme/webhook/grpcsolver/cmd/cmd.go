@@ -0,0 +1,210 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd provides the command used to run out-of-process ACME solvers
+// over gRPC.
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/component-base/logs"
+	"k8s.io/klog/v2"
+
+	"github.com/jetstack/cert-manager/pkg/acme/webhook"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/grpcsolver"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/grpcsolver/v1alpha1"
+)
+
+const defaultListenAddress = "unix:///var/run/cert-manager/solver.sock"
+
+// SolverServerOptions configures how a gRPC solver server is run.
+type SolverServerOptions struct {
+	// ListenAddress is the address to serve on, either of the form
+	// 'unix:///path/to/socket' or 'host:port'.
+	ListenAddress string
+
+	// TLSCertFile and TLSPrivateKeyFile are the serving certificate and
+	// private key. They are required when serving over TCP.
+	TLSCertFile       string
+	TLSPrivateKeyFile string
+
+	// ClientCAFile is the path to a PEM encoded CA bundle. If set, clients
+	// connecting over TCP must present a certificate signed by one of the
+	// CAs in the bundle.
+	ClientCAFile string
+
+	// Kubeconfig is the path to a kubeconfig file used to build the
+	// Kubernetes client configuration passed to each solver's Initialize
+	// method. If empty, the in-cluster configuration is used.
+	Kubeconfig string
+
+	Solvers []webhook.Solver
+}
+
+// NewSolverServerOptions returns SolverServerOptions with default values.
+func NewSolverServerOptions(solvers ...webhook.Solver) *SolverServerOptions {
+	return &SolverServerOptions{
+		ListenAddress: defaultListenAddress,
+		Solvers:       solvers,
+	}
+}
+
+// AddFlags adds flags for the options to the given FlagSet.
+func (o *SolverServerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ListenAddress, "listen-address", o.ListenAddress, ""+
+		"The address to serve the solver gRPC service on. Use the form "+
+		"'unix:///path/to/socket' to serve on a Unix domain socket, or 'host:port' "+
+		"to serve over TCP using TLS.")
+	fs.StringVar(&o.TLSCertFile, "tls-cert-file", o.TLSCertFile, ""+
+		"Path to a file containing the PEM encoded serving certificate. "+
+		"Required when serving over TCP.")
+	fs.StringVar(&o.TLSPrivateKeyFile, "tls-private-key-file", o.TLSPrivateKeyFile, ""+
+		"Path to a file containing the PEM encoded private key of the serving certificate. "+
+		"Required when serving over TCP.")
+	fs.StringVar(&o.ClientCAFile, "client-ca-file", o.ClientCAFile, ""+
+		"Path to a file containing PEM encoded CA certificates. If set, clients "+
+		"connecting over TCP must present a certificate signed by one of these CAs.")
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, ""+
+		"Path to a kubeconfig file used by solvers to connect to Kubernetes. "+
+		"If not set, the in-cluster configuration is used.")
+}
+
+// Validate checks the options are valid.
+func (o *SolverServerOptions) Validate() error {
+	if len(o.Solvers) == 0 {
+		return fmt.Errorf("at least one solver must be provided")
+	}
+	if !grpcsolver.IsUnixEndpoint(o.ListenAddress) && (o.TLSCertFile == "" || o.TLSPrivateKeyFile == "") {
+		return fmt.Errorf("--tls-cert-file and --tls-private-key-file must be set when serving over TCP")
+	}
+	if grpcsolver.IsUnixEndpoint(o.ListenAddress) && o.ClientCAFile != "" {
+		return fmt.Errorf("--client-ca-file can only be set when serving over TCP")
+	}
+	return nil
+}
+
+// RunSolverServer initializes all solvers and serves them until stopCh is
+// closed.
+func (o *SolverServerOptions) RunSolverServer(stopCh <-chan struct{}) error {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", o.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error building kubernetes client config: %v", err)
+	}
+
+	for _, s := range o.Solvers {
+		if err := s.Initialize(restConfig, stopCh); err != nil {
+			return fmt.Errorf("error initializing solver %q: %v", s.Name(), err)
+		}
+	}
+
+	var opts []grpc.ServerOption
+	if !grpcsolver.IsUnixEndpoint(o.ListenAddress) {
+		tlsConfig, err := o.serverTLSConfig()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	lis, err := grpcsolver.Listen(o.ListenAddress)
+	if err != nil {
+		return err
+	}
+
+	srv := grpc.NewServer(opts...)
+	v1alpha1.RegisterSolverServer(srv, grpcsolver.NewServer(o.Solvers...))
+
+	go func() {
+		<-stopCh
+		srv.GracefulStop()
+	}()
+
+	klog.Infof("serving solvers on %s", o.ListenAddress)
+	return srv.Serve(lis)
+}
+
+// serverTLSConfig loads the serving certificate and, if configured, the CA
+// bundle used to verify client certificates.
+func (o *SolverServerOptions) serverTLSConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(o.TLSCertFile, o.TLSPrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading serving certificate: %v", err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if o.ClientCAFile != "" {
+		caPEM, err := ioutil.ReadFile(o.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("failed to parse client CA file %q", o.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// NewCommandStartSolverServer returns a command that runs a gRPC solver
+// server for the given solvers.
+func NewCommandStartSolverServer(stopCh <-chan struct{}, solvers ...webhook.Solver) *cobra.Command {
+	o := NewSolverServerOptions(solvers...)
+
+	cmd := &cobra.Command{
+		Short: "Launch an ACME solver gRPC server",
+		Long:  "Launch an ACME solver gRPC server",
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return o.RunSolverServer(stopCh)
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// RunSolverServer runs a gRPC solver server for the given solvers until the
+// process receives a termination signal.
+// It is the gRPC equivalent of RunWebhookServer.
+func RunSolverServer(solvers ...webhook.Solver) {
+	logs.InitLogs()
+	defer logs.FlushLogs()
+
+	stopCh := genericapiserver.SetupSignalHandler()
+
+	cmd := NewCommandStartSolverServer(stopCh, solvers...)
+	cmd.Flags().AddGoFlagSet(flag.CommandLine)
+	if err := cmd.Execute(); err != nil {
+		klog.Fatal(err)
+	}
+}
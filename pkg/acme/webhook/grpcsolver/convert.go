@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcsolver
+
+import (
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+
+	whapi "github.com/jetstack/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/grpcsolver/v1alpha1"
+)
+
+func challengeRequestToProto(solverName string, ch *whapi.ChallengeRequest) *v1alpha1.ChallengeRequest {
+	req := &v1alpha1.ChallengeRequest{
+		SolverName:              solverName,
+		Uid:                     string(ch.UID),
+		Type:                    ch.Type,
+		DnsName:                 ch.DNSName,
+		Key:                     ch.Key,
+		ResourceNamespace:       ch.ResourceNamespace,
+		ResolvedFqdn:            ch.ResolvedFQDN,
+		ResolvedZone:            ch.ResolvedZone,
+		AllowAmbientCredentials: ch.AllowAmbientCredentials,
+	}
+	if ch.Config != nil {
+		req.Config = ch.Config.Raw
+	}
+	return req
+}
+
+func challengeRequestFromProto(req *v1alpha1.ChallengeRequest, action whapi.ChallengeAction) *whapi.ChallengeRequest {
+	ch := &whapi.ChallengeRequest{
+		UID:                     types.UID(req.Uid),
+		Action:                  action,
+		Type:                    req.Type,
+		DNSName:                 req.DnsName,
+		Key:                     req.Key,
+		ResourceNamespace:       req.ResourceNamespace,
+		ResolvedFQDN:            req.ResolvedFqdn,
+		ResolvedZone:            req.ResolvedZone,
+		AllowAmbientCredentials: req.AllowAmbientCredentials,
+	}
+	if len(req.Config) > 0 {
+		ch.Config = &apiext.JSON{Raw: req.Config}
+	}
+	return ch
+}
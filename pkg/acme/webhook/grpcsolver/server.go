@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package grpcsolver provides a library that can be used to build
+// out-of-process ACME solvers that are reached by cert-manager over gRPC,
+// as a lighter weight alternative to the aggregated apiserver based solver
+// webhooks.
+package grpcsolver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+
+	"github.com/jetstack/cert-manager/pkg/acme/webhook"
+	whapi "github.com/jetstack/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/grpcsolver/v1alpha1"
+)
+
+// Server implements the Solver gRPC service by dispatching requests to one
+// or more webhook.Solver implementations.
+type Server struct {
+	solvers map[string]webhook.Solver
+}
+
+var _ v1alpha1.SolverServer = &Server{}
+
+// NewServer returns a Server that serves the given solvers. The solvers must
+// already have been initialized.
+func NewServer(solvers ...webhook.Solver) *Server {
+	m := make(map[string]webhook.Solver, len(solvers))
+	for _, s := range solvers {
+		m[s.Name()] = s
+	}
+	return &Server{solvers: m}
+}
+
+// Initialize returns the names of all solvers served by this Server.
+func (s *Server) Initialize(_ context.Context, _ *v1alpha1.InitializeRequest) (*v1alpha1.InitializeResponse, error) {
+	var names []string
+	for name := range s.solvers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &v1alpha1.InitializeResponse{SolverNames: names}, nil
+}
+
+// Present calls Present on the solver named in the request.
+func (s *Server) Present(_ context.Context, req *v1alpha1.ChallengeRequest) (*v1alpha1.ChallengeResponse, error) {
+	slv, ok := s.solvers[req.SolverName]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "solver %q not found", req.SolverName)
+	}
+
+	if err := slv.Present(challengeRequestFromProto(req, whapi.ChallengeActionPresent)); err != nil {
+		klog.Errorf("Present call for solver %q failed: %v", req.SolverName, err)
+		// preserve the status code of errors that carry one
+		return nil, status.Convert(err).Err()
+	}
+
+	return &v1alpha1.ChallengeResponse{}, nil
+}
+
+// CleanUp calls CleanUp on the solver named in the request.
+func (s *Server) CleanUp(_ context.Context, req *v1alpha1.ChallengeRequest) (*v1alpha1.ChallengeResponse, error) {
+	slv, ok := s.solvers[req.SolverName]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "solver %q not found", req.SolverName)
+	}
+
+	if err := slv.CleanUp(challengeRequestFromProto(req, whapi.ChallengeActionCleanUp)); err != nil {
+		klog.Errorf("CleanUp call for solver %q failed: %v", req.SolverName, err)
+		return nil, status.Convert(err).Err()
+	}
+
+	return &v1alpha1.ChallengeResponse{}, nil
+}
+
+// Listen listens on the given address, which is either of the form
+// 'unix:///path/to/socket' or 'host:port'. Any stale Unix domain socket at
+// the given path is removed first.
+func Listen(address string) (net.Listener, error) {
+	if !IsUnixEndpoint(address) {
+		return net.Listen("tcp", address)
+	}
+
+	path := strings.TrimPrefix(address, unixPrefix)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error removing existing socket %q: %v", path, err)
+	}
+	return net.Listen("unix", path)
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcsolver
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	restclient "k8s.io/client-go/rest"
+
+	"github.com/jetstack/cert-manager/pkg/acme/webhook"
+	whapi "github.com/jetstack/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/grpcsolver/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+type fakeSolver struct {
+	name      string
+	err       error
+	presented *whapi.ChallengeRequest
+	cleanedUp *whapi.ChallengeRequest
+}
+
+func (f *fakeSolver) Name() string { return f.name }
+
+func (f *fakeSolver) Present(ch *whapi.ChallengeRequest) error {
+	f.presented = ch
+	return f.err
+}
+
+func (f *fakeSolver) CleanUp(ch *whapi.ChallengeRequest) error {
+	f.cleanedUp = ch
+	return f.err
+}
+
+func (f *fakeSolver) Initialize(_ *restclient.Config, _ <-chan struct{}) error { return nil }
+
+func serve(t *testing.T, solvers ...*fakeSolver) (*grpc.ClientConn, func()) {
+	dir, err := ioutil.TempDir("", "grpcsolver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoint := unixPrefix + filepath.Join(dir, "solver.sock")
+
+	lis, err := Listen(endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s []webhook.Solver
+	for _, slv := range solvers {
+		s = append(s, slv)
+	}
+	srv := grpc.NewServer()
+	v1alpha1.RegisterSolverServer(srv, NewServer(s...))
+	go srv.Serve(lis)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	conn, err := Dial(ctx, endpoint, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return conn, func() {
+		conn.Close()
+		srv.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestInitialize(t *testing.T) {
+	conn, stop := serve(t, &fakeSolver{name: "b"}, &fakeSolver{name: "a"})
+	defer stop()
+
+	resp, err := v1alpha1.NewSolverClient(conn).Initialize(context.Background(), &v1alpha1.InitializeRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(resp.SolverNames, []string{"a", "b"}) {
+		t.Errorf("unexpected solver names: %v", resp.SolverNames)
+	}
+
+	if err := NewClient(conn, "a").Initialize(nil, nil); err != nil {
+		t.Errorf("unexpected error initializing served solver: %v", err)
+	}
+	if err := NewClient(conn, "c").Initialize(nil, nil); err == nil {
+		t.Errorf("expected error initializing solver that is not served")
+	}
+}
+
+func TestPresentAndCleanUp(t *testing.T) {
+	slv := &fakeSolver{name: "test"}
+	conn, stop := serve(t, slv)
+	defer stop()
+
+	ch := &whapi.ChallengeRequest{
+		UID:                     "uid",
+		Type:                    "dns-01",
+		DNSName:                 "example.com",
+		Key:                     "key",
+		ResourceNamespace:       "ns",
+		ResolvedFQDN:            "_acme-challenge.example.com.",
+		ResolvedZone:            "example.com.",
+		AllowAmbientCredentials: true,
+		Config:                  &apiext.JSON{Raw: []byte(`{"a":"b"}`)},
+	}
+
+	cl := NewClient(conn, "test")
+	if err := cl.Present(ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := ch.DeepCopy()
+	expected.Action = whapi.ChallengeActionPresent
+	if !reflect.DeepEqual(slv.presented, expected) {
+		t.Errorf("unexpected request presented, exp=%+v, got=%+v", expected, slv.presented)
+	}
+
+	if err := cl.CleanUp(ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected.Action = whapi.ChallengeActionCleanUp
+	if !reflect.DeepEqual(slv.cleanedUp, expected) {
+		t.Errorf("unexpected request cleaned up, exp=%+v, got=%+v", expected, slv.cleanedUp)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	slv := &fakeSolver{name: "test", err: errors.New("solver failed")}
+	conn, stop := serve(t, slv)
+	defer stop()
+
+	ch := &whapi.ChallengeRequest{DNSName: "example.com"}
+
+	err := NewClient(conn, "test").Present(ch)
+	if err == nil || err.Error() != "solver failed" || status.Code(err) != codes.Unknown {
+		t.Errorf("expected solver error to be returned, got: %v", err)
+	}
+
+	err = NewClient(conn, "missing").CleanUp(ch)
+	if err == nil || err.Error() != `solver "missing" not found` || status.Code(err) != codes.NotFound {
+		t.Errorf("expected not found error to be returned, got: %v", err)
+	}
+
+	slv.err = status.Error(codes.InvalidArgument, "invalid config")
+	err = NewClient(conn, "test").CleanUp(ch)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code() != codes.InvalidArgument || err.Error() != "invalid config" {
+		t.Errorf("expected the status code returned by the solver to be preserved, got: %v", err)
+	}
+}
+
+func TestDialMutualTLS(t *testing.T) {
+	ca, caKey := generateCertificate(t, "ca", nil, nil)
+	serverCert, serverKey := generateCertificate(t, "server", ca, caKey)
+	clientCert, clientKey := generateCertificate(t, "client", ca, caKey)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	lis, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{serverCert.Raw}, PrivateKey: serverKey}},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	v1alpha1.RegisterSolverServer(srv, NewServer(&fakeSolver{name: "test"}))
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	caPEM, err := pki.EncodeX509(ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initialize := func(cert *tls.Certificate) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		conn, err := Dial(ctx, lis.Addr().String(), caPEM, cert)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		return NewClient(conn, "test").Initialize(nil, nil)
+	}
+
+	if err := initialize(&tls.Certificate{Certificate: [][]byte{clientCert.Raw}, PrivateKey: clientKey}); err != nil {
+		t.Errorf("unexpected error connecting with a client certificate: %v", err)
+	}
+	if err := initialize(nil); err == nil {
+		t.Errorf("expected an error connecting without a client certificate")
+	}
+}
+
+// generateCertificate returns a certificate for commonName signed by parent,
+// or a self-signed CA certificate if parent is nil.
+func generateCertificate(t *testing.T, commonName string, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer) {
+	pk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, pk
+	}
+	_, cert, err := pki.SignCertificate(template, parent, pk.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pk
+}
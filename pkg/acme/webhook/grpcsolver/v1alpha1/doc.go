@@ -0,0 +1,23 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains the message types and service definition of the
+// gRPC protocol spoken between cert-manager and out-of-process ACME solvers.
+// The Go types are generated from solver.proto, which should be used to build
+// solvers in languages other than Go.
+package v1alpha1
+
+//go:generate protoc --go_out=plugins=grpc:. solver.proto
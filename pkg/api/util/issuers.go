@@ -19,6 +19,7 @@ package util
 import (
 	"fmt"
 
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 )
@@ -34,6 +35,10 @@ const (
 	IssuerSelfSigned string = "selfsigned"
 	// IssuerVenafi uses Venafi Trust Protection Platform and Venafi Cloud
 	IssuerVenafi string = "venafi"
+	// IssuerEST uses a server implementing Enrollment over Secure Transport
+	IssuerEST string = "est"
+	// IssuerKubernetesCSR uses a signer of the Kubernetes CertificateSigningRequest API
+	IssuerKubernetesCSR string = "kubernetescsr"
 )
 
 // NameForIssuer determines the name of the Issuer implementation given an
@@ -50,6 +55,10 @@ func NameForIssuer(i cmapi.GenericIssuer) (string, error) {
 		return IssuerSelfSigned, nil
 	case i.GetSpec().Venafi != nil:
 		return IssuerVenafi, nil
+	case i.GetSpec().EST != nil:
+		return IssuerEST, nil
+	case i.GetSpec().KubernetesCSR != nil:
+		return IssuerKubernetesCSR, nil
 	}
 	return "", fmt.Errorf("no issuer specified for Issuer '%s/%s'", i.GetObjectMeta().Namespace, i.GetObjectMeta().Name)
 }
@@ -61,3 +70,15 @@ func IssuerKind(ref cmmeta.ObjectReference) string {
 	}
 	return ref.Kind
 }
+
+// IssuerGroupsEqual returns whether the two issuer groups are the same. An
+// empty group is the cert-manager.io group.
+func IssuerGroupsEqual(l, r string) bool {
+	if l == "" {
+		l = certmanager.GroupName
+	}
+	if r == "" {
+		r = certmanager.GroupName
+	}
+	return l == r
+}
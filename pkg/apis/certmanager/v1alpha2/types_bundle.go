@@ -0,0 +1,213 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A Bundle is a set of trusted CA certificates gathered from a number of
+// sources, which is synced into a ConfigMap in each selected namespace.
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",priority=1,description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. It is not guaranteed to be set in happens-before order across separate operations. Clients may not set this value. It is represented in RFC3339 form and is in UTC."
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=bundles,scope=Cluster
+type Bundle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BundleSpec   `json:"spec,omitempty"`
+	Status BundleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BundleList is a list of Bundles
+type BundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Bundle `json:"items"`
+}
+
+// BundleSpec defines the sources of the CA certificates in a Bundle, and
+// where the Bundle should be synced to.
+type BundleSpec struct {
+	// Sources is the list of sources of PEM encoded CA certificates that make
+	// up the bundle. Certificates found in more than one source are only
+	// included in the bundle once, and expired certificates are omitted.
+	Sources []BundleSource `json:"sources"`
+
+	// Target configures where the bundle is synced to.
+	Target BundleTarget `json:"target"`
+}
+
+// BundleSource is a source of PEM encoded CA certificates.
+// Exactly one field must be set.
+type BundleSource struct {
+	// Secret selects a key of a Secret containing PEM encoded certificates.
+	// +optional
+	Secret *BundleSourceObjectKeySelector `json:"secret,omitempty"`
+
+	// ConfigMap selects a key of a ConfigMap containing PEM encoded
+	// certificates.
+	// +optional
+	ConfigMap *BundleSourceObjectKeySelector `json:"configMap,omitempty"`
+
+	// Issuer references a CA Issuer or ClusterIssuer whose CA certificate
+	// should be included in the bundle.
+	// +optional
+	Issuer *BundleSourceIssuerReference `json:"issuer,omitempty"`
+
+	// InLine is a PEM encoded certificate bundle.
+	// +optional
+	InLine *string `json:"inLine,omitempty"`
+}
+
+// BundleSourceObjectKeySelector selects a key of a namespaced resource.
+type BundleSourceObjectKeySelector struct {
+	// Namespace of the resource being referred to.
+	Namespace string `json:"namespace"`
+
+	// Name of the resource being referred to.
+	Name string `json:"name"`
+
+	// Key of the entry in the resource's `data` field to be used.
+	Key string `json:"key"`
+}
+
+// BundleSourceIssuerReference is a reference to an Issuer or ClusterIssuer.
+type BundleSourceIssuerReference struct {
+	// Name of the issuer being referred to.
+	Name string `json:"name"`
+
+	// Kind of the issuer being referred to, either Issuer or ClusterIssuer.
+	// Defaults to Issuer.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Namespace of the Issuer being referred to. Required if the Kind is
+	// Issuer.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BundleTarget configures where a Bundle is synced to.
+type BundleTarget struct {
+	// ConfigMap configures the ConfigMap that the bundle is written to in
+	// each selected namespace.
+	ConfigMap BundleTargetConfigMap `json:"configMap"`
+
+	// NamespaceSelector selects the namespaces that the bundle is synced to.
+	// If not set, the bundle is synced to all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// AdditionalFormats configures other encodings of the bundle to be
+	// written to the target ConfigMap as binary data.
+	// +optional
+	AdditionalFormats *BundleTargetAdditionalFormats `json:"additionalFormats,omitempty"`
+}
+
+// DefaultBundleTargetKey is the key of a Bundle's target ConfigMap that the
+// PEM encoded bundle is written to if no key is specified.
+const DefaultBundleTargetKey = "ca.crt"
+
+// BundleTargetConfigMap configures the ConfigMap a Bundle is written to.
+type BundleTargetConfigMap struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key of the ConfigMap's `data` field that the PEM encoded bundle is
+	// written to. Defaults to `ca.crt`.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// BundleTargetAdditionalFormats configures other encodings of a Bundle.
+type BundleTargetAdditionalFormats struct {
+	// JKS configures the bundle to be written as a Java keystore containing
+	// each certificate as a trusted certificate entry. The keystore is
+	// encrypted with the password `changeit`.
+	// +optional
+	JKS *BundleTargetKeystore `json:"jks,omitempty"`
+
+	// PKCS12 configures the bundle to be written as a PKCS#12 truststore
+	// containing each certificate as a trusted certificate. The truststore is
+	// encrypted with the password `changeit`.
+	// +optional
+	PKCS12 *BundleTargetKeystore `json:"pkcs12,omitempty"`
+}
+
+// BundleTargetKeystore configures a keystore encoding of a Bundle.
+type BundleTargetKeystore struct {
+	// Key of the ConfigMap's `binaryData` field that the keystore is written
+	// to.
+	Key string `json:"key"`
+}
+
+// BundleStatus defines the observed state of a Bundle.
+type BundleStatus struct {
+	// +optional
+	Conditions []BundleCondition `json:"conditions,omitempty"`
+
+	// NotAfter is the earliest expiry time of the certificates in the
+	// bundle.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+}
+
+// BundleCondition contains condition information for a Bundle.
+type BundleCondition struct {
+	// Type of the condition, currently ('Ready').
+	Type BundleConditionType `json:"type"`
+
+	// Status of the condition, one of ('True', 'False', 'Unknown').
+	Status cmmeta.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BundleConditionType represents a Bundle condition value.
+type BundleConditionType string
+
+const (
+	// BundleConditionReady indicates that a bundle has been synced to all
+	// of its selected namespaces.
+	BundleConditionReady BundleConditionType = "Ready"
+)
@@ -122,6 +122,19 @@ type CertificateSpec struct {
 	// +optional
 	Keystores *CertificateKeystores `json:"keystores,omitempty"`
 
+	// SecretReplication configures other namespaces that the `secretName`
+	// Secret resource is copied into. Copies are kept up to date when the
+	// certificate is renewed, and are deleted when the Certificate is deleted
+	// or a namespace is no longer selected.
+	// Secrets are only copied into namespaces that allow it using the
+	// `cert-manager.io/allow-secret-replication-from` annotation, which holds
+	// a comma separated list of the namespaces Secrets may be copied from, or
+	// '*' to allow any namespace.
+	// Replication is only performed by the experimental certificates
+	// controllers.
+	// +optional
+	SecretReplication *CertificateSecretReplication `json:"secretReplication,omitempty"`
+
 	// IssuerRef is a reference to the issuer for this certificate.
 	// If the 'kind' field is not set, or set to 'Issuer', an Issuer resource
 	// with the given name in the same namespace as the Certificate will be used.
@@ -227,6 +240,19 @@ type X509Subject struct {
 	SerialNumber string `json:"serialNumber,omitempty"`
 }
 
+// CertificateSecretReplication configures the namespaces that a
+// Certificate's Secret resource is replicated into. A namespace is selected
+// if it is listed in `namespaces` or matches `namespaceSelector`.
+type CertificateSecretReplication struct {
+	// Namespaces is a list of namespaces that the Secret is copied into.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector selects the namespaces that the Secret is copied into.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
 // CertificateKeystores configures additional keystore output formats to be
 // created in the Certificate's output Secret.
 type CertificateKeystores struct {
@@ -46,6 +46,40 @@ const (
 	// if the challenge type is set to http01
 	IngressACMEIssuerHTTP01IngressClassAnnotationKey = "acme.cert-manager.io/http01-ingress-class"
 
+	// The following annotations can be used to configure the Certificate
+	// resources created for an Ingress. The common name is set using
+	// CommonNameAnnotationKey.
+
+	// DurationAnnotationKey sets the requested duration of the Certificate,
+	// as parsed by time.ParseDuration.
+	DurationAnnotationKey = "cert-manager.io/duration"
+	// RenewBeforeAnnotationKey sets how long before expiry the Certificate
+	// should be renewed, as parsed by time.ParseDuration.
+	RenewBeforeAnnotationKey = "cert-manager.io/renew-before"
+	// UsagesAnnotationKey is a comma separated list of key usages to request.
+	UsagesAnnotationKey = "cert-manager.io/usages"
+	// PrivateKeyAlgorithmAnnotationKey sets the private key algorithm, either
+	// 'rsa' or 'ecdsa'.
+	PrivateKeyAlgorithmAnnotationKey = "cert-manager.io/private-key-algorithm"
+	// PrivateKeyEncodingAnnotationKey sets the private key encoding, either
+	// 'pkcs1' or 'pkcs8'.
+	PrivateKeyEncodingAnnotationKey = "cert-manager.io/private-key-encoding"
+	// PrivateKeySizeAnnotationKey sets the private key size in bits.
+	PrivateKeySizeAnnotationKey = "cert-manager.io/private-key-size"
+	// PrivateKeyRotationPolicyAnnotationKey sets the private key rotation
+	// policy, either 'Never' or 'Always'.
+	PrivateKeyRotationPolicyAnnotationKey = "cert-manager.io/private-key-rotation-policy"
+
+	// Subject annotations. All but the serial number are comma separated lists.
+	SubjectOrganizationsAnnotationKey       = "cert-manager.io/subject-organizations"
+	SubjectOrganizationalUnitsAnnotationKey = "cert-manager.io/subject-organizationalunits"
+	SubjectCountriesAnnotationKey           = "cert-manager.io/subject-countries"
+	SubjectProvincesAnnotationKey           = "cert-manager.io/subject-provinces"
+	SubjectLocalitiesAnnotationKey          = "cert-manager.io/subject-localities"
+	SubjectStreetAddressesAnnotationKey     = "cert-manager.io/subject-streetaddresses"
+	SubjectPostalCodesAnnotationKey         = "cert-manager.io/subject-postalcodes"
+	SubjectSerialNumberAnnotationKey        = "cert-manager.io/subject-serialnumber"
+
 	// IngressClassAnnotationKey picks a specific "class" for the Ingress. The
 	// controller only processes Ingresses with this annotation either unset, or
 	// set to either the configured value or the empty string.
@@ -57,6 +91,20 @@ const (
 	CRPrivateKeyAnnotationKey = "cert-manager.io/private-key-secret-name"
 	// Annotation to declare the CertificateRequest "revision", beloning to a Certificate Resource
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
+
+	// CertificateRequestNamespaceAnnotationKey and
+	// CertificateRequestNameAnnotationKey are set on resources created to
+	// fulfil a CertificateRequest that cannot be owned by it, such as cluster
+	// scoped Kubernetes CertificateSigningRequests.
+	CertificateRequestNamespaceAnnotationKey = "cert-manager.io/certificate-request-namespace"
+	CertificateRequestNameAnnotationKey      = "cert-manager.io/certificate-request-name"
+)
+
+// Label names for ConfigMaps
+const (
+	// BundleNameLabelKey is set on the ConfigMaps that a Bundle is synced to,
+	// and holds the name of the Bundle.
+	BundleNameLabelKey = "cert-manager.io/bundle-name"
 )
 
 const (
@@ -73,6 +121,7 @@ const (
 	IssuerKind             = "Issuer"
 	CertificateKind        = "Certificate"
 	CertificateRequestKind = "CertificateRequest"
+	BundleKind             = "Bundle"
 )
 
 const (
@@ -92,12 +141,35 @@ const (
 	// as namespace/name.
 	WantInjectFromSecretAnnotation = "cert-manager.io/inject-ca-from-secret"
 
+	// WantInjectFromIssuerAnnotation is the annotation that specifies that a
+	// particular object wants injection of the CA of an Issuer. It takes the
+	// form of a reference to an Issuer as namespace/name, or to a
+	// ClusterIssuer as name. Only CA, SelfSigned and Vault issuers are
+	// supported.
+	WantInjectFromIssuerAnnotation = "cert-manager.io/inject-ca-from-issuer"
+
 	// AllowsInjectionFromSecretAnnotation is an annotation that must be added
 	// to Secret resource that want to denote that they can be directly
 	// injected into injectables that have a `inject-ca-from-secret` annotation.
 	// If an injectable references a Secret that does NOT have this annotation,
 	// the cainjector will refuse to inject the secret.
 	AllowsInjectionFromSecretAnnotation = "cert-manager.io/allow-direct-injection"
+
+	// InjectCAKeyAnnotation is the annotation that specifies the data key of
+	// a ConfigMap that CA data should be injected into. Defaults to 'ca.crt'.
+	InjectCAKeyAnnotation = "cert-manager.io/inject-ca-key"
+
+	// InjectCAPathAnnotation is the annotation that specifies the field that
+	// CA data should be injected into for resources that are injected into
+	// using the unstructured injector. It takes the form of a JSONPath to a
+	// single field, such as '.spec.caBundle'.
+	InjectCAPathAnnotation = "cert-manager.io/inject-ca-path"
+
+	// InjectCAFormatAnnotation is the annotation that specifies how CA data
+	// is written to the field named by the inject-ca-path annotation. It may
+	// be 'base64' (the default, as used by Kubernetes caBundle fields) or
+	// 'pem' to write the PEM encoded CA data as a plain string.
+	InjectCAFormatAnnotation = "cert-manager.io/inject-ca-format"
 )
 
 // Issuer specific Annotations
@@ -107,6 +179,12 @@ const (
 	// The value is an array with objects containing the name and value keys
 	// for example: `[{"name": "custom-field", "value": "custom-value"}]`
 	VenafiCustomFieldsAnnotationKey = "venafi.cert-manager.io/custom-fields"
+
+	// VenafiPickupIDAnnotationKey is the annotation used to store the pickup ID
+	// of the request sent to Venafi for a CertificateRequest. Once set, the
+	// Venafi issuer will poll this request for the signed certificate rather
+	// than sending a new request.
+	VenafiPickupIDAnnotationKey = "venafi.cert-manager.io/pickup-id"
 )
 
 // KeyUsage specifies valid usage contexts for keys.
@@ -28,6 +28,10 @@ type FakeCertmanagerV1alpha2 struct {
 	*testing.Fake
 }
 
+func (c *FakeCertmanagerV1alpha2) Bundles() v1alpha2.BundleInterface {
+	return &FakeBundles{c}
+}
+
 func (c *FakeCertmanagerV1alpha2) Certificates(namespace string) v1alpha2.CertificateInterface {
 	return &FakeCertificates{c, namespace}
 }
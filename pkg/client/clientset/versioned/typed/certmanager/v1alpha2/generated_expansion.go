@@ -18,6 +18,8 @@ limitations under the License.
 
 package v1alpha2
 
+type BundleExpansion interface{}
+
 type CertificateExpansion interface{}
 
 type CertificateRequestExpansion interface{}
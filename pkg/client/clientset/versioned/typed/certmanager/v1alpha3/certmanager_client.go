@@ -26,6 +26,7 @@ import (
 
 type CertmanagerV1alpha3Interface interface {
 	RESTClient() rest.Interface
+	BundlesGetter
 	CertificatesGetter
 	CertificateRequestsGetter
 	ClusterIssuersGetter
@@ -37,6 +38,10 @@ type CertmanagerV1alpha3Client struct {
 	restClient rest.Interface
 }
 
+func (c *CertmanagerV1alpha3Client) Bundles() BundleInterface {
+	return newBundles(c)
+}
+
 func (c *CertmanagerV1alpha3Client) Certificates(namespace string) CertificateInterface {
 	return newCertificates(c, namespace)
 }
@@ -28,6 +28,10 @@ type FakeCertmanagerV1alpha3 struct {
 	*testing.Fake
 }
 
+func (c *FakeCertmanagerV1alpha3) Bundles() v1alpha3.BundleInterface {
+	return &FakeBundles{c}
+}
+
 func (c *FakeCertmanagerV1alpha3) Certificates(namespace string) v1alpha3.CertificateInterface {
 	return &FakeCertificates{c, namespace}
 }
@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// Bundles returns a BundleInformer.
+	Bundles() BundleInformer
 	// Certificates returns a CertificateInformer.
 	Certificates() CertificateInformer
 	// CertificateRequests returns a CertificateRequestInformer.
@@ -45,6 +47,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// Bundles returns a BundleInformer.
+func (v *version) Bundles() BundleInformer {
+	return &bundleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Certificates returns a CertificateInformer.
 func (v *version) Certificates() CertificateInformer {
 	return &certificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -68,6 +68,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Acme().V1alpha3().Orders().Informer()}, nil
 
 		// Group=cert-manager.io, Version=v1alpha2
+	case certmanagerv1alpha2.SchemeGroupVersion.WithResource("bundles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1alpha2().Bundles().Informer()}, nil
 	case certmanagerv1alpha2.SchemeGroupVersion.WithResource("certificates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1alpha2().Certificates().Informer()}, nil
 	case certmanagerv1alpha2.SchemeGroupVersion.WithResource("certificaterequests"):
@@ -78,6 +80,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1alpha2().Issuers().Informer()}, nil
 
 		// Group=cert-manager.io, Version=v1alpha3
+	case certmanagerv1alpha3.SchemeGroupVersion.WithResource("bundles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1alpha3().Bundles().Informer()}, nil
 	case certmanagerv1alpha3.SchemeGroupVersion.WithResource("certificates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1alpha3().Certificates().Informer()}, nil
 	case certmanagerv1alpha3.SchemeGroupVersion.WithResource("certificaterequests"):
@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1alpha3
 
+// BundleListerExpansion allows custom methods to be added to
+// BundleLister.
+type BundleListerExpansion interface{}
+
 // CertificateListerExpansion allows custom methods to be added to
 // CertificateLister.
 type CertificateListerExpansion interface{}
@@ -18,6 +18,7 @@ package acmechallenges
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -40,6 +41,7 @@ import (
 
 const (
 	reasonDomainVerified = "DomainVerified"
+	reasonCNAMERequired  = "CNAMERequired"
 )
 
 // solver solves ACME challenges by presenting the given token and key in an
@@ -168,6 +170,12 @@ func (c *controller) Sync(ctx context.Context, ch *cmacme.Challenge) (err error)
 
 	if !ch.Status.Presented {
 		err := solver.Present(ctx, genericIssuer, ch)
+		var cnameErr *dnsutil.CNAMERequiredError
+		if errors.As(err, &cnameErr) {
+			c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonCNAMERequired, "Waiting for CNAME record to be created: %v", err)
+			ch.Status.Reason = fmt.Sprintf("Waiting for CNAME record %q pointing to %q to be created", cnameErr.Record, cnameErr.Target)
+			return err
+		}
 		if err != nil {
 			c.recorder.Eventf(ch, corev1.EventTypeWarning, "PresentError", "Error presenting challenge: %v", err)
 			ch.Status.Reason = err.Error()
@@ -32,6 +32,7 @@ import (
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
 	"github.com/jetstack/cert-manager/pkg/issuer"
+	dnsutil "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
 	"github.com/jetstack/cert-manager/test/unit/gen"
 )
 
@@ -154,6 +155,43 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
+		"set a CNAME required reason if acme-dns reports a missing CNAME record": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType("dns-01"),
+			),
+			dnsSolver: &fakeSolver{
+				fakePresent: func(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *cmacme.Challenge) error {
+					return &dnsutil.CNAMERequiredError{Record: "_acme-challenge.example.com", Target: "abc.acme-dns.example.net"}
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType("dns-01"),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengeType("dns-01"),
+							gen.SetChallengeReason(`Waiting for CNAME record "_acme-challenge.example.com" pointing to "abc.acme-dns.example.net" to be created`),
+						))),
+				},
+				ExpectedEvents: []string{
+					`Warning CNAMERequired Waiting for CNAME record to be created: a CNAME record for "_acme-challenge.example.com" pointing to "abc.acme-dns.example.net" must be created to solve challenges`,
+				},
+			},
+			expectErr: true,
+		},
 		"accept the challenge if the self check is passing": {
 			challenge: gen.ChallengeFrom(baseChallenge,
 				gen.SetChallengeProcessing(true),
@@ -281,8 +281,9 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 		return nil, err
 	}
 
-	// 4. handle overriding the HTTP01 ingress class and name fields using the
-	//    ACMECertificateHTTP01IngressNameOverride & Class annotations
+	// 4. handle overriding the HTTP01 ingress class, class name and name
+	//    fields using the ACMECertificateHTTP01IngressNameOverride, Class &
+	//    ClassName annotations
 	if err := applyIngressParameterAnnotationOverrides(o, selectedSolver); err != nil {
 		return nil, err
 	}
@@ -309,14 +310,22 @@ func applyIngressParameterAnnotationOverrides(o *cmacme.Order, s *cmacme.ACMECha
 
 	manualIngressName, hasManualIngressName := o.Annotations[cmacme.ACMECertificateHTTP01IngressNameOverride]
 	manualIngressClass, hasManualIngressClass := o.Annotations[cmacme.ACMECertificateHTTP01IngressClassOverride]
-	// don't allow both override annotations to be specified at once
-	if hasManualIngressName && hasManualIngressClass {
-		return fmt.Errorf("both ingress name and ingress class overrides specified - only one may be specified at a time")
+	manualIngressClassName, hasManualIngressClassName := o.Annotations[cmacme.ACMECertificateHTTP01IngressClassNameOverride]
+	// don't allow more than one override annotation to be specified at once
+	overrides := 0
+	for _, has := range []bool{hasManualIngressName, hasManualIngressClass, hasManualIngressClassName} {
+		if has {
+			overrides++
+		}
+	}
+	if overrides > 1 {
+		return fmt.Errorf("more than one of the ingress name, ingress class and ingress class name overrides specified - only one may be specified at a time")
 	}
 	// if an override annotation is specified, clear out the existing solver
 	// config
-	if hasManualIngressClass || hasManualIngressName {
+	if overrides > 0 {
 		s.HTTP01.Ingress.Class = nil
+		s.HTTP01.Ingress.IngressClassName = nil
 		s.HTTP01.Ingress.Name = ""
 	}
 	if hasManualIngressName {
@@ -325,6 +334,9 @@ func applyIngressParameterAnnotationOverrides(o *cmacme.Order, s *cmacme.ACMECha
 	if hasManualIngressClass {
 		s.HTTP01.Ingress.Class = &manualIngressClass
 	}
+	if hasManualIngressClassName {
+		s.HTTP01.Ingress.IngressClassName = &manualIngressClassName
+	}
 	return nil
 }
 
@@ -0,0 +1,256 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundles implements the bundles controller, which gathers the CA
+// certificates of a Bundle from its sources and syncs them into a ConfigMap
+// in each of the Bundle's selected namespaces.
+package bundles
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	ControllerName = "bundles"
+)
+
+type controller struct {
+	bundleLister        cmlisters.BundleLister
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	namespaceLister     corelisters.NamespaceLister
+	configMapLister     corelisters.ConfigMapLister
+	secretLister        corelisters.SecretLister
+
+	// maintain a reference to the workqueue for this controller
+	// so the handleOwnedResource method can enqueue resources
+	queue workqueue.RateLimitingInterface
+
+	// logger to be used by this controller
+	log logr.Logger
+
+	// clientsets used to update cert-manager API resources and ConfigMaps
+	cmClient   cmclient.Interface
+	kubeClient kubernetes.Interface
+
+	// used to record Events about resources to the API
+	recorder record.EventRecorder
+
+	// clock is used to determine whether certificates have expired
+	clock clock.Clock
+
+	// clusterResourceNamespace is the namespace used to store resources
+	// referenced by ClusterIssuer resources, e.g. CA secrets
+	clusterResourceNamespace string
+}
+
+// Register registers and constructs the controller using the provided context.
+// It returns the workqueue to be used to enqueue items, a list of
+// InformerSynced functions that must be synced, or an error.
+func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	c.log = logf.FromContext(ctx.RootContext, ControllerName)
+
+	// create a queue used to queue up items to be processed
+	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	bundleInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Bundles()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Issuers()
+	clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().ClusterIssuers()
+	namespaceInformer := ctx.KubeSharedInformerFactory.Core().V1().Namespaces()
+	configMapInformer := ctx.KubeSharedInformerFactory.Core().V1().ConfigMaps()
+	secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		bundleInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		clusterIssuerInformer.Informer().HasSynced,
+		namespaceInformer.Informer().HasSynced,
+		configMapInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+	}
+
+	// set all the references to the listers for used by the Sync function
+	c.bundleLister = bundleInformer.Lister()
+	c.issuerLister = issuerInformer.Lister()
+	c.clusterIssuerLister = clusterIssuerInformer.Lister()
+	c.namespaceLister = namespaceInformer.Lister()
+	c.configMapLister = configMapInformer.Lister()
+	c.secretLister = secretInformer.Lister()
+
+	// register handler functions
+	bundleInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.issuerChanged})
+	clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.issuerChanged})
+	namespaceInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.namespaceChanged})
+	configMapInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.configMapChanged})
+	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.secretChanged})
+
+	// instantiate additional helpers used by this controller
+	c.cmClient = ctx.CMClient
+	c.kubeClient = ctx.Client
+	c.recorder = ctx.Recorder
+	c.clock = ctx.Clock
+	c.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
+
+	return c.queue, mustSync, nil
+}
+
+// namespaceChanged enqueues all Bundles, as a new or relabelled namespace
+// may change the set of namespaces each Bundle is synced to.
+func (c *controller) namespaceChanged(obj interface{}) {
+	c.enqueueBundles(func(*cmapi.Bundle) bool { return true })
+}
+
+// configMapChanged enqueues the Bundles which use the ConfigMap as a source,
+// and the Bundle that owns the ConfigMap so that changes to synced ConfigMaps
+// are reverted.
+func (c *controller) configMapChanged(obj interface{}) {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Object is not a configmap object %#v", obj))
+		return
+	}
+	if ref := metav1.GetControllerOf(cm); ref != nil && ref.Kind == cmapi.BundleKind {
+		c.queue.Add(ref.Name)
+	}
+	c.enqueueBundles(func(bundle *cmapi.Bundle) bool {
+		for _, source := range bundle.Spec.Sources {
+			if source.ConfigMap != nil && source.ConfigMap.Namespace == cm.Namespace && source.ConfigMap.Name == cm.Name {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// secretChanged enqueues the Bundles which use the Secret as a source,
+// either directly or as the CA of a referenced issuer.
+func (c *controller) secretChanged(obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Object is not a secret object %#v", obj))
+		return
+	}
+	c.enqueueBundles(func(bundle *cmapi.Bundle) bool {
+		for _, source := range bundle.Spec.Sources {
+			if source.Secret != nil && source.Secret.Namespace == secret.Namespace && source.Secret.Name == secret.Name {
+				return true
+			}
+			if source.Issuer == nil {
+				continue
+			}
+			iss, namespace, err := c.getIssuer(source.Issuer)
+			if err != nil {
+				continue
+			}
+			if ca := iss.GetSpec().CA; ca != nil && namespace == secret.Namespace && ca.SecretName == secret.Name {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// issuerChanged enqueues the Bundles which use the Issuer or ClusterIssuer
+// as a source.
+func (c *controller) issuerChanged(obj interface{}) {
+	iss, ok := obj.(cmapi.GenericIssuer)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Object is not an issuer object %#v", obj))
+		return
+	}
+	kind := cmapi.IssuerKind
+	if _, ok := iss.(*cmapi.ClusterIssuer); ok {
+		kind = cmapi.ClusterIssuerKind
+	}
+	c.enqueueBundles(func(bundle *cmapi.Bundle) bool {
+		for _, source := range bundle.Spec.Sources {
+			if source.Issuer != nil && issuerRefKind(source.Issuer) == kind &&
+				source.Issuer.Namespace == iss.GetObjectMeta().Namespace && source.Issuer.Name == iss.GetObjectMeta().Name {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// enqueueBundles enqueues all Bundles for which fn returns true.
+func (c *controller) enqueueBundles(fn func(*cmapi.Bundle) bool) {
+	bundles, err := c.bundleLister.List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("Error listing bundles: %v", err))
+		return
+	}
+	for _, bundle := range bundles {
+		if fn(bundle) {
+			c.queue.Add(bundle.Name)
+		}
+	}
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(nil, "invalid resource key")
+		return nil
+	}
+
+	bundle, err := c.bundleLister.Get(name)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Error(err, "bundle in work queue no longer exists")
+			return nil
+		}
+
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, bundle))
+	return c.Sync(ctx, bundle)
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controller{}).
+			Complete()
+	})
+}
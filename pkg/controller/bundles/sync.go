@@ -0,0 +1,401 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+
+	jks "github.com/pavel-v-chernykh/keystore-go"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"software.sslmate.com/src/go-pkcs12"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	internalapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/pkg/webhook"
+)
+
+const (
+	reasonSynced             = "Synced"
+	reasonConfigError        = "ConfigError"
+	reasonSourceError        = "SourceError"
+	reasonSyncError          = "SyncError"
+	reasonExpiredCertificate = "ExpiredCertificate"
+
+	// truststorePassword is the password used to encrypt JKS and PKCS#12
+	// truststores. The truststores only contain public certificates, so the
+	// default password of the Java truststore is used.
+	truststorePassword = "changeit"
+)
+
+// bundleData is the contents of a Bundle.
+type bundleData struct {
+	certs []*x509.Certificate
+	pem   []byte
+}
+
+func (c *controller) Sync(ctx context.Context, bundle *cmapi.Bundle) (err error) {
+	log := logf.FromContext(ctx)
+
+	bundleCopy := bundle.DeepCopy()
+	defer func() {
+		if _, saveErr := c.updateBundleStatus(bundle, bundleCopy); saveErr != nil {
+			err = errors.NewAggregate([]error{saveErr, err})
+		}
+	}()
+
+	el := webhook.ValidationRegistry.Validate(bundleCopy, internalapi.SchemeGroupVersion.WithKind("Bundle"))
+	if len(el) > 0 {
+		msg := fmt.Sprintf("Resource validation failed: %v", el.ToAggregate())
+		apiutil.SetBundleCondition(bundleCopy, cmapi.BundleConditionReady, cmmeta.ConditionFalse, reasonConfigError, msg)
+		return nil
+	}
+
+	data, err := c.buildBundle(bundleCopy)
+	if err != nil {
+		log.Error(err, "error building bundle from sources")
+		c.recorder.Event(bundleCopy, corev1.EventTypeWarning, reasonSourceError, err.Error())
+		apiutil.SetBundleCondition(bundleCopy, cmapi.BundleConditionReady, cmmeta.ConditionFalse, reasonSourceError, err.Error())
+		// the bundle will be resynced when its sources change
+		return nil
+	}
+
+	notAfter := data.certs[0].NotAfter
+	for _, cert := range data.certs[1:] {
+		if cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	// only update the expiry time if it has changed, as times read from the
+	// API may be in a different location
+	if bundleCopy.Status.NotAfter == nil || !bundleCopy.Status.NotAfter.Time.Equal(notAfter) {
+		bundleCopy.Status.NotAfter = &metav1.Time{Time: notAfter}
+	}
+
+	synced, err := c.syncTargets(ctx, bundleCopy, data)
+	if err != nil {
+		log.Error(err, "error syncing bundle to target namespaces")
+		c.recorder.Event(bundleCopy, corev1.EventTypeWarning, reasonSyncError, err.Error())
+		apiutil.SetBundleCondition(bundleCopy, cmapi.BundleConditionReady, cmmeta.ConditionFalse, reasonSyncError, err.Error())
+		return err
+	}
+
+	apiutil.SetBundleCondition(bundleCopy, cmapi.BundleConditionReady, cmmeta.ConditionTrue, reasonSynced,
+		fmt.Sprintf("Bundle of %d certificates synced to %d namespaces", len(data.certs), synced))
+
+	return nil
+}
+
+// buildBundle reads and decodes the certificates from each of the Bundle's
+// sources. Certificates are de-duplicated and returned in the order of the
+// Bundle's sources, omitting any that have expired.
+func (c *controller) buildBundle(bundle *cmapi.Bundle) (*bundleData, error) {
+	seen := make(map[string]bool)
+	data := &bundleData{}
+	for i, source := range bundle.Spec.Sources {
+		raw, err := c.readSource(&source)
+		if err != nil {
+			return nil, fmt.Errorf("error reading source %d: %v", i, err)
+		}
+		certs, err := pki.DecodeX509CertificateChainBytes(raw)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding certificates of source %d: %v", i, err)
+		}
+		for _, cert := range certs {
+			if seen[string(cert.Raw)] {
+				continue
+			}
+			seen[string(cert.Raw)] = true
+
+			if c.clock.Now().After(cert.NotAfter) {
+				c.recorder.Eventf(bundle, corev1.EventTypeWarning, reasonExpiredCertificate,
+					"Omitting certificate %q of source %d, which expired at %s", cert.Subject.String(), i, cert.NotAfter)
+				continue
+			}
+
+			data.certs = append(data.certs, cert)
+			data.pem = append(data.pem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+		}
+	}
+	if len(data.certs) == 0 {
+		return nil, fmt.Errorf("no unexpired certificates found in bundle sources")
+	}
+	return data, nil
+}
+
+// readSource returns the PEM encoded certificates of a Bundle source.
+func (c *controller) readSource(source *cmapi.BundleSource) ([]byte, error) {
+	switch {
+	case source.Secret != nil:
+		ref := source.Secret
+		secret, err := c.secretLister.Secrets(ref.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		data, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("secret %s/%s has no data for key %q", ref.Namespace, ref.Name, ref.Key)
+		}
+		return data, nil
+
+	case source.ConfigMap != nil:
+		ref := source.ConfigMap
+		cm, err := c.configMapLister.ConfigMaps(ref.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		data, ok := cm.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("configmap %s/%s has no data for key %q", ref.Namespace, ref.Name, ref.Key)
+		}
+		return []byte(data), nil
+
+	case source.Issuer != nil:
+		iss, namespace, err := c.getIssuer(source.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		ca := iss.GetSpec().CA
+		if ca == nil {
+			return nil, fmt.Errorf("%s %q is not a CA issuer", issuerRefKind(source.Issuer), source.Issuer.Name)
+		}
+		secret, err := c.secretLister.Secrets(namespace).Get(ca.SecretName)
+		if err != nil {
+			return nil, err
+		}
+		data, ok := secret.Data[corev1.TLSCertKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %s/%s has no data for key %q", namespace, ca.SecretName, corev1.TLSCertKey)
+		}
+		return data, nil
+
+	case source.InLine != nil:
+		return []byte(*source.InLine), nil
+	}
+
+	return nil, fmt.Errorf("no source type configured")
+}
+
+// getIssuer returns the issuer referenced by a Bundle source, along with the
+// namespace its resources are stored in.
+func (c *controller) getIssuer(ref *cmapi.BundleSourceIssuerReference) (cmapi.GenericIssuer, string, error) {
+	if issuerRefKind(ref) == cmapi.ClusterIssuerKind {
+		iss, err := c.clusterIssuerLister.Get(ref.Name)
+		return iss, c.clusterResourceNamespace, err
+	}
+	iss, err := c.issuerLister.Issuers(ref.Namespace).Get(ref.Name)
+	return iss, ref.Namespace, err
+}
+
+func issuerRefKind(ref *cmapi.BundleSourceIssuerReference) string {
+	if ref.Kind == "" {
+		return cmapi.IssuerKind
+	}
+	return ref.Kind
+}
+
+// syncTargets syncs the Bundle's target ConfigMap in each of its selected
+// namespaces, and deletes any ConfigMaps owned by the Bundle that are no
+// longer targeted. It returns the number of namespaces the bundle was synced
+// to.
+func (c *controller) syncTargets(ctx context.Context, bundle *cmapi.Bundle, data *bundleData) (int, error) {
+	log := logf.FromContext(ctx)
+	target := bundle.Spec.Target
+
+	selector := labels.Everything()
+	if target.NamespaceSelector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(target.NamespaceSelector)
+		if err != nil {
+			return 0, err
+		}
+	}
+	namespaces, err := c.namespaceLister.List(selector)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	selected := make(map[string]bool)
+	for _, ns := range namespaces {
+		// ConfigMaps cannot be created in terminating namespaces
+		if ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		selected[ns.Name] = true
+		if err := c.syncConfigMap(ctx, bundle, ns.Name, data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	owned, err := c.configMapLister.List(labels.SelectorFromSet(labels.Set{cmapi.BundleNameLabelKey: bundle.Name}))
+	if err != nil {
+		return 0, err
+	}
+	for _, cm := range owned {
+		if (selected[cm.Namespace] && cm.Name == target.ConfigMap.Name) || !metav1.IsControlledBy(cm, bundle) {
+			continue
+		}
+		log.Info("deleting configmap no longer targeted by bundle", "configmap", cm.Namespace+"/"+cm.Name)
+		err := c.kubeClient.CoreV1().ConfigMaps(cm.Namespace).Delete(context.TODO(), cm.Name, metav1.DeleteOptions{})
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(selected), errors.NewAggregate(errs)
+}
+
+// syncConfigMap ensures the Bundle's target ConfigMap in the given namespace
+// contains the bundle. ConfigMaps which are not owned by the Bundle are not
+// modified.
+func (c *controller) syncConfigMap(ctx context.Context, bundle *cmapi.Bundle, namespace string, data *bundleData) error {
+	log := logf.FromContext(ctx)
+	target := bundle.Spec.Target
+	name := target.ConfigMap.Name
+
+	existing, err := c.configMapLister.ConfigMaps(namespace).Get(name)
+	if k8sErrors.IsNotFound(err) {
+		existing = nil
+	} else if err != nil {
+		return err
+	}
+	if existing != nil && !metav1.IsControlledBy(existing, bundle) {
+		return fmt.Errorf("configmap %s/%s already exists and is not owned by this bundle", namespace, name)
+	}
+
+	key := target.ConfigMap.Key
+	if key == "" {
+		key = cmapi.DefaultBundleTargetKey
+	}
+	cmData := map[string]string{key: string(data.pem)}
+
+	var cmBinaryData map[string][]byte
+	// truststores are not encoded deterministically, so an existing
+	// truststore is kept unless the certificates in the bundle change.
+	unchanged := existing != nil && existing.Data[key] == string(data.pem)
+	addTruststore := func(keystore *cmapi.BundleTargetKeystore, encode func(*cmapi.Bundle, []*x509.Certificate) ([]byte, error)) error {
+		if keystore == nil {
+			return nil
+		}
+		truststore := []byte(nil)
+		if unchanged {
+			truststore = existing.BinaryData[keystore.Key]
+		}
+		if len(truststore) == 0 {
+			var err error
+			truststore, err = encode(bundle, data.certs)
+			if err != nil {
+				return err
+			}
+		}
+		if cmBinaryData == nil {
+			cmBinaryData = make(map[string][]byte)
+		}
+		cmBinaryData[keystore.Key] = truststore
+		return nil
+	}
+	if formats := target.AdditionalFormats; formats != nil {
+		if err := addTruststore(formats.JKS, encodeJKSTruststore); err != nil {
+			return err
+		}
+		if err := addTruststore(formats.PKCS12, encodePKCS12Truststore); err != nil {
+			return err
+		}
+	}
+
+	if existing == nil {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       namespace,
+				Labels:          map[string]string{cmapi.BundleNameLabelKey: bundle.Name},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(bundle, cmapi.SchemeGroupVersion.WithKind(cmapi.BundleKind))},
+			},
+			Data:       cmData,
+			BinaryData: cmBinaryData,
+		}
+		log.Info("creating bundle configmap", "configmap", namespace+"/"+name)
+		_, err := c.kubeClient.CoreV1().ConfigMaps(namespace).Create(context.TODO(), cm, metav1.CreateOptions{})
+		return err
+	}
+
+	if reflect.DeepEqual(existing.Data, cmData) && reflect.DeepEqual(existing.BinaryData, cmBinaryData) &&
+		existing.Labels[cmapi.BundleNameLabelKey] == bundle.Name {
+		return nil
+	}
+
+	cm := existing.DeepCopy()
+	if cm.Labels == nil {
+		cm.Labels = make(map[string]string)
+	}
+	cm.Labels[cmapi.BundleNameLabelKey] = bundle.Name
+	cm.Data = cmData
+	cm.BinaryData = cmBinaryData
+	log.Info("updating bundle configmap", "configmap", namespace+"/"+name)
+	_, err = c.kubeClient.CoreV1().ConfigMaps(namespace).Update(context.TODO(), cm, metav1.UpdateOptions{})
+	return err
+}
+
+// encodeJKSTruststore encodes the certificates as trusted certificate entries
+// of a JKS keystore.
+func encodeJKSTruststore(bundle *cmapi.Bundle, certs []*x509.Certificate) ([]byte, error) {
+	ks := jks.KeyStore{}
+	for i, cert := range certs {
+		ks[fmt.Sprintf("ca-%d", i)] = &jks.TrustedCertificateEntry{
+			Entry: jks.Entry{
+				CreationDate: bundle.CreationTimestamp.Time,
+			},
+			Certificate: jks.Certificate{
+				Type:    "X509",
+				Content: cert.Raw,
+			},
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := jks.Encode(buf, ks, []byte(truststorePassword)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodePKCS12Truststore encodes the certificates as trusted certificates of
+// a PKCS#12 truststore.
+func encodePKCS12Truststore(_ *cmapi.Bundle, certs []*x509.Certificate) ([]byte, error) {
+	return pkcs12.EncodeTrustStore(rand.Reader, certs, truststorePassword)
+}
+
+func (c *controller) updateBundleStatus(old, new *cmapi.Bundle) (*cmapi.Bundle, error) {
+	if reflect.DeepEqual(old.Status, new.Status) {
+		return nil, nil
+	}
+	return c.cmClient.CertmanagerV1alpha2().Bundles().UpdateStatus(context.TODO(), new, metav1.UpdateOptions{})
+}
@@ -0,0 +1,387 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	jks "github.com/pavel-v-chernykh/keystore-go"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclock "k8s.io/utils/clock/testing"
+	"software.sslmate.com/src/go-pkcs12"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const clusterResourceNamespace = "cert-manager"
+
+func TestSync(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	caA := mustCreateCertificatePEM(t, "ca-a", now.Add(time.Hour*24))
+	caB := mustCreateCertificatePEM(t, "ca-b", now.Add(time.Hour*48))
+	expired := mustCreateCertificatePEM(t, "expired", now.Add(-time.Hour))
+
+	inLine := func(data ...[]byte) cmapi.BundleSource {
+		s := string(bytes.Join(data, nil))
+		return cmapi.BundleSource{InLine: &s}
+	}
+	bundle := func(selector *metav1.LabelSelector, sources ...cmapi.BundleSource) *cmapi.Bundle {
+		return &cmapi.Bundle{
+			ObjectMeta: metav1.ObjectMeta{Name: "bundle", UID: "bundle-uid"},
+			Spec: cmapi.BundleSpec{
+				Sources: sources,
+				Target: cmapi.BundleTarget{
+					ConfigMap:         cmapi.BundleTargetConfigMap{Name: "trust"},
+					NamespaceSelector: selector,
+				},
+			},
+		}
+	}
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	ownedConfigMap := func(b *cmapi.Bundle, namespace string, data []byte) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "trust",
+				Namespace:       namespace,
+				Labels:          map[string]string{cmapi.BundleNameLabelKey: b.Name},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(b, cmapi.SchemeGroupVersion.WithKind(cmapi.BundleKind))},
+			},
+			Data: map[string]string{cmapi.DefaultBundleTargetKey: string(data)},
+		}
+	}
+	withReadyCondition := func(b *cmapi.Bundle, notAfter time.Time) *cmapi.Bundle {
+		b = b.DeepCopy()
+		b.Status = cmapi.BundleStatus{
+			Conditions: []cmapi.BundleCondition{{
+				Type:    cmapi.BundleConditionReady,
+				Status:  cmmeta.ConditionTrue,
+				Reason:  reasonSynced,
+				Message: "Bundle of 1 certificates synced to 1 namespaces",
+			}},
+			NotAfter: &metav1.Time{Time: notAfter},
+		}
+		return b
+	}
+
+	type testT struct {
+		bundle      *cmapi.Bundle
+		kubeObjects []runtime.Object
+		cmObjects   []runtime.Object
+
+		// expectedConfigMaps maps namespaces to the bundle expected in the
+		// target ConfigMap in that namespace
+		expectedConfigMaps map[string][]byte
+		// expectedNoConfigMaps are namespaces that are expected not to have
+		// a target ConfigMap
+		expectedNoConfigMaps []string
+		expectedReason       string
+		expectedNotAfter     *time.Time
+		expectedEvents       []string
+		expectNoWrites       bool
+		expectErr            bool
+	}
+	tests := map[string]testT{
+		"should sync an inline bundle to all namespaces": {
+			bundle:             bundle(nil, inLine(caA, caB)),
+			kubeObjects:        []runtime.Object{namespace("ns1", nil), namespace("ns2", nil)},
+			expectedConfigMaps: map[string][]byte{"ns1": append(caA, caB...), "ns2": append(caA, caB...)},
+			expectedReason:     reasonSynced,
+			expectedNotAfter:   timePtr(now.Add(time.Hour * 24)),
+		},
+		"should only sync to namespaces matching the selector": {
+			bundle:               bundle(&metav1.LabelSelector{MatchLabels: map[string]string{"trust": "true"}}, inLine(caA)),
+			kubeObjects:          []runtime.Object{namespace("ns1", map[string]string{"trust": "true"}), namespace("ns2", nil)},
+			expectedConfigMaps:   map[string][]byte{"ns1": caA},
+			expectedNoConfigMaps: []string{"ns2"},
+			expectedReason:       reasonSynced,
+		},
+		"should de-duplicate certificates and omit expired certificates": {
+			bundle: bundle(nil,
+				inLine(caA, expired, caA),
+				cmapi.BundleSource{Secret: &cmapi.BundleSourceObjectKeySelector{Namespace: "ns1", Name: "secret", Key: "ca.crt"}},
+				cmapi.BundleSource{ConfigMap: &cmapi.BundleSourceObjectKeySelector{Namespace: "ns1", Name: "configmap", Key: "ca.crt"}},
+			),
+			kubeObjects: []runtime.Object{
+				namespace("ns1", nil),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "secret"},
+					Data:       map[string][]byte{"ca.crt": append(caB, caA...)},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "configmap"},
+					Data:       map[string]string{"ca.crt": string(caB)},
+				},
+			},
+			expectedConfigMaps: map[string][]byte{"ns1": append(caA, caB...)},
+			expectedReason:     reasonSynced,
+			expectedEvents: []string{
+				`Warning ExpiredCertificate Omitting certificate "CN=expired" of source 0, which expired at ` + now.Add(-time.Hour).UTC().String(),
+			},
+		},
+		"should include the CA of a CA issuer": {
+			bundle: bundle(nil, cmapi.BundleSource{Issuer: &cmapi.BundleSourceIssuerReference{Name: "ca", Kind: cmapi.ClusterIssuerKind}}),
+			kubeObjects: []runtime.Object{
+				namespace("ns1", nil),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: clusterResourceNamespace, Name: "ca-key-pair"},
+					Data:       map[string][]byte{corev1.TLSCertKey: caA},
+				},
+			},
+			cmObjects: []runtime.Object{
+				&cmapi.ClusterIssuer{
+					ObjectMeta: metav1.ObjectMeta{Name: "ca"},
+					Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+						CA: &cmapi.CAIssuer{SecretName: "ca-key-pair"},
+					}},
+				},
+			},
+			expectedConfigMaps: map[string][]byte{"ns1": caA},
+			expectedReason:     reasonSynced,
+		},
+		"should not be ready if a source does not exist": {
+			bundle:               bundle(nil, cmapi.BundleSource{Secret: &cmapi.BundleSourceObjectKeySelector{Namespace: "ns1", Name: "secret", Key: "ca.crt"}}),
+			kubeObjects:          []runtime.Object{namespace("ns1", nil)},
+			expectedNoConfigMaps: []string{"ns1"},
+			expectedReason:       reasonSourceError,
+			expectedEvents:       []string{`Warning SourceError error reading source 0: secret "secret" not found`},
+		},
+		"should not be ready if all certificates have expired": {
+			bundle:               bundle(nil, inLine(expired)),
+			kubeObjects:          []runtime.Object{namespace("ns1", nil)},
+			expectedNoConfigMaps: []string{"ns1"},
+			expectedReason:       reasonSourceError,
+			expectedEvents: []string{
+				`Warning ExpiredCertificate Omitting certificate "CN=expired" of source 0, which expired at ` + now.Add(-time.Hour).UTC().String(),
+				`Warning SourceError no unexpired certificates found in bundle sources`,
+			},
+		},
+		"should not modify a configmap that is not owned by the bundle": {
+			bundle: bundle(nil, inLine(caA)),
+			kubeObjects: []runtime.Object{
+				namespace("ns1", nil),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "trust"},
+					Data:       map[string]string{cmapi.DefaultBundleTargetKey: "other"},
+				},
+			},
+			expectedConfigMaps: map[string][]byte{"ns1": []byte("other")},
+			expectedReason:     reasonSyncError,
+			expectedEvents:     []string{`Warning SyncError configmap ns1/trust already exists and is not owned by this bundle`},
+			expectErr:          true,
+		},
+		"should update an owned configmap and delete configmaps in namespaces no longer selected": {
+			bundle: bundle(&metav1.LabelSelector{MatchLabels: map[string]string{"trust": "true"}}, inLine(caB)),
+			kubeObjects: []runtime.Object{
+				namespace("ns1", map[string]string{"trust": "true"}),
+				namespace("ns2", nil),
+				ownedConfigMap(bundle(nil), "ns1", caA),
+				ownedConfigMap(bundle(nil), "ns2", caA),
+			},
+			expectedConfigMaps:   map[string][]byte{"ns1": caB},
+			expectedNoConfigMaps: []string{"ns2"},
+			expectedReason:       reasonSynced,
+		},
+		"should not update an up to date bundle": {
+			bundle: withReadyCondition(bundle(nil, inLine(caA)), now.Add(time.Hour*24)),
+			kubeObjects: []runtime.Object{
+				namespace("ns1", nil),
+				ownedConfigMap(bundle(nil), "ns1", caA),
+			},
+			expectedConfigMaps: map[string][]byte{"ns1": caA},
+			expectedReason:     reasonSynced,
+			expectNoWrites:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := &testpkg.Builder{
+				T:                  t,
+				KubeObjects:        test.kubeObjects,
+				CertManagerObjects: append([]runtime.Object{test.bundle}, test.cmObjects...),
+				ExpectedEvents:     test.expectedEvents,
+				Clock:              fakeclock.NewFakeClock(now),
+				Context: &controllerpkg.Context{
+					RootContext:   context.Background(),
+					IssuerOptions: controllerpkg.IssuerOptions{ClusterResourceNamespace: clusterResourceNamespace},
+				},
+			}
+			b.Init()
+			defer b.Stop()
+
+			c := &controller{}
+			if _, _, err := c.Register(b.Context); err != nil {
+				t.Fatal(err)
+			}
+			b.Start()
+
+			err := c.Sync(context.Background(), test.bundle)
+			if err != nil != test.expectErr {
+				t.Errorf("expected error=%t, got: %v", test.expectErr, err)
+			}
+
+			if err := b.AllEventsCalled(); err != nil {
+				t.Error(err)
+			}
+
+			if test.expectNoWrites {
+				for _, a := range append(b.FakeKubeClient().Actions(), b.FakeCMClient().Actions()...) {
+					switch a.GetVerb() {
+					case "create", "update", "delete":
+						t.Errorf("unexpected action: %s %s", a.GetVerb(), a.GetResource().Resource)
+					}
+				}
+			}
+
+			for ns, expected := range test.expectedConfigMaps {
+				cm, err := b.Client.CoreV1().ConfigMaps(ns).Get(context.TODO(), "trust", metav1.GetOptions{})
+				if err != nil {
+					t.Errorf("unexpected error getting configmap in namespace %q: %v", ns, err)
+					continue
+				}
+				if cm.Data[cmapi.DefaultBundleTargetKey] != string(expected) {
+					t.Errorf("unexpected bundle in namespace %q\nexp=%s\ngot=%s", ns, expected, cm.Data[cmapi.DefaultBundleTargetKey])
+				}
+			}
+			for _, ns := range test.expectedNoConfigMaps {
+				_, err := b.Client.CoreV1().ConfigMaps(ns).Get(context.TODO(), "trust", metav1.GetOptions{})
+				if !k8sErrors.IsNotFound(err) {
+					t.Errorf("expected configmap in namespace %q not to exist, got: %v", ns, err)
+				}
+			}
+
+			updated, err := b.CMClient.CertmanagerV1alpha2().Bundles().Get(context.TODO(), test.bundle.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(updated.Status.Conditions) != 1 || updated.Status.Conditions[0].Reason != test.expectedReason {
+				t.Errorf("expected a Ready condition with reason %q, got: %+v", test.expectedReason, updated.Status.Conditions)
+			}
+			if test.expectedNotAfter != nil {
+				if updated.Status.NotAfter == nil || !updated.Status.NotAfter.Time.Equal(*test.expectedNotAfter) {
+					t.Errorf("expected notAfter %v, got: %v", *test.expectedNotAfter, updated.Status.NotAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeJKSTruststore(t *testing.T) {
+	now := time.Now()
+	caA := mustCreateCertificatePEM(t, "ca-a", now.Add(time.Hour))
+	caB := mustCreateCertificatePEM(t, "ca-b", now.Add(time.Hour))
+	certs, err := pki.DecodeX509CertificateChainBytes(append(caA, caB...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := encodeJKSTruststore(&cmapi.Bundle{}, certs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ks, err := jks.Decode(bytes.NewReader(data), []byte(truststorePassword))
+	if err != nil {
+		t.Fatalf("error decoding truststore: %v", err)
+	}
+	if len(ks) != len(certs) {
+		t.Fatalf("expected %d entries, got %d", len(certs), len(ks))
+	}
+	for _, cert := range certs {
+		found := false
+		for _, entry := range ks {
+			if tce, ok := entry.(*jks.TrustedCertificateEntry); ok && bytes.Equal(tce.Certificate.Content, cert.Raw) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected truststore to contain certificate %q", cert.Subject.CommonName)
+		}
+	}
+}
+
+func TestEncodePKCS12Truststore(t *testing.T) {
+	now := time.Now()
+	caA := mustCreateCertificatePEM(t, "ca-a", now.Add(time.Hour))
+	caB := mustCreateCertificatePEM(t, "ca-b", now.Add(time.Hour))
+	certs, err := pki.DecodeX509CertificateChainBytes(append(caA, caB...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := encodePKCS12Truststore(&cmapi.Bundle{}, certs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, err := pkcs12.DecodeTrustStore(data, truststorePassword)
+	if err != nil {
+		t.Fatalf("error decoding truststore: %v", err)
+	}
+	if len(decoded) != len(certs) {
+		t.Fatalf("expected %d certificates, got %d", len(certs), len(decoded))
+	}
+	for i, cert := range certs {
+		if !decoded[i].Equal(cert) {
+			t.Errorf("expected truststore to contain certificate %q at index %d", cert.Subject.CommonName, i)
+		}
+	}
+}
+
+func mustCreateCertificatePEM(t *testing.T, commonName string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notAfter.Add(-time.Hour * 24 * 365),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
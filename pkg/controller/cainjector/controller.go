@@ -26,7 +26,6 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -64,12 +63,14 @@ func OwningCertForSecret(secret *corev1.Secret) *types.NamespacedName {
 type InjectTarget interface {
 	// AsObject returns this injectable as an object.
 	// It should be a pointer suitable for mutation.
-	AsObject() runtime.Object
+	AsObject() client.Object
 
 	// SetCA sets the CA of this target to the given certificate data (in the standard
 	// PEM format used across Kubernetes).  In cases where multiple CA fields exist per
 	// target (like admission webhook configs), all CAs are set to the given value.
-	SetCA(data []byte)
+	// An error is returned if the target is not configured correctly for
+	// injection.
+	SetCA(data []byte) error
 }
 
 // Injectable is a point in a Kubernetes API object that represents a Kubernetes Service
@@ -122,8 +123,7 @@ func splitNamespacedName(nameStr string) types.NamespacedName {
 
 // Reconcile attempts to ensure that a particular object has all the CAs injected that
 // it has requested.
-func (r *genericInjectReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
+func (r *genericInjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.log.WithValues(r.resourceName, req.NamespacedName)
 
 	// fetch the target object
@@ -162,7 +162,11 @@ func (r *genericInjectReconciler) Reconcile(req ctrl.Request) (ctrl.Result, erro
 	}
 
 	// actually do the injection
-	target.SetCA(caData)
+	if err := target.SetCA(caData); err != nil {
+		log.Error(err, "unable to inject CA data into target object")
+		// don't requeue, we'll get called when the target gets updated
+		return ctrl.Result{}, nil
+	}
 
 	// actually update with injected CA data
 	if err := r.Client.Update(ctx, target.AsObject()); err != nil {
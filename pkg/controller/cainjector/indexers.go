@@ -22,11 +22,11 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 )
@@ -41,7 +41,7 @@ type certificateToInjectableFunc func(log logr.Logger, cl client.Client, certNam
 func buildCertToInjectableFunc(listTyp runtime.Object, resourceName string) certificateToInjectableFunc {
 	return func(log logr.Logger, cl client.Client, certName types.NamespacedName) []ctrl.Request {
 		log = log.WithValues("type", resourceName)
-		objs := listTyp.DeepCopyObject()
+		objs := listTyp.DeepCopyObject().(client.ObjectList)
 		if err := cl.List(context.Background(), objs, client.MatchingFields{injectFromPath: certName.String()}); err != nil {
 			log.Error(err, "unable to fetch injectables associated with certificate")
 			return nil
@@ -76,14 +76,14 @@ type secretForCertificateMapper struct {
 	certificateToInjectable certificateToInjectableFunc
 }
 
-func (m *secretForCertificateMapper) Map(obj handler.MapObject) []ctrl.Request {
+func (m *secretForCertificateMapper) Map(obj client.Object) []ctrl.Request {
 	// grab the certificate, if it exists
-	certName := OwningCertForSecret(obj.Object.(*corev1.Secret))
+	certName := OwningCertForSecret(obj.(*corev1.Secret))
 	if certName == nil {
 		return nil
 	}
 
-	secretName := types.NamespacedName{Name: obj.Meta.GetName(), Namespace: obj.Meta.GetNamespace()}
+	secretName := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
 	log := m.log.WithValues("secret", secretName, "certificate", *certName)
 
 	var cert cmapi.Certificate
@@ -104,8 +104,8 @@ type certMapper struct {
 	toInjectable certificateToInjectableFunc
 }
 
-func (m *certMapper) Map(obj handler.MapObject) []ctrl.Request {
-	certName := types.NamespacedName{Name: obj.Meta.GetName(), Namespace: obj.Meta.GetNamespace()}
+func (m *certMapper) Map(obj client.Object) []ctrl.Request {
+	certName := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
 	log := m.log.WithValues("certificate", certName)
 	return m.toInjectable(log, m.Client, certName)
 }
@@ -117,7 +117,7 @@ var (
 
 // injectableCAFromIndexer is an IndexerFunc indexing on certificates
 // referenced by injectables.
-func injectableCAFromIndexer(rawObj runtime.Object) []string {
+func injectableCAFromIndexer(rawObj client.Object) []string {
 	metaInfo, err := meta.Accessor(rawObj)
 	if err != nil {
 		return nil
@@ -144,7 +144,7 @@ type secretToInjectableFunc func(log logr.Logger, cl client.Client, certName typ
 func buildSecretToInjectableFunc(listTyp runtime.Object, resourceName string) secretToInjectableFunc {
 	return func(log logr.Logger, cl client.Client, secretName types.NamespacedName) []ctrl.Request {
 		log = log.WithValues("type", resourceName)
-		objs := listTyp.DeepCopyObject()
+		objs := listTyp.DeepCopyObject().(client.ObjectList)
 		if err := cl.List(context.Background(), objs, client.MatchingFields{injectFromSecretPath: secretName.String()}); err != nil {
 			log.Error(err, "unable to fetch injectables associated with secret")
 			return nil
@@ -180,8 +180,8 @@ type secretForInjectableMapper struct {
 	secretToInjectable secretToInjectableFunc
 }
 
-func (m *secretForInjectableMapper) Map(obj handler.MapObject) []ctrl.Request {
-	secretName := types.NamespacedName{Namespace: obj.Meta.GetNamespace(), Name: obj.Meta.GetName()}
+func (m *secretForInjectableMapper) Map(obj client.Object) []ctrl.Request {
+	secretName := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
 	log := m.log.WithValues("secret", secretName)
 	return m.secretToInjectable(log, m.Client, secretName)
 }
@@ -194,7 +194,7 @@ var (
 
 // injectableCAFromSecretIndexer is an IndexerFunc indexing on secrets
 // referenced by injectables.
-func injectableCAFromSecretIndexer(rawObj runtime.Object) []string {
+func injectableCAFromSecretIndexer(rawObj client.Object) []string {
 	metaInfo, err := meta.Accessor(rawObj)
 	if err != nil {
 		return nil
@@ -212,3 +212,166 @@ func injectableCAFromSecretIndexer(rawObj runtime.Object) []string {
 
 	return []string{secretNameRaw}
 }
+
+// issuerToInjectableFunc converts a given issuer to the reconcile requests
+// for the corresponding injectables (webhooks, api services, etc) that
+// reference it. The namespace of ClusterIssuers is empty.
+type issuerToInjectableFunc func(log logr.Logger, cl client.Client, issuerName types.NamespacedName) []ctrl.Request
+
+// buildIssuerToInjectableFunc creates an issuerToInjectableFunc that maps from issuers to the given type of injectable.
+func buildIssuerToInjectableFunc(listTyp runtime.Object, resourceName string) issuerToInjectableFunc {
+	return func(log logr.Logger, cl client.Client, issuerName types.NamespacedName) []ctrl.Request {
+		log = log.WithValues("type", resourceName)
+		objs := listTyp.DeepCopyObject().(client.ObjectList)
+		if err := cl.List(context.Background(), objs, client.MatchingFields{injectFromIssuerPath: issuerRefString(issuerName)}); err != nil {
+			log.Error(err, "unable to fetch injectables associated with issuer")
+			return nil
+		}
+
+		var reqs []ctrl.Request
+		if err := meta.EachListItem(objs, func(obj runtime.Object) error {
+			metaInfo, err := meta.Accessor(obj)
+			if err != nil {
+				log.Error(err, "unable to get metadata from list item")
+				// continue on error
+				return nil
+			}
+			reqs = append(reqs, ctrl.Request{NamespacedName: types.NamespacedName{
+				Name:      metaInfo.GetName(),
+				Namespace: metaInfo.GetNamespace(),
+			}})
+			return nil
+		}); err != nil {
+			log.Error(err, "unable get items from list")
+			return nil
+		}
+
+		return reqs
+	}
+}
+
+// issuerMapper is a mapper that converts Issuers and ClusterIssuers up to
+// injectables
+type issuerMapper struct {
+	client.Client
+	log          logr.Logger
+	toInjectable issuerToInjectableFunc
+}
+
+func (m *issuerMapper) Map(obj client.Object) []ctrl.Request {
+	issuerName := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
+	log := m.log.WithValues("issuer", issuerRefString(issuerName))
+	return m.toInjectable(log, m.Client, issuerName)
+}
+
+// secretForIssuerMapper is a Mapper that converts secrets up to injectables,
+// through the issuers whose CA, or Vault client certificate, is stored in the
+// secret.
+type secretForIssuerMapper struct {
+	client.Client
+	log                      logr.Logger
+	issuerToInjectable       issuerToInjectableFunc
+	clusterResourceNamespace string
+}
+
+func (m *secretForIssuerMapper) Map(obj client.Object) []ctrl.Request {
+	secretName := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
+	log := m.log.WithValues("secret", secretName)
+
+	var reqs []ctrl.Request
+
+	// the secret may be the CA of a CA issuer, or the client certificate of
+	// a Vault issuer
+	var issuers cmapi.IssuerList
+	if err := m.Client.List(context.Background(), &issuers, client.InNamespace(secretName.Namespace)); err != nil {
+		log.Error(err, "unable to list issuers")
+		return nil
+	}
+	for _, iss := range issuers.Items {
+		if issuerUsesSecret(&iss.Spec, secretName.Name) {
+			reqs = append(reqs, m.issuerToInjectable(log, m.Client, types.NamespacedName{Namespace: iss.Namespace, Name: iss.Name})...)
+		}
+	}
+	if secretName.Namespace == m.clusterResourceNamespace {
+		var clusterIssuers cmapi.ClusterIssuerList
+		if err := m.Client.List(context.Background(), &clusterIssuers); err != nil {
+			log.Error(err, "unable to list clusterissuers")
+			return nil
+		}
+		for _, iss := range clusterIssuers.Items {
+			if issuerUsesSecret(&iss.Spec, secretName.Name) {
+				reqs = append(reqs, m.issuerToInjectable(log, m.Client, types.NamespacedName{Name: iss.Name})...)
+			}
+		}
+	}
+
+	// the secret may contain a certificate issued by a SelfSigned issuer
+	if issuerName := secretIssuerName(obj); issuerName != nil {
+		reqs = append(reqs, m.issuerToInjectable(log, m.Client, *issuerName)...)
+	}
+
+	return reqs
+}
+
+// issuerUsesSecret returns true if the CA injected for the given issuer is
+// read using the named secret in the issuer's resource namespace.
+func issuerUsesSecret(spec *cmapi.IssuerSpec, secretName string) bool {
+	switch {
+	case spec.CA != nil:
+		return spec.CA.SecretName == secretName
+	case spec.Vault != nil && spec.Vault.ClientCertSecretRef != nil:
+		return spec.Vault.ClientCertSecretRef.Name == secretName
+	}
+	return false
+}
+
+// secretIssuerName returns the name of the issuer that issued the
+// certificate in the given secret, using the annotations set by
+// cert-manager, or nil if the secret has no such annotations.
+// The namespace of ClusterIssuers is empty.
+func secretIssuerName(secret metav1.Object) *types.NamespacedName {
+	annotations := secret.GetAnnotations()
+	name := annotations[cmapi.IssuerNameAnnotationKey]
+	if name == "" {
+		return nil
+	}
+	switch annotations[cmapi.IssuerKindAnnotationKey] {
+	case cmapi.IssuerKind:
+		return &types.NamespacedName{Namespace: secret.GetNamespace(), Name: name}
+	case cmapi.ClusterIssuerKind:
+		return &types.NamespacedName{Name: name}
+	}
+	return nil
+}
+
+// issuerRefString returns the form an issuer is referenced by in the
+// 'inject-ca-from-issuer' annotation: namespace/name for Issuers, or name
+// for ClusterIssuers.
+func issuerRefString(issuerName types.NamespacedName) string {
+	if issuerName.Namespace == "" {
+		return issuerName.Name
+	}
+	return issuerName.String()
+}
+
+var (
+	// injectFromIssuerPath is the index key used to look up the value of
+	// inject-ca-from-issuer on targeted objects
+	injectFromIssuerPath = ".metadata.annotations.inject-ca-from-issuer"
+)
+
+// injectableCAFromIssuerIndexer is an IndexerFunc indexing on issuers
+// referenced by injectables.
+func injectableCAFromIssuerIndexer(rawObj client.Object) []string {
+	metaInfo, err := meta.Accessor(rawObj)
+	if err != nil {
+		return nil
+	}
+
+	issuerNameRaw := metaInfo.GetAnnotations()[cmapi.WantInjectFromIssuerAnnotation]
+	if issuerNameRaw == "" {
+		return nil
+	}
+
+	return []string{issuerNameRaw}
+}
@@ -17,11 +17,20 @@ limitations under the License.
 package cainjector
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
 	admissionreg "k8s.io/api/admissionregistration/v1beta1"
-	"k8s.io/api/auditregistration/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apireg "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 )
 
 // this contains implementations of CertInjector (and dependents)
@@ -47,13 +56,14 @@ type mutatingWebhookTarget struct {
 	obj admissionreg.MutatingWebhookConfiguration
 }
 
-func (t *mutatingWebhookTarget) AsObject() runtime.Object {
+func (t *mutatingWebhookTarget) AsObject() client.Object {
 	return &t.obj
 }
-func (t *mutatingWebhookTarget) SetCA(data []byte) {
+func (t *mutatingWebhookTarget) SetCA(data []byte) error {
 	for ind := range t.obj.Webhooks {
 		t.obj.Webhooks[ind].ClientConfig.CABundle = data
 	}
+	return nil
 }
 
 // validatingWebhookInjector knows how to create an InjectTarget a ValidatingWebhookConfiguration.
@@ -73,14 +83,15 @@ type validatingWebhookTarget struct {
 	obj admissionreg.ValidatingWebhookConfiguration
 }
 
-func (t *validatingWebhookTarget) AsObject() runtime.Object {
+func (t *validatingWebhookTarget) AsObject() client.Object {
 	return &t.obj
 }
 
-func (t *validatingWebhookTarget) SetCA(data []byte) {
+func (t *validatingWebhookTarget) SetCA(data []byte) error {
 	for ind := range t.obj.Webhooks {
 		t.obj.Webhooks[ind].ClientConfig.CABundle = data
 	}
+	return nil
 }
 
 // apiServiceInjector knows how to create an InjectTarget for APICAReferences
@@ -100,12 +111,13 @@ type apiServiceTarget struct {
 	obj apireg.APIService
 }
 
-func (t *apiServiceTarget) AsObject() runtime.Object {
+func (t *apiServiceTarget) AsObject() client.Object {
 	return &t.obj
 }
 
-func (t *apiServiceTarget) SetCA(data []byte) {
+func (t *apiServiceTarget) SetCA(data []byte) error {
 	t.obj.Spec.CABundle = data
+	return nil
 }
 
 // TODO(directxman12): conversion webhooks
@@ -125,38 +137,130 @@ type crdConversionTarget struct {
 	obj apiext.CustomResourceDefinition
 }
 
-func (t *crdConversionTarget) AsObject() runtime.Object {
+func (t *crdConversionTarget) AsObject() client.Object {
 	return &t.obj
 }
 
-func (t *crdConversionTarget) SetCA(data []byte) {
+func (t *crdConversionTarget) SetCA(data []byte) error {
 	if t.obj.Spec.Conversion == nil || t.obj.Spec.Conversion.Strategy != apiext.WebhookConverter {
-		return
+		return nil
 	}
 	if t.obj.Spec.Conversion.WebhookClientConfig == nil {
 		t.obj.Spec.Conversion.WebhookClientConfig = &apiext.WebhookClientConfig{}
 	}
 	t.obj.Spec.Conversion.WebhookClientConfig.CABundle = data
+	return nil
 }
 
-// auditSinkTarget knows how to set CA data for the auditSink webhook
-type auditSinkTarget struct {
-	obj v1alpha1.AuditSink
+// configMapInjector knows how to create an InjectTarget for ConfigMaps
+type configMapInjector struct{}
+
+func (i configMapInjector) NewTarget() InjectTarget {
+	return &configMapTarget{}
 }
 
-func (i auditSinkTarget) NewTarget() InjectTarget {
-	return &auditSinkTarget{}
+func (i configMapInjector) IsAlpha() bool {
+	return false
 }
 
-func (i auditSinkTarget) IsAlpha() bool {
-	// TODO set this to false when auditregistration goes GA
-	return true
+// configMapTarget knows how to set CA data for a ConfigMap. The CA is stored
+// under the data key named by the 'cert-manager.io/inject-ca-key'
+// annotation, or 'ca.crt' if it is not set.
+type configMapTarget struct {
+	obj corev1.ConfigMap
 }
 
-func (t *auditSinkTarget) AsObject() runtime.Object {
+func (t *configMapTarget) AsObject() client.Object {
 	return &t.obj
 }
 
-func (t *auditSinkTarget) SetCA(data []byte) {
-	t.obj.Spec.Webhook.ClientConfig.CABundle = data
+func (t *configMapTarget) SetCA(data []byte) error {
+	key := t.obj.Annotations[cmapi.InjectCAKeyAnnotation]
+	if key == "" {
+		key = cmmeta.TLSCAKey
+	}
+	if t.obj.Data == nil {
+		t.obj.Data = make(map[string]string)
+	}
+	t.obj.Data[key] = string(data)
+	return nil
+}
+
+// unstructuredInjector knows how to create an InjectTarget for resources of
+// an arbitrary kind, which are accessed as unstructured objects.
+type unstructuredInjector struct {
+	gvk schema.GroupVersionKind
+}
+
+func (i unstructuredInjector) NewTarget() InjectTarget {
+	t := &unstructuredTarget{}
+	t.obj.SetGroupVersionKind(i.gvk)
+	return t
+}
+
+func (i unstructuredInjector) IsAlpha() bool {
+	return false
+}
+
+// unstructuredTarget knows how to set CA data for the field of an arbitrary
+// resource named by the 'cert-manager.io/inject-ca-path' annotation.
+type unstructuredTarget struct {
+	obj unstructured.Unstructured
+}
+
+func (t *unstructuredTarget) AsObject() client.Object {
+	return &t.obj
+}
+
+func (t *unstructuredTarget) SetCA(data []byte) error {
+	annotations := t.obj.GetAnnotations()
+	path, ok := annotations[cmapi.InjectCAPathAnnotation]
+	if !ok {
+		return fmt.Errorf("the %q annotation must be set to inject into %s resources",
+			cmapi.InjectCAPathAnnotation, t.obj.GetKind())
+	}
+	fields, err := parseInjectPath(path)
+	if err != nil {
+		return err
+	}
+
+	var value string
+	switch format := annotations[cmapi.InjectCAFormatAnnotation]; format {
+	case "", "base64":
+		value = base64.StdEncoding.EncodeToString(data)
+	case "pem":
+		value = string(data)
+	default:
+		return fmt.Errorf("invalid %q annotation %q, must be one of 'base64' or 'pem'",
+			cmapi.InjectCAFormatAnnotation, format)
+	}
+
+	return unstructured.SetNestedField(t.obj.Object, value, fields...)
+}
+
+// parseInjectPath parses a JSONPath to a single field, such as
+// '.spec.caBundle' or '{.spec.tls.ca}', into its field names.
+// Array indices and wildcards are not supported, and the resource's
+// metadata, apiVersion and kind may not be injected into.
+func parseInjectPath(path string) ([]string, error) {
+	p := strings.TrimSpace(path)
+	if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+		p = p[1 : len(p)-1]
+	}
+	if !strings.HasPrefix(p, ".") {
+		return nil, fmt.Errorf("invalid inject path %q, must be of the form '.spec.field'", path)
+	}
+
+	fields := strings.Split(p[1:], ".")
+	for _, f := range fields {
+		if f == "" || strings.ContainsAny(f, "[]{}*@$") {
+			return nil, fmt.Errorf("invalid inject path %q, must be of the form '.spec.field'", path)
+		}
+	}
+	switch fields[0] {
+	case "metadata", "apiVersion", "kind":
+		return nil, fmt.Errorf("invalid inject path %q, %s may not be injected into", path, fields[0])
+	}
+
+	return fields, nil
 }
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cainjector
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+)
+
+func TestConfigMapTargetSetCA(t *testing.T) {
+	tests := map[string]struct {
+		annotations  map[string]string
+		data         map[string]string
+		expectedData map[string]string
+	}{
+		"should inject into ca.crt by default": {
+			expectedData: map[string]string{"ca.crt": "ca"},
+		},
+		"should inject into the key named by the annotation": {
+			annotations:  map[string]string{cmapi.InjectCAKeyAnnotation: "bundle.pem"},
+			data:         map[string]string{"other": "value"},
+			expectedData: map[string]string{"other": "value", "bundle.pem": "ca"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			target := configMapInjector{}.NewTarget().(*configMapTarget)
+			target.obj.Annotations = test.annotations
+			target.obj.Data = test.data
+			if err := target.SetCA([]byte("ca")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(target.obj.Data, test.expectedData) {
+				t.Errorf("expected data %v, got %v", test.expectedData, target.obj.Data)
+			}
+		})
+	}
+}
+
+func TestUnstructuredTargetSetCA(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Operator"}
+	tests := map[string]struct {
+		annotations    map[string]string
+		expectedFields []string
+		expectedValue  string
+		err            bool
+	}{
+		"should inject base64 encoded data by default": {
+			annotations:    map[string]string{cmapi.InjectCAPathAnnotation: ".spec.caBundle"},
+			expectedFields: []string{"spec", "caBundle"},
+			expectedValue:  "Y2E=",
+		},
+		"should inject PEM data into a braced path": {
+			annotations: map[string]string{
+				cmapi.InjectCAPathAnnotation:   "{.spec.tls.ca}",
+				cmapi.InjectCAFormatAnnotation: "pem",
+			},
+			expectedFields: []string{"spec", "tls", "ca"},
+			expectedValue:  "ca",
+		},
+		"should error if no path is set": {
+			err: true,
+		},
+		"should error on an invalid format": {
+			annotations: map[string]string{
+				cmapi.InjectCAPathAnnotation:   ".spec.caBundle",
+				cmapi.InjectCAFormatAnnotation: "der",
+			},
+			err: true,
+		},
+		"should error on a path with array indices": {
+			annotations: map[string]string{cmapi.InjectCAPathAnnotation: ".spec.webhooks[0].caBundle"},
+			err:         true,
+		},
+		"should error on a path into metadata": {
+			annotations: map[string]string{cmapi.InjectCAPathAnnotation: ".metadata.annotations"},
+			err:         true,
+		},
+		"should error on a path that is not rooted": {
+			annotations: map[string]string{cmapi.InjectCAPathAnnotation: "spec.caBundle"},
+			err:         true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			target := unstructuredInjector{gvk: gvk}.NewTarget().(*unstructuredTarget)
+			if target.obj.GroupVersionKind() != gvk {
+				t.Errorf("expected target kind %v, got %v", gvk, target.obj.GroupVersionKind())
+			}
+			target.obj.SetAnnotations(test.annotations)
+
+			err := target.SetCA([]byte("ca"))
+			if err != nil != test.err {
+				t.Fatalf("expected error=%t, got: %v", test.err, err)
+			}
+			if test.err {
+				return
+			}
+			value, _, _ := unstructured.NestedString(target.obj.Object, test.expectedFields...)
+			if value != test.expectedValue {
+				t.Errorf("expected %q, got %q", test.expectedValue, value)
+			}
+		})
+	}
+}
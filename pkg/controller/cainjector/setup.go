@@ -18,21 +18,24 @@ package cainjector
 
 import (
 	"io/ioutil"
+	"strings"
 
 	admissionreg "k8s.io/api/admissionregistration/v1beta1"
-	"k8s.io/api/auditregistration/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/meta"
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apireg "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // injectorSet describes a particular setup of the injector controller
 type injectorSetup struct {
 	resourceName string
 	injector     CertInjector
-	listType     runtime.Object
+	listType     client.ObjectList
 }
 
 var (
@@ -60,20 +63,38 @@ var (
 		listType:     &apiext.CustomResourceDefinitionList{},
 	}
 
-	AuditSinkSetup = injectorSetup{
-		resourceName: "auditsink",
-		injector:     auditSinkTarget{},
-		listType:     &v1alpha1.AuditSinkList{},
+	ConfigMapSetup = injectorSetup{
+		resourceName: "configmap",
+		injector:     configMapInjector{},
+		listType:     &corev1.ConfigMapList{},
 	}
 
-	injectorSetups  = []injectorSetup{MutatingWebhookSetup, ValidatingWebhookSetup, APIServiceSetup, CRDSetup, AuditSinkSetup}
+	injectorSetups  = []injectorSetup{MutatingWebhookSetup, ValidatingWebhookSetup, APIServiceSetup, CRDSetup, ConfigMapSetup}
 	ControllerNames []string
 )
 
-// registerAllInjectors registers all injectors and based on the
-// graduation state of the injector decides how to log no kind/resource match errors
-func registerAllInjectors(mgr ctrl.Manager, sources ...caDataSource) error {
-	for _, setup := range injectorSetups {
+// unstructuredSetup returns the setup of an injector for resources of the
+// given kind, which are accessed as unstructured objects. CA data is injected
+// into the field named by the 'cert-manager.io/inject-ca-path' annotation.
+func unstructuredSetup(gvk schema.GroupVersionKind) injectorSetup {
+	listType := &unstructured.UnstructuredList{}
+	listType.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	return injectorSetup{
+		resourceName: strings.ToLower(gvk.Kind),
+		injector:     unstructuredInjector{gvk: gvk},
+		listType:     listType,
+	}
+}
+
+// registerAllInjectors registers all injectors, plus unstructured injectors
+// for the given kinds, and based on the graduation state of the injector
+// decides how to log no kind/resource match errors
+func registerAllInjectors(mgr ctrl.Manager, unstructuredKinds []schema.GroupVersionKind, sources ...caDataSource) error {
+	setups := append([]injectorSetup{}, injectorSetups...)
+	for _, gvk := range unstructuredKinds {
+		setups = append(setups, unstructuredSetup(gvk))
+	}
+	for _, setup := range setups {
 		if err := Register(mgr, setup, sources...); err != nil {
 			if !meta.IsNoMatchError(err) || !setup.injector.IsAlpha() {
 				return err
@@ -123,25 +144,34 @@ func dataFromSliceOrFile(data []byte, file string) ([]byte, error) {
 
 // RegisterCertificateBased registers all known injection controllers that
 // target Certificate resources with the  given manager, and adds relevant
-// indices.
+// indices. Controllers are also registered for resources of the given kinds,
+// which are injected into as unstructured objects.
+// CA data may also be injected from Issuers, and from ClusterIssuers whose
+// resources are stored in the given cluster resource namespace.
 // The registered controllers require the cert-manager API to be available
 // in order to run.
-func RegisterCertificateBased(mgr ctrl.Manager) error {
+func RegisterCertificateBased(mgr ctrl.Manager, clusterResourceNamespace string, unstructuredKinds ...schema.GroupVersionKind) error {
 	sources := []caDataSource{
 		&certificateDataSource{client: mgr.GetClient()},
+		&issuerDataSource{
+			client:                   mgr.GetClient(),
+			clusterResourceNamespace: clusterResourceNamespace,
+			fetchVaultCA:             fetchVaultCA,
+		},
 	}
-	return registerAllInjectors(mgr, sources...)
+	return registerAllInjectors(mgr, unstructuredKinds, sources...)
 }
 
 // RegisterSecretBased registers all known injection controllers that
 // target Secret resources with the  given manager, and adds relevant
-// indices.
+// indices. Controllers are also registered for resources of the given kinds,
+// which are injected into as unstructured objects.
 // The registered controllers only require the corev1 APi to be available in
 // order to run.
-func RegisterSecretBased(mgr ctrl.Manager) error {
+func RegisterSecretBased(mgr ctrl.Manager, unstructuredKinds ...schema.GroupVersionKind) error {
 	sources := []caDataSource{
 		&secretDataSource{client: mgr.GetClient()},
 		&kubeconfigDataSource{},
 	}
-	return registerAllInjectors(mgr, sources...)
+	return registerAllInjectors(mgr, unstructuredKinds, sources...)
 }
@@ -18,6 +18,16 @@ package cainjector
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +40,7 @@ import (
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	vaultinternal "github.com/jetstack/cert-manager/pkg/internal/vault"
 )
 
 // caDataSource knows how to extract CA data given a provided InjectTarget.
@@ -145,18 +156,18 @@ func (c *certificateDataSource) ApplyTo(mgr ctrl.Manager, setup injectorSetup, b
 	}
 
 	builder.Watches(&source.Kind{Type: &cmapi.Certificate{}},
-		&handler.EnqueueRequestsFromMapFunc{ToRequests: &certMapper{
+		handler.EnqueueRequestsFromMapFunc((&certMapper{
 			Client:       mgr.GetClient(),
 			log:          ctrl.Log.WithName("cert-mapper"),
 			toInjectable: buildCertToInjectableFunc(setup.listType, setup.resourceName),
-		}},
+		}).Map),
 	).
 		Watches(&source.Kind{Type: &corev1.Secret{}},
-			&handler.EnqueueRequestsFromMapFunc{ToRequests: &secretForCertificateMapper{
+			handler.EnqueueRequestsFromMapFunc((&secretForCertificateMapper{
 				Client:                  mgr.GetClient(),
 				log:                     ctrl.Log.WithName("secret-for-certificate-mapper"),
 				certificateToInjectable: buildCertToInjectableFunc(setup.listType, setup.resourceName),
-			}},
+			}).Map),
 		)
 	return nil
 }
@@ -218,11 +229,246 @@ func (c *secretDataSource) ApplyTo(mgr ctrl.Manager, setup injectorSetup, builde
 	}
 
 	builder.Watches(&source.Kind{Type: &corev1.Secret{}},
-		&handler.EnqueueRequestsFromMapFunc{ToRequests: &secretForInjectableMapper{
+		handler.EnqueueRequestsFromMapFunc((&secretForInjectableMapper{
 			Client:             mgr.GetClient(),
 			log:                ctrl.Log.WithName("secret-mapper"),
 			secretToInjectable: buildSecretToInjectableFunc(setup.listType, setup.resourceName),
-		}},
+		}).Map),
 	)
 	return nil
 }
+
+// issuerDataSource reads a CA bundle from the Issuer named using the
+// 'cert-manager.io/inject-ca-from-issuer' annotation in the form
+// 'namespace/name', or from the ClusterIssuer named in the form 'name'.
+// The CA of CA issuers is read from their CA Secret, and the CA of Vault
+// issuers is read from the Vault PKI backend. As every certificate issued by
+// a SelfSigned issuer is its own CA, the CA bundle of SelfSigned issuers
+// contains all the certificates they have issued.
+type issuerDataSource struct {
+	client client.Client
+	// clusterResourceNamespace is the namespace that the Secrets referenced
+	// by ClusterIssuers are stored in
+	clusterResourceNamespace string
+	// fetchVaultCA is used to read the CA of Vault issuers
+	fetchVaultCA func(ctx context.Context, vault *cmapi.VaultIssuer, clientCert *tls.Certificate) ([]byte, error)
+}
+
+func (c *issuerDataSource) Configured(log logr.Logger, metaObj metav1.Object) bool {
+	issuerNameRaw, ok := metaObj.GetAnnotations()[cmapi.WantInjectFromIssuerAnnotation]
+	if !ok {
+		return false
+	}
+	log.Info("Extracting CA from Issuer resource", "issuer", issuerNameRaw)
+	return true
+}
+
+func (c *issuerDataSource) ReadCA(ctx context.Context, log logr.Logger, metaObj metav1.Object) ([]byte, error) {
+	issuerNameRaw := metaObj.GetAnnotations()[cmapi.WantInjectFromIssuerAnnotation]
+	issuerName := splitNamespacedName(issuerNameRaw)
+	log = log.WithValues("issuer", issuerNameRaw)
+
+	var issuer cmapi.GenericIssuer
+	resourceNamespace := issuerName.Namespace
+	if issuerName.Namespace == "" {
+		issuer = &cmapi.ClusterIssuer{}
+		resourceNamespace = c.clusterResourceNamespace
+	} else {
+		issuer = &cmapi.Issuer{}
+	}
+	if err := c.client.Get(ctx, issuerName, issuer); err != nil {
+		log.Error(err, "unable to fetch associated issuer")
+		// don't requeue if we're just not found, we'll get called when the issuer gets created
+		return nil, dropNotFound(err)
+	}
+
+	spec := issuer.GetSpec()
+	switch {
+	case spec.CA != nil:
+		secretName := types.NamespacedName{Namespace: resourceNamespace, Name: spec.CA.SecretName}
+		log = log.WithValues("secret", secretName)
+		var secret corev1.Secret
+		if err := c.client.Get(ctx, secretName, &secret); err != nil {
+			log.Error(err, "unable to fetch issuer CA secret")
+			// don't requeue if we're just not found, we'll get called when the secret gets created
+			return nil, dropNotFound(err)
+		}
+		caData, hasCAData := secret.Data[corev1.TLSCertKey]
+		if !hasCAData {
+			log.Error(nil, "issuer CA secret has no certificate data")
+			// don't requeue, we'll get called when the secret gets updated
+			return nil, nil
+		}
+		return caData, nil
+
+	case spec.SelfSigned != nil:
+		return c.readSelfSignedCA(ctx, log, issuerName, resourceNamespace)
+
+	case spec.Vault != nil:
+		var clientCert *tls.Certificate
+		if ref := spec.Vault.ClientCertSecretRef; ref != nil {
+			secretName := types.NamespacedName{Namespace: resourceNamespace, Name: ref.Name}
+			log = log.WithValues("secret", secretName)
+			var secret corev1.Secret
+			if err := c.client.Get(ctx, secretName, &secret); err != nil {
+				log.Error(err, "unable to fetch vault client certificate secret")
+				// don't requeue if we're just not found, we'll get called when the secret gets created
+				return nil, dropNotFound(err)
+			}
+			cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+			if err != nil {
+				log.Error(err, "unable to load vault client certificate")
+				// don't requeue, we'll get called when the secret gets updated
+				return nil, nil
+			}
+			clientCert = &cert
+		}
+		caData, err := c.fetchVaultCA(ctx, spec.Vault, clientCert)
+		if err != nil {
+			log.Error(err, "unable to fetch CA from vault")
+			return nil, err
+		}
+		return caData, nil
+	}
+
+	log.Info("issuer type does not support CA injection, only CA, SelfSigned and Vault issuers are supported")
+	return nil, nil
+}
+
+// readSelfSignedCA returns a CA bundle containing all the certificates
+// issued by the given SelfSigned issuer. Certificates are sorted by the
+// namespace and name of their Secret so that the bundle is stable.
+func (c *issuerDataSource) readSelfSignedCA(ctx context.Context, log logr.Logger, issuerName types.NamespacedName, namespace string) ([]byte, error) {
+	var secrets corev1.SecretList
+	var opts []client.ListOption
+	if issuerName.Namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := c.client.List(ctx, &secrets, opts...); err != nil {
+		log.Error(err, "unable to list secrets")
+		return nil, err
+	}
+
+	sort.Slice(secrets.Items, func(i, j int) bool {
+		a, b := secrets.Items[i], secrets.Items[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	var bundle []byte
+	for _, secret := range secrets.Items {
+		name := secretIssuerName(&secret)
+		if name == nil || *name != issuerName {
+			continue
+		}
+		certData := secret.Data[corev1.TLSCertKey]
+		if len(certData) == 0 {
+			continue
+		}
+		bundle = append(bundle, certData...)
+		if certData[len(certData)-1] != '\n' {
+			bundle = append(bundle, '\n')
+		}
+	}
+	if len(bundle) == 0 {
+		log.Info("selfsigned issuer has not issued any certificates")
+		// don't requeue, we'll get called when a secret gets updated
+		return nil, nil
+	}
+
+	return bundle, nil
+}
+
+func (c *issuerDataSource) ApplyTo(mgr ctrl.Manager, setup injectorSetup, builder *ctrl.Builder) error {
+	typ := setup.injector.NewTarget().AsObject()
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), typ, injectFromIssuerPath, injectableCAFromIssuerIndexer); err != nil {
+		return err
+	}
+
+	toInjectable := buildIssuerToInjectableFunc(setup.listType, setup.resourceName)
+	builder.Watches(&source.Kind{Type: &cmapi.Issuer{}},
+		handler.EnqueueRequestsFromMapFunc((&issuerMapper{
+			Client:       mgr.GetClient(),
+			log:          ctrl.Log.WithName("issuer-mapper"),
+			toInjectable: toInjectable,
+		}).Map),
+	).
+		Watches(&source.Kind{Type: &cmapi.ClusterIssuer{}},
+			handler.EnqueueRequestsFromMapFunc((&issuerMapper{
+				Client:       mgr.GetClient(),
+				log:          ctrl.Log.WithName("clusterissuer-mapper"),
+				toInjectable: toInjectable,
+			}).Map),
+		).
+		Watches(&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc((&secretForIssuerMapper{
+				Client:                   mgr.GetClient(),
+				log:                      ctrl.Log.WithName("secret-for-issuer-mapper"),
+				issuerToInjectable:       toInjectable,
+				clusterResourceNamespace: c.clusterResourceNamespace,
+			}).Map),
+		)
+	return nil
+}
+
+// fetchVaultCA reads the PEM encoded CA of the Vault PKI backend used by the
+// given Vault issuer. The CA endpoint of the PKI backend does not require
+// authentication, but Vault servers that require mutual TLS need the client
+// certificate of the issuer, if any, to be presented.
+func fetchVaultCA(ctx context.Context, vault *cmapi.VaultIssuer, clientCert *tls.Certificate) ([]byte, error) {
+	mount, _, err := vaultinternal.SplitSignPath(vault.Path)
+	if err != nil {
+		return nil, err
+	}
+	caPath := mount + "/ca/pem"
+	if vault.IssuerRef != "" {
+		// the CA of a named issuer of a multi-issuer PKI mount
+		caPath = path.Join(mount, "issuer", vault.IssuerRef, "pem")
+	}
+
+	tlsConfig := &tls.Config{}
+	if len(vault.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(vault.CABundle) {
+			return nil, fmt.Errorf("no certificates could be parsed from the vault issuer caBundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+	httpClient := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig},
+	}
+
+	url := strings.TrimSuffix(vault.Server, "/") + "/v1/" + caPath
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if vault.Namespace != "" {
+		// the same header is set by the Vault client used by the issuer
+		req.Header.Set("X-Vault-Namespace", vault.Namespace)
+	}
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d reading CA from %s", resp.StatusCode, url)
+	}
+
+	caData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(caData); block == nil {
+		return nil, fmt.Errorf("CA read from %s is not PEM encoded", url)
+	}
+
+	return caData, nil
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cainjector
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/jetstack/cert-manager/pkg/api"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestIssuerDataSourceReadCA(t *testing.T) {
+	const clusterResourceNamespace = "cert-manager"
+
+	caSecret := func(namespace string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ca-key-pair"},
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("ca-cert")},
+		}
+	}
+	issuedSecret := func(namespace, name, issuerName, issuerKind string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Annotations: map[string]string{
+					cmapi.IssuerNameAnnotationKey: issuerName,
+					cmapi.IssuerKindAnnotationKey: issuerKind,
+				},
+			},
+			Data: map[string][]byte{corev1.TLSCertKey: []byte(name + "-cert")},
+		}
+	}
+
+	tests := map[string]struct {
+		annotation string
+		objects    []runtime.Object
+		expectedCA string
+	}{
+		"should read the CA of a CA issuer": {
+			annotation: "ns/ca-issuer",
+			objects: []runtime.Object{
+				&cmapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ca-issuer"},
+					Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+						CA: &cmapi.CAIssuer{SecretName: "ca-key-pair"},
+					}},
+				},
+				caSecret("ns"),
+			},
+			expectedCA: "ca-cert",
+		},
+		"should read the CA of a CA clusterissuer from the cluster resource namespace": {
+			annotation: "ca-issuer",
+			objects: []runtime.Object{
+				&cmapi.ClusterIssuer{
+					ObjectMeta: metav1.ObjectMeta{Name: "ca-issuer"},
+					Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+						CA: &cmapi.CAIssuer{SecretName: "ca-key-pair"},
+					}},
+				},
+				caSecret(clusterResourceNamespace),
+			},
+			expectedCA: "ca-cert",
+		},
+		"should bundle the certificates issued by a selfsigned issuer": {
+			annotation: "ns/selfsigned",
+			objects: []runtime.Object{
+				&cmapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "selfsigned"},
+					Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+						SelfSigned: &cmapi.SelfSignedIssuer{},
+					}},
+				},
+				issuedSecret("ns", "b", "selfsigned", cmapi.IssuerKind),
+				issuedSecret("ns", "a", "selfsigned", cmapi.IssuerKind),
+				issuedSecret("ns", "other", "other", cmapi.IssuerKind),
+				issuedSecret("ns", "cluster", "selfsigned", cmapi.ClusterIssuerKind),
+			},
+			expectedCA: "a-cert\nb-cert\n",
+		},
+		"should read the CA of a vault issuer": {
+			annotation: "ns/vault",
+			objects: []runtime.Object{
+				&cmapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "vault"},
+					Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+						Vault: &cmapi.VaultIssuer{Server: "https://vault", Path: "pki/sign/role"},
+					}},
+				},
+			},
+			expectedCA: "vault-ca",
+		},
+		"should read the CA of a vault issuer using its client certificate": {
+			annotation: "ns/vault",
+			objects: []runtime.Object{
+				&cmapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "vault"},
+					Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+						Vault: &cmapi.VaultIssuer{
+							Server:              "https://vault",
+							Path:                "pki/sign/role",
+							ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "vault-client-cert"},
+						},
+					}},
+				},
+				clientCertSecret(t, "ns", "vault-client-cert"),
+			},
+			expectedCA: "vault-ca-for-client",
+		},
+		"should not inject if the vault client certificate secret does not exist": {
+			annotation: "ns/vault",
+			objects: []runtime.Object{
+				&cmapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "vault"},
+					Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+						Vault: &cmapi.VaultIssuer{
+							Server:              "https://vault",
+							Path:                "pki/sign/role",
+							ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "vault-client-cert"},
+						},
+					}},
+				},
+			},
+		},
+		"should not inject the CA of an unsupported issuer type": {
+			annotation: "ns/acme",
+			objects: []runtime.Object{
+				&cmapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "acme"},
+				},
+			},
+		},
+		"should not inject if the issuer does not exist": {
+			annotation: "ns/missing",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := &issuerDataSource{
+				client:                   fake.NewFakeClientWithScheme(api.Scheme, test.objects...),
+				clusterResourceNamespace: clusterResourceNamespace,
+				fetchVaultCA: func(ctx context.Context, vault *cmapi.VaultIssuer, clientCert *tls.Certificate) ([]byte, error) {
+					if clientCert != nil {
+						return []byte("vault-ca-for-client"), nil
+					}
+					return []byte("vault-ca"), nil
+				},
+			}
+			target := &metav1.ObjectMeta{
+				Annotations: map[string]string{cmapi.WantInjectFromIssuerAnnotation: test.annotation},
+			}
+			log := logf.Log
+
+			if !source.Configured(log, target) {
+				t.Fatalf("expected data source to be configured")
+			}
+			ca, err := source.ReadCA(context.TODO(), log, target)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(ca) != test.expectedCA {
+				t.Errorf("expected CA %q, got %q", test.expectedCA, string(ca))
+			}
+		})
+	}
+}
+
+func TestFetchVaultCA(t *testing.T) {
+	const caPEM = "-----BEGIN CERTIFICATE-----\nY2E=\n-----END CERTIFICATE-----\n"
+
+	tests := map[string]struct {
+		vault             cmapi.VaultIssuer
+		expectedPath      string
+		expectedNamespace string
+	}{
+		"should read the CA of the PKI mount": {
+			vault:        cmapi.VaultIssuer{Path: "pki/sign/role"},
+			expectedPath: "/v1/pki/ca/pem",
+		},
+		"should send the vault namespace": {
+			vault:             cmapi.VaultIssuer{Path: "pki/sign/role", Namespace: "team"},
+			expectedPath:      "/v1/pki/ca/pem",
+			expectedNamespace: "team",
+		},
+		"should read the CA of the referenced issuer of the PKI mount": {
+			vault:        cmapi.VaultIssuer{Path: "team/pki/sign/role", IssuerRef: "intermediate-2020"},
+			expectedPath: "/v1/team/pki/issuer/intermediate-2020/pem",
+		},
+		"should read the CA of a sign-verbatim PKI mount": {
+			vault:        cmapi.VaultIssuer{Path: "pki/sign-verbatim"},
+			expectedPath: "/v1/pki/ca/pem",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var path, namespace string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				namespace = r.Header.Get("X-Vault-Namespace")
+				w.Write([]byte(caPEM))
+			}))
+			defer server.Close()
+
+			test.vault.Server = server.URL
+			ca, err := fetchVaultCA(context.TODO(), &test.vault, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(ca) != caPEM {
+				t.Errorf("expected CA %q, got %q", caPEM, string(ca))
+			}
+			if path != test.expectedPath {
+				t.Errorf("expected request to %q, got %q", test.expectedPath, path)
+			}
+			if namespace != test.expectedNamespace {
+				t.Errorf("expected vault namespace %q, got %q", test.expectedNamespace, namespace)
+			}
+		})
+	}
+}
+
+// clientCertSecret returns a TLS Secret containing a self-signed client
+// certificate and its private key.
+func clientCertSecret(t *testing.T, namespace, name string) *corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "vault-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
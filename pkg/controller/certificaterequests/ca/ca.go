@@ -18,6 +18,7 @@ package ca
 
 import (
 	"context"
+	"crypto"
 	"crypto/x509"
 	"fmt"
 
@@ -29,6 +30,7 @@ import (
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/jetstack/cert-manager/pkg/internal/pkcs11"
 	issuerpkg "github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	cmerrors "github.com/jetstack/cert-manager/pkg/util/errors"
@@ -50,6 +52,9 @@ type CA struct {
 
 	// Used for testing to get reproducible resulting certificates
 	templateGenerator templateGenerator
+
+	// Used for testing to sign without a PKCS#11 token
+	pkcs11SignerBuilder pkcs11.SignerBuilder
 }
 
 func init() {
@@ -63,10 +68,11 @@ func init() {
 
 func NewCA(ctx *controllerpkg.Context) *CA {
 	return &CA{
-		issuerOptions:     ctx.IssuerOptions,
-		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
-		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
-		templateGenerator: pki.GenerateTemplateFromCertificateRequest,
+		issuerOptions:       ctx.IssuerOptions,
+		secretsLister:       ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		reporter:            crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		templateGenerator:   pki.GenerateTemplateFromCertificateRequest,
+		pkcs11SignerBuilder: pkcs11.SignerForKey,
 	}
 }
 
@@ -76,8 +82,19 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	secretName := issuerObj.GetSpec().CA.SecretName
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
-	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPair(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	pkcs11Key := issuerObj.GetSpec().CA.PKCS11
+
+	// get a copy of the CA certificate named on the Issuer. If the private
+	// key is stored in a PKCS#11 token, the secret only contains the
+	// certificate.
+	var caCerts []*x509.Certificate
+	var caKey crypto.Signer
+	var err error
+	if pkcs11Key != nil {
+		caCerts, err = kube.SecretTLSCertChain(ctx, c.secretsLister, resourceNamespace, secretName)
+	} else {
+		caCerts, caKey, err = kube.SecretTLSKeyPair(ctx, c.secretsLister, resourceNamespace, secretName)
+	}
 	if k8sErrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 
@@ -103,6 +120,26 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 		return nil, err
 	}
 
+	if pkcs11Key != nil {
+		caKey, err = c.pkcs11SignerBuilder(resourceNamespace, c.secretsLister, pkcs11Key, caCerts[0].PublicKey)
+		if k8sErrors.IsNotFound(err) {
+			message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, pkcs11Key.PINSecretRef.Name)
+
+			c.reporter.Pending(cr, err, "SecretMissing", message)
+			log.Error(err, message)
+
+			return nil, nil
+		}
+
+		if err != nil {
+			// The token may be temporarily unavailable so we should backoff and retry
+			message := "Failed to load signing CA private key from PKCS#11 token"
+			c.reporter.Pending(cr, err, "PKCS11Error", message)
+			log.Error(err, message)
+			return nil, err
+		}
+	}
+
 	template, err := c.templateGenerator(cr)
 	if err != nil {
 		message := "Error generating certificate template"
@@ -26,10 +26,12 @@ import (
 	"encoding/asn1"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
@@ -42,6 +44,7 @@ import (
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/internal/pkcs11"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 	"github.com/jetstack/cert-manager/test/unit/gen"
 	testlisters "github.com/jetstack/cert-manager/test/unit/listers"
@@ -138,6 +141,30 @@ func TestSign(t *testing.T) {
 		},
 	}
 
+	pkcs11Issuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerCA(cmapi.CAIssuer{
+			SecretName: "root-ca-cert",
+			PKCS11: &cmapi.CAPKCS11Key{
+				ModulePath: "/usr/lib/softhsm/libsofthsm2.so",
+				TokenLabel: "cert-manager",
+				KeyLabel:   "root-ca",
+				PINSecretRef: cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "token-pin"},
+					Key:                  "pin",
+				},
+			},
+		}),
+	)
+	rsaCACertSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "root-ca-cert",
+			Namespace: gen.DefaultTestNamespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: rsaPEMCert,
+		},
+	}
+
 	badDataSecret := rsaCASecret.DeepCopy()
 	badDataSecret.Data[corev1.TLSPrivateKeyKey] = []byte("bad key")
 
@@ -341,6 +368,105 @@ func TestSign(t *testing.T) {
 				},
 			},
 		},
+		"a successful signing using a PKCS#11 key should set condition to Ready": {
+			certificateRequest: baseCR.DeepCopy(),
+			templateGenerator: func(cr *cmapi.CertificateRequest) (*x509.Certificate, error) {
+				return template, nil
+			},
+			pkcs11SignerBuilder: func(namespace string, _ clientcorev1.SecretLister, key *cmapi.CAPKCS11Key, pub crypto.PublicKey) (crypto.Signer, error) {
+				if key.KeyLabel != "root-ca" {
+					return nil, fmt.Errorf("unexpected key label %q", key.KeyLabel)
+				}
+				if matches, err := pki.PublicKeyMatchesCertificate(pub, template); err != nil || !matches {
+					return nil, errors.New("signer was not given the public key of the CA certificate")
+				}
+				return skRSA, nil
+			},
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{rsaCACertSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), pkcs11Issuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCA(rsaPEMCert),
+							gen.SetCertificateRequestCertificate(certPEM),
+						),
+					)),
+				},
+			},
+		},
+		"a missing PKCS#11 PIN secret should set the condition to pending and wait for a re-sync": {
+			certificateRequest: baseCR.DeepCopy(),
+			pkcs11SignerBuilder: func(string, clientcorev1.SecretLister, *cmapi.CAPKCS11Key, crypto.PublicKey) (crypto.Signer, error) {
+				return nil, apierrors.NewNotFound(corev1.Resource("secrets"), "token-pin")
+			},
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{rsaCACertSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), pkcs11Issuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal SecretMissing Referenced secret default-unit-test-ns/token-pin not found: secrets "token-pin" not found`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Referenced secret default-unit-test-ns/token-pin not found: secrets "token-pin" not found`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"a PKCS#11 token that fails to load the key should set the condition to pending and backoff error to retry": {
+			certificateRequest: baseCR.DeepCopy(),
+			pkcs11SignerBuilder: func(string, clientcorev1.SecretLister, *cmapi.CAPKCS11Key, crypto.PublicKey) (crypto.Signer, error) {
+				return nil, errors.New(`no PKCS#11 token found with label "cert-manager"`)
+			},
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{rsaCACertSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), pkcs11Issuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal PKCS11Error Failed to load signing CA private key from PKCS#11 token: no PKCS#11 token found with label "cert-manager"`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Failed to load signing CA private key from PKCS#11 token: no PKCS#11 token found with label "cert-manager"`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			expectedErr: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -357,6 +483,8 @@ type testT struct {
 	certificateRequest *cmapi.CertificateRequest
 	templateGenerator  templateGenerator
 
+	pkcs11SignerBuilder pkcs11.SignerBuilder
+
 	expectedErr bool
 
 	fakeLister *testlisters.FakeSecretLister
@@ -377,6 +505,10 @@ func runTest(t *testing.T, test testT) {
 		ca.templateGenerator = test.templateGenerator
 	}
 
+	if test.pkcs11SignerBuilder != nil {
+		ca.pkcs11SignerBuilder = test.pkcs11SignerBuilder
+	}
+
 	controller := certificaterequests.New(apiutil.IssuerCA, ca)
 	controller.Register(test.builder.Context)
 	test.builder.Start()
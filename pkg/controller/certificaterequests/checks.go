@@ -19,6 +19,8 @@ package certificaterequests
 import (
 	"fmt"
 
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
@@ -78,3 +80,45 @@ func (c *Controller) certificatesRequestsForGenericIssuer(iss cmapi.GenericIssue
 
 	return affected, nil
 }
+
+// handleReferencingResource queues the CertificateRequest referenced by the
+// annotations of a resource created to fulfil it. This is used for resources
+// that cannot be owned by a CertificateRequest, such as cluster scoped
+// resources.
+func (c *Controller) handleReferencingResource(obj interface{}) {
+	log := c.log.WithName("handleReferencingResource")
+
+	metaobj, ok := obj.(metav1.Object)
+	if !ok {
+		log.Error(nil, "object does not implement metav1.Object")
+		return
+	}
+
+	namespace := metaobj.GetAnnotations()[cmapi.CertificateRequestNamespaceAnnotationKey]
+	name := metaobj.GetAnnotations()[cmapi.CertificateRequestNameAnnotationKey]
+	if namespace == "" || name == "" {
+		return
+	}
+
+	log = logf.WithResource(log, metaobj).WithValues(
+		logf.RelatedResourceNamespaceKey, namespace,
+		logf.RelatedResourceNameKey, name,
+		logf.RelatedResourceKindKey, cmapi.CertificateRequestKind,
+	)
+
+	cr, err := c.certificateRequestLister.CertificateRequests(namespace).Get(name)
+	if k8sErrors.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		log.Error(err, "error getting referenced certificate request")
+		return
+	}
+
+	key, err := keyFunc(cr)
+	if err != nil {
+		log.Error(err, "error computing key for resource")
+		return
+	}
+	c.queue.Add(key)
+}
@@ -41,6 +41,8 @@ const (
 
 var keyFunc = controllerpkg.KeyFunc
 
+// Issuer signs CertificateRequests that reference a cert-manager Issuer or
+// ClusterIssuer. It has the same contract as Signer.
 type Issuer interface {
 	Sign(context.Context, *v1alpha2.CertificateRequest, v1alpha2.GenericIssuer) (*issuer.IssueResponse, error)
 }
@@ -147,11 +149,13 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
 	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
 
-	// Ensure we catch extra informers that are owned by certificate requests
+	// Ensure we catch extra informers that are owned by certificate requests,
+	// or that reference them by annotation if they cannot be owned by them
 	for _, i := range c.extraInformers {
 		i.AddEventHandler(&controllerpkg.BlockingEventHandler{
 			WorkFunc: controllerpkg.HandleOwnedResourceNamespacedFunc(c.log, c.queue, certificateRequestGvk, certificateRequestGetter(c.certificateRequestLister)),
 		})
+		i.AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleReferencingResource})
 	}
 
 	// create an issuer helper for reading generic issuers
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"context"
+	"errors"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	estinternal "github.com/jetstack/cert-manager/pkg/internal/est"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	CRControllerName = "certificaterequests-issuer-est"
+)
+
+type EST struct {
+	issuerOptions controllerpkg.IssuerOptions
+	secretsLister corelisters.SecretLister
+	reporter      *crutil.Reporter
+
+	estClientBuilder estinternal.ClientBuilder
+}
+
+func init() {
+	// create certificate request controller for est issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(apiutil.IssuerEST, NewEST(ctx))).
+			Complete()
+	})
+}
+
+func NewEST(ctx *controllerpkg.Context) *EST {
+	return &EST{
+		issuerOptions:    ctx.IssuerOptions,
+		secretsLister:    ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		reporter:         crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		estClientBuilder: estinternal.New,
+	}
+}
+
+// Sign enrolls the CertificateRequest's CSR with the EST server. EST does not
+// allow the duration of the certificate to be requested, so the duration is
+// determined by the EST server.
+func (e *EST) Sign(ctx context.Context, cr *v1alpha2.CertificateRequest, issuerObj v1alpha2.GenericIssuer) (*issuer.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+	log = logf.WithRelatedResource(log, issuerObj)
+
+	client, err := e.estClientBuilder(e.issuerOptions.ResourceNamespace(issuerObj), e.secretsLister, issuerObj)
+	if k8sErrors.IsNotFound(err) {
+		message := "Required secret resource not found"
+
+		e.reporter.Pending(cr, err, "SecretMissing", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	if err != nil {
+		message := "Failed to initialise EST client for signing"
+		e.reporter.Pending(cr, err, "ESTInitError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	certPem, caPem, err := client.Sign(ctx, cr.Spec.CSRPEM)
+
+	// The EST server has accepted the request but requires it to be approved
+	// before issuing the certificate, so the request is sent again later.
+	var pendingErr *estinternal.EnrollmentPendingError
+	if errors.As(err, &pendingErr) {
+		message := "Enrollment is pending approval on the EST server"
+		e.reporter.Pending(cr, err, "EnrollmentPending", message)
+		log.V(4).Info(message, "retry_after", pendingErr.RetryAfter)
+		return nil, err
+	}
+
+	if err != nil {
+		message := "EST server failed to sign certificate"
+
+		e.reporter.Failed(cr, err, "SigningError", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	log.Info("certificate issued")
+
+	return &issuer.IssueResponse{
+		Certificate: certPem,
+		CA:          caPem,
+	}, nil
+}
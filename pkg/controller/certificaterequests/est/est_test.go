@@ -0,0 +1,304 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	internalest "github.com/jetstack/cert-manager/pkg/internal/est"
+	fakeest "github.com/jetstack/cert-manager/pkg/internal/est/fake"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+func generateCSR(t *testing.T, sk crypto.Signer) []byte {
+	csrBytes, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "test"},
+	}, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrBytes})
+}
+
+func generateSelfSignedCertFromCR(t *testing.T, cr *cmapi.CertificateRequest, sk crypto.Signer) []byte {
+	template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM, _, err := pki.SignCertificate(template, template, sk.Public(), sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certPEM
+}
+
+func TestSign(t *testing.T) {
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	estIssuer := cmapi.ESTIssuer{
+		URL: "https://est.example.com/.well-known/est",
+		Auth: cmapi.ESTAuth{
+			BasicAuth: &cmapi.ESTBasicAuth{
+				Username: "cert-manager",
+				PasswordSecretRef: cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "est-password"},
+					Key:                  "password",
+				},
+			},
+		},
+	}
+	baseIssuer := gen.Issuer("est-issuer",
+		gen.SetIssuerEST(estIssuer),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	csrPEM := generateCSR(t, sk)
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Name:  baseIssuer.Name,
+			Group: certmanager.GroupName,
+			Kind:  baseIssuer.Kind,
+		}),
+	)
+
+	certPEM := generateSelfSignedCertFromCR(t, baseCR, sk)
+
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: gen.DefaultTestNamespace,
+			Name:      "est-password",
+		},
+		Data: map[string][]byte{
+			"password": []byte("secret"),
+		},
+	}
+
+	signingClient := func(certPEM, caPEM []byte, err error) *fakeest.EST {
+		return &fakeest.EST{
+			SignFn: func(_ context.Context, csrPEM []byte) ([]byte, []byte, error) {
+				if string(csrPEM) != string(baseCR.Spec.CSRPEM) {
+					t.Errorf("unexpected CSR sent to EST server: %q", csrPEM)
+				}
+				return certPEM, caPEM, err
+			},
+			CACertsFn: func(context.Context) ([]*x509.Certificate, error) {
+				return nil, nil
+			},
+		}
+	}
+
+	tests := map[string]testT{
+		"a missing password secret should report pending": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal SecretMissing Required secret resource not found: secret "est-password" not found`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Required secret resource not found: secret "est-password" not found`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"a pending enrollment should report pending and return an error to retry": {
+			certificateRequest: baseCR.DeepCopy(),
+			fakeEST:            signingClient(nil, nil, &internalest.EnrollmentPendingError{RetryAfter: time.Minute}),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{passwordSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal EnrollmentPending Enrollment is pending approval on the EST server: enrollment is pending manual approval on the EST server, retry after 1m0s",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Enrollment is pending approval on the EST server: enrollment is pending manual approval on the EST server, retry after 1m0s",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			expectedErr: true,
+		},
+		"a failure to sign should report failed": {
+			certificateRequest: baseCR.DeepCopy(),
+			fakeEST:            signingClient(nil, nil, errors.New("failed to sign")),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{passwordSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning SigningError EST server failed to sign certificate: failed to sign",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "EST server failed to sign certificate: failed to sign",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
+		"a successful enrollment should return the certificate and CA": {
+			certificateRequest: baseCR.DeepCopy(),
+			fakeEST:            signingClient(certPEM, certPEM, nil),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{passwordSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestCA(certPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			test.builder.Clock = fixedClock
+			runTest(t, test)
+		})
+	}
+}
+
+type testT struct {
+	builder            *testpkg.Builder
+	certificateRequest *cmapi.CertificateRequest
+
+	expectedErr bool
+
+	// fakeEST is returned by the client builder once the referenced
+	// secrets have been found
+	fakeEST *fakeest.EST
+}
+
+func runTest(t *testing.T, test testT) {
+	test.builder.T = t
+	test.builder.Init()
+	defer test.builder.Stop()
+
+	est := NewEST(test.builder.Context)
+
+	if test.fakeEST != nil {
+		est.estClientBuilder = func(ns string, sl corelisters.SecretLister, iss cmapi.GenericIssuer) (internalest.Interface, error) {
+			if _, err := internalest.New(ns, sl, iss); err != nil {
+				return nil, err
+			}
+			return test.fakeEST, nil
+		}
+	}
+
+	controller := certificaterequests.New(apiutil.IssuerEST, est)
+	controller.Register(test.builder.Context)
+	test.builder.Start()
+
+	err := controller.Sync(context.Background(), test.certificateRequest)
+	if err != nil && !test.expectedErr {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	if err == nil && test.expectedErr {
+		t.Errorf("expected to get an error but did not get one")
+	}
+
+	test.builder.CheckAndFinish(err)
+}
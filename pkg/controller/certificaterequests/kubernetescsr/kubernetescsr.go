@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetescsr
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1beta1"
+	certificateslisters "k8s.io/client-go/listers/certificates/v1beta1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	issuerpkg "github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	CRControllerName = "certificaterequests-issuer-kubernetescsr"
+)
+
+type KubernetesCSR struct {
+	csrLister certificateslisters.CertificateSigningRequestLister
+	csrClient certificatesclient.CertificateSigningRequestsGetter
+
+	reporter *crutil.Reporter
+}
+
+func init() {
+	// create certificate request controller for kubernetes csr issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		// watch CertificateSigningRequest resources and trigger resyncs of
+		// the CertificateRequests they were created for. These are cluster
+		// scoped so cannot be owned by CertificateRequests, and instead
+		// reference them by annotation.
+		csrInformer := ctx.KubeSharedInformerFactory.Certificates().V1beta1().CertificateSigningRequests().Informer()
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(apiutil.IssuerKubernetesCSR, NewKubernetesCSR(ctx), csrInformer)).
+			Complete()
+	})
+}
+
+func NewKubernetesCSR(ctx *controllerpkg.Context) *KubernetesCSR {
+	return &KubernetesCSR{
+		csrLister: ctx.KubeSharedInformerFactory.Certificates().V1beta1().CertificateSigningRequests().Lister(),
+		csrClient: ctx.Client.CertificatesV1beta1(),
+		reporter:  crutil.NewReporter(ctx.Clock, ctx.Recorder),
+	}
+}
+
+func (k *KubernetesCSR) Sign(ctx context.Context, cr *v1alpha2.CertificateRequest, issuerObj v1alpha2.GenericIssuer) (*issuerpkg.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+
+	// If we can't decode the CSR PEM we have to hard fail
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.CSRPEM)
+	if err != nil {
+		message := "Failed to decode CSR in spec"
+
+		k.reporter.Failed(cr, err, "CSRParsingError", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	issuerSpec := issuerObj.GetSpec().KubernetesCSR
+	signerName := issuerSpec.SignerName
+	expected := buildCertificateSigningRequest(cr, signerName)
+
+	kubeCSR, err := k.csrLister.Get(expected.Name)
+	if k8sErrors.IsNotFound(err) {
+		// Failing to create the CertificateSigningRequest here is most
+		// likely network related. We should backoff and keep trying.
+		_, err = k.csrClient.CertificateSigningRequests().Create(ctx, expected, metav1.CreateOptions{})
+		if err != nil {
+			message := fmt.Sprintf("Failed to create CertificateSigningRequest %s", expected.Name)
+
+			k.reporter.Pending(cr, err, "CertificateSigningRequestCreatingError", message)
+			log.Error(err, message)
+
+			return nil, err
+		}
+
+		message := fmt.Sprintf("Created CertificateSigningRequest %s for signer %q, waiting for it to be approved and signed",
+			expected.Name, signerName)
+		k.reporter.Pending(cr, nil, "CertificateSigningRequestCreated", message)
+		log.V(4).Info(message)
+
+		return nil, nil
+	}
+	if err != nil {
+		// We are probably in a network error here so we should backoff and retry
+		message := fmt.Sprintf("Failed to get CertificateSigningRequest %s", expected.Name)
+
+		k.reporter.Pending(cr, err, "CertificateSigningRequestGetError", message)
+		log.Error(err, message)
+
+		return nil, err
+	}
+	if kubeCSR.Annotations[v1alpha2.CertificateRequestNamespaceAnnotationKey] != cr.Namespace ||
+		kubeCSR.Annotations[v1alpha2.CertificateRequestNameAnnotationKey] != cr.Name {
+		return nil, fmt.Errorf("found CertificateSigningRequest %s not created for this CertificateRequest, retrying", kubeCSR.Name)
+	}
+
+	log = logf.WithRelatedResource(log, kubeCSR)
+
+	for _, cond := range kubeCSR.Status.Conditions {
+		if cond.Type == certificatesv1beta1.CertificateDenied {
+			message := fmt.Sprintf("CertificateSigningRequest %s has been denied", kubeCSR.Name)
+			err := fmt.Errorf("%s: %s", cond.Reason, cond.Message)
+
+			k.reporter.Failed(cr, err, "CertificateSigningRequestDenied", message)
+			log.V(4).Info(message, "reason", cond.Reason, "message", cond.Message)
+
+			return nil, nil
+		}
+	}
+
+	if len(kubeCSR.Status.Certificate) == 0 {
+		message := fmt.Sprintf("Waiting for CertificateSigningRequest %s to be approved", kubeCSR.Name)
+		if isApproved(kubeCSR) {
+			message = fmt.Sprintf("Waiting for CertificateSigningRequest %s to be signed by signer %q", kubeCSR.Name, signerName)
+		}
+
+		k.reporter.Pending(cr, nil, "CertificateSigningRequestPending", message)
+		log.V(4).Info(message)
+
+		return nil, nil
+	}
+
+	// The signer has issued a certificate, ensure it is for the requested key
+	x509Cert, err := pki.DecodeX509CertificateBytes(kubeCSR.Status.Certificate)
+	if err != nil {
+		message := fmt.Sprintf("Invalid certificate on CertificateSigningRequest %s", kubeCSR.Name)
+
+		k.reporter.Failed(cr, err, "CertificateSigningRequestInvalidCertificate", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+	ok, err := pki.PublicKeyMatchesCertificate(csr.PublicKey, x509Cert)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		message := fmt.Sprintf("Invalid certificate on CertificateSigningRequest %s", kubeCSR.Name)
+		err := fmt.Errorf("public key of the certificate does not match the CSR")
+
+		k.reporter.Failed(cr, err, "CertificateSigningRequestInvalidCertificate", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	log.Info("certificate issued")
+
+	// The CertificateSigningRequest API does not return the CA that signed
+	// the certificate, so use the CA configured on the issuer, if any.
+	return &issuerpkg.IssueResponse{
+		Certificate: kubeCSR.Status.Certificate,
+		CA:          issuerSpec.CABundle,
+	}, nil
+}
+
+func isApproved(csr *certificatesv1beta1.CertificateSigningRequest) bool {
+	for _, cond := range csr.Status.Conditions {
+		if cond.Type == certificatesv1beta1.CertificateApproved {
+			return true
+		}
+	}
+	return false
+}
+
+// buildCertificateSigningRequest builds the CertificateSigningRequest
+// addressed to signerName for the CertificateRequest. Its name is derived
+// from the CertificateRequest so that the same CertificateSigningRequest is
+// found on every sync, and includes the UID of the CertificateRequest so that
+// a new CertificateRequest with the same name does not reuse it.
+func buildCertificateSigningRequest(cr *v1alpha2.CertificateRequest, signerName string) *certificatesv1beta1.CertificateSigningRequest {
+	hash := fnv.New32()
+	hash.Write([]byte(cr.Namespace + "/" + cr.Name + "/" + string(cr.UID)))
+
+	usages := cr.Spec.Usages
+	if len(usages) == 0 {
+		usages = v1alpha2.DefaultKeyUsages()
+	}
+	var csrUsages []certificatesv1beta1.KeyUsage
+	for _, usage := range usages {
+		csrUsages = append(csrUsages, certificatesv1beta1.KeyUsage(usage))
+	}
+
+	// Only the annotations linking the CertificateSigningRequest back to the
+	// CertificateRequest and Certificate are set, as the labels and
+	// annotations of namespaced resources should not be copied onto cluster
+	// scoped ones.
+	annotations := map[string]string{
+		v1alpha2.CertificateRequestNamespaceAnnotationKey: cr.Namespace,
+		v1alpha2.CertificateRequestNameAnnotationKey:      cr.Name,
+	}
+	if crtName, ok := cr.Annotations[v1alpha2.CertificateNameKey]; ok {
+		annotations[v1alpha2.CertificateNameKey] = crtName
+	}
+
+	// truncate certificate request name so final name will be <= 63
+	// characters. hash (uint32) will be at most 10 digits long, and we
+	// account for the hyphen.
+	return &certificatesv1beta1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%.52s-%d", cr.Name, hash.Sum32()),
+			Annotations: annotations,
+		},
+		Spec: certificatesv1beta1.CertificateSigningRequestSpec{
+			Request:    cr.Spec.CSRPEM,
+			SignerName: &signerName,
+			Usages:     csrUsages,
+		},
+	}
+}
@@ -0,0 +1,392 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetescsr
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+func generateCSR(t *testing.T, sk crypto.Signer) []byte {
+	csrBytes, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "test"},
+	}, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrBytes})
+}
+
+func generateSelfSignedCertFromCR(t *testing.T, cr *cmapi.CertificateRequest, sk crypto.Signer) []byte {
+	template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM, _, err := pki.SignCertificate(template, template, sk.Public(), sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certPEM
+}
+
+func TestSign(t *testing.T) {
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	signerName := "example.com/my-signer"
+	baseIssuer := gen.Issuer("kubernetes-csr-issuer",
+		gen.SetIssuerKubernetesCSR(cmapi.KubernetesCSRIssuer{SignerName: signerName}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+
+	sk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSK, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(generateCSR(t, sk)),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Name:  baseIssuer.Name,
+			Group: certmanager.GroupName,
+			Kind:  baseIssuer.Kind,
+		}),
+		func(cr *cmapi.CertificateRequest) {
+			cr.UID = "test-uid"
+		},
+	)
+
+	certPEM := generateSelfSignedCertFromCR(t, baseCR, sk)
+	otherCertPEM := generateSelfSignedCertFromCR(t, gen.CertificateRequestFrom(baseCR,
+		gen.SetCertificateRequestCSR(generateCSR(t, otherSK)),
+	), otherSK)
+
+	baseKubeCSR := buildCertificateSigningRequest(baseCR, signerName)
+	kubeCSRWith := func(certificate []byte, conditions ...certificatesv1beta1.CertificateSigningRequestCondition) *certificatesv1beta1.CertificateSigningRequest {
+		kubeCSR := baseKubeCSR.DeepCopy()
+		kubeCSR.Status.Certificate = certificate
+		kubeCSR.Status.Conditions = conditions
+		return kubeCSR
+	}
+	approved := certificatesv1beta1.CertificateSigningRequestCondition{
+		Type:   certificatesv1beta1.CertificateApproved,
+		Reason: "Approved",
+	}
+	denied := certificatesv1beta1.CertificateSigningRequestCondition{
+		Type:    certificatesv1beta1.CertificateDenied,
+		Reason:  "PolicyViolation",
+		Message: "not allowed",
+	}
+
+	otherKubeCSR := kubeCSRWith(certPEM, approved)
+	otherKubeCSR.Annotations[cmapi.CertificateRequestNamespaceAnnotationKey] = "other-namespace"
+
+	pendingUpdate := func(message string) testpkg.Action {
+		return testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+			cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+			"status",
+			gen.DefaultTestNamespace,
+			gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:               cmapi.CertificateRequestConditionReady,
+					Status:             cmmeta.ConditionFalse,
+					Reason:             cmapi.CertificateRequestReasonPending,
+					Message:            message,
+					LastTransitionTime: &metaFixedClockStart,
+				}),
+			),
+		))
+	}
+	failedUpdate := func(message string) testpkg.Action {
+		return testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+			cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+			"status",
+			gen.DefaultTestNamespace,
+			gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:               cmapi.CertificateRequestConditionReady,
+					Status:             cmmeta.ConditionFalse,
+					Reason:             cmapi.CertificateRequestReasonFailed,
+					Message:            message,
+					LastTransitionTime: &metaFixedClockStart,
+				}),
+				gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+			),
+		))
+	}
+
+	tests := map[string]testT{
+		"should create a CertificateSigningRequest if one does not exist": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal CertificateSigningRequestCreated Created CertificateSigningRequest ` + baseKubeCSR.Name + ` for signer "example.com/my-signer", waiting for it to be approved and signed`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						certificatesv1beta1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
+						"",
+						baseKubeCSR,
+					)),
+					pendingUpdate(`Created CertificateSigningRequest ` + baseKubeCSR.Name + ` for signer "example.com/my-signer", waiting for it to be approved and signed`),
+				},
+			},
+		},
+		"should report pending if the CertificateSigningRequest has not been approved": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{kubeCSRWith(nil)},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateSigningRequestPending Waiting for CertificateSigningRequest " + baseKubeCSR.Name + " to be approved",
+				},
+				ExpectedActions: []testpkg.Action{
+					pendingUpdate("Waiting for CertificateSigningRequest " + baseKubeCSR.Name + " to be approved"),
+				},
+			},
+		},
+		"should report pending if the CertificateSigningRequest has been approved but not signed": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{kubeCSRWith(nil, approved)},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal CertificateSigningRequestPending Waiting for CertificateSigningRequest ` + baseKubeCSR.Name + ` to be signed by signer "example.com/my-signer"`,
+				},
+				ExpectedActions: []testpkg.Action{
+					pendingUpdate(`Waiting for CertificateSigningRequest ` + baseKubeCSR.Name + ` to be signed by signer "example.com/my-signer"`),
+				},
+			},
+		},
+		"should report failed if the CertificateSigningRequest has been denied": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{kubeCSRWith(nil, denied)},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning CertificateSigningRequestDenied CertificateSigningRequest " + baseKubeCSR.Name + " has been denied: PolicyViolation: not allowed",
+				},
+				ExpectedActions: []testpkg.Action{
+					failedUpdate("CertificateSigningRequest " + baseKubeCSR.Name + " has been denied: PolicyViolation: not allowed"),
+				},
+			},
+		},
+		"should report failed if the signed certificate is not for the requested key": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{kubeCSRWith(otherCertPEM, approved)},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning CertificateSigningRequestInvalidCertificate Invalid certificate on CertificateSigningRequest " + baseKubeCSR.Name + ": public key of the certificate does not match the CSR",
+				},
+				ExpectedActions: []testpkg.Action{
+					failedUpdate("Invalid certificate on CertificateSigningRequest " + baseKubeCSR.Name + ": public key of the certificate does not match the CSR"),
+				},
+			},
+		},
+		"should return an error if the CertificateSigningRequest was not created for the CertificateRequest": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{otherKubeCSR},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+			},
+			expectedErr: true,
+		},
+		"should copy the signed certificate back to the CertificateRequest": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{kubeCSRWith(certPEM, approved)},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"should set the CA of the CertificateRequest to the issuer's CA bundle": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{kubeCSRWith(certPEM, approved)},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerKubernetesCSR(cmapi.KubernetesCSRIssuer{SignerName: signerName, CABundle: certPEM}),
+				)},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestCA(certPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			test.builder.Clock = fixedClock
+			runTest(t, test)
+		})
+	}
+}
+
+func TestBuildCertificateSigningRequest(t *testing.T) {
+	cr := gen.CertificateRequest(strings.Repeat("a", 60),
+		gen.SetCertificateRequestCSR([]byte("csr")),
+		func(cr *cmapi.CertificateRequest) {
+			cr.UID = "test-uid"
+			cr.Labels = map[string]string{"app": "test"}
+			cr.Annotations = map[string]string{
+				cmapi.CertificateNameKey: "test-crt",
+				"example.com/other":      "value",
+			}
+		},
+	)
+
+	kubeCSR := buildCertificateSigningRequest(cr, "example.com/my-signer")
+	if len(kubeCSR.Name) > 63 {
+		t.Errorf("expected name to be at most 63 characters, got=%q", kubeCSR.Name)
+	}
+	if !strings.HasPrefix(kubeCSR.Name, strings.Repeat("a", 52)+"-") {
+		t.Errorf("expected name to be prefixed by the truncated CertificateRequest name, got=%q", kubeCSR.Name)
+	}
+	if string(kubeCSR.Spec.Request) != "csr" || *kubeCSR.Spec.SignerName != "example.com/my-signer" {
+		t.Errorf("unexpected spec: %+v", kubeCSR.Spec)
+	}
+	if len(kubeCSR.Labels) != 0 {
+		t.Errorf("expected no labels to be copied from the CertificateRequest, got=%v", kubeCSR.Labels)
+	}
+	expAnnotations := map[string]string{
+		cmapi.CertificateRequestNamespaceAnnotationKey: cr.Namespace,
+		cmapi.CertificateRequestNameAnnotationKey:      cr.Name,
+		cmapi.CertificateNameKey:                       "test-crt",
+	}
+	if !reflect.DeepEqual(kubeCSR.Annotations, expAnnotations) {
+		t.Errorf("expected only linking annotations, exp=%v got=%v", expAnnotations, kubeCSR.Annotations)
+	}
+	expUsages := []certificatesv1beta1.KeyUsage{certificatesv1beta1.UsageDigitalSignature, certificatesv1beta1.UsageKeyEncipherment}
+	if !reflect.DeepEqual(kubeCSR.Spec.Usages, expUsages) {
+		t.Errorf("expected default usages, exp=%v got=%v", expUsages, kubeCSR.Spec.Usages)
+	}
+
+	for name, modify := range map[string]func(*cmapi.CertificateRequest){
+		"namespace": func(cr *cmapi.CertificateRequest) { cr.Namespace = "other-namespace" },
+		"uid":       func(cr *cmapi.CertificateRequest) { cr.UID = "other-uid" },
+	} {
+		other := cr.DeepCopy()
+		modify(other)
+		if otherName := buildCertificateSigningRequest(other, "example.com/my-signer").Name; otherName == kubeCSR.Name {
+			t.Errorf("expected a different name for a CertificateRequest with a different %s, got=%q", name, otherName)
+		}
+	}
+}
+
+type testT struct {
+	builder            *testpkg.Builder
+	certificateRequest *cmapi.CertificateRequest
+
+	expectedErr bool
+}
+
+func runTest(t *testing.T, test testT) {
+	test.builder.T = t
+	test.builder.Init()
+	defer test.builder.Stop()
+
+	kubeCSR := NewKubernetesCSR(test.builder.Context)
+
+	controller := certificaterequests.New(apiutil.IssuerKubernetesCSR, kubeCSR)
+	controller.Register(test.builder.Context)
+	test.builder.Start()
+
+	err := controller.Sync(context.Background(), test.certificateRequest)
+	if err != nil && !test.expectedErr {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	if err == nil && test.expectedErr {
+		t.Errorf("expected to get an error but did not get one")
+	}
+
+	test.builder.CheckAndFinish(err)
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kr/pretty"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	internalapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/pkg/webhook"
+)
+
+// IssuerObject is an issuer resource referenced by a CertificateRequest. The
+// in-tree issuers use cert-manager Issuers and ClusterIssuers, but
+// out-of-tree issuers may use resources of any type and API group.
+type IssuerObject interface {
+	runtime.Object
+	metav1.Object
+}
+
+// IssuerGetter looks up the issuers referenced by CertificateRequests for a
+// Reconciler.
+type IssuerGetter interface {
+	// GetIssuer returns the issuer referenced by the CertificateRequest.
+	// An error satisfying k8s.io/apimachinery/pkg/api/errors.IsNotFound, or
+	// an IssuerPendingError, marks the CertificateRequest as pending. If the
+	// issuer exists but is not handled by the Reconciler, a nil issuer and
+	// error should be returned and the CertificateRequest is ignored.
+	GetIssuer(ctx context.Context, cr *v1alpha2.CertificateRequest) (IssuerObject, error)
+
+	// IssuerReady returns whether the issuer is ready to sign
+	// CertificateRequests. The CertificateRequest is marked as pending until
+	// it is.
+	IssuerReady(iss IssuerObject) bool
+}
+
+// Signer signs CertificateRequests using the issuer they reference.
+type Signer interface {
+	// Sign is called once the referenced issuer is ready, and the
+	// CertificateRequest is valid and has not been signed yet. It returns the
+	// signed certificate, which is stored in the status of the
+	// CertificateRequest and marks it as ready.
+	// If the certificate has not been signed, Sign should mark the
+	// CertificateRequest as pending or failed using a util.Reporter and
+	// return a nil response. Returning an error causes the
+	// CertificateRequest to be retried with backoff.
+	Sign(ctx context.Context, cr *v1alpha2.CertificateRequest, iss IssuerObject) (*issuer.IssueResponse, error)
+}
+
+// IssuerPendingError can be returned by an IssuerGetter to mark the
+// CertificateRequest as pending with the given reason and message, for
+// example if the referenced issuer is misconfigured.
+type IssuerPendingError struct {
+	Reason  string
+	Message string
+	Err     error
+}
+
+func (e *IssuerPendingError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
+}
+
+// Reconciler implements the CertificateRequest reconcile loop that is shared
+// by all issuers: looking up the referenced issuer, validating the request,
+// calling the Signer and reporting the result in the status of the
+// CertificateRequest. It is used by the in-tree issuers, and can be used by
+// out-of-tree issuers to reconcile CertificateRequests that reference their
+// own issuer resources.
+type Reconciler struct {
+	// IssuerGroup is the API group of the issuers handled by the Reconciler.
+	// CertificateRequests referencing issuers in other groups are ignored.
+	// An issuerRef without a group references the cert-manager.io group.
+	IssuerGroup string
+
+	// Issuers looks up the issuers referenced by CertificateRequests.
+	Issuers IssuerGetter
+
+	// Signer signs CertificateRequests.
+	Signer Signer
+
+	// Reporter sets the conditions of CertificateRequests and records
+	// Events for them.
+	Reporter *util.Reporter
+
+	// Client is used to update the status of CertificateRequests.
+	Client cmclient.Interface
+}
+
+// Reconcile reconciles the CertificateRequest, updating its status with the
+// result. An error is returned if the CertificateRequest should be retried.
+func (r *Reconciler) Reconcile(ctx context.Context, cr *v1alpha2.CertificateRequest) (err error) {
+	log := logf.FromContext(ctx)
+	dbg := log.V(logf.DebugLevel)
+
+	if !r.handlesIssuerGroup(cr.Spec.IssuerRef.Group) {
+		dbg.Info("certificate request issuerRef group does not match the issuer group of this controller so skipping processing")
+		return nil
+	}
+
+	switch apiutil.CertificateRequestReadyReason(cr) {
+	case v1alpha2.CertificateRequestReasonFailed:
+		dbg.Info("certificate request Ready condition failed so skipping processing")
+		return
+
+	case v1alpha2.CertificateRequestReasonIssued:
+		dbg.Info("certificate request Ready condition true so skipping processing")
+		return
+	}
+
+	crCopy := cr.DeepCopy()
+
+	defer func() {
+		if _, saveErr := r.updateStatus(ctx, cr, crCopy); saveErr != nil {
+			err = utilerrors.NewAggregate([]error{saveErr, err})
+		}
+	}()
+
+	dbg.Info("fetching issuer object referenced by CertificateRequest")
+
+	issuerObj, err := r.Issuers.GetIssuer(ctx, crCopy)
+	if k8sErrors.IsNotFound(err) {
+		r.Reporter.Pending(crCopy, err, "IssuerNotFound",
+			fmt.Sprintf("Referenced %q not found", apiutil.IssuerKind(crCopy.Spec.IssuerRef)))
+		return nil
+	}
+	if pendingErr, ok := err.(*IssuerPendingError); ok {
+		r.Reporter.Pending(crCopy, pendingErr.Err, pendingErr.Reason, pendingErr.Message)
+		return nil
+	}
+	if err != nil {
+		log.Error(err, "failed to get issuer")
+		return err
+	}
+
+	// This CertificateRequest is not meant for us, ignore
+	if issuerObj == nil {
+		return nil
+	}
+
+	log = logf.WithRelatedResource(log, issuerObj)
+
+	// check ready condition
+	if !r.Issuers.IssuerReady(issuerObj) {
+		r.Reporter.Pending(crCopy, nil, "IssuerNotReady",
+			"Referenced issuer does not have a Ready status condition")
+		return nil
+	}
+
+	dbg.Info("validating CertificateRequest resource object")
+
+	el := webhook.ValidationRegistry.Validate(crCopy, internalapi.SchemeGroupVersion.WithKind("CertificateRequest"))
+	if len(el) > 0 {
+		r.Reporter.Failed(crCopy, el.ToAggregate(), "BadConfig",
+			"Resource validation failed")
+		return nil
+	}
+
+	if len(crCopy.Status.Certificate) > 0 {
+		dbg.Info("certificate field is already set in status so skipping processing")
+		return nil
+	}
+
+	dbg.Info("invoking sign function as existing certificate does not exist")
+
+	// Attempt to call the Sign function on our issuer
+	resp, err := r.Signer.Sign(ctx, crCopy, issuerObj)
+	if err != nil {
+		log.Error(err, "error issuing certificate request")
+		return err
+	}
+
+	// If the issuer has not returned any data we may be pending or failed. The
+	// underlying issuer will have set the condition of pending or failed and we
+	// should potentially wait for a re-sync.
+	if resp == nil {
+		return nil
+	}
+
+	// Update to status with the new given response.
+	crCopy.Status.Certificate = resp.Certificate
+	crCopy.Status.CA = resp.CA
+
+	// invalid cert
+	cert, err := pki.DecodeX509CertificateBytes(crCopy.Status.Certificate)
+	if err != nil {
+		r.Reporter.Failed(crCopy, err, "DecodeError", "Failed to decode returned certificate")
+		return nil
+	}
+
+	// The certificate will stop being trusted when its CA expires, so warn
+	// if that happens before the certificate itself expires.
+	if ca := caExpiringBefore(cert, crCopy.Status.Certificate, crCopy.Status.CA); ca != nil {
+		r.Reporter.Warning(crCopy, "CAExpiresBeforeCertificate", fmt.Sprintf(
+			"The issuing CA certificate %q expires at %s, before the issued certificate expires at %s",
+			ca.Subject.String(), ca.NotAfter.Format(time.RFC3339), cert.NotAfter.Format(time.RFC3339)))
+	}
+
+	// Set condition to Ready.
+	r.Reporter.Ready(crCopy)
+
+	return nil
+}
+
+// caExpiringBefore returns the CA certificate from the issued chain and CA
+// that expires first, if it expires before cert. Certificates that cannot be
+// decoded are ignored.
+func caExpiringBefore(cert *x509.Certificate, chainPEM, caPEM []byte) *x509.Certificate {
+	var cas []*x509.Certificate
+	if chain, err := pki.DecodeX509CertificateChainBytes(chainPEM); err == nil {
+		cas = append(cas, chain[1:]...)
+	}
+	if ca, err := pki.DecodeX509CertificateChainBytes(caPEM); err == nil {
+		cas = append(cas, ca...)
+	}
+
+	var earliest *x509.Certificate
+	for _, ca := range cas {
+		if !ca.NotAfter.Before(cert.NotAfter) {
+			continue
+		}
+		if earliest == nil || ca.NotAfter.Before(earliest.NotAfter) {
+			earliest = ca
+		}
+	}
+
+	return earliest
+}
+
+func (r *Reconciler) handlesIssuerGroup(group string) bool {
+	if group == "" {
+		group = certmanager.GroupName
+	}
+	issuerGroup := r.IssuerGroup
+	if issuerGroup == "" {
+		issuerGroup = certmanager.GroupName
+	}
+	return group == issuerGroup
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, old, new *v1alpha2.CertificateRequest) (*v1alpha2.CertificateRequest, error) {
+	log := logf.FromContext(ctx, "updateStatus")
+	oldBytes, _ := json.Marshal(old.Status)
+	newBytes, _ := json.Marshal(new.Status)
+	if reflect.DeepEqual(oldBytes, newBytes) {
+		return nil, nil
+	}
+
+	log.V(logf.DebugLevel).Info("updating resource due to change in status", "diff", pretty.Diff(string(oldBytes), string(newBytes)))
+	return r.Client.CertmanagerV1alpha2().CertificateRequests(new.Namespace).UpdateStatus(context.TODO(), new, metav1.UpdateOptions{})
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequests
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	coretesting "k8s.io/client-go/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+const externalIssuerGroup = "example.issuer.io"
+
+// fakeIssuerGetter is an IssuerGetter for an out-of-tree issuer type.
+type fakeIssuerGetter struct {
+	issuer IssuerObject
+	err    error
+	ready  bool
+}
+
+func (f *fakeIssuerGetter) GetIssuer(context.Context, *cmapi.CertificateRequest) (IssuerObject, error) {
+	return f.issuer, f.err
+}
+
+func (f *fakeIssuerGetter) IssuerReady(IssuerObject) bool {
+	return f.ready
+}
+
+type fakeSigner func(context.Context, *cmapi.CertificateRequest, IssuerObject) (*issuer.IssueResponse, error)
+
+func (f fakeSigner) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss IssuerObject) (*issuer.IssueResponse, error) {
+	return f(ctx, cr, iss)
+}
+
+func TestReconcile(t *testing.T) {
+	nowMetaTime := metav1.NewTime(fixedClockStart)
+
+	sk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	externalIssuer := &unstructured.Unstructured{}
+	externalIssuer.SetGroupVersionKind(schema.GroupVersionKind{Group: externalIssuerGroup, Version: "v1", Kind: "ExampleIssuer"})
+	externalIssuer.SetName("test-issuer")
+	externalIssuer.SetNamespace(gen.DefaultTestNamespace)
+
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(generateCSR(t, sk, x509.SHA256WithRSA)),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Group: externalIssuerGroup,
+			Kind:  "ExampleIssuer",
+			Name:  "test-issuer",
+		}),
+	)
+
+	certPEM := generateSelfSignedCert(t, baseCR, sk, fixedClockStart, fixedClockStart.Add(time.Hour*12))
+	expiringCAPEM := generateSelfSignedCert(t, baseCR, sk, fixedClockStart, fixedClockStart.Add(time.Hour))
+
+	unexpectedSign := fakeSigner(func(context.Context, *cmapi.CertificateRequest, IssuerObject) (*issuer.IssueResponse, error) {
+		return nil, errors.New("unexpected sign call")
+	})
+
+	tests := map[string]struct {
+		certificateRequest *cmapi.CertificateRequest
+		issuers            *fakeIssuerGetter
+		signer             Signer
+
+		builder     *testpkg.Builder
+		expectedErr bool
+	}{
+		"should ignore requests for issuers in other groups": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Kind: "Issuer", Name: "test-issuer"}),
+			),
+			issuers: &fakeIssuerGetter{issuer: externalIssuer, ready: true},
+			signer:  unexpectedSign,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+			},
+		},
+		"should ignore requests the issuer getter does not handle": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuers:            &fakeIssuerGetter{},
+			signer:             unexpectedSign,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+			},
+		},
+		"should set the request pending if the issuer does not exist": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuers: &fakeIssuerGetter{
+				err: k8sErrors.NewNotFound(schema.GroupResource{Group: externalIssuerGroup, Resource: "exampleissuers"}, "test-issuer"),
+			},
+			signer: unexpectedSign,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal IssuerNotFound Referenced "ExampleIssuer" not found: exampleissuers.example.issuer.io "test-issuer" not found`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Pending",
+								Message:            `Referenced "ExampleIssuer" not found: exampleissuers.example.issuer.io "test-issuer" not found`,
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"should set the request pending with the reason of an IssuerPendingError": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuers: &fakeIssuerGetter{
+				err: &IssuerPendingError{Reason: "IssuerMisconfigured", Message: "Issuer is misconfigured", Err: errors.New("no URL")},
+			},
+			signer: unexpectedSign,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuerMisconfigured Issuer is misconfigured: no URL",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Pending",
+								Message:            "Issuer is misconfigured: no URL",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"should set the request pending if the issuer is not ready": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuers:            &fakeIssuerGetter{issuer: externalIssuer},
+			signer:             unexpectedSign,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal IssuerNotReady Referenced issuer does not have a Ready status condition",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Pending",
+								Message:            "Referenced issuer does not have a Ready status condition",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"should return an error if signing fails": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuers:            &fakeIssuerGetter{issuer: externalIssuer, ready: true},
+			signer: fakeSigner(func(context.Context, *cmapi.CertificateRequest, IssuerObject) (*issuer.IssueResponse, error) {
+				return nil, errors.New("sign error")
+			}),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+			},
+			expectedErr: true,
+		},
+		"should sign the request using the external issuer and set it ready": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuers:            &fakeIssuerGetter{issuer: externalIssuer, ready: true},
+			signer: fakeSigner(func(_ context.Context, _ *cmapi.CertificateRequest, iss IssuerObject) (*issuer.IssueResponse, error) {
+				if iss != IssuerObject(externalIssuer) {
+					return nil, errors.New("unexpected issuer passed to signer")
+				}
+				return &issuer.IssueResponse{Certificate: certPEM}, nil
+			}),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"should warn if the CA expires before the issued certificate": {
+			certificateRequest: baseCR.DeepCopy(),
+			issuers:            &fakeIssuerGetter{issuer: externalIssuer, ready: true},
+			signer: fakeSigner(func(context.Context, *cmapi.CertificateRequest, IssuerObject) (*issuer.IssueResponse, error) {
+				return &issuer.IssueResponse{Certificate: certPEM, CA: expiringCAPEM}, nil
+			}),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					fmt.Sprintf(`Warning CAExpiresBeforeCertificate The issuing CA certificate "CN=test" expires at %s, before the issued certificate expires at %s`,
+						fixedClockStart.Add(time.Hour).Format(time.RFC3339), fixedClockStart.Add(time.Hour*12).Format(time.RFC3339)),
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestCA(expiringCAPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.builder.T = t
+			test.builder.Clock = fixedClock
+			test.builder.Init()
+			defer test.builder.Stop()
+
+			r := &Reconciler{
+				IssuerGroup: externalIssuerGroup,
+				Issuers:     test.issuers,
+				Signer:      test.signer,
+				Reporter:    util.NewReporter(fixedClock, test.builder.Recorder),
+				Client:      test.builder.CMClient,
+			}
+
+			test.builder.Start()
+
+			err := r.Reconcile(context.Background(), test.certificateRequest)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+			test.builder.CheckAndFinish(err)
+		})
+	}
+}
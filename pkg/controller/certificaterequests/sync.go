@@ -18,153 +18,75 @@ package certificaterequests
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"reflect"
-
-	"github.com/kr/pretty"
-	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
-	internalapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
-	"github.com/jetstack/cert-manager/pkg/util/pki"
-	"github.com/jetstack/cert-manager/pkg/webhook"
 )
 
 var (
 	certificateRequestGvk = v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.CertificateRequestKind)
 )
 
-func (c *Controller) Sync(ctx context.Context, cr *v1alpha2.CertificateRequest) (err error) {
-	log := logf.FromContext(ctx)
-	dbg := log.V(logf.DebugLevel)
-
-	if !(cr.Spec.IssuerRef.Group == "" || cr.Spec.IssuerRef.Group == certmanager.GroupName) {
-		dbg.Info("certificate request issuerRef group does not match certmanager group so skipping processing")
-		return nil
-	}
-
-	switch apiutil.CertificateRequestReadyReason(cr) {
-	case v1alpha2.CertificateRequestReasonFailed:
-		dbg.Info("certificate request Ready condition failed so skipping processing")
-		return
-
-	case v1alpha2.CertificateRequestReasonIssued:
-		dbg.Info("certificate request Ready condition true so skipping processing")
-		return
+func (c *Controller) Sync(ctx context.Context, cr *v1alpha2.CertificateRequest) error {
+	r := &Reconciler{
+		IssuerGroup: certmanager.GroupName,
+		Issuers:     &genericIssuerGetter{controller: c},
+		Signer:      &genericIssuerSigner{issuer: c.issuer},
+		Reporter:    c.reporter,
+		Client:      c.cmClient,
 	}
 
-	crCopy := cr.DeepCopy()
-
-	defer func() {
-		if _, saveErr := c.updateCertificateRequestStatus(ctx, cr, crCopy); saveErr != nil {
-			err = utilerrors.NewAggregate([]error{saveErr, err})
-		}
-	}()
-
-	dbg.Info("fetching issuer object referenced by CertificateRequest")
+	return r.Reconcile(ctx, cr)
+}
 
-	issuerObj, err := c.helper.GetGenericIssuer(crCopy.Spec.IssuerRef, crCopy.Namespace)
-	if k8sErrors.IsNotFound(err) {
-		c.reporter.Pending(crCopy, err, "IssuerNotFound",
-			fmt.Sprintf("Referenced %q not found", apiutil.IssuerKind(crCopy.Spec.IssuerRef)))
-		return nil
-	}
+// genericIssuerGetter looks up the cert-manager Issuer or ClusterIssuer
+// referenced by a CertificateRequest, ignoring issuers that are not of the
+// type handled by the controller.
+type genericIssuerGetter struct {
+	controller *Controller
+}
 
+func (g *genericIssuerGetter) GetIssuer(ctx context.Context, cr *v1alpha2.CertificateRequest) (IssuerObject, error) {
+	issuerObj, err := g.controller.helper.GetGenericIssuer(cr.Spec.IssuerRef, cr.Namespace)
 	if err != nil {
-		log.Error(err, "failed to get issuer")
-		return err
+		return nil, err
 	}
 
-	log = logf.WithRelatedResource(log, issuerObj)
-	dbg.Info("ensuring issuer type matches this controller")
+	logf.FromContext(ctx).V(logf.DebugLevel).Info("ensuring issuer type matches this controller")
 
 	issuerType, err := apiutil.NameForIssuer(issuerObj)
 	if err != nil {
-		c.reporter.Pending(crCopy, err, "IssuerTypeMissing",
-			"Missing issuer type")
-		return nil
+		return nil, &IssuerPendingError{Reason: "IssuerTypeMissing", Message: "Missing issuer type", Err: err}
 	}
 
 	// This CertificateRequest is not meant for us, ignore
-	if issuerType != c.issuerType {
-		c.log.WithValues(
+	if issuerType != g.controller.issuerType {
+		g.controller.log.WithValues(
 			logf.RelatedResourceKindKey, issuerType,
 		).V(5).Info("issuer reference type does not match controller resource kind, ignoring")
-		return nil
+		return nil, nil
 	}
 
-	// check ready condition
-	if !apiutil.IssuerHasCondition(issuerObj, v1alpha2.IssuerCondition{
+	return issuerObj, nil
+}
+
+func (g *genericIssuerGetter) IssuerReady(iss IssuerObject) bool {
+	return apiutil.IssuerHasCondition(iss.(v1alpha2.GenericIssuer), v1alpha2.IssuerCondition{
 		Type:   v1alpha2.IssuerConditionReady,
 		Status: cmmeta.ConditionTrue,
-	}) {
-		c.reporter.Pending(crCopy, nil, "IssuerNotReady",
-			"Referenced issuer does not have a Ready status condition")
-		return nil
-	}
-
-	dbg.Info("validating CertificateRequest resource object")
-
-	el := webhook.ValidationRegistry.Validate(crCopy, internalapi.SchemeGroupVersion.WithKind("CertificateRequest"))
-	if len(el) > 0 {
-		c.reporter.Failed(crCopy, el.ToAggregate(), "BadConfig",
-			"Resource validation failed")
-		return nil
-	}
-
-	if len(crCopy.Status.Certificate) > 0 {
-		dbg.Info("certificate field is already set in status so skipping processing")
-		return nil
-	}
-
-	dbg.Info("invoking sign function as existing certificate does not exist")
-
-	// Attempt to call the Sign function on our issuer
-	resp, err := c.issuer.Sign(ctx, crCopy, issuerObj)
-	if err != nil {
-		log.Error(err, "error issuing certificate request")
-		return err
-	}
-
-	// If the issuer has not returned any data we may be pending or failed. The
-	// underlying issuer will have set the condition of pending or failed and we
-	// should potentially wait for a re-sync.
-	if resp == nil {
-		return nil
-	}
-
-	// Update to status with the new given response.
-	crCopy.Status.Certificate = resp.Certificate
-	crCopy.Status.CA = resp.CA
-
-	// invalid cert
-	_, err = pki.DecodeX509CertificateBytes(crCopy.Status.Certificate)
-	if err != nil {
-		c.reporter.Failed(crCopy, err, "DecodeError", "Failed to decode returned certificate")
-		return nil
-	}
-
-	// Set condition to Ready.
-	c.reporter.Ready(crCopy)
-
-	return nil
+	})
 }
 
-func (c *Controller) updateCertificateRequestStatus(ctx context.Context, old, new *v1alpha2.CertificateRequest) (*v1alpha2.CertificateRequest, error) {
-	log := logf.FromContext(ctx, "updateStatus")
-	oldBytes, _ := json.Marshal(old.Status)
-	newBytes, _ := json.Marshal(new.Status)
-	if reflect.DeepEqual(oldBytes, newBytes) {
-		return nil, nil
-	}
+// genericIssuerSigner signs CertificateRequests using an Issuer
+// implementation for cert-manager Issuers and ClusterIssuers.
+type genericIssuerSigner struct {
+	issuer Issuer
+}
 
-	log.V(logf.DebugLevel).Info("updating resource due to change in status", "diff", pretty.Diff(string(oldBytes), string(newBytes)))
-	return c.cmClient.CertmanagerV1alpha2().CertificateRequests(new.Namespace).UpdateStatus(context.TODO(), new, metav1.UpdateOptions{})
+func (s *genericIssuerSigner) Sign(ctx context.Context, cr *v1alpha2.CertificateRequest, iss IssuerObject) (*issuer.IssueResponse, error) {
+	return s.issuer.Sign(ctx, cr, iss.(v1alpha2.GenericIssuer))
 }
@@ -80,6 +80,12 @@ func (r *Reporter) Pending(cr *cmapi.CertificateRequest, err error, reason, mess
 		cmmeta.ConditionFalse, cmapi.CertificateRequestReasonPending, message)
 }
 
+// Warning records a Warning Event for the CertificateRequest without
+// changing its conditions.
+func (r *Reporter) Warning(cr *cmapi.CertificateRequest, reason, message string) {
+	r.recorder.Event(cr, corev1.EventTypeWarning, reason, message)
+}
+
 func (r *Reporter) Ready(cr *cmapi.CertificateRequest) {
 	r.recorder.Event(cr, corev1.EventTypeNormal, "CertificateIssued", readyMessage)
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
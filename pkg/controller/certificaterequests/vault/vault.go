@@ -20,6 +20,7 @@ import (
 	"context"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
@@ -29,6 +30,7 @@ import (
 	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
 	vaultinternal "github.com/jetstack/cert-manager/pkg/internal/vault"
 	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/vault/tokencache"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
 
@@ -38,7 +40,9 @@ const (
 
 type Vault struct {
 	issuerOptions controllerpkg.IssuerOptions
+	kubeClient    kubernetes.Interface
 	secretsLister corelisters.SecretLister
+	tokenCache    *tokencache.Cache
 	reporter      *crutil.Reporter
 
 	vaultClientBuilder vaultinternal.VaultClientBuilder
@@ -56,7 +60,9 @@ func init() {
 func NewVault(ctx *controllerpkg.Context) *Vault {
 	return &Vault{
 		issuerOptions:      ctx.IssuerOptions,
+		kubeClient:         ctx.Client,
 		secretsLister:      ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		tokenCache:         ctx.IssuerOptions.VaultTokenCache,
 		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		vaultClientBuilder: vaultinternal.New,
 	}
@@ -68,7 +74,8 @@ func (v *Vault) Sign(ctx context.Context, cr *v1alpha2.CertificateRequest, issue
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
-	client, err := v.vaultClientBuilder(resourceNamespace, v.secretsLister, issuerObj)
+	client, err := v.vaultClientBuilder(resourceNamespace, v.secretsLister,
+		v.kubeClient.CoreV1().ServiceAccounts(resourceNamespace).CreateToken, v.tokenCache, issuerObj)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 
@@ -23,10 +23,14 @@ import (
 
 	"github.com/Venafi/vcert/pkg/endpoint"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	clientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
@@ -43,6 +47,8 @@ const (
 type Venafi struct {
 	issuerOptions controllerpkg.IssuerOptions
 	secretsLister corelisters.SecretLister
+	secretsClient corev1client.SecretsGetter
+	cmClient      clientset.Interface
 	reporter      *crutil.Reporter
 
 	clientBuilder venafiinternal.VenafiClientBuilder
@@ -61,6 +67,8 @@ func NewVenafi(ctx *controllerpkg.Context) *Venafi {
 	return &Venafi{
 		issuerOptions: ctx.IssuerOptions,
 		secretsLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		secretsClient: ctx.Client.CoreV1(),
+		cmClient:      ctx.CMClient,
 		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		clientBuilder: venafiinternal.New,
 	}
@@ -70,7 +78,7 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 	log := logf.FromContext(ctx, "sign")
 	log = logf.WithRelatedResource(log, issuerObj)
 
-	client, err := v.clientBuilder(v.issuerOptions.ResourceNamespace(issuerObj), v.secretsLister, issuerObj)
+	client, err := v.clientBuilder(v.issuerOptions.ResourceNamespace(issuerObj), v.secretsLister, v.secretsClient, issuerObj)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 
@@ -104,18 +112,46 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 		}
 	}
 
-	certPem, err := client.Sign(cr.Spec.CSRPEM, duration, customFields)
+	// If a request has already been sent to Venafi for this
+	// CertificateRequest, poll it rather than sending a new one.
+	pickupID := cr.GetAnnotations()[cmapi.VenafiPickupIDAnnotationKey]
+	if pickupID == "" {
+		pickupID, err = client.RequestCertificate(cr.Spec.CSRPEM, duration, customFields)
+		if err != nil {
+			switch err.(type) {
+
+			case venafiinternal.ErrCustomFieldsType:
+				v.reporter.Failed(cr, err, "CustomFieldsError", err.Error())
+				log.Error(err, err.Error())
+
+				return nil, nil
+
+			default:
+				message := "Failed to request venafi certificate"
+
+				v.reporter.Failed(cr, err, "RequestError", message)
+				log.Error(err, message)
+
+				return nil, err
+			}
+		}
+
+		if err := v.storePickupID(ctx, cr, pickupID); err != nil {
+			message := "Failed to store venafi pickup ID"
+
+			v.reporter.Pending(cr, err, "PickupIDError", message)
+			log.Error(err, message)
+
+			return nil, err
+		}
+	}
+
+	certPem, err := client.RetrieveCertificate(pickupID)
 
 	// Check some known error types
 	if err != nil {
 		switch err.(type) {
 
-		case venafiinternal.ErrCustomFieldsType:
-			v.reporter.Failed(cr, err, "CustomFieldsError", err.Error())
-			log.Error(err, err.Error())
-
-			return nil, nil
-
 		case endpoint.ErrCertificatePending:
 			message := "Venafi certificate still in a pending state, the request will be retried"
 
@@ -126,9 +162,9 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 		case endpoint.ErrRetrieveCertificateTimeout:
 			message := "Timed out waiting for venafi certificate, the request will be retried"
 
-			v.reporter.Failed(cr, err, "Timeout", message)
+			v.reporter.Pending(cr, err, "Timeout", message)
 			log.Error(err, message)
-			return nil, nil
+			return nil, err
 
 		default:
 			message := "Failed to obtain venafi certificate"
@@ -146,3 +182,32 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 		Certificate: certPem,
 	}, nil
 }
+
+// storePickupID records the pickup ID of the request sent to Venafi on the
+// CertificateRequest, so that retries and restarts of the controller poll the
+// same request rather than sending a new one. A merge patch is used so that
+// the pickup ID is not lost if the CertificateRequest has been modified since
+// it was read.
+func (v *Venafi) storePickupID(ctx context.Context, cr *cmapi.CertificateRequest, pickupID string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				cmapi.VenafiPickupIDAnnotationKey: pickupID,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	updated, err := v.cmClient.CertmanagerV1alpha2().CertificateRequests(cr.Namespace).Patch(ctx, cr.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Keep the metadata of the CertificateRequest up to date so that its
+	// status can still be updated once the certificate has been retrieved.
+	cr.ObjectMeta = updated.ObjectMeta
+
+	return nil
+}
@@ -24,13 +24,17 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/Venafi/vcert/pkg/endpoint"
 	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
@@ -39,6 +43,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
 	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
 	controllertest "github.com/jetstack/cert-manager/pkg/controller/test"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
@@ -161,6 +166,12 @@ func TestSign(t *testing.T) {
 		}),
 	)
 
+	pickupIDAnnotations := map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test-cert-id"}
+	pickupIDPatch := []byte(`{"metadata":{"annotations":{"venafi.cert-manager.io/pickup-id":"test-cert-id"}}}`)
+	tppCRWithPickupID := gen.CertificateRequestFrom(tppCR, gen.AddCertificateRequestAnnotations(pickupIDAnnotations))
+	cloudCRWithPickupID := gen.CertificateRequestFrom(cloudCR, gen.AddCertificateRequestAnnotations(pickupIDAnnotations))
+	tppCRWithCustomFieldsAndPickupID := gen.CertificateRequestFrom(tppCRWithCustomFields, gen.AddCertificateRequestAnnotations(pickupIDAnnotations))
+
 	failGetSecretLister := &testlisters.FakeSecretLister{
 		SecretsFn: func(namespace string) corelisters.SecretNamespaceLister {
 			return &testlisters.FakeSecretNamespaceLister{
@@ -183,8 +194,13 @@ func TestSign(t *testing.T) {
 		t.FailNow()
 	}
 
+	requestReturnsPickupID := func([]byte, time.Duration, []internalvanafiapi.CustomField) (string, error) {
+		return "test-cert-id", nil
+	}
+
 	clientReturnsPending := &internalvenafifake.Venafi{
-		SignFn: func([]byte, time.Duration, []internalvanafiapi.CustomField) ([]byte, error) {
+		RequestCertificateFn: requestReturnsPickupID,
+		RetrieveCertificateFn: func(string) ([]byte, error) {
 			return nil, endpoint.ErrCertificatePending{
 				CertificateID: "test-cert-id",
 				Status:        "test-status-pending",
@@ -192,35 +208,58 @@ func TestSign(t *testing.T) {
 		},
 	}
 	clientReturnsTimeout := &internalvenafifake.Venafi{
-		SignFn: func([]byte, time.Duration, []internalvanafiapi.CustomField) ([]byte, error) {
+		RequestCertificateFn: requestReturnsPickupID,
+		RetrieveCertificateFn: func(string) ([]byte, error) {
 			return nil, endpoint.ErrRetrieveCertificateTimeout{
 				CertificateID: "test-cert-id",
 			}
 		},
 	}
 	clientReturnsGenericError := &internalvenafifake.Venafi{
-		SignFn: func([]byte, time.Duration, []internalvanafiapi.CustomField) ([]byte, error) {
+		RequestCertificateFn: requestReturnsPickupID,
+		RetrieveCertificateFn: func(string) ([]byte, error) {
 			return nil, errors.New("this is an error")
 		},
 	}
+	clientReturnsRequestError := &internalvenafifake.Venafi{
+		RequestCertificateFn: func([]byte, time.Duration, []internalvanafiapi.CustomField) (string, error) {
+			return "", errors.New("this is an error")
+		},
+	}
 	clientReturnsCert := &internalvenafifake.Venafi{
-		SignFn: func([]byte, time.Duration, []internalvanafiapi.CustomField) ([]byte, error) {
+		RequestCertificateFn: requestReturnsPickupID,
+		RetrieveCertificateFn: func(string) ([]byte, error) {
+			return certPEM, nil
+		},
+	}
+
+	clientReturnsCertIfPickupID := &internalvenafifake.Venafi{
+		RequestCertificateFn: func([]byte, time.Duration, []internalvanafiapi.CustomField) (string, error) {
+			return "", errors.New("a new request should not be sent")
+		},
+		RetrieveCertificateFn: func(pickupID string) ([]byte, error) {
+			if pickupID != "test-cert-id" {
+				return nil, errors.New("unexpected pickup ID")
+			}
 			return certPEM, nil
 		},
 	}
 
 	clientReturnsCertIfCustomField := &internalvenafifake.Venafi{
-		SignFn: func(csr []byte, t time.Duration, fields []internalvanafiapi.CustomField) ([]byte, error) {
+		RequestCertificateFn: func(csr []byte, t time.Duration, fields []internalvanafiapi.CustomField) (string, error) {
 			if len(fields) > 0 && fields[0].Name == "cert-manager-test" && fields[0].Value == "test ok" {
-				return certPEM, nil
+				return "test-cert-id", nil
 			}
-			return nil, errors.New("Custom field not set")
+			return "", errors.New("Custom field not set")
+		},
+		RetrieveCertificateFn: func(string) ([]byte, error) {
+			return certPEM, nil
 		},
 	}
 
 	clientReturnsInvalidCustomFieldType := &internalvenafifake.Venafi{
-		SignFn: func(csr []byte, t time.Duration, fields []internalvanafiapi.CustomField) ([]byte, error) {
-			return nil, internalvenafi.ErrCustomFieldsType{Type: fields[0].Type}
+		RequestCertificateFn: func(csr []byte, t time.Duration, fields []internalvanafiapi.CustomField) (string, error) {
+			return "", internalvenafi.ErrCustomFieldsType{Type: fields[0].Type}
 		},
 	}
 
@@ -370,11 +409,18 @@ func TestSign(t *testing.T) {
 					"Normal IssuancePending Venafi certificate still in a pending state, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 				},
 				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewPatchAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						tppCRWithPickupID.Name,
+						types.MergePatchType,
+						pickupIDPatch,
+					)),
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(tppCR,
+						gen.CertificateRequestFrom(tppCRWithPickupID,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -399,11 +445,18 @@ func TestSign(t *testing.T) {
 					"Normal IssuancePending Venafi certificate still in a pending state, the request will be retried: Issuance is pending. You may try retrieving the certificate later using Pickup ID: test-cert-id\n\tStatus: test-status-pending",
 				},
 				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewPatchAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						cloudCRWithPickupID.Name,
+						types.MergePatchType,
+						pickupIDPatch,
+					)),
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(cloudCR,
+						gen.CertificateRequestFrom(cloudCRWithPickupID,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -419,63 +472,77 @@ func TestSign(t *testing.T) {
 			fakeClient:       clientReturnsPending,
 			expectedErr:      true,
 		},
-		"tpp: if sign returns timeout error then set failed and return nil": {
+		"tpp: if sign returns timeout error then set pending and return err": {
 			certificateRequest: tppCR.DeepCopy(),
 			builder: &controllertest.Builder{
 				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
 				KubeObjects:        []runtime.Object{tppSecret},
 				ExpectedEvents: []string{
-					"Warning Timeout Timed out waiting for venafi certificate, the request will be retried: Operation timed out. You may try retrieving the certificate later using Pickup ID: test-cert-id",
+					"Normal Timeout Timed out waiting for venafi certificate, the request will be retried: Operation timed out. You may try retrieving the certificate later using Pickup ID: test-cert-id",
 				},
 				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewPatchAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						tppCRWithPickupID.Name,
+						types.MergePatchType,
+						pickupIDPatch,
+					)),
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(tppCR,
+						gen.CertificateRequestFrom(tppCRWithPickupID,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
-								Reason:             cmapi.CertificateRequestReasonFailed,
+								Reason:             cmapi.CertificateRequestReasonPending,
 								Message:            "Timed out waiting for venafi certificate, the request will be retried: Operation timed out. You may try retrieving the certificate later using Pickup ID: test-cert-id",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
 					)),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
 			fakeClient:       clientReturnsTimeout,
+			expectedErr:      true,
 		},
-		"cloud: if sign returns timeout error then set failed and return nil": {
+		"cloud: if sign returns timeout error then set pending and return err": {
 			certificateRequest: cloudCR.DeepCopy(),
 			builder: &controllertest.Builder{
 				KubeObjects:        []runtime.Object{cloudSecret},
 				CertManagerObjects: []runtime.Object{cloudCR.DeepCopy(), cloudIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Warning Timeout Timed out waiting for venafi certificate, the request will be retried: Operation timed out. You may try retrieving the certificate later using Pickup ID: test-cert-id",
+					"Normal Timeout Timed out waiting for venafi certificate, the request will be retried: Operation timed out. You may try retrieving the certificate later using Pickup ID: test-cert-id",
 				},
 				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewPatchAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						cloudCRWithPickupID.Name,
+						types.MergePatchType,
+						pickupIDPatch,
+					)),
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(cloudCR,
+						gen.CertificateRequestFrom(cloudCRWithPickupID,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
-								Reason:             cmapi.CertificateRequestReasonFailed,
+								Reason:             cmapi.CertificateRequestReasonPending,
 								Message:            "Timed out waiting for venafi certificate, the request will be retried: Operation timed out. You may try retrieving the certificate later using Pickup ID: test-cert-id",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
-							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
 						),
 					)),
 				},
 			},
 			fakeSecretLister: failGetSecretLister,
 			fakeClient:       clientReturnsTimeout,
+			expectedErr:      true,
 		},
 		"tpp: if sign returns generic error then set pending and return error": {
 			certificateRequest: tppCR.DeepCopy(),
@@ -486,11 +553,18 @@ func TestSign(t *testing.T) {
 					"Warning RetrieveError Failed to obtain venafi certificate: this is an error",
 				},
 				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewPatchAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						tppCRWithPickupID.Name,
+						types.MergePatchType,
+						pickupIDPatch,
+					)),
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(tppCR,
+						gen.CertificateRequestFrom(tppCRWithPickupID,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -516,11 +590,18 @@ func TestSign(t *testing.T) {
 					"Warning RetrieveError Failed to obtain venafi certificate: this is an error",
 				},
 				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewPatchAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						cloudCRWithPickupID.Name,
+						types.MergePatchType,
+						pickupIDPatch,
+					)),
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(cloudCR,
+						gen.CertificateRequestFrom(cloudCRWithPickupID,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
@@ -546,11 +627,18 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewPatchAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						tppCRWithPickupID.Name,
+						types.MergePatchType,
+						pickupIDPatch,
+					)),
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(tppCR,
+						gen.CertificateRequestFrom(tppCRWithPickupID,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionTrue,
@@ -566,6 +654,65 @@ func TestSign(t *testing.T) {
 			fakeSecretLister: failGetSecretLister,
 			fakeClient:       clientReturnsCert,
 		},
+		"tpp: if requesting the certificate fails then set failed and return error": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning RequestError Failed to request venafi certificate: this is an error",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Failed to request venafi certificate: this is an error",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsRequestError,
+			expectedErr:      true,
+		},
+		"tpp: if pickup ID is already set then retrieve the certificate without sending a new request": {
+			certificateRequest: tppCRWithPickupID.DeepCopy(),
+			builder: &controllertest.Builder{
+				KubeObjects:        []runtime.Object{tppSecret},
+				CertManagerObjects: []runtime.Object{tppCRWithPickupID.DeepCopy(), tppIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCRWithPickupID,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certPEM),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsCertIfPickupID,
+		},
 		"cloud: if sign returns cert then return cert and not failed": {
 			certificateRequest: cloudCR.DeepCopy(),
 			builder: &controllertest.Builder{
@@ -575,11 +722,18 @@ func TestSign(t *testing.T) {
 					"Normal CertificateIssued Certificate fetched from issuer successfully",
 				},
 				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewPatchAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						cloudCRWithPickupID.Name,
+						types.MergePatchType,
+						pickupIDPatch,
+					)),
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(cloudCR,
+						gen.CertificateRequestFrom(cloudCRWithPickupID,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionTrue,
@@ -604,11 +758,18 @@ func TestSign(t *testing.T) {
 					`Normal CertificateIssued Certificate fetched from issuer successfully`,
 				},
 				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewPatchAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						tppCRWithCustomFieldsAndPickupID.Name,
+						types.MergePatchType,
+						pickupIDPatch,
+					)),
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
 						"status",
 						gen.DefaultTestNamespace,
-						gen.CertificateRequestFrom(tppCRWithCustomFields,
+						gen.CertificateRequestFrom(tppCRWithCustomFieldsAndPickupID,
 							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionTrue,
@@ -696,6 +857,70 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestStorePickupID(t *testing.T) {
+	staleCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+	)
+	staleCR.ResourceVersion = "1"
+	// the CertificateRequest has been modified since staleCR was read
+	currentCR := gen.CertificateRequestFrom(staleCR,
+		gen.AddCertificateRequestAnnotations(map[string]string{"example.com/other": "value"}),
+	)
+	currentCR.ResourceVersion = "2"
+
+	tests := map[string]struct {
+		reactor             coretesting.ReactionFunc
+		expectedErr         bool
+		expectedAnnotations map[string]string
+	}{
+		"should add the pickup ID without overwriting changes made since the CertificateRequest was read": {
+			expectedAnnotations: map[string]string{
+				"example.com/other":               "value",
+				cmapi.VenafiPickupIDAnnotationKey: "test-cert-id",
+			},
+		},
+		"should return an error so that storing the pickup ID is retried if the patch fails": {
+			reactor: func(coretesting.Action) (bool, runtime.Object, error) {
+				return true, nil, k8sErrors.NewConflict(cmapi.Resource("certificaterequests"), staleCR.Name, errors.New("conflict"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmClient := cmfake.NewSimpleClientset(currentCR.DeepCopy())
+			if test.reactor != nil {
+				cmClient.PrependReactor("patch", "certificaterequests", test.reactor)
+			}
+			v := &Venafi{cmClient: cmClient}
+
+			cr := staleCR.DeepCopy()
+			err := v.storePickupID(context.Background(), cr, "test-cert-id")
+			if err != nil != test.expectedErr {
+				t.Fatalf("expected error=%t, got: %v", test.expectedErr, err)
+			}
+			if test.expectedErr {
+				if _, ok := cr.Annotations[cmapi.VenafiPickupIDAnnotationKey]; ok {
+					t.Errorf("expected pickup ID to not be set on the CertificateRequest after an error")
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(cr.Annotations, test.expectedAnnotations) {
+				t.Errorf("unexpected annotations, exp=%v got=%v", test.expectedAnnotations, cr.Annotations)
+			}
+			stored, err := cmClient.CertmanagerV1alpha2().CertificateRequests(cr.Namespace).Get(context.Background(), cr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(stored.Annotations, test.expectedAnnotations) {
+				t.Errorf("unexpected stored annotations, exp=%v got=%v", test.expectedAnnotations, stored.Annotations)
+			}
+		})
+	}
+}
+
 type testT struct {
 	builder            *controllertest.Builder
 	certificateRequest *cmapi.CertificateRequest
@@ -721,7 +946,7 @@ func runTest(t *testing.T, test testT) {
 
 	if test.fakeClient != nil {
 		v.clientBuilder = func(namespace string, secretsLister corelisters.SecretLister,
-			issuer cmapi.GenericIssuer) (internalvenafi.Interface, error) {
+			secretsClient corev1client.SecretsGetter, issuer cmapi.GenericIssuer) (internalvenafi.Interface, error) {
 			return test.fakeClient, nil
 		}
 	}
@@ -32,6 +32,7 @@ import (
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/scheduler"
 )
@@ -77,6 +78,13 @@ type certificateRequestManager struct {
 	// localTemporarySigner signs a certificate that is stored temporarily
 	localTemporarySigner localTemporarySignerFn
 
+	// validateCertificateForIssuer validates a Certificate against the policy
+	// of its issuer, so that violations can be reported before a
+	// CertificateRequest is created.
+	// This is a field on the controller struct to make it easier to fake out
+	// this call during tests.
+	validateCertificateForIssuer issuer.ValidateCertificateForIssuerFunc
+
 	// if true, Secret resources created by the controller will have an
 	// 'owner reference' set, meaning when the Certificate is deleted, the
 	// Secret resource will be automatically deleted.
@@ -100,6 +108,7 @@ func (c *certificateRequestManager) Register(ctx *controllerpkg.Context) (workqu
 	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Certificates()
 	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().CertificateRequests()
 	secretsInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Issuers()
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
@@ -107,6 +116,16 @@ func (c *certificateRequestManager) Register(ctx *controllerpkg.Context) (workqu
 		certificateRequestInformer.Informer().HasSynced,
 		secretsInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+	}
+
+	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
+	// obtain a lister for clusterissuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
 	}
 
 	// set all the references to the listers for used by the Sync function
@@ -139,6 +158,12 @@ func (c *certificateRequestManager) Register(ctx *controllerpkg.Context) (workqu
 	c.localTemporarySigner = generateLocallySignedTemporaryCertificate
 	c.enableSecretOwnerReferences = ctx.CertificateOptions.EnableOwnerRef
 
+	// the issuer policy is checked before CertificateRequests are created
+	c.validateCertificateForIssuer = issuer.NewCertificateValidator(
+		issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		issuer.NewFactory(ctx),
+	)
+
 	c.cmClient = ctx.CMClient
 	c.kubeClient = ctx.Client
 
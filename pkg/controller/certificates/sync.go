@@ -39,6 +39,7 @@ import (
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util/errors"
 	"github.com/jetstack/cert-manager/pkg/util/kube"
@@ -111,6 +112,7 @@ func (c *certificateRequestManager) updateCertificateStatus(ctx context.Context,
 	}
 
 	isTempCert := isTemporaryCertificate(cert)
+	policyErrs := issuer.PolicyViolations(ctx, c.validateCertificateForIssuer, crt)
 
 	// begin setting certificate status fields
 	if !matches || isTempCert {
@@ -125,13 +127,16 @@ func (c *certificateRequestManager) updateCertificateStatus(ctx context.Context,
 	reason := ""
 	message := ""
 	switch {
-	case !secretExists || key == nil:
-		reason = "NotFound"
-		message = "Certificate does not exist"
 	case matches && !isTempCert && !certExpired:
 		ready = cmmeta.ConditionTrue
 		reason = "Ready"
 		message = "Certificate is up to date and has not expired"
+	case len(policyErrs) > 0:
+		reason = "PolicyViolation"
+		message = fmt.Sprintf("Certificate does not comply with the policy of its issuer: %s", policyErrs.ToAggregate())
+	case !secretExists || key == nil:
+		reason = "NotFound"
+		message = "Certificate does not exist"
 	case apiutil.CertificateRequestHasInvalidRequest(req):
 		reason = "InvalidRequest"
 		message = fmt.Sprintf("The certificate request could not be completed due to invalid request options: %s",
@@ -360,6 +365,22 @@ func (c *certificateRequestManager) processCertificate(ctx context.Context, crt
 	}
 
 	if existingReq == nil {
+		// Check that the Certificate complies with the policy of its issuer
+		// before creating a CertificateRequest that would be rejected.
+		if policyErrs := issuer.PolicyViolations(ctx, c.validateCertificateForIssuer, crt); len(policyErrs) > 0 {
+			log.Info("certificate does not comply with the policy of its issuer, not creating CertificateRequest", "errors", policyErrs.ToAggregate().Error())
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, "PolicyViolation", "Certificate does not comply with the policy of its issuer: %s", policyErrs.ToAggregate())
+
+			// The policy of the issuer may change, so schedule a re-check
+			key, err := keyFunc(crt)
+			if err != nil {
+				log.Error(err, "error getting key for certificate resource")
+				return nil
+			}
+			c.scheduledWorkQueue.Add(key, issuer.PolicyRecheckInterval)
+			return nil
+		}
+
 		// If no existing CertificateRequest resource exists, we must create one
 		log.Info("no existing CertificateRequest resource exists, creating new request...")
 		req, err := c.buildCertificateRequest(log, crt, expectedReqName, existingKey)
@@ -886,6 +907,7 @@ func (c *certificateRequestManager) setSecretValues(ctx context.Context, crt *cm
 	s.Annotations[cmapi.CertificateNameKey] = crt.Name
 	s.Annotations[cmapi.IssuerNameAnnotationKey] = crt.Spec.IssuerRef.Name
 	s.Annotations[cmapi.IssuerKindAnnotationKey] = apiutil.IssuerKind(crt.Spec.IssuerRef)
+	s.Annotations[cmapi.IssuerGroupAnnotationKey] = crt.Spec.IssuerRef.Group
 
 	// If deprecated annotations exist with any value, then they too shall be
 	// updated
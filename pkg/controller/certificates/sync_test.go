@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
@@ -36,6 +37,7 @@ import (
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	"github.com/jetstack/cert-manager/pkg/controller"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 	"github.com/jetstack/cert-manager/test/unit/gen"
 )
@@ -416,6 +418,8 @@ func TestProcessCertificate(t *testing.T) {
 									cmapi.CertificateNameKey:      "test",
 									cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 									cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -461,6 +465,8 @@ func TestProcessCertificate(t *testing.T) {
 									cmapi.CertificateNameKey:      "test",
 									cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 									cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -509,6 +515,8 @@ func TestProcessCertificate(t *testing.T) {
 									cmapi.CertificateNameKey:      "test",
 									cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 									cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -557,6 +565,8 @@ func TestProcessCertificate(t *testing.T) {
 									cmapi.CertificateNameKey:      "test",
 									cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 									cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -584,6 +594,8 @@ func TestProcessCertificate(t *testing.T) {
 								cmapi.CertificateNameKey:      "test",
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -607,6 +619,40 @@ func TestProcessCertificate(t *testing.T) {
 				ExpectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-850937773"`},
 			},
 		},
+		"do not create a certificaterequest if the certificate does not comply with the policy of its issuer": {
+			certificate: exampleBundle1.certificate,
+			generateCSR: testGenerateCSRFn(exampleBundle1.csrBytes),
+			validateCertificateForIssuer: testPolicyViolationFn(
+				field.Invalid(field.NewPath("spec", "dnsNames").Index(0), "example.com", "not allowed"),
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: gen.DefaultTestNamespace,
+							Name:      "output",
+							Annotations: map[string]string{
+								cmapi.CertificateNameKey:      "test",
+								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
+							},
+						},
+						Data: map[string][]byte{
+							corev1.TLSCertKey:       nil,
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+							cmmeta.TLSCAKey:         nil,
+						},
+						Type: corev1.SecretTypeTLS,
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleBundle1.certificate,
+				},
+				ExpectedEvents: []string{`Warning PolicyViolation Certificate does not comply with the policy of its issuer: spec.dnsNames[0]: Invalid value: "example.com": not allowed`},
+			},
+		},
 		"delete an existing certificaterequest that does not have matching dnsnames": {
 			certificate: exampleBundle1.certificate,
 			generateCSR: testGenerateCSRFn(exampleBundle1.csrBytes),
@@ -621,6 +667,8 @@ func TestProcessCertificate(t *testing.T) {
 								cmapi.CertificateNameKey:      "test",
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -704,6 +752,8 @@ func TestProcessCertificate(t *testing.T) {
 								cmapi.AltNamesAnnotationKey:   "example.com",
 								cmapi.CommonNameAnnotationKey: "",
 								cmapi.URISANAnnotationKey:     "",
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -744,6 +794,8 @@ func TestProcessCertificate(t *testing.T) {
 								cmapi.AltNamesAnnotationKey:   "example.com",
 								cmapi.CommonNameAnnotationKey: "",
 								cmapi.URISANAnnotationKey:     "",
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -770,6 +822,8 @@ func TestProcessCertificate(t *testing.T) {
 								"custom-annotation":           "value",
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -800,6 +854,8 @@ func TestProcessCertificate(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.CommonNameAnnotationKey: "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -826,6 +882,8 @@ func TestProcessCertificate(t *testing.T) {
 								cmapi.CertificateNameKey:      "test",
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -857,6 +915,8 @@ func TestProcessCertificate(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.CommonNameAnnotationKey: "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -887,6 +947,8 @@ func TestProcessCertificate(t *testing.T) {
 								cmapi.AltNamesAnnotationKey:   "example.com",
 								cmapi.CommonNameAnnotationKey: "",
 								cmapi.URISANAnnotationKey:     "",
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -919,6 +981,8 @@ func TestProcessCertificate(t *testing.T) {
 								cmapi.AltNamesAnnotationKey:   "example.com",
 								cmapi.CommonNameAnnotationKey: "",
 								cmapi.URISANAnnotationKey:     "",
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -950,6 +1014,8 @@ func TestProcessCertificate(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.CommonNameAnnotationKey: "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -980,6 +1046,8 @@ func TestProcessCertificate(t *testing.T) {
 								cmapi.AltNamesAnnotationKey:   "example.com",
 								cmapi.CommonNameAnnotationKey: "",
 								cmapi.URISANAnnotationKey:     "",
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1024,6 +1092,8 @@ func TestProcessCertificate(t *testing.T) {
 								cmapi.AltNamesAnnotationKey:   "example.com",
 								cmapi.CommonNameAnnotationKey: "",
 								cmapi.URISANAnnotationKey:     "",
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1061,6 +1131,8 @@ func TestProcessCertificate(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1094,6 +1166,8 @@ func TestProcessCertificate(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1121,6 +1195,8 @@ func TestProcessCertificate(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1157,6 +1233,8 @@ func TestProcessCertificate(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1187,6 +1265,8 @@ func TestProcessCertificate(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1218,6 +1298,8 @@ func TestProcessCertificate(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1261,6 +1343,8 @@ func TestProcessCertificate(t *testing.T) {
 									cmapi.CertificateNameKey:      "test",
 									cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 									cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 								OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(exampleBundle1.certificate, certificateGvk)},
 							},
@@ -1310,6 +1394,8 @@ func TestProcessCertificate(t *testing.T) {
 									cmapi.CertificateNameKey:      "test",
 									cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 									cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -1366,6 +1452,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 								cmapi.CertificateNameKey:      "test",
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1396,6 +1484,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.CommonNameAnnotationKey: "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -1422,6 +1512,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 								cmapi.CertificateNameKey:      "test",
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1453,6 +1545,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.CommonNameAnnotationKey: "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -1479,6 +1573,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 								cmapi.CertificateNameKey:      "test",
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1510,6 +1606,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.CommonNameAnnotationKey: "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -1540,6 +1638,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 								cmapi.AltNamesAnnotationKey:   "example.com",
 								cmapi.CommonNameAnnotationKey: "",
 								cmapi.URISANAnnotationKey:     "",
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1570,6 +1670,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.CommonNameAnnotationKey: "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -1600,6 +1702,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 								cmapi.AltNamesAnnotationKey:   "example.com",
 								cmapi.CommonNameAnnotationKey: "",
 								cmapi.URISANAnnotationKey:     "",
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1631,6 +1735,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.CommonNameAnnotationKey: "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -1662,6 +1768,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 								cmapi.AltNamesAnnotationKey:   "notexample.com",
 								cmapi.CommonNameAnnotationKey: "",
 								cmapi.URISANAnnotationKey:     "",
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1700,6 +1808,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 								"custom-annotation":           "value",
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1730,6 +1840,8 @@ func TestTemporaryCertificateEnabled(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.CommonNameAnnotationKey: "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -1860,6 +1972,44 @@ func TestUpdateStatus(t *testing.T) {
 				},
 			},
 		},
+		"mark certificate PolicyViolation if it does not comply with the policy of its issuer": {
+			certificate: exampleBundle1.certificate,
+			validateCertificateForIssuer: testPolicyViolationFn(
+				field.Invalid(field.NewPath("spec", "dnsNames").Index(0), "example.com", "not allowed"),
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      exampleBundle1.certificate.Spec.SecretName,
+							Namespace: exampleBundle1.certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle1.privateKeyBytes,
+						},
+					},
+				},
+				CertManagerObjects: []runtime.Object{
+					exampleBundle1.certificate,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateFrom(exampleBundle1.certificate,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "PolicyViolation",
+								Message:            `Certificate does not comply with the policy of its issuer: spec.dnsNames[0]: Invalid value: "example.com": not allowed`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
 		"mark certificate as in progress if existing Secret contains only private key and request exists & is up to date": {
 			certificate: exampleBundle1.certificate,
 			builder: &testpkg.Builder{
@@ -1907,6 +2057,8 @@ func TestUpdateStatus(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1948,6 +2100,8 @@ func TestUpdateStatus(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -1990,6 +2144,8 @@ func TestUpdateStatus(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -2031,6 +2187,8 @@ func TestUpdateStatus(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -2073,6 +2231,8 @@ func TestUpdateStatus(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -2115,6 +2275,8 @@ func TestUpdateStatus(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -2159,6 +2321,8 @@ func TestUpdateStatus(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -2204,6 +2368,8 @@ func TestUpdateStatus(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -2244,6 +2410,8 @@ func TestUpdateStatus(t *testing.T) {
 							Annotations: map[string]string{
 								cmapi.IssuerNameAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Name,
 								cmapi.IssuerKindAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Kind,
+
+								cmapi.IssuerGroupAnnotationKey: exampleBundle1.certificate.Spec.IssuerRef.Group,
 							},
 						},
 						Data: map[string][]byte{
@@ -2286,6 +2454,7 @@ func TestUpdateStatus(t *testing.T) {
 			testManager := &certificateRequestManager{}
 			testManager.Register(test.builder.Context)
 			testManager.clock = fixedClock
+			testManager.validateCertificateForIssuer = test.validateCertificateForIssuer
 			test.builder.Start()
 
 			err := testManager.updateCertificateStatus(context.Background(), test.certificate, test.certificate.DeepCopy())
@@ -2300,13 +2469,22 @@ func TestUpdateStatus(t *testing.T) {
 	}
 }
 
+func testPolicyViolationFn(errs ...*field.Error) issuer.ValidateCertificateForIssuerFunc {
+	return func(context.Context, *cmapi.Certificate) (field.ErrorList, error) {
+		return errs, nil
+	}
+}
+
 type testT struct {
 	builder                 *testpkg.Builder
 	generatePrivateKeyBytes generatePrivateKeyBytesFn
 	generateCSR             generateCSRFn
 	localTemporarySigner    localTemporarySignerFn
-	certificate             *cmapi.Certificate
-	expectedErr             bool
+	// validateCertificateForIssuer stubs out the issuer policy check.
+	// If nil, Certificates are not checked against any issuer policy.
+	validateCertificateForIssuer issuer.ValidateCertificateForIssuerFunc
+	certificate                  *cmapi.Certificate
+	expectedErr                  bool
 }
 
 func runTest(t *testing.T, test testT) {
@@ -2319,6 +2497,7 @@ func runTest(t *testing.T, test testT) {
 	testManager.generatePrivateKeyBytes = test.generatePrivateKeyBytes
 	testManager.generateCSR = test.generateCSR
 	testManager.localTemporarySigner = test.localTemporarySigner
+	testManager.validateCertificateForIssuer = test.validateCertificateForIssuer
 	test.builder.Start()
 
 	err := testManager.processCertificate(context.Background(), test.certificate)
@@ -147,6 +147,12 @@ func certificateMatchesSpec(crt *v1alpha2.Certificate, key crypto.Signer, cert *
 		annotationError(v1alpha2.IssuerKindAnnotationKey, secret.Annotations[v1alpha2.IssuerKindAnnotationKey])
 	}
 
+	// Secrets issued before the group annotation was added do not have it, so
+	// only compare the group if the annotation exists.
+	if group, ok := secret.Annotations[v1alpha2.IssuerGroupAnnotationKey]; ok && !apiutil.IssuerGroupsEqual(group, crt.Spec.IssuerRef.Group) {
+		annotationError(v1alpha2.IssuerGroupAnnotationKey, group)
+	}
+
 	return len(errs) == 0, errs
 }
 
@@ -265,6 +265,49 @@ func TestCertificateMatchesSpec(t *testing.T) {
 				`Issuer "certmanager.k8s.io/issuer-kind" of the certificate is not up to date: "bar"`,
 			},
 		},
+
+		"if the issuer group annotation matches the spec then it should match": {
+			cb:          mustCreateCryptoBundle(t, gen.CertificateFrom(exampleBundle.certificate)),
+			certificate: gen.CertificateFrom(exampleBundle.certificate),
+			secret: gen.SecretFrom(secret,
+				gen.SetSecretAnnotations(map[string]string{
+					cmapi.IssuerNameAnnotationKey:  "ca-issuer",
+					cmapi.IssuerKindAnnotationKey:  "Issuer",
+					cmapi.IssuerGroupAnnotationKey: "not-empty",
+				})),
+			expMatch:  true,
+			expErrors: nil,
+		},
+
+		"if the issuer group annotation is empty and the spec group is cert-manager.io then it should match": {
+			cb: mustCreateCryptoBundle(t, gen.CertificateFrom(exampleBundle.certificate)),
+			certificate: gen.CertificateFrom(exampleBundle.certificate,
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "cert-manager.io"}),
+			),
+			secret: gen.SecretFrom(secret,
+				gen.SetSecretAnnotations(map[string]string{
+					cmapi.IssuerNameAnnotationKey:  "ca-issuer",
+					cmapi.IssuerKindAnnotationKey:  "Issuer",
+					cmapi.IssuerGroupAnnotationKey: "",
+				})),
+			expMatch:  true,
+			expErrors: nil,
+		},
+
+		"if the issuer group annotation does not match the spec then it should not match": {
+			cb:          mustCreateCryptoBundle(t, gen.CertificateFrom(exampleBundle.certificate)),
+			certificate: gen.CertificateFrom(exampleBundle.certificate),
+			secret: gen.SecretFrom(secret,
+				gen.SetSecretAnnotations(map[string]string{
+					cmapi.IssuerNameAnnotationKey:  "ca-issuer",
+					cmapi.IssuerKindAnnotationKey:  "Issuer",
+					cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+				})),
+			expMatch: false,
+			expErrors: []string{
+				`Issuer "cert-manager.io/issuer-group" of the certificate is not up to date: "cert-manager.io"`,
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			match, errs := certificateMatchesSpec(
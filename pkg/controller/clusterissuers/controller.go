@@ -27,11 +27,14 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/vault/tokencache"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/metrics"
 )
 
 type controller struct {
@@ -58,6 +61,13 @@ type controller struct {
 	// clusterResourceNamespace is the namespace used to store resources
 	// referenced by ClusterIssuer resources, e.g. acme account secrets
 	clusterResourceNamespace string
+
+	// used to expose the status of issuers as metrics
+	metrics *metrics.Metrics
+
+	// vaultTokenCache holds the Vault tokens of issuers, which are removed
+	// once an issuer has been deleted
+	vaultTokenCache *tokencache.Cache
 }
 
 // Register registers and constructs the controller using the provided context.
@@ -93,6 +103,8 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.cmClient = ctx.CMClient
 	c.recorder = ctx.Recorder
 	c.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
+	c.metrics = ctx.Metrics
+	c.vaultTokenCache = ctx.IssuerOptions.VaultTokenCache
 
 	return c.queue, mustSync, nil
 }
@@ -129,7 +141,7 @@ func (c *controller) secretDeleted(obj interface{}) {
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	log := logf.FromContext(ctx)
 
-	_, name, err := cache.SplitMetaNamespaceKey(key)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		log.Error(nil, "invalid resource key")
 		return nil
@@ -139,6 +151,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Error(err, "clusterissuer in work queue no longer exists")
+			c.metrics.RemoveIssuer(v1alpha2.ClusterIssuerKind, namespace, name)
+			if c.vaultTokenCache != nil {
+				c.vaultTokenCache.Remove(namespace, name)
+			}
 			return nil
 		}
 
@@ -146,7 +162,14 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	ctx = logf.NewContext(ctx, logf.WithResource(log, issuer))
-	return c.Sync(ctx, issuer)
+	if err := c.Sync(ctx, issuer); err != nil {
+		return err
+	}
+
+	// Set up the issuer again later so that its Ready condition reflects
+	// the current state of its backend and CA certificate
+	c.queue.AddAfter(key, issuerRecheckInterval)
+	return nil
 }
 
 var keyFunc = controllerpkg.KeyFunc
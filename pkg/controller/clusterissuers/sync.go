@@ -41,6 +41,10 @@ const (
 	messageErrorInitIssuer = "Error initializing issuer: "
 )
 
+// issuerRecheckInterval is how often issuers are set up again to check that
+// their backend is still available and their CA certificate is still valid.
+const issuerRecheckInterval = time.Minute * 10
+
 func (c *controller) Sync(ctx context.Context, iss *v1alpha2.ClusterIssuer) (err error) {
 	log := logf.FromContext(ctx)
 
@@ -49,6 +53,7 @@ func (c *controller) Sync(ctx context.Context, iss *v1alpha2.ClusterIssuer) (err
 		if _, saveErr := c.updateIssuerStatus(iss, issuerCopy); saveErr != nil {
 			err = errors.NewAggregate([]error{saveErr, err})
 		}
+		c.metrics.UpdateIssuer(v1alpha2.ClusterIssuerKind, issuerCopy)
 	}()
 
 	el := webhook.ValidationRegistry.Validate(issuerCopy, internalapi.SchemeGroupVersion.WithKind("ClusterIssuer"))
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -30,6 +31,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/acme/accounts"
 	clientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	informers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	"github.com/jetstack/cert-manager/pkg/issuer/vault/tokencache"
 	"github.com/jetstack/cert-manager/pkg/metrics"
 )
 
@@ -50,6 +52,9 @@ type Context struct {
 	Client kubernetes.Interface
 	// CMClient is a cert-manager clientset
 	CMClient clientset.Interface
+	// DynamicClient is a Kubernetes dynamic client, used to access resources
+	// whose types are not compiled into cert-manager
+	DynamicClient dynamic.Interface
 	// Recorder to record events to
 	Recorder record.EventRecorder
 
@@ -96,6 +101,14 @@ type IssuerOptions struct {
 	// Once a certificate is within this duration until expiry, a new Certificate
 	// will be attempted to be issued.
 	RenewBeforeExpiryDuration time.Duration
+
+	// VaultTokenCache is used as a cache of the tokens obtained by logging in
+	// to Vault between various components of cert-manager
+	VaultTokenCache *tokencache.Cache
+
+	// PKCS11AllowedModules is the list of paths to PKCS#11 modules that CA
+	// issuers may use to sign with private keys stored in PKCS#11 tokens.
+	PKCS11AllowedModules []string
 }
 
 type ACMEOptions struct {
@@ -115,6 +128,11 @@ type ACMEOptions struct {
 	// HTTP01SolverResourceLimitsMemory defines the ACME pod's resource limits Memory size
 	HTTP01SolverResourceLimitsMemory resource.Quantity
 
+	// HTTP01SolverShared enables shared HTTP01 solver mode, where a single
+	// long running solver Deployment per namespace serves the tokens of all
+	// pending challenges, instead of one pod being created per challenge
+	HTTP01SolverShared bool
+
 	// DNS01CheckAuthoritative is a flag for controlling if auth nss are used
 	// for checking propagation of an RR. This is the ideal scenario
 	DNS01CheckAuthoritative bool
@@ -0,0 +1,267 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// Replicator copies a Certificate's Secret resource into the namespaces
+// selected by the Certificate's `spec.secretReplication`.
+// Replicas cannot be garbage collected using owner references as they live
+// in a different namespace to the Certificate, so they are labelled with the
+// namespace of the Certificate and annotated with its name instead.
+type Replicator struct {
+	kubeClient      kubernetes.Interface
+	secretLister    corelisters.SecretLister
+	namespaceLister corelisters.NamespaceLister
+}
+
+func NewReplicator(
+	kubeClient kubernetes.Interface,
+	secretLister corelisters.SecretLister,
+	namespaceLister corelisters.NamespaceLister,
+) *Replicator {
+	return &Replicator{
+		kubeClient:      kubeClient,
+		secretLister:    secretLister,
+		namespaceLister: namespaceLister,
+	}
+}
+
+// UpdateReplicas will ensure that the Certificate's Secret resource is copied
+// into each namespace selected by `spec.secretReplication`, and that replicas
+// in namespaces that are no longer selected are deleted.
+// If the Certificate's Secret does not exist, existing replicas are left as
+// they are until it has been issued.
+func (r *Replicator) UpdateReplicas(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+
+	targets, err := r.targetNamespaces(crt)
+	if err != nil {
+		return err
+	}
+
+	secret, err := r.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	var errs []error
+	if secret != nil {
+		for _, ns := range targets.List() {
+			if err := r.updateReplica(ctx, crt, secret, ns); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	} else if targets.Len() > 0 {
+		log.V(logf.DebugLevel).Info("secret does not exist yet, not replicating")
+	}
+
+	replicas, err := r.listReplicas(crt.Namespace, crt.Name)
+	if err != nil {
+		return err
+	}
+	for _, replica := range replicas {
+		if replica.Name == crt.Spec.SecretName && targets.Has(replica.Namespace) {
+			continue
+		}
+		if err := r.deleteReplica(ctx, replica); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// DeleteReplicas deletes all replicas of the Secret of the named Certificate.
+// It should be called once a Certificate has been deleted.
+func (r *Replicator) DeleteReplicas(ctx context.Context, namespace, name string) error {
+	replicas, err := r.listReplicas(namespace, name)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, replica := range replicas {
+		if err := r.deleteReplica(ctx, replica); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// targetNamespaces returns the set of namespaces that the Certificate's
+// Secret should be replicated into. Namespaces that do not exist, are being
+// deleted or do not allow replication from the Certificate's namespace are
+// not included, nor is the Certificate's own namespace.
+func (r *Replicator) targetNamespaces(crt *cmapi.Certificate) (sets.String, error) {
+	targets := sets.NewString()
+	replication := crt.Spec.SecretReplication
+	if replication == nil {
+		return targets, nil
+	}
+
+	var namespaces []*corev1.Namespace
+	for _, name := range replication.Namespaces {
+		ns, err := r.namespaceLister.Get(name)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		namespaces = append(namespaces, ns)
+	}
+	if replication.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(replication.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector: %w", err)
+		}
+		selected, err := r.namespaceLister.List(selector)
+		if err != nil {
+			return nil, err
+		}
+		namespaces = append(namespaces, selected...)
+	}
+
+	for _, ns := range namespaces {
+		if ns.Name == crt.Namespace || ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		if !allowsReplicationFrom(ns, crt.Namespace) {
+			continue
+		}
+		targets.Insert(ns.Name)
+	}
+	return targets, nil
+}
+
+// allowsReplicationFrom returns true if ns has opted in to Secrets being
+// replicated into it from the given namespace using the
+// AllowSecretReplicationFromAnnotationKey annotation.
+func allowsReplicationFrom(ns *corev1.Namespace, namespace string) bool {
+	allowed, ok := ns.Annotations[cmapi.AllowSecretReplicationFromAnnotationKey]
+	if !ok {
+		return false
+	}
+	for _, n := range strings.Split(allowed, ",") {
+		n = strings.TrimSpace(n)
+		if n == "*" || n == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// updateReplica creates or updates the replica of secret in namespace.
+// An existing Secret that is not a replica of the Certificate's Secret will
+// not be modified.
+func (r *Replicator) updateReplica(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret, namespace string) error {
+	existing, err := r.secretLister.Secrets(namespace).Get(secret.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if existing != nil && !isReplicaOf(existing, crt.Namespace, crt.Name) {
+		return fmt.Errorf("secret %s/%s already exists and is not a replica of this certificate", namespace, secret.Name)
+	}
+
+	annotations := make(map[string]string, len(secret.Annotations))
+	for k, v := range secret.Annotations {
+		annotations[k] = v
+	}
+	annotations[cmapi.CertificateNameKey] = crt.Name
+
+	if existing == nil {
+		replica := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secret.Name,
+				Namespace:   namespace,
+				Labels:      map[string]string{cmapi.ReplicaOfNamespaceLabelKey: crt.Namespace},
+				Annotations: annotations,
+			},
+			Data: secret.Data,
+			Type: secret.Type,
+		}
+		_, err = r.kubeClient.CoreV1().Secrets(namespace).Create(ctx, replica, metav1.CreateOptions{})
+		return err
+	}
+
+	if reflect.DeepEqual(existing.Data, secret.Data) && reflect.DeepEqual(existing.Annotations, annotations) {
+		return nil
+	}
+
+	replica := existing.DeepCopy()
+	replica.Annotations = annotations
+	replica.Data = secret.Data
+	_, err = r.kubeClient.CoreV1().Secrets(namespace).Update(ctx, replica, metav1.UpdateOptions{})
+	return err
+}
+
+func (r *Replicator) deleteReplica(ctx context.Context, replica *corev1.Secret) error {
+	err := r.kubeClient.CoreV1().Secrets(replica.Namespace).Delete(ctx, replica.Name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// listReplicas lists the replicas of the named Certificate's Secret in all
+// namespaces.
+func (r *Replicator) listReplicas(namespace, name string) ([]*corev1.Secret, error) {
+	selector := labels.SelectorFromSet(labels.Set{cmapi.ReplicaOfNamespaceLabelKey: namespace})
+	secrets, err := r.secretLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	var replicas []*corev1.Secret
+	for _, secret := range secrets {
+		if isReplicaOf(secret, namespace, name) {
+			replicas = append(replicas, secret)
+		}
+	}
+	return replicas, nil
+}
+
+func isReplicaOf(secret *corev1.Secret, namespace, name string) bool {
+	return secret.Labels[cmapi.ReplicaOfNamespaceLabelKey] == namespace &&
+		secret.Annotations[cmapi.CertificateNameKey] == name
+}
+
+// ReplicaOf returns the namespace and name of the Certificate that the Secret
+// is a replica of, and false if the Secret is not a replica.
+func ReplicaOf(secret *corev1.Secret) (namespace, name string, ok bool) {
+	namespace, ok = secret.Labels[cmapi.ReplicaOfNamespaceLabelKey]
+	if !ok {
+		return "", "", false
+	}
+	return namespace, secret.Annotations[cmapi.CertificateNameKey], true
+}
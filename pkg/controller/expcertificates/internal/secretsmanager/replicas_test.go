@@ -0,0 +1,252 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestReplicatorUpdateReplicas(t *testing.T) {
+	namespaceAllowing := func(name string, labels map[string]string, allowFrom string) *corev1.Namespace {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		if allowFrom != "" {
+			ns.Annotations = map[string]string{cmapi.AllowSecretReplicationFromAnnotationKey: allowFrom}
+		}
+		return ns
+	}
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return namespaceAllowing(name, labels, gen.DefaultTestNamespace)
+	}
+	secret := func(namespace string, data string, labels map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        "output",
+				Labels:      labels,
+				Annotations: map[string]string{cmapi.CertificateNameKey: "test"},
+			},
+			Data: map[string][]byte{corev1.TLSCertKey: []byte(data)},
+			Type: corev1.SecretTypeTLS,
+		}
+	}
+	replica := func(namespace string, data string) *corev1.Secret {
+		return secret(namespace, data, map[string]string{cmapi.ReplicaOfNamespaceLabelKey: gen.DefaultTestNamespace})
+	}
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateSecretName("output"),
+	)
+	withReplication := func(r *cmapi.CertificateSecretReplication) *cmapi.Certificate {
+		crt := baseCert.DeepCopy()
+		crt.Spec.SecretReplication = r
+		return crt
+	}
+
+	tests := map[string]struct {
+		certificate     *cmapi.Certificate
+		kubeObjects     []runtime.Object
+		expectedActions []testpkg.Action
+		expectedErr     bool
+	}{
+		"should create replicas in listed and selected namespaces": {
+			certificate: withReplication(&cmapi.CertificateSecretReplication{
+				Namespaces:        []string{"listed", "does-not-exist"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"replicate": "true"}},
+			}),
+			kubeObjects: []runtime.Object{
+				namespace(gen.DefaultTestNamespace, map[string]string{"replicate": "true"}),
+				namespace("listed", nil),
+				namespace("selected", map[string]string{"replicate": "true"}),
+				namespace("other", nil),
+				secret(gen.DefaultTestNamespace, "cert", nil),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "listed", replica("listed", "cert"))),
+				testpkg.NewAction(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "selected", replica("selected", "cert"))),
+			},
+		},
+		"should update out of date replicas": {
+			certificate: withReplication(&cmapi.CertificateSecretReplication{Namespaces: []string{"listed"}}),
+			kubeObjects: []runtime.Object{
+				namespace("listed", nil),
+				secret(gen.DefaultTestNamespace, "renewed", nil),
+				replica("listed", "cert"),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "listed", replica("listed", "renewed"))),
+			},
+		},
+		"should not update up to date replicas": {
+			certificate: withReplication(&cmapi.CertificateSecretReplication{Namespaces: []string{"listed"}}),
+			kubeObjects: []runtime.Object{
+				namespace("listed", nil),
+				secret(gen.DefaultTestNamespace, "cert", nil),
+				replica("listed", "cert"),
+			},
+		},
+		"should delete replicas in namespaces that are no longer selected": {
+			certificate: withReplication(&cmapi.CertificateSecretReplication{Namespaces: []string{"listed"}}),
+			kubeObjects: []runtime.Object{
+				namespace("listed", nil),
+				namespace("unlisted", nil),
+				secret(gen.DefaultTestNamespace, "cert", nil),
+				replica("listed", "cert"),
+				replica("unlisted", "cert"),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(corev1.SchemeGroupVersion.WithResource("secrets"), "unlisted", "output")),
+			},
+		},
+		"should delete all replicas if replication is disabled": {
+			certificate: baseCert,
+			kubeObjects: []runtime.Object{
+				namespace("listed", nil),
+				secret(gen.DefaultTestNamespace, "cert", nil),
+				replica("listed", "cert"),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(corev1.SchemeGroupVersion.WithResource("secrets"), "listed", "output")),
+			},
+		},
+		"should not create replicas if the secret does not exist": {
+			certificate: withReplication(&cmapi.CertificateSecretReplication{Namespaces: []string{"listed"}}),
+			kubeObjects: []runtime.Object{
+				namespace("listed", nil),
+			},
+		},
+		"should only replicate into namespaces that allow replication from the certificate's namespace": {
+			certificate: withReplication(&cmapi.CertificateSecretReplication{
+				Namespaces: []string{"no-annotation", "other-allowed", "some-allowed", "all-allowed"},
+			}),
+			kubeObjects: []runtime.Object{
+				namespaceAllowing("no-annotation", nil, ""),
+				namespaceAllowing("other-allowed", nil, "other"),
+				namespaceAllowing("some-allowed", nil, "other, "+gen.DefaultTestNamespace),
+				namespaceAllowing("all-allowed", nil, "*"),
+				secret(gen.DefaultTestNamespace, "cert", nil),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "all-allowed", replica("all-allowed", "cert"))),
+				testpkg.NewAction(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "some-allowed", replica("some-allowed", "cert"))),
+			},
+		},
+		"should delete replicas in namespaces that no longer allow replication": {
+			certificate: withReplication(&cmapi.CertificateSecretReplication{Namespaces: []string{"listed"}}),
+			kubeObjects: []runtime.Object{
+				namespaceAllowing("listed", nil, ""),
+				secret(gen.DefaultTestNamespace, "cert", nil),
+				replica("listed", "cert"),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(corev1.SchemeGroupVersion.WithResource("secrets"), "listed", "output")),
+			},
+		},
+		"should not overwrite secrets that are not replicas": {
+			certificate: withReplication(&cmapi.CertificateSecretReplication{Namespaces: []string{"listed", "other"}}),
+			kubeObjects: []runtime.Object{
+				namespace("listed", nil),
+				namespace("other", nil),
+				secret(gen.DefaultTestNamespace, "cert", nil),
+				secret("listed", "unrelated", nil),
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewCreateAction(corev1.SchemeGroupVersion.WithResource("secrets"), "other", replica("other", "cert"))),
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:               t,
+				KubeObjects:     test.kubeObjects,
+				ExpectedActions: test.expectedActions,
+			}
+			builder.Init()
+
+			replicator := NewReplicator(
+				builder.Client,
+				builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+				builder.KubeSharedInformerFactory.Core().V1().Namespaces().Lister(),
+			)
+
+			builder.Start()
+
+			err := replicator.UpdateReplicas(context.Background(), test.certificate)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+			builder.CheckAndFinish(err)
+		})
+	}
+}
+
+func TestReplicatorDeleteReplicas(t *testing.T) {
+	replica := func(namespace, crtNamespace, crtName string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        "output",
+				Labels:      map[string]string{cmapi.ReplicaOfNamespaceLabelKey: crtNamespace},
+				Annotations: map[string]string{cmapi.CertificateNameKey: crtName},
+			},
+		}
+	}
+
+	builder := &testpkg.Builder{
+		T: t,
+		KubeObjects: []runtime.Object{
+			replica("ns1", gen.DefaultTestNamespace, "test"),
+			replica("ns2", gen.DefaultTestNamespace, "test"),
+			replica("ns3", gen.DefaultTestNamespace, "other"),
+			replica("ns4", "other", "test"),
+		},
+		ExpectedActions: []testpkg.Action{
+			testpkg.NewAction(coretesting.NewDeleteAction(corev1.SchemeGroupVersion.WithResource("secrets"), "ns1", "output")),
+			testpkg.NewAction(coretesting.NewDeleteAction(corev1.SchemeGroupVersion.WithResource("secrets"), "ns2", "output")),
+		},
+	}
+	builder.Init()
+
+	replicator := NewReplicator(
+		builder.Client,
+		builder.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		builder.KubeSharedInformerFactory.Core().V1().Namespaces().Lister(),
+	)
+
+	builder.Start()
+
+	err := replicator.DeleteReplicas(context.Background(), gen.DefaultTestNamespace, "test")
+	if err != nil {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	builder.CheckAndFinish(err)
+}
@@ -99,6 +99,8 @@ func TestSecretsManager(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   strings.Join(exampleBundle.Cert.DNSNames, ","),
 									cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(exampleBundle.Cert.IPAddresses), ","),
 									cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(exampleBundle.Cert.URIs), ","),
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle.Certificate.Spec.IssuerRef.Group,
 								},
 								OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(exampleBundle.Certificate, certificateGvk)},
 							},
@@ -158,6 +160,8 @@ func TestSecretsManager(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   strings.Join(exampleBundle.Cert.DNSNames, ","),
 									cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(exampleBundle.Cert.IPAddresses), ","),
 									cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(exampleBundle.Cert.URIs), ","),
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle.Certificate.Spec.IssuerRef.Group,
 								},
 								OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(exampleBundle.Certificate, certificateGvk)},
 							},
@@ -199,6 +203,8 @@ func TestSecretsManager(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   strings.Join(exampleBundle.Cert.DNSNames, ","),
 									cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(exampleBundle.Cert.IPAddresses), ","),
 									cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(exampleBundle.Cert.URIs), ","),
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle.Certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -257,6 +263,8 @@ func TestSecretsManager(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   strings.Join(exampleBundle.Cert.DNSNames, ","),
 									cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(exampleBundle.Cert.IPAddresses), ","),
 									cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(exampleBundle.Cert.URIs), ","),
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle.Certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -387,6 +387,8 @@ func TestIssuingController(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.IPSANAnnotationKey:      "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle.Certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -460,6 +462,8 @@ func TestIssuingController(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.IPSANAnnotationKey:      "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle.Certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -518,6 +522,8 @@ func TestIssuingController(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.IPSANAnnotationKey:      "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle.Certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -588,6 +594,8 @@ func TestIssuingController(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.IPSANAnnotationKey:      "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle.Certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -705,6 +713,8 @@ func TestIssuingController(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.IPSANAnnotationKey:      "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle.Certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
@@ -818,6 +828,8 @@ func TestIssuingController(t *testing.T) {
 									cmapi.AltNamesAnnotationKey:   "example.com",
 									cmapi.IPSANAnnotationKey:      "",
 									cmapi.URISANAnnotationKey:     "",
+
+									cmapi.IssuerGroupAnnotationKey: exampleBundle.Certificate.Spec.IssuerRef.Group,
 								},
 							},
 							Data: map[string][]byte{
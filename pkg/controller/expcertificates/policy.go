@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"k8s.io/client-go/tools/cache"
+
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+)
+
+// NewIssuerPolicyValidator returns a function that validates Certificates
+// against the policy of the issuer they reference, along with the
+// InformerSynced functions of the informers that issuers are read from.
+func NewIssuerPolicyValidator(ctx *controllerpkg.Context) (issuer.ValidateCertificateForIssuerFunc, []cache.InformerSynced) {
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Issuers()
+	mustSync := []cache.InformerSynced{issuerInformer.Informer().HasSynced}
+
+	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
+	// obtain a lister for clusterissuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	validate := issuer.NewCertificateValidator(
+		issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		issuer.NewFactory(ctx),
+	)
+
+	return validate, mustSync
+}
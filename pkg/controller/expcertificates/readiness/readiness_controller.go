@@ -18,6 +18,7 @@ package readiness
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -40,6 +41,7 @@ import (
 	certificates "github.com/jetstack/cert-manager/pkg/controller/expcertificates"
 	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/internal/predicate"
 	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/trigger/policies"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
@@ -68,6 +70,11 @@ type controller struct {
 	secretLister             corelisters.SecretLister
 	client                   cmclient.Interface
 	gatherer                 *policies.Gatherer
+
+	// validateCertificateForIssuer validates a Certificate against the policy
+	// of its issuer, so that violations are reported in the Ready condition.
+	// If nil, Certificates are not checked against any issuer policy.
+	validateCertificateForIssuer issuer.ValidateCertificateForIssuerFunc
 }
 
 func NewController(
@@ -144,6 +151,12 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	condition := readyCondition(c.policyChain, input)
+	if condition.Status != cmmeta.ConditionTrue {
+		if policyErrs := issuer.PolicyViolations(ctx, c.validateCertificateForIssuer, crt); len(policyErrs) > 0 {
+			condition.Reason = "PolicyViolation"
+			condition.Message = fmt.Sprintf("Certificate does not comply with the policy of its issuer: %s", policyErrs.ToAggregate())
+		}
+	}
 
 	crt = crt.DeepCopy()
 	apiutil.SetCertificateCondition(crt, condition.Type, condition.Status, condition.Reason, condition.Message)
@@ -200,6 +213,11 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 	)
 	c.controller = ctrl
 
+	// the issuer policy is reported when a Certificate is not ready
+	validate, issuerMustSync := certificates.NewIssuerPolicyValidator(ctx)
+	c.controller.validateCertificateForIssuer = validate
+	mustSync = append(mustSync, issuerMustSync...)
+
 	return queue, mustSync, nil
 }
 
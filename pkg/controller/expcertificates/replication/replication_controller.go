@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	certificates "github.com/jetstack/cert-manager/pkg/controller/expcertificates"
+	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/internal/predicate"
+	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/internal/secretsmanager"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	ControllerName = "CertificateSecretReplication"
+)
+
+// This controller copies the Secret of Certificates that set
+// `spec.secretReplication` into the selected namespaces, keeps the copies up
+// to date and deletes them once they are no longer selected or the
+// Certificate has been deleted.
+type controller struct {
+	certificateLister cmlisters.CertificateLister
+	recorder          record.EventRecorder
+
+	replicator *secretsmanager.Replicator
+}
+
+func NewController(
+	log logr.Logger,
+	kubeClient kubernetes.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	recorder record.EventRecorder,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1alpha2().Certificates()
+	secretsInformer := factory.Core().V1().Secrets()
+	namespacesInformer := factory.Core().V1().Namespaces()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Secret resource changes, enqueue any Certificate resources that
+	// name it as spec.secretName, or that it is a replica of.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueCertificateForReplica(log, queue),
+	})
+	// When a Namespace resource changes, enqueue all Certificate resources
+	// that replicate their Secret, as the set of selected namespaces may
+	// have changed.
+	namespacesInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueReplicatingCertificates(log, queue, certificateInformer.Lister()),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+		namespacesInformer.Informer().HasSynced,
+	}
+
+	return &controller{
+		certificateLister: certificateInformer.Lister(),
+		recorder:          recorder,
+		replicator: secretsmanager.NewReplicator(
+			kubeClient,
+			secretsInformer.Lister(),
+			namespacesInformer.Lister(),
+		),
+	}, queue, mustSync
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key, deleting any secret replicas")
+		return c.replicator.DeleteReplicas(ctx, namespace, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, crt))
+	if err := c.replicator.UpdateReplicas(ctx, crt); err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, "ReplicationFailed", "Failed to replicate secret: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// enqueueCertificateForReplica enqueues the Certificate that a Secret is a
+// replica of, so that modified replicas are restored, and replicas of deleted
+// Certificates are cleaned up.
+func enqueueCertificateForReplica(log logr.Logger, queue workqueue.Interface) func(obj interface{}) {
+	return func(obj interface{}) {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			log.Info("Non-Secret type resource passed to enqueueCertificateForReplica")
+			return
+		}
+		namespace, name, ok := secretsmanager.ReplicaOf(secret)
+		if !ok {
+			return
+		}
+		queue.Add(namespace + "/" + name)
+	}
+}
+
+// enqueueReplicatingCertificates enqueues all Certificates that have
+// `spec.secretReplication` set.
+func enqueueReplicatingCertificates(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		crts, err := lister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "Failed listing Certificate resources")
+			return
+		}
+		for _, crt := range crts {
+			if crt.Spec.SecretReplication == nil {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "Error determining 'key' for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Recorder,
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -46,7 +46,9 @@ import (
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	certificates "github.com/jetstack/cert-manager/pkg/controller/expcertificates"
 	"github.com/jetstack/cert-manager/pkg/controller/expcertificates/internal/predicate"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/scheduler"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
@@ -64,6 +66,15 @@ type controller struct {
 	secretLister             corelisters.SecretLister
 	client                   cmclient.Interface
 	recorder                 record.EventRecorder
+
+	// validateCertificateForIssuer validates a Certificate against the policy
+	// of its issuer, so that CertificateRequests that would be rejected are
+	// not created.
+	// If nil, Certificates are not checked against any issuer policy.
+	validateCertificateForIssuer issuer.ValidateCertificateForIssuerFunc
+	// scheduledWorkQueue is used to check Certificates that violate the
+	// policy of their issuer again, as the policy may change.
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
 }
 
 func NewController(
@@ -109,6 +120,7 @@ func NewController(
 		secretLister:             secretsInformer.Lister(),
 		client:                   client,
 		recorder:                 recorder,
+		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(queue.Add),
 	}, queue, mustSync
 }
 
@@ -203,6 +215,16 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	// Check that the Certificate complies with the policy of its issuer
+	// before creating a CertificateRequest that would be rejected.
+	if policyErrs := issuer.PolicyViolations(ctx, c.validateCertificateForIssuer, crt); len(policyErrs) > 0 {
+		log.Info("Certificate does not comply with the policy of its issuer, not creating CertificateRequest", "errors", policyErrs.ToAggregate().Error())
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, "PolicyViolation", "Certificate does not comply with the policy of its issuer: %s", policyErrs.ToAggregate())
+		// The policy of the issuer may change, so schedule a re-check
+		c.scheduledWorkQueue.Add(key, issuer.PolicyRecheckInterval)
+		return nil
+	}
+
 	return c.createNewCertificateRequest(ctx, crt, pk, nextRevision, nextPrivateKeySecret.Name)
 }
 
@@ -378,6 +400,11 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 	)
 	c.controller = ctrl
 
+	// the issuer policy is checked before CertificateRequests are created
+	validate, issuerMustSync := certificates.NewIssuerPolicyValidator(ctx)
+	c.controller.validateCertificateForIssuer = validate
+	mustSync = append(mustSync, issuerMustSync...)
+
 	return queue, mustSync, nil
 }
 
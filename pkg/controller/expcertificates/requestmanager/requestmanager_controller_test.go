@@ -26,12 +26,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	coretesting "k8s.io/client-go/testing"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 	"github.com/jetstack/cert-manager/test/unit/gen"
 )
@@ -107,6 +109,10 @@ func TestProcessItem(t *testing.T) {
 
 		expectedEvents []string
 
+		// validateCertificateForIssuer stubs out the issuer policy check.
+		// If nil, Certificates are not checked against any issuer policy.
+		validateCertificateForIssuer issuer.ValidateCertificateForIssuerFunc
+
 		// err is the expected error text returned by the controller, if any.
 		err string
 	}{
@@ -181,6 +187,22 @@ func TestProcessItem(t *testing.T) {
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
+		"do not create a CertificateRequest if the Certificate does not comply with the policy of its issuer": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: bundle1.certificate.Namespace, Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle1.privateKeyBytes},
+				},
+			},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			validateCertificateForIssuer: func(context.Context, *cmapi.Certificate) (field.ErrorList, error) {
+				return field.ErrorList{field.Invalid(field.NewPath("spec", "commonName"), "test-bundle-1", "not allowed")}, nil
+			},
+			expectedEvents: []string{`Warning PolicyViolation Certificate does not comply with the policy of its issuer: spec.commonName: Invalid value: "test-bundle-1": not allowed`},
+		},
 		"delete the owned CertificateRequest and create a new one if existing one does not have the annotation": {
 			secrets: []runtime.Object{
 				&corev1.Secret{
@@ -554,6 +576,9 @@ func TestProcessItem(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+			if test.validateCertificateForIssuer != nil {
+				w.controller.validateCertificateForIssuer = test.validateCertificateForIssuer
+			}
 			// Start the informers and begin processing updates
 			builder.Start()
 			defer builder.Stop()
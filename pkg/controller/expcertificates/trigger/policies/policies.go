@@ -23,6 +23,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	certificates "github.com/jetstack/cert-manager/pkg/controller/expcertificates"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
@@ -126,7 +127,7 @@ func SecretHasUpToDateIssuerAnnotations(input Input) (string, string, bool) {
 	group := input.Secret.Annotations[cmapi.IssuerGroupAnnotationKey]
 	if name != input.Certificate.Spec.IssuerRef.Name ||
 		!issuerKindsEqual(kind, input.Certificate.Spec.IssuerRef.Kind) ||
-		!issuerGroupsEqual(group, input.Certificate.Spec.IssuerRef.Group) {
+		!apiutil.IssuerGroupsEqual(group, input.Certificate.Spec.IssuerRef.Group) {
 		return "IncorrectIssuer", fmt.Sprintf("Issuing certificate as Secret was previously issued by %s", formatIssuerRef(name, kind, group)), true
 	}
 	return "", "", false
@@ -231,7 +232,6 @@ func formatIssuerRef(name, kind, group string) string {
 }
 
 const defaultIssuerKind = "Issuer"
-const defaultIssuerGroup = "cert-manager.io"
 
 func issuerKindsEqual(l, r string) bool {
 	if l == "" {
@@ -242,13 +242,3 @@ func issuerKindsEqual(l, r string) bool {
 	}
 	return l == r
 }
-
-func issuerGroupsEqual(l, r string) bool {
-	if l == "" {
-		l = defaultIssuerGroup
-	}
-	if r == "" {
-		r = defaultIssuerGroup
-	}
-	return l == r
-}
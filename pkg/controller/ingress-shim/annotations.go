@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+)
+
+// translateAnnotations configures the given Certificate using the
+// Certificate annotations set on an Ingress. Fields of the Certificate for
+// which no annotation is present are left untouched.
+// An error is returned if any annotation has an invalid value.
+func translateAnnotations(crt *cmapi.Certificate, annotations map[string]string) error {
+	if commonName, ok := annotations[cmapi.CommonNameAnnotationKey]; ok {
+		crt.Spec.CommonName = commonName
+	}
+
+	if duration, ok := annotations[cmapi.DurationAnnotationKey]; ok {
+		d, err := time.ParseDuration(duration)
+		if err != nil {
+			return fmt.Errorf("%s annotation has invalid value %q: %v", cmapi.DurationAnnotationKey, duration, err)
+		}
+		crt.Spec.Duration = &metav1.Duration{Duration: d}
+	}
+
+	if renewBefore, ok := annotations[cmapi.RenewBeforeAnnotationKey]; ok {
+		d, err := time.ParseDuration(renewBefore)
+		if err != nil {
+			return fmt.Errorf("%s annotation has invalid value %q: %v", cmapi.RenewBeforeAnnotationKey, renewBefore, err)
+		}
+		crt.Spec.RenewBefore = &metav1.Duration{Duration: d}
+	}
+
+	if usages, ok := annotations[cmapi.UsagesAnnotationKey]; ok {
+		var keyUsages []cmapi.KeyUsage
+		for _, u := range splitList(usages) {
+			usage := cmapi.KeyUsage(u)
+			_, kok := util.KeyUsageType(usage)
+			_, ekok := util.ExtKeyUsageType(usage)
+			if !kok && !ekok {
+				return fmt.Errorf("%s annotation contains unknown key usage %q", cmapi.UsagesAnnotationKey, u)
+			}
+			keyUsages = append(keyUsages, usage)
+		}
+		crt.Spec.Usages = keyUsages
+	}
+
+	if algorithm, ok := annotations[cmapi.PrivateKeyAlgorithmAnnotationKey]; ok {
+		switch cmapi.KeyAlgorithm(algorithm) {
+		case cmapi.RSAKeyAlgorithm, cmapi.ECDSAKeyAlgorithm:
+			crt.Spec.KeyAlgorithm = cmapi.KeyAlgorithm(algorithm)
+		default:
+			return fmt.Errorf("%s annotation has invalid value %q, must be one of %q or %q",
+				cmapi.PrivateKeyAlgorithmAnnotationKey, algorithm, cmapi.RSAKeyAlgorithm, cmapi.ECDSAKeyAlgorithm)
+		}
+	}
+
+	if encoding, ok := annotations[cmapi.PrivateKeyEncodingAnnotationKey]; ok {
+		switch cmapi.KeyEncoding(encoding) {
+		case cmapi.PKCS1, cmapi.PKCS8:
+			crt.Spec.KeyEncoding = cmapi.KeyEncoding(encoding)
+		default:
+			return fmt.Errorf("%s annotation has invalid value %q, must be one of %q or %q",
+				cmapi.PrivateKeyEncodingAnnotationKey, encoding, cmapi.PKCS1, cmapi.PKCS8)
+		}
+	}
+
+	if size, ok := annotations[cmapi.PrivateKeySizeAnnotationKey]; ok {
+		keySize, err := strconv.Atoi(size)
+		if err != nil || keySize <= 0 {
+			return fmt.Errorf("%s annotation has invalid value %q, must be a positive integer", cmapi.PrivateKeySizeAnnotationKey, size)
+		}
+		crt.Spec.KeySize = keySize
+	}
+
+	if policy, ok := annotations[cmapi.PrivateKeyRotationPolicyAnnotationKey]; ok {
+		switch cmapi.PrivateKeyRotationPolicy(policy) {
+		case cmapi.RotationPolicyNever, cmapi.RotationPolicyAlways:
+			crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{
+				RotationPolicy: cmapi.PrivateKeyRotationPolicy(policy),
+			}
+		default:
+			return fmt.Errorf("%s annotation has invalid value %q, must be one of %q or %q",
+				cmapi.PrivateKeyRotationPolicyAnnotationKey, policy, cmapi.RotationPolicyNever, cmapi.RotationPolicyAlways)
+		}
+	}
+
+	if organizations, ok := annotations[cmapi.SubjectOrganizationsAnnotationKey]; ok {
+		crt.Spec.Organization = splitList(organizations)
+	}
+
+	subjectLists := []struct {
+		key   string
+		field *[]string
+	}{
+		{cmapi.SubjectOrganizationalUnitsAnnotationKey, &ensureSubject(crt).OrganizationalUnits},
+		{cmapi.SubjectCountriesAnnotationKey, &ensureSubject(crt).Countries},
+		{cmapi.SubjectProvincesAnnotationKey, &ensureSubject(crt).Provinces},
+		{cmapi.SubjectLocalitiesAnnotationKey, &ensureSubject(crt).Localities},
+		{cmapi.SubjectStreetAddressesAnnotationKey, &ensureSubject(crt).StreetAddresses},
+		{cmapi.SubjectPostalCodesAnnotationKey, &ensureSubject(crt).PostalCodes},
+	}
+	for _, s := range subjectLists {
+		if val, ok := annotations[s.key]; ok {
+			*s.field = splitList(val)
+		}
+	}
+
+	if serialNumber, ok := annotations[cmapi.SubjectSerialNumberAnnotationKey]; ok {
+		ensureSubject(crt).SerialNumber = serialNumber
+	}
+
+	// don't leave an empty subject behind if no subject annotations were set
+	if crt.Spec.Subject != nil && isEmptySubject(crt.Spec.Subject) {
+		crt.Spec.Subject = nil
+	}
+
+	return nil
+}
+
+func ensureSubject(crt *cmapi.Certificate) *cmapi.X509Subject {
+	if crt.Spec.Subject == nil {
+		crt.Spec.Subject = &cmapi.X509Subject{}
+	}
+	return crt.Spec.Subject
+}
+
+func isEmptySubject(s *cmapi.X509Subject) bool {
+	return len(s.OrganizationalUnits) == 0 &&
+		len(s.Countries) == 0 &&
+		len(s.Provinces) == 0 &&
+		len(s.Localities) == 0 &&
+		len(s.StreetAddresses) == 0 &&
+		len(s.PostalCodes) == 0 &&
+		s.SerialNumber == ""
+}
+
+// splitList splits a comma separated annotation value, trimming whitespace
+// and ignoring empty entries.
+func splitList(val string) []string {
+	var out []string
+	for _, s := range strings.Split(val, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+)
+
+func TestTranslateAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		expectedErr bool
+		check       func(t *testing.T, crt *cmapi.Certificate)
+	}{
+		"should not modify the certificate if no annotations are set": {
+			annotations: map[string]string{},
+			check: func(t *testing.T, crt *cmapi.Certificate) {
+				if !reflect.DeepEqual(crt, &cmapi.Certificate{}) {
+					t.Errorf("expected certificate to be unmodified, got %+v", crt)
+				}
+			},
+		},
+		"should ignore empty entries in lists": {
+			annotations: map[string]string{
+				cmapi.SubjectLocalitiesAnnotationKey: "London, ,Bristol,",
+			},
+			check: func(t *testing.T, crt *cmapi.Certificate) {
+				exp := []string{"London", "Bristol"}
+				if crt.Spec.Subject == nil || !reflect.DeepEqual(crt.Spec.Subject.Localities, exp) {
+					t.Errorf("expected localities %v, got %+v", exp, crt.Spec.Subject)
+				}
+			},
+		},
+		"should error on an invalid renew-before": {
+			annotations: map[string]string{cmapi.RenewBeforeAnnotationKey: "1 day"},
+			expectedErr: true,
+		},
+		"should error on an unknown key usage": {
+			annotations: map[string]string{cmapi.UsagesAnnotationKey: "server auth,flying"},
+			expectedErr: true,
+		},
+		"should error on an unknown key algorithm": {
+			annotations: map[string]string{cmapi.PrivateKeyAlgorithmAnnotationKey: "dsa"},
+			expectedErr: true,
+		},
+		"should error on an unknown key encoding": {
+			annotations: map[string]string{cmapi.PrivateKeyEncodingAnnotationKey: "der"},
+			expectedErr: true,
+		},
+		"should error on a non-numeric key size": {
+			annotations: map[string]string{cmapi.PrivateKeySizeAnnotationKey: "large"},
+			expectedErr: true,
+		},
+		"should error on an unknown rotation policy": {
+			annotations: map[string]string{cmapi.PrivateKeyRotationPolicyAnnotationKey: "Sometimes"},
+			expectedErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := &cmapi.Certificate{}
+			err := translateAnnotations(crt, test.annotations)
+			if err != nil != test.expectedErr {
+				t.Errorf("expected error=%t, got: %v", test.expectedErr, err)
+			}
+			if test.check != nil {
+				test.check(t, crt)
+			}
+		})
+	}
+}
@@ -22,9 +22,10 @@ import (
 
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
-	extlisters "k8s.io/client-go/listers/extensions/v1beta1"
+	networkingv1beta1listers "k8s.io/client-go/listers/networking/v1beta1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -33,6 +34,7 @@ import (
 	clientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/internal/ingress"
 	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
@@ -58,7 +60,11 @@ type controller struct {
 	cmClient clientset.Interface
 	recorder record.EventRecorder
 
-	ingressLister       extlisters.IngressLister
+	// ingressGVK is the GroupVersionKind of the Ingress API served by the
+	// apiserver, used when setting owner references on Certificates
+	ingressGVK schema.GroupVersionKind
+
+	ingressLister       networkingv1beta1listers.IngressLister
 	certificateLister   cmlisters.CertificateLister
 	issuerLister        cmlisters.IssuerLister
 	clusterIssuerLister cmlisters.ClusterIssuerLister
@@ -78,19 +84,23 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
 
 	// obtain references to all the informers used by this controller
-	ingressInformer := ctx.KubeSharedInformerFactory.Extensions().V1beta1().Ingresses()
+	ingresses, err := ingress.NewResources(ctx.Client, ctx.KubeSharedInformerFactory)
+	if err != nil {
+		return nil, nil, err
+	}
 	certificatesInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Certificates()
 	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Issuers()
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
-		ingressInformer.Informer().HasSynced,
+		ingresses.HasSynced,
 		certificatesInformer.Informer().HasSynced,
 		issuerInformer.Informer().HasSynced,
 	}
 
 	// set all the references to the listers for used by the Sync function
-	c.ingressLister = ingressInformer.Lister()
+	c.ingressGVK = ingresses.GroupVersionKind
+	c.ingressLister = ingresses.Lister
 	c.certificateLister = certificatesInformer.Lister()
 	c.issuerLister = issuerInformer.Lister()
 
@@ -104,7 +114,7 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	}
 
 	// register handler functions
-	ingressInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	ingresses.Informer.AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
 	certificatesInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.certificateDeleted})
 
 	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister)
@@ -25,7 +25,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
-	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -37,9 +37,7 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
-var ingressGVK = extv1beta1.SchemeGroupVersion.WithKind("Ingress")
-
-func (c *controller) Sync(ctx context.Context, ing *extv1beta1.Ingress) error {
+func (c *controller) Sync(ctx context.Context, ing *networkingv1beta1.Ingress) error {
 	log := logs.WithResource(logs.FromContext(ctx), ing)
 	ctx = logs.NewContext(ctx, log)
 
@@ -104,7 +102,7 @@ func (c *controller) Sync(ctx context.Context, ing *extv1beta1.Ingress) error {
 	return nil
 }
 
-func (c *controller) validateIngress(ing *extv1beta1.Ingress) []error {
+func (c *controller) validateIngress(ing *networkingv1beta1.Ingress) []error {
 	var errs []error
 	namedSecrets := make(map[string]int)
 	for i, tls := range ing.Spec.TLS {
@@ -122,10 +120,15 @@ func (c *controller) validateIngress(ing *extv1beta1.Ingress) []error {
 			errs = append(errs, fmt.Errorf("Duplicate TLS entry for secretName %q", name))
 		}
 	}
+	// check that any Certificate annotations are valid so that an event can
+	// be recorded, rather than failing when building Certificates
+	if err := translateAnnotations(&cmapi.Certificate{}, ing.Annotations); err != nil {
+		errs = append(errs, err)
+	}
 	return errs
 }
 
-func (c *controller) buildCertificates(ctx context.Context, ing *extv1beta1.Ingress,
+func (c *controller) buildCertificates(ctx context.Context, ing *networkingv1beta1.Ingress,
 	issuerName, issuerKind, issuerGroup string) (new, update []*cmapi.Certificate, _ error) {
 	log := logs.FromContext(ctx)
 
@@ -142,7 +145,7 @@ func (c *controller) buildCertificates(ctx context.Context, ing *extv1beta1.Ingr
 				Name:            tls.SecretName,
 				Namespace:       ing.Namespace,
 				Labels:          ing.Labels,
-				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ing, ingressGVK)},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ing, c.ingressGVK)},
 			},
 			Spec: cmapi.CertificateSpec{
 				DNSNames:   tls.Hosts,
@@ -155,6 +158,11 @@ func (c *controller) buildCertificates(ctx context.Context, ing *extv1beta1.Ingr
 			},
 		}
 
+		err = translateAnnotations(crt, ing.Annotations)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		err = c.setIssuerSpecificConfig(crt, ing, tls)
 		if err != nil {
 			return nil, nil, err
@@ -188,7 +196,16 @@ func (c *controller) buildCertificates(ctx context.Context, ing *extv1beta1.Ingr
 			updateCrt.Spec.IssuerRef.Name = issuerName
 			updateCrt.Spec.IssuerRef.Kind = issuerKind
 			updateCrt.Spec.IssuerRef.Group = issuerGroup
-			updateCrt.Spec.CommonName = ""
+			updateCrt.Spec.CommonName = crt.Spec.CommonName
+			updateCrt.Spec.Duration = crt.Spec.Duration
+			updateCrt.Spec.RenewBefore = crt.Spec.RenewBefore
+			updateCrt.Spec.Usages = crt.Spec.Usages
+			updateCrt.Spec.KeyAlgorithm = crt.Spec.KeyAlgorithm
+			updateCrt.Spec.KeyEncoding = crt.Spec.KeyEncoding
+			updateCrt.Spec.KeySize = crt.Spec.KeySize
+			updateCrt.Spec.PrivateKey = crt.Spec.PrivateKey
+			updateCrt.Spec.Organization = crt.Spec.Organization
+			updateCrt.Spec.Subject = crt.Spec.Subject
 			updateCrt.Labels = ing.Labels
 			err = c.setIssuerSpecificConfig(updateCrt, ing, tls)
 			if err != nil {
@@ -202,7 +219,7 @@ func (c *controller) buildCertificates(ctx context.Context, ing *extv1beta1.Ingr
 	return newCrts, updateCrts, nil
 }
 
-func (c *controller) findUnrequiredCertificates(ing *extv1beta1.Ingress) ([]*cmapi.Certificate, error) {
+func (c *controller) findUnrequiredCertificates(ing *networkingv1beta1.Ingress) ([]*cmapi.Certificate, error) {
 	var unrequired []*cmapi.Certificate
 	// TODO: investigate selector which filters for certificates controlled by the ingress
 	crts, err := c.certificateLister.Certificates(ing.Namespace).List(labels.Everything())
@@ -219,7 +236,7 @@ func (c *controller) findUnrequiredCertificates(ing *extv1beta1.Ingress) ([]*cma
 	return unrequired, nil
 }
 
-func isUnrequiredCertificate(crt *cmapi.Certificate, ing *extv1beta1.Ingress) bool {
+func isUnrequiredCertificate(crt *cmapi.Certificate, ing *networkingv1beta1.Ingress) bool {
 	if !metav1.IsControlledBy(crt, ing) {
 		return false
 	}
@@ -272,10 +289,27 @@ func certNeedsUpdate(a, b *cmapi.Certificate) bool {
 		return true
 	}
 
+	if a.Spec.IssuerRef.Group != b.Spec.IssuerRef.Group {
+		return true
+	}
+
+	// fields which may be configured using Ingress annotations
+	if !reflect.DeepEqual(a.Spec.Duration, b.Spec.Duration) ||
+		!reflect.DeepEqual(a.Spec.RenewBefore, b.Spec.RenewBefore) ||
+		!reflect.DeepEqual(a.Spec.Usages, b.Spec.Usages) ||
+		a.Spec.KeyAlgorithm != b.Spec.KeyAlgorithm ||
+		a.Spec.KeyEncoding != b.Spec.KeyEncoding ||
+		a.Spec.KeySize != b.Spec.KeySize ||
+		!reflect.DeepEqual(a.Spec.PrivateKey, b.Spec.PrivateKey) ||
+		!reflect.DeepEqual(a.Spec.Organization, b.Spec.Organization) ||
+		!reflect.DeepEqual(a.Spec.Subject, b.Spec.Subject) {
+		return true
+	}
+
 	return false
 }
 
-func (c *controller) setIssuerSpecificConfig(crt *cmapi.Certificate, ing *extv1beta1.Ingress, tls extv1beta1.IngressTLS) error {
+func (c *controller) setIssuerSpecificConfig(crt *cmapi.Certificate, ing *networkingv1beta1.Ingress, tls networkingv1beta1.IngressTLS) error {
 	ingAnnotations := ing.Annotations
 	if ingAnnotations == nil {
 		ingAnnotations = map[string]string{}
@@ -302,12 +336,22 @@ func (c *controller) setIssuerSpecificConfig(crt *cmapi.Certificate, ing *extv1b
 		crt.Annotations[cmacme.ACMECertificateHTTP01IngressClassOverride] = ingressClassVal
 	}
 
+	// if the Ingress selects its IngressClass using spec.ingressClassName,
+	// solve challenges using the same IngressClass unless the HTTP01 solver
+	// configuration has been explicitly overridden above
+	if ing.Spec.IngressClassName != nil && !editInPlace && !hasIngressClassVal {
+		if crt.Annotations == nil {
+			crt.Annotations = make(map[string]string)
+		}
+		crt.Annotations[cmacme.ACMECertificateHTTP01IngressClassNameOverride] = *ing.Spec.IngressClassName
+	}
+
 	return nil
 }
 
 // shouldSync returns true if this ingress should have a Certificate resource
 // created for it
-func shouldSync(ing *extv1beta1.Ingress, autoCertificateAnnotations []string) bool {
+func shouldSync(ing *networkingv1beta1.Ingress, autoCertificateAnnotations []string) bool {
 	annotations := ing.Annotations
 	if annotations == nil {
 		annotations = map[string]string{}
@@ -331,7 +375,7 @@ func shouldSync(ing *extv1beta1.Ingress, autoCertificateAnnotations []string) bo
 // issuerForIngress will determine the issuer that should be specified on a
 // Certificate created for the given Ingress resource. If one is not set, the
 // default issuer given to the controller will be used.
-func (c *controller) issuerForIngress(ing *extv1beta1.Ingress) (name, kind, group string, err error) {
+func (c *controller) issuerForIngress(ing *networkingv1beta1.Ingress) (name, kind, group string, err error) {
 	var errs []string
 
 	name = c.defaults.issuerName
@@ -21,12 +21,14 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
-	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
@@ -85,7 +87,7 @@ func TestSync(t *testing.T) {
 		gen.SetIssuerACME(cmacme.ACMEIssuer{}))
 	type testT struct {
 		Name                string
-		Ingress             *extv1beta1.Ingress
+		Ingress             *networkingv1beta1.Ingress
 		Issuer              cmapi.GenericIssuer
 		IssuerLister        []runtime.Object
 		ClusterIssuerLister []runtime.Object
@@ -103,7 +105,7 @@ func TestSync(t *testing.T) {
 		{
 			Name:   "return a single HTTP01 Certificate for an ingress with a single valid TLS entry and HTTP01 annotations using edit-in-place",
 			Issuer: acmeClusterIssuer,
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -116,8 +118,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com", "www.example.com"},
 							SecretName: "example-com-tls",
@@ -155,7 +157,7 @@ func TestSync(t *testing.T) {
 		{
 			Name:   "create a Certificate with the HTTP01 name override if the given ingress uses http01 annotations",
 			Issuer: gen.Issuer(acmeIssuer.Name),
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -168,8 +170,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com", "www.example.com"},
 							SecretName: "example-com-tls",
@@ -207,7 +209,7 @@ func TestSync(t *testing.T) {
 		{
 			Name:   "return a single HTTP01 Certificate for an ingress with a single valid TLS entry and HTTP01 annotations with no ingress class set",
 			Issuer: acmeClusterIssuer,
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -216,8 +218,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com", "www.example.com"},
 							SecretName: "example-com-tls",
@@ -248,7 +250,7 @@ func TestSync(t *testing.T) {
 		{
 			Name:   "return a single HTTP01 Certificate for an ingress with a single valid TLS entry and HTTP01 annotations with a custom ingress class",
 			Issuer: acmeClusterIssuer,
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -258,8 +260,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com", "www.example.com"},
 							SecretName: "example-com-tls",
@@ -290,7 +292,7 @@ func TestSync(t *testing.T) {
 		{
 			Name:   "return a single HTTP01 Certificate for an ingress with a single valid TLS entry and HTTP01 annotations with a certificate ingress class",
 			Issuer: acmeClusterIssuer,
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -301,8 +303,99 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
+						{
+							Hosts:      []string{"example.com", "www.example.com"},
+							SecretName: "example-com-tls",
+						},
+					},
+				},
+			},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedEvents:      []string{`Normal CreateCertificate Successfully created Certificate "example-com-tls"`},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildOwnerReferences("ingress-name", gen.DefaultTestNamespace),
+						Annotations: map[string]string{
+							cmacme.ACMECertificateHTTP01IngressClassOverride: "cert-ing",
+						},
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com", "www.example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:   "return a single HTTP01 Certificate using the ingress class name for an ingress with spec.ingressClassName set",
+			Issuer: acmeClusterIssuer,
+			Ingress: &networkingv1beta1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1beta1.IngressSpec{
+					IngressClassName: pointer.StringPtr("nginx"),
+					TLS: []networkingv1beta1.IngressTLS{
+						{
+							Hosts:      []string{"example.com", "www.example.com"},
+							SecretName: "example-com-tls",
+						},
+					},
+				},
+			},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedEvents:      []string{`Normal CreateCertificate Successfully created Certificate "example-com-tls"`},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildOwnerReferences("ingress-name", gen.DefaultTestNamespace),
+						Annotations: map[string]string{
+							cmacme.ACMECertificateHTTP01IngressClassNameOverride: "nginx",
+						},
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com", "www.example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:   "the certificate ingress class annotation should take precedence over spec.ingressClassName",
+			Issuer: acmeClusterIssuer,
+			Ingress: &networkingv1beta1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey:            "issuer-name",
+						cmapi.IngressACMEIssuerHTTP01IngressClassAnnotationKey: "cert-ing",
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1beta1.IngressSpec{
+					IngressClassName: pointer.StringPtr("nginx"),
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com", "www.example.com"},
 							SecretName: "example-com-tls",
@@ -336,7 +429,7 @@ func TestSync(t *testing.T) {
 		{
 			Name:   "edit-in-place set to false should not trigger editing the ingress in-place",
 			Issuer: acmeClusterIssuer,
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -347,8 +440,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com", "www.example.com"},
 							SecretName: "example-com-tls",
@@ -380,7 +473,7 @@ func TestSync(t *testing.T) {
 			Name:   "return a single DNS01 Certificate for an ingress with a single valid TLS entry",
 			Issuer: acmeClusterIssuer,
 			Err:    true,
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -389,8 +482,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com", "www.example.com"},
 							SecretName: "example-com-tls",
@@ -425,7 +518,7 @@ func TestSync(t *testing.T) {
 			DefaultIssuerKind:   "ClusterIssuer",
 			DefaultIssuerGroup:  "cert-manager.io",
 			ClusterIssuerLister: []runtime.Object{clusterIssuer},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -434,8 +527,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com", "www.example.com"},
 							SecretName: "example-com-tls",
@@ -463,13 +556,168 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:                "should configure the Certificate using annotations set on the ingress",
+			Issuer:              clusterIssuer,
+			ClusterIssuerLister: []runtime.Object{clusterIssuer},
+			Ingress: &networkingv1beta1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey:   "issuer-name",
+						cmapi.CommonNameAnnotationKey:                 "example.com",
+						cmapi.DurationAnnotationKey:                   "168h",
+						cmapi.RenewBeforeAnnotationKey:                "24h",
+						cmapi.UsagesAnnotationKey:                     "server auth, client auth",
+						cmapi.PrivateKeyAlgorithmAnnotationKey:        "ecdsa",
+						cmapi.PrivateKeyEncodingAnnotationKey:         "pkcs8",
+						cmapi.PrivateKeySizeAnnotationKey:             "384",
+						cmapi.PrivateKeyRotationPolicyAnnotationKey:   "Always",
+						cmapi.SubjectOrganizationsAnnotationKey:       "Example Org",
+						cmapi.SubjectOrganizationalUnitsAnnotationKey: "Team A,Team B",
+						cmapi.SubjectCountriesAnnotationKey:           "GB",
+						cmapi.SubjectSerialNumberAnnotationKey:        "1234",
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
+						{
+							Hosts:      []string{"example.com", "www.example.com"},
+							SecretName: "example-com-tls",
+						},
+					},
+				},
+			},
+			ExpectedEvents: []string{`Normal CreateCertificate Successfully created Certificate "example-com-tls"`},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildOwnerReferences("ingress-name", gen.DefaultTestNamespace),
+					},
+					Spec: cmapi.CertificateSpec{
+						CommonName:   "example.com",
+						DNSNames:     []string{"example.com", "www.example.com"},
+						SecretName:   "example-com-tls",
+						Duration:     &metav1.Duration{Duration: time.Hour * 168},
+						RenewBefore:  &metav1.Duration{Duration: time.Hour * 24},
+						Usages:       []cmapi.KeyUsage{cmapi.UsageServerAuth, cmapi.UsageClientAuth},
+						KeyAlgorithm: cmapi.ECDSAKeyAlgorithm,
+						KeyEncoding:  cmapi.PKCS8,
+						KeySize:      384,
+						PrivateKey: &cmapi.CertificatePrivateKey{
+							RotationPolicy: cmapi.RotationPolicyAlways,
+						},
+						Organization: []string{"Example Org"},
+						Subject: &cmapi.X509Subject{
+							OrganizationalUnits: []string{"Team A", "Team B"},
+							Countries:           []string{"GB"},
+							SerialNumber:        "1234",
+						},
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:                "should update an existing Certificate if annotations on the ingress change",
+			Issuer:              clusterIssuer,
+			ClusterIssuerLister: []runtime.Object{clusterIssuer},
+			Ingress: &networkingv1beta1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+						cmapi.DurationAnnotationKey:                 "720h",
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
+						{
+							Hosts:      []string{"example.com"},
+							SecretName: "existing-crt",
+						},
+					},
+				},
+			},
+			CertificateLister: []runtime.Object{
+				&cmapi.Certificate{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "existing-crt",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildOwnerReferences("ingress-name", gen.DefaultTestNamespace),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "existing-crt",
+						Duration:   &metav1.Duration{Duration: time.Hour * 168},
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+					},
+				},
+			},
+			ExpectedEvents: []string{`Normal UpdateCertificate Successfully updated Certificate "existing-crt"`},
+			ExpectedUpdate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "existing-crt",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildOwnerReferences("ingress-name", gen.DefaultTestNamespace),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "existing-crt",
+						Duration:   &metav1.Duration{Duration: time.Hour * 720},
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:                "should record an event if an annotation on the ingress is invalid",
+			Issuer:              clusterIssuer,
+			ClusterIssuerLister: []runtime.Object{clusterIssuer},
+			ExpectedEvents:      []string{`Warning BadConfig cert-manager.io/duration annotation has invalid value "forever": time: invalid duration "forever"`},
+			Ingress: &networkingv1beta1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+						cmapi.DurationAnnotationKey:                 "forever",
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
+						{
+							Hosts:      []string{"example.com"},
+							SecretName: "example-com-tls",
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:           "should return an error when no TLS hosts are specified",
 			Issuer:         acmeIssuer,
 			IssuerLister:   []runtime.Object{acmeIssuer},
 			Err:            true,
 			ExpectedEvents: []string{`Warning BadConfig Secret "example-com-tls" for ingress TLS has no hosts specified`},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -478,8 +726,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							SecretName: "example-com-tls",
 						},
@@ -492,7 +740,7 @@ func TestSync(t *testing.T) {
 			Issuer:         acmeIssuer,
 			Err:            true,
 			ExpectedEvents: []string{`Warning BadConfig TLS entry 0 for hosts [example.com] must specify a secretName`},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -501,8 +749,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts: []string{"example.com"},
 						},
@@ -514,7 +762,7 @@ func TestSync(t *testing.T) {
 		{
 			Name: "should error if the specified issuer is not found",
 			Err:  true,
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -529,7 +777,7 @@ func TestSync(t *testing.T) {
 			Name:         "should not return any certificates if a correct Certificate already exists",
 			Issuer:       acmeIssuer,
 			IssuerLister: []runtime.Object{acmeIssuer},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -538,8 +786,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com"},
 							SecretName: "existing-crt",
@@ -572,7 +820,7 @@ func TestSync(t *testing.T) {
 			Name:         "should update a certificate if an incorrect Certificate exists",
 			Issuer:       acmeIssuer,
 			IssuerLister: []runtime.Object{acmeIssuer},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -581,8 +829,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com"},
 							SecretName: "existing-crt",
@@ -616,11 +864,75 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:         "should update a Certificate if the issuer group annotation on the ingress changes",
+			Issuer:       acmeIssuer,
+			IssuerLister: []runtime.Object{acmeIssuer},
+			Ingress: &networkingv1beta1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressIssuerNameAnnotationKey: "issuer-name",
+						cmapi.IssuerKindAnnotationKey:        "Issuer",
+						cmapi.IssuerGroupAnnotationKey:       "external.issuer.io",
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
+						{
+							Hosts:      []string{"example.com"},
+							SecretName: "existing-crt",
+						},
+					},
+				},
+			},
+			DefaultIssuerKind:  "Issuer",
+			DefaultIssuerGroup: "cert-manager.io",
+			CertificateLister: []runtime.Object{
+				&cmapi.Certificate{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "existing-crt",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildOwnerReferences("ingress-name", gen.DefaultTestNamespace),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "existing-crt",
+						IssuerRef: cmmeta.ObjectReference{
+							Name:  "issuer-name",
+							Kind:  "Issuer",
+							Group: "cert-manager.io",
+						},
+					},
+				},
+			},
+			ExpectedEvents: []string{`Normal UpdateCertificate Successfully updated Certificate "existing-crt"`},
+			ExpectedUpdate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "existing-crt",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildOwnerReferences("ingress-name", gen.DefaultTestNamespace),
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com"},
+						SecretName: "existing-crt",
+						IssuerRef: cmmeta.ObjectReference{
+							Name:  "issuer-name",
+							Kind:  "Issuer",
+							Group: "external.issuer.io",
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:         "should update an existing Certificate resource with new labels if they do not match those specified on the Ingress",
 			Issuer:       acmeIssuer,
 			IssuerLister: []runtime.Object{acmeIssuerNewFormat},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -632,8 +944,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com"},
 							SecretName: "cert-secret-name",
@@ -688,7 +1000,7 @@ func TestSync(t *testing.T) {
 			Name:         "should not update certificate if it does not belong to any ingress",
 			Issuer:       acmeIssuer,
 			IssuerLister: []runtime.Object{acmeIssuer},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -698,8 +1010,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com"},
 							SecretName: "existing-crt",
@@ -729,7 +1041,7 @@ func TestSync(t *testing.T) {
 			Name:         "should not update certificate if it does not belong to the ingress",
 			Issuer:       acmeIssuer,
 			IssuerLister: []runtime.Object{acmeIssuer},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -739,8 +1051,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com"},
 							SecretName: "existing-crt",
@@ -770,7 +1082,7 @@ func TestSync(t *testing.T) {
 			Name:         "should delete a Certificate if its SecretName is not present in the ingress",
 			Issuer:       acmeIssuer,
 			IssuerLister: []runtime.Object{acmeIssuer},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -820,7 +1132,7 @@ func TestSync(t *testing.T) {
 			Name:         "should update a Certificate if is contains a Common Name that is not defined on the ingress annotations",
 			Issuer:       acmeIssuer,
 			IssuerLister: []runtime.Object{acmeIssuer},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -831,8 +1143,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com"},
 							SecretName: "example-com-tls",
@@ -886,7 +1198,7 @@ func TestSync(t *testing.T) {
 			ExpectedEvents: []string{
 				`Warning BadConfig Duplicate TLS entry for secretName "example-com-tls"`,
 			},
-			Ingress: &extv1beta1.Ingress{
+			Ingress: &networkingv1beta1.Ingress{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "ingress-name",
 					Namespace: gen.DefaultTestNamespace,
@@ -897,8 +1209,8 @@ func TestSync(t *testing.T) {
 					},
 					UID: types.UID("ingress-name"),
 				},
-				Spec: extv1beta1.IngressSpec{
-					TLS: []extv1beta1.IngressTLS{
+				Spec: networkingv1beta1.IngressSpec{
+					TLS: []networkingv1beta1.IngressTLS{
 						{
 							Hosts:      []string{"example.com"},
 							SecretName: "example-com-tls",
@@ -954,6 +1266,7 @@ func TestSync(t *testing.T) {
 			b.Init()
 			defer b.Stop()
 			c := &controller{
+				ingressGVK:          ingressGVK,
 				kClient:             b.Client,
 				cmClient:            b.CMClient,
 				recorder:            b.Recorder,
@@ -1004,7 +1317,7 @@ func (f *fakeHelper) GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (cm
 
 func TestIssuerForIngress(t *testing.T) {
 	type testT struct {
-		Ingress       *extv1beta1.Ingress
+		Ingress       *networkingv1beta1.Ingress
 		DefaultName   string
 		DefaultKind   string
 		DefaultGroup  string
@@ -1119,8 +1432,10 @@ func buildACMEIssuer(name, namespace string) *cmapi.Issuer {
 	}
 }
 
-func buildIngress(name, namespace string, annotations map[string]string) *extv1beta1.Ingress {
-	return &extv1beta1.Ingress{
+var ingressGVK = networkingv1beta1.SchemeGroupVersion.WithKind("Ingress")
+
+func buildIngress(name, namespace string, annotations map[string]string) *networkingv1beta1.Ingress {
+	return &networkingv1beta1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   namespace,
@@ -56,6 +56,10 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1alpha2.Issuer
 				affected = append(affected, iss)
 				continue
 			}
+			if iss.Spec.CA.PKCS11 != nil && iss.Spec.CA.PKCS11.PINSecretRef.Name == secret.Name {
+				affected = append(affected, iss)
+				continue
+			}
 		case iss.Spec.Venafi != nil:
 			if iss.Spec.Venafi.TPP != nil {
 				if iss.Spec.Venafi.TPP.CredentialsRef.Name == secret.Name {
@@ -88,6 +92,12 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1alpha2.Issuer
 					continue
 				}
 			}
+			if iss.Spec.Vault.ClientCertSecretRef != nil {
+				if iss.Spec.Vault.ClientCertSecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		}
 	}
 
@@ -27,11 +27,14 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/vault/tokencache"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/metrics"
 )
 
 type controller struct {
@@ -54,6 +57,13 @@ type controller struct {
 	// issuerFactory is used to obtain a reference to the Issuer implementation
 	// for each ClusterIssuer resource
 	issuerFactory issuer.Factory
+
+	// used to expose the status of issuers as metrics
+	metrics *metrics.Metrics
+
+	// vaultTokenCache holds the Vault tokens of issuers, which are removed
+	// once an issuer has been deleted
+	vaultTokenCache *tokencache.Cache
 }
 
 // Register registers and constructs the controller using the provided context.
@@ -88,6 +98,8 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.issuerFactory = issuer.NewFactory(ctx)
 	c.cmClient = ctx.CMClient
 	c.recorder = ctx.Recorder
+	c.metrics = ctx.Metrics
+	c.vaultTokenCache = ctx.IssuerOptions.VaultTokenCache
 
 	return c.queue, mustSync, nil
 }
@@ -131,6 +143,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Error(err, "issuer in work queue no longer exists")
+			c.metrics.RemoveIssuer(v1alpha2.IssuerKind, namespace, name)
+			if c.vaultTokenCache != nil {
+				c.vaultTokenCache.Remove(namespace, name)
+			}
 			return nil
 		}
 
@@ -138,7 +154,14 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	ctx = logf.NewContext(ctx, logf.WithResource(log, issuer))
-	return c.Sync(ctx, issuer)
+	if err := c.Sync(ctx, issuer); err != nil {
+		return err
+	}
+
+	// Set up the issuer again later so that its Ready condition reflects
+	// the current state of its backend and CA certificate
+	c.queue.AddAfter(key, issuerRecheckInterval)
+	return nil
 }
 
 var keyFunc = controllerpkg.KeyFunc
@@ -0,0 +1,214 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controller implements the route-shim controller, which creates
+// Certificates for OpenShift Routes and copies the issued certificates into
+// the Route's TLS configuration.
+// Routes are accessed using the dynamic client so that cert-manager does not
+// depend on the OpenShift API types.
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	clientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1alpha2"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	ControllerName = "route-shim"
+)
+
+var (
+	routeGVR = schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"}
+	routeGVK = schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+)
+
+type controller struct {
+	// maintain a reference to the workqueue for this controller
+	// so the handleOwnedResource method can enqueue resources
+	queue workqueue.RateLimitingInterface
+
+	// logger to be used by this controller
+	log logr.Logger
+
+	routeClient dynamic.NamespaceableResourceInterface
+	cmClient    clientset.Interface
+	recorder    record.EventRecorder
+
+	routeLister       cache.GenericLister
+	certificateLister cmlisters.CertificateLister
+	secretLister      corelisters.SecretLister
+}
+
+// Register registers and constructs the controller using the provided context.
+// It returns the workqueue to be used to enqueue items, a list of
+// InformerSynced functions that must be synced, or an error.
+func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	c.log = logf.FromContext(ctx.RootContext, ControllerName)
+
+	// create a queue used to queue up items to be processed
+	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+
+	if err := checkRoutesServed(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	// Routes are not a part of any of the shared informer factories, so a
+	// dynamic informer factory is created and started by this controller.
+	routeInformerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(ctx.DynamicClient, time.Second*30, ctx.Namespace, nil)
+
+	// obtain references to all the informers used by this controller
+	routeInformer := routeInformerFactory.ForResource(routeGVR)
+	certificatesInformer := ctx.SharedInformerFactory.Certmanager().V1alpha2().Certificates()
+	secretsInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		routeInformer.Informer().HasSynced,
+		certificatesInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	// set all the references to the listers for used by the Sync function
+	c.routeLister = routeInformer.Lister()
+	c.certificateLister = certificatesInformer.Lister()
+	c.secretLister = secretsInformer.Lister()
+
+	// register handler functions
+	routeInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	certificatesInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.certificateChanged})
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.secretChanged})
+
+	c.routeClient = ctx.DynamicClient.Resource(routeGVR)
+	c.cmClient = ctx.CMClient
+	c.recorder = ctx.Recorder
+
+	routeInformerFactory.Start(ctx.StopCh)
+
+	return c.queue, mustSync, nil
+}
+
+// checkRoutesServed returns an error if the apiserver does not serve
+// route.openshift.io/v1 Routes.
+func checkRoutesServed(ctx *controllerpkg.Context) error {
+	resources, err := ctx.Client.Discovery().ServerResourcesForGroupVersion(routeGVR.GroupVersion().String())
+	if err != nil {
+		return fmt.Errorf("error discovering %s resources, the %s controller can only be used with OpenShift: %v",
+			routeGVR.GroupVersion(), ControllerName, err)
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == routeGVR.Resource {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s Routes are not served by the apiserver, the %s controller can only be used with OpenShift",
+		routeGVR.GroupVersion(), ControllerName)
+}
+
+// certificateChanged enqueues the Route that owns the given Certificate, if
+// any.
+func (c *controller) certificateChanged(obj interface{}) {
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Object is not a certificate object %#v", obj))
+		return
+	}
+	c.enqueueOwningRoute(crt)
+}
+
+// secretChanged enqueues the Routes that own Certificates which use the
+// given Secret, so that updated certificates are copied into the Route.
+func (c *controller) secretChanged(obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Object is not a secret object %#v", obj))
+		return
+	}
+	crts, err := c.certificateLister.Certificates(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("Error listing certificates: %v", err))
+		return
+	}
+	for _, crt := range crts {
+		if crt.Spec.SecretName == secret.Name {
+			c.enqueueOwningRoute(crt)
+		}
+	}
+}
+
+func (c *controller) enqueueOwningRoute(crt *cmapi.Certificate) {
+	ref := metav1.GetControllerOf(crt)
+	if ref == nil || ref.Kind != routeGVK.Kind || ref.APIVersion != routeGVK.GroupVersion().String() {
+		return
+	}
+	c.queue.Add(crt.Namespace + "/" + ref.Name)
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	obj, err := c.routeLister.ByNamespace(namespace).Get(name)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			runtime.HandleError(fmt.Errorf("route '%s' in work queue no longer exists", key))
+			return nil
+		}
+
+		return err
+	}
+
+	route, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		runtime.HandleError(fmt.Errorf("Object is not an unstructured object %#v", obj))
+		return nil
+	}
+
+	return c.Sync(ctx, route)
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controller{}).
+			Complete()
+	})
+}
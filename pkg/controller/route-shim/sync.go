@@ -0,0 +1,265 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	// defaultTermination is the TLS termination type set on Routes which do
+	// not already have a TLS configuration.
+	defaultTermination = "edge"
+)
+
+func (c *controller) Sync(ctx context.Context, route *unstructured.Unstructured) error {
+	log := logs.WithResource(logs.FromContext(ctx), route)
+	ctx = logs.NewContext(ctx, log)
+
+	if !shouldSync(route) {
+		log.V(logs.DebugLevel).Info(fmt.Sprintf("not syncing route resource as it does not contain a %q or %q annotation",
+			cmapi.IngressIssuerNameAnnotationKey, cmapi.IngressClusterIssuerNameAnnotationKey))
+		return nil
+	}
+
+	issuerRef, err := issuerForRoute(route)
+	if err != nil {
+		log.Error(err, "failed to determine issuer to be used for route resource")
+		c.recorder.Eventf(route, corev1.EventTypeWarning, "BadConfig", "Could not determine issuer for route due to bad annotations: %s",
+			err)
+		return nil
+	}
+
+	host, _, err := unstructured.NestedString(route.Object, "spec", "host")
+	if err != nil || host == "" {
+		c.recorder.Event(route, corev1.EventTypeWarning, "BadConfig", "Route must specify spec.host to request a certificate")
+		return nil
+	}
+
+	crt, err := c.ensureCertificate(ctx, route, host, issuerRef)
+	if err != nil {
+		return err
+	}
+	if crt == nil {
+		return nil
+	}
+
+	return c.syncRouteTLS(ctx, route, crt.Spec.SecretName)
+}
+
+// ensureCertificate ensures a Certificate owned by the route exists for the
+// given host. It returns nil if a Certificate with the same name exists that
+// is not owned by the Route.
+func (c *controller) ensureCertificate(ctx context.Context, route *unstructured.Unstructured, host string, issuerRef cmmeta.ObjectReference) (*cmapi.Certificate, error) {
+	log := logs.FromContext(ctx)
+
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            route.GetName(),
+			Namespace:       route.GetNamespace(),
+			Labels:          route.GetLabels(),
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(route, routeGVK)},
+		},
+		Spec: cmapi.CertificateSpec{
+			DNSNames:   []string{host},
+			SecretName: route.GetName() + "-tls",
+			IssuerRef:  issuerRef,
+		},
+	}
+
+	existingCrt, err := c.certificateLister.Certificates(crt.Namespace).Get(crt.Name)
+	if apierrors.IsNotFound(err) {
+		crt, err = c.cmClient.CertmanagerV1alpha2().Certificates(crt.Namespace).Create(context.TODO(), crt, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		c.recorder.Eventf(route, corev1.EventTypeNormal, "CreateCertificate", "Successfully created Certificate %q", crt.Name)
+		return crt, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log = logs.WithRelatedResource(log, existingCrt)
+	if !metav1.IsControlledBy(existingCrt, route) {
+		log.Info("certificate resource is not owned by this route. refusing to update non-owned certificate resource for route")
+		return nil, nil
+	}
+
+	if !certNeedsUpdate(existingCrt, crt) {
+		return existingCrt, nil
+	}
+
+	updateCrt := existingCrt.DeepCopy()
+	updateCrt.Labels = crt.Labels
+	updateCrt.Spec.DNSNames = crt.Spec.DNSNames
+	updateCrt.Spec.SecretName = crt.Spec.SecretName
+	updateCrt.Spec.IssuerRef = crt.Spec.IssuerRef
+	updateCrt, err = c.cmClient.CertmanagerV1alpha2().Certificates(updateCrt.Namespace).Update(context.TODO(), updateCrt, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	c.recorder.Eventf(route, corev1.EventTypeNormal, "UpdateCertificate", "Successfully updated Certificate %q", updateCrt.Name)
+
+	return updateCrt, nil
+}
+
+// syncRouteTLS copies the certificate, private key and CA stored in the
+// named Secret into the Route's spec.tls, if they have changed.
+func (c *controller) syncRouteTLS(ctx context.Context, route *unstructured.Unstructured, secretName string) error {
+	log := logs.FromContext(ctx)
+
+	secret, err := c.secretLister.Secrets(route.GetNamespace()).Get(secretName)
+	if apierrors.IsNotFound(err) {
+		log.V(logs.DebugLevel).Info("certificate secret does not exist yet, waiting for it to be issued", "secret", secretName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	certData := secret.Data[corev1.TLSCertKey]
+	keyData := secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certData) == 0 || len(keyData) == 0 {
+		log.V(logs.DebugLevel).Info("certificate secret does not contain a certificate and private key yet", "secret", secretName)
+		return nil
+	}
+
+	tls, _, err := unstructured.NestedMap(route.Object, "spec", "tls")
+	if err != nil {
+		return err
+	}
+	existing := tls
+	tls = make(map[string]interface{}, len(existing)+3)
+	for k, v := range existing {
+		tls[k] = v
+	}
+	if _, ok := tls["termination"]; !ok {
+		tls["termination"] = defaultTermination
+	}
+	tls["certificate"] = string(certData)
+	tls["key"] = string(keyData)
+	if caData := secret.Data[cmmeta.TLSCAKey]; len(caData) > 0 {
+		tls["caCertificate"] = string(caData)
+	} else {
+		delete(tls, "caCertificate")
+	}
+
+	if reflect.DeepEqual(existing, tls) {
+		return nil
+	}
+
+	updated := route.DeepCopy()
+	if err := unstructured.SetNestedMap(updated.Object, tls, "spec", "tls"); err != nil {
+		return err
+	}
+	_, err = c.routeClient.Namespace(updated.GetNamespace()).Update(context.TODO(), updated, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	c.recorder.Eventf(route, corev1.EventTypeNormal, "UpdateRoute", "Successfully updated Route TLS configuration from Secret %q", secretName)
+
+	return nil
+}
+
+// certNeedsUpdate returns true if the fields managed by this controller
+// differ between the two Certificates.
+func certNeedsUpdate(a, b *cmapi.Certificate) bool {
+	return !reflect.DeepEqual(a.Labels, b.Labels) ||
+		!reflect.DeepEqual(a.Spec.DNSNames, b.Spec.DNSNames) ||
+		a.Spec.SecretName != b.Spec.SecretName ||
+		a.Spec.IssuerRef != b.Spec.IssuerRef
+}
+
+// shouldSync returns true if this route should have a Certificate resource
+// created for it
+func shouldSync(route *unstructured.Unstructured) bool {
+	annotations := route.GetAnnotations()
+	if _, ok := annotations[cmapi.IngressIssuerNameAnnotationKey]; ok {
+		return true
+	}
+	if _, ok := annotations[cmapi.IngressClusterIssuerNameAnnotationKey]; ok {
+		return true
+	}
+	return false
+}
+
+// issuerForRoute determines the issuer that should be specified on a
+// Certificate created for the given Route, using the same annotations as
+// ingress-shim.
+func issuerForRoute(route *unstructured.Unstructured) (cmmeta.ObjectReference, error) {
+	var errs []string
+	var ref cmmeta.ObjectReference
+	annotations := route.GetAnnotations()
+
+	issuerName, issuerNameOK := annotations[cmapi.IngressIssuerNameAnnotationKey]
+	if issuerNameOK {
+		ref.Name = issuerName
+		ref.Kind = cmapi.IssuerKind
+	}
+
+	clusterIssuerName, clusterIssuerNameOK := annotations[cmapi.IngressClusterIssuerNameAnnotationKey]
+	if clusterIssuerNameOK {
+		ref.Name = clusterIssuerName
+		ref.Kind = cmapi.ClusterIssuerKind
+	}
+
+	kindName, kindNameOK := annotations[cmapi.IssuerKindAnnotationKey]
+	if kindNameOK {
+		ref.Kind = kindName
+	}
+
+	groupName, groupNameOK := annotations[cmapi.IssuerGroupAnnotationKey]
+	if groupNameOK {
+		ref.Group = groupName
+	}
+
+	if len(ref.Name) == 0 {
+		errs = append(errs, "failed to determine issuer name to be used for route resource")
+	}
+
+	if issuerNameOK && clusterIssuerNameOK {
+		errs = append(errs,
+			fmt.Sprintf("both %q and %q may not be set",
+				cmapi.IngressIssuerNameAnnotationKey, cmapi.IngressClusterIssuerNameAnnotationKey))
+	}
+
+	if clusterIssuerNameOK && (groupNameOK || kindNameOK) {
+		errs = append(errs,
+			fmt.Sprintf("%q may not be set together with %q or %q",
+				cmapi.IngressClusterIssuerNameAnnotationKey, cmapi.IssuerKindAnnotationKey, cmapi.IssuerGroupAnnotationKey))
+	}
+
+	if len(errs) > 0 {
+		return ref, errors.New(strings.Join(errs, ", "))
+	}
+
+	return ref, nil
+}
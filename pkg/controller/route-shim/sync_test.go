@@ -0,0 +1,257 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	coretesting "k8s.io/client-go/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestSync(t *testing.T) {
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "route-name-tls",
+			Namespace: gen.DefaultTestNamespace,
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+			cmmeta.TLSCAKey:         []byte("ca"),
+		},
+	}
+
+	type testT struct {
+		route              *unstructured.Unstructured
+		kubeObjects        []runtime.Object
+		certificates       []runtime.Object
+		expectedCreate     *cmapi.Certificate
+		expectedUpdate     *cmapi.Certificate
+		expectedEvents     []string
+		expectedRouteTLS   map[string]interface{}
+		expectRouteUpdated bool
+	}
+	tests := map[string]testT{
+		"should not sync a route without issuer annotations": {
+			route: buildRoute("route-name", "example.com", nil, nil),
+		},
+		"should record an event if the route has no host": {
+			route: buildRoute("route-name", "", map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey: "issuer-name",
+			}, nil),
+			expectedEvents: []string{`Warning BadConfig Route must specify spec.host to request a certificate`},
+		},
+		"should record an event if the issuer annotations are invalid": {
+			route: buildRoute("route-name", "example.com", map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey:        "issuer-name",
+				cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+			}, nil),
+			expectedEvents: []string{`Warning BadConfig Could not determine issuer for route due to bad annotations: both "cert-manager.io/issuer" and "cert-manager.io/cluster-issuer" may not be set`},
+		},
+		"should create a Certificate for the route host": {
+			route: buildRoute("route-name", "example.com", map[string]string{
+				cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+			}, nil),
+			expectedCreate: buildCertificate("example.com", cmmeta.ObjectReference{Name: "issuer-name", Kind: "ClusterIssuer"}),
+			expectedEvents: []string{`Normal CreateCertificate Successfully created Certificate "route-name"`},
+		},
+		"should update a Certificate if the route host changes": {
+			route: buildRoute("route-name", "new.example.com", map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey: "issuer-name",
+			}, nil),
+			certificates:   []runtime.Object{buildCertificate("example.com", cmmeta.ObjectReference{Name: "issuer-name", Kind: "Issuer"})},
+			expectedUpdate: buildCertificate("new.example.com", cmmeta.ObjectReference{Name: "issuer-name", Kind: "Issuer"}),
+			expectedEvents: []string{`Normal UpdateCertificate Successfully updated Certificate "route-name"`},
+		},
+		"should not modify a Certificate that is not owned by the route": {
+			route: buildRoute("route-name", "example.com", map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey: "issuer-name",
+			}, nil),
+			kubeObjects: []runtime.Object{tlsSecret},
+			certificates: []runtime.Object{
+				&cmapi.Certificate{
+					ObjectMeta: metav1.ObjectMeta{Name: "route-name", Namespace: gen.DefaultTestNamespace},
+				},
+			},
+		},
+		"should copy the issued certificate into the route": {
+			route: buildRoute("route-name", "example.com", map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey: "issuer-name",
+			}, nil),
+			kubeObjects:        []runtime.Object{tlsSecret},
+			certificates:       []runtime.Object{buildCertificate("example.com", cmmeta.ObjectReference{Name: "issuer-name", Kind: "Issuer"})},
+			expectedEvents:     []string{`Normal UpdateRoute Successfully updated Route TLS configuration from Secret "route-name-tls"`},
+			expectRouteUpdated: true,
+			expectedRouteTLS: map[string]interface{}{
+				"termination":   "edge",
+				"certificate":   "cert",
+				"key":           "key",
+				"caCertificate": "ca",
+			},
+		},
+		"should preserve existing route TLS settings": {
+			route: buildRoute("route-name", "example.com", map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey: "issuer-name",
+			}, map[string]interface{}{
+				"termination":                   "reencrypt",
+				"insecureEdgeTerminationPolicy": "Redirect",
+				"certificate":                   "old-cert",
+				"key":                           "old-key",
+			}),
+			kubeObjects:        []runtime.Object{tlsSecret},
+			certificates:       []runtime.Object{buildCertificate("example.com", cmmeta.ObjectReference{Name: "issuer-name", Kind: "Issuer"})},
+			expectedEvents:     []string{`Normal UpdateRoute Successfully updated Route TLS configuration from Secret "route-name-tls"`},
+			expectRouteUpdated: true,
+			expectedRouteTLS: map[string]interface{}{
+				"termination":                   "reencrypt",
+				"insecureEdgeTerminationPolicy": "Redirect",
+				"certificate":                   "cert",
+				"key":                           "key",
+				"caCertificate":                 "ca",
+			},
+		},
+		"should not update the route if the TLS configuration is up to date": {
+			route: buildRoute("route-name", "example.com", map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey: "issuer-name",
+			}, map[string]interface{}{
+				"termination":   "edge",
+				"certificate":   "cert",
+				"key":           "key",
+				"caCertificate": "ca",
+			}),
+			kubeObjects:  []runtime.Object{tlsSecret},
+			certificates: []runtime.Object{buildCertificate("example.com", cmmeta.ObjectReference{Name: "issuer-name", Kind: "Issuer"})},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var expectedActions []testpkg.Action
+			if test.expectedCreate != nil {
+				expectedActions = append(expectedActions, testpkg.NewAction(coretesting.NewCreateAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					gen.DefaultTestNamespace,
+					test.expectedCreate,
+				)))
+			}
+			if test.expectedUpdate != nil {
+				expectedActions = append(expectedActions, testpkg.NewAction(coretesting.NewUpdateAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					gen.DefaultTestNamespace,
+					test.expectedUpdate,
+				)))
+			}
+			b := &testpkg.Builder{
+				T:                  t,
+				KubeObjects:        test.kubeObjects,
+				CertManagerObjects: test.certificates,
+				ExpectedActions:    expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			b.Init()
+			defer b.Stop()
+
+			routeClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), test.route)
+			c := &controller{
+				routeClient:       routeClient.Resource(routeGVR),
+				cmClient:          b.CMClient,
+				recorder:          b.Recorder,
+				certificateLister: b.SharedInformerFactory.Certmanager().V1alpha2().Certificates().Lister(),
+				secretLister:      b.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+			}
+			b.Start()
+
+			if err := c.Sync(context.Background(), test.route); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if err := b.AllEventsCalled(); err != nil {
+				t.Error(err)
+			}
+			if err := b.AllActionsExecuted(); err != nil {
+				t.Error(err)
+			}
+
+			var updated bool
+			for _, a := range routeClient.Actions() {
+				if a.GetVerb() == "update" {
+					updated = true
+				}
+			}
+			if updated != test.expectRouteUpdated {
+				t.Errorf("expected route updated=%t, but got %t", test.expectRouteUpdated, updated)
+			}
+			if !test.expectRouteUpdated {
+				return
+			}
+
+			route, err := routeClient.Resource(routeGVR).Namespace(gen.DefaultTestNamespace).Get(context.TODO(), test.route.GetName(), metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error getting route: %v", err)
+			}
+			tls, _, _ := unstructured.NestedMap(route.Object, "spec", "tls")
+			if !reflect.DeepEqual(tls, test.expectedRouteTLS) {
+				t.Errorf("unexpected route TLS configuration\nexp=%v\ngot=%v", test.expectedRouteTLS, tls)
+			}
+		})
+	}
+}
+
+func buildRoute(name, host string, annotations map[string]string, tls map[string]interface{}) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(routeGVK)
+	route.SetName(name)
+	route.SetNamespace(gen.DefaultTestNamespace)
+	route.SetUID("route-uid")
+	route.SetAnnotations(annotations)
+	spec := map[string]interface{}{}
+	if host != "" {
+		spec["host"] = host
+	}
+	if tls != nil {
+		spec["tls"] = tls
+	}
+	route.Object["spec"] = spec
+	return route
+}
+
+func buildCertificate(host string, issuerRef cmmeta.ObjectReference) *cmapi.Certificate {
+	return &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "route-name",
+			Namespace:       gen.DefaultTestNamespace,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(buildRoute("route-name", host, nil, nil), routeGVK)},
+		},
+		Spec: cmapi.CertificateSpec{
+			DNSNames:   []string{host},
+			SecretName: "route-name-tls",
+			IssuerRef:  issuerRef,
+		},
+	}
+}
@@ -26,7 +26,9 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	kubeinformers "k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	coretesting "k8s.io/client-go/testing"
@@ -50,14 +52,15 @@ func init() {
 }
 
 // Builder is a structure used to construct new Contexts for use during tests.
-// Currently, only KubeObjects and CertManagerObjects can be specified.
-// These will be auto loaded into the constructed fake Clientsets.
+// Currently, only KubeObjects, CertManagerObjects and DynamicObjects can be
+// specified. These will be auto loaded into the constructed fake Clientsets.
 // Call ToContext() to construct a new context using the given values.
 type Builder struct {
 	T *testing.T
 
 	KubeObjects        []runtime.Object
 	CertManagerObjects []runtime.Object
+	DynamicObjects     []runtime.Object
 	ExpectedActions    []Action
 	ExpectedEvents     []string
 	StringGenerator    StringGenerator
@@ -99,6 +102,26 @@ func (b *Builder) generateNameReactor(action coretesting.Action) (handled bool,
 
 const informerResyncPeriod = time.Millisecond * 10
 
+// discoveryResources are the resources recorded by the fake discovery client
+// for discovery requests.
+var discoveryResources = map[schema.GroupVersionResource]bool{
+	{Resource: "group"}:    true,
+	{Resource: "resource"}: true,
+}
+
+// fakeDiscoveryResources are the API resources that will be returned by the
+// discovery client of the fake kubernetes Clientset, for controllers that
+// select API versions using discovery.
+var fakeDiscoveryResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "networking.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{
+			{Name: "ingresses", Namespaced: true, Kind: "Ingress"},
+			{Name: "ingressclasses", Namespaced: false, Kind: "IngressClass"},
+		},
+	},
+}
+
 // Init will construct a new context for this builder and set default values
 // for any unset fields.
 func (b *Builder) Init() {
@@ -113,9 +136,11 @@ func (b *Builder) Init() {
 	b.requiredReactors = make(map[string]bool)
 	b.Client = kubefake.NewSimpleClientset(b.KubeObjects...)
 	b.CMClient = cmfake.NewSimpleClientset(b.CertManagerObjects...)
+	b.DynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), b.DynamicObjects...)
 	b.Recorder = new(FakeRecorder)
 
 	b.FakeKubeClient().PrependReactor("create", "*", b.generateNameReactor)
+	b.FakeKubeClient().Resources = fakeDiscoveryResources
 	b.FakeCMClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.KubeSharedInformerFactory = kubeinformers.NewSharedInformerFactory(b.Client, informerResyncPeriod)
 	b.SharedInformerFactory = informers.NewSharedInformerFactory(b.CMClient, informerResyncPeriod)
@@ -137,6 +162,10 @@ func (b *Builder) FakeKubeClient() *kubefake.Clientset {
 	return b.Context.Client.(*kubefake.Clientset)
 }
 
+func (b *Builder) FakeDynamicClient() *dynamicfake.FakeDynamicClient {
+	return b.Context.DynamicClient.(*dynamicfake.FakeDynamicClient)
+}
+
 func (b *Builder) FakeKubeInformerFactory() kubeinformers.SharedInformerFactory {
 	return b.Context.KubeSharedInformerFactory
 }
@@ -217,6 +246,10 @@ func (b *Builder) AllActionsExecuted() error {
 		if a.GetVerb() == "list" || a.GetVerb() == "watch" {
 			continue
 		}
+		// skip discovery requests made by the fake discovery client
+		if discoveryResources[a.GetResource()] {
+			continue
+		}
 		found := false
 		var err error
 		for i, expA := range missingActions {
@@ -125,6 +125,22 @@ type ACMEChallengeSolverHTTP01 struct {
 	// '/.well-known/acme-challenge/XYZ' to 'challenge solver' pods that are
 	// provisioned by cert-manager for each Challenge to be completed.
 	Ingress *ACMEChallengeSolverHTTP01Ingress
+
+	// The HTTPProxy based HTTP01 challenge solver will solve challenges by
+	// adding a route for '/.well-known/acme-challenge/XYZ' to an existing
+	// Contour HTTPProxy resource, which routes requests to 'challenge solver'
+	// pods that are provisioned by cert-manager for each Challenge to be
+	// completed.
+	// Only one of 'ingress', 'httpProxy' or 'ingressRoute' may be specified.
+	HTTPProxy *ACMEChallengeSolverHTTP01HTTPProxy
+
+	// The IngressRoute based HTTP01 challenge solver will solve challenges by
+	// adding a route for '/.well-known/acme-challenge/XYZ' to an existing
+	// Traefik IngressRoute resource, which routes requests to 'challenge
+	// solver' pods that are provisioned by cert-manager for each Challenge to
+	// be completed.
+	// Only one of 'ingress', 'httpProxy' or 'ingressRoute' may be specified.
+	IngressRoute *ACMEChallengeSolverHTTP01IngressRoute
 }
 
 type ACMEChallengeSolverHTTP01Ingress struct {
@@ -132,10 +148,17 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 	ServiceType corev1.ServiceType
 
 	// The ingress class to use when creating Ingress resources to solve ACME
-	// challenges that use this challenge solver.
-	// Only one of 'class' or 'name' may be specified.
+	// challenges that use this challenge solver. This will be set using the
+	// 'kubernetes.io/ingress.class' annotation.
+	// Only one of 'class', 'ingressClassName' or 'name' may be specified.
 	Class *string
 
+	// The name of the IngressClass resource to use when creating Ingress
+	// resources to solve ACME challenges that use this challenge solver. This
+	// will be set as the 'spec.ingressClassName' field of created Ingresses.
+	// Only one of 'class', 'ingressClassName' or 'name' may be specified.
+	IngressClassName *string
+
 	// The name of the ingress resource that should have ACME challenge solving
 	// routes inserted into it in order to solve HTTP01 challenges.
 	// This is typically used in conjunction with ingress controllers like
@@ -152,6 +175,42 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 	IngressTemplate *ACMEChallengeSolverHTTP01IngressTemplate
 }
 
+// ACMEChallengeSolverHTTP01HTTPProxy configures an existing Contour HTTPProxy
+// resource to route HTTP01 challenge requests to challenge solver pods.
+type ACMEChallengeSolverHTTP01HTTPProxy struct {
+	// The name of the Contour HTTPProxy resource that should have ACME
+	// challenge solving routes inserted into it in order to solve HTTP01
+	// challenges.
+	// The resource must be in the same namespace as the Challenge.
+	// Its 'virtualhost.fqdn' must be the domain being validated.
+	Name string
+
+	// Optional service type for Kubernetes solver service
+	ServiceType corev1.ServiceType
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for HTTP01 challenges
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate
+}
+
+// ACMEChallengeSolverHTTP01IngressRoute configures an existing Traefik IngressRoute
+// resource to route HTTP01 challenge requests to challenge solver pods.
+type ACMEChallengeSolverHTTP01IngressRoute struct {
+	// The name of the Traefik IngressRoute resource that should have ACME
+	// challenge solving routes inserted into it in order to solve HTTP01
+	// challenges.
+	// The resource must be in the same namespace as the Challenge.
+	// Requests are matched using the domain being validated as the 'Host'.
+	Name string
+
+	// Optional service type for Kubernetes solver service
+	ServiceType corev1.ServiceType
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for HTTP01 challenges
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate
+}
+
 type ACMEChallengeSolverHTTP01IngressPodTemplate struct {
 	// ObjectMeta overrides for the pod used to solve HTTP01 challenges.
 	// Only the 'labels' and 'annotations' fields may be set.
@@ -160,8 +219,8 @@ type ACMEChallengeSolverHTTP01IngressPodTemplate struct {
 	ACMEChallengeSolverHTTP01IngressPodObjectMeta
 
 	// PodSpec defines overrides for the HTTP01 challenge solver pod.
-	// Only the 'nodeSelector', 'affinity' and 'tolerations' fields are
-	// supported currently. All other fields will be ignored.
+	// Only the fields listed in ACMEChallengeSolverHTTP01IngressPodSpec are
+	// supported. All other fields will be ignored.
 	Spec ACMEChallengeSolverHTTP01IngressPodSpec
 }
 
@@ -184,6 +243,30 @@ type ACMEChallengeSolverHTTP01IngressPodSpec struct {
 
 	// If specified, the pod's tolerations.
 	Tolerations []corev1.Toleration
+
+	// If specified, the pod's topology spread constraints.
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+
+	// If specified, the pod's priorityClassName.
+	PriorityClassName string
+
+	// If specified, the pod's service account.
+	ServiceAccountName string
+
+	// If specified, the pod's imagePullSecrets.
+	ImagePullSecrets []corev1.LocalObjectReference
+
+	// If specified, the pod's security context.
+	SecurityContext *corev1.PodSecurityContext
+
+	// If specified, the security context of the acmesolver container.
+	ContainerSecurityContext *corev1.SecurityContext
+
+	// Compute resources required by the acmesolver container. Any requests or
+	// limits set here override the defaults configured on the controller.
+	// Requests may not exceed limits set here. If an overridden value
+	// conflicts with a default, the default is adjusted to match it.
+	Resources *corev1.ResourceRequirements
 }
 
 type ACMEChallengeSolverHTTP01IngressTemplate struct {
@@ -224,6 +307,8 @@ type ACMEChallengeSolverDNS01 struct {
 	RFC2136 *ACMEIssuerDNS01ProviderRFC2136
 
 	Webhook *ACMEIssuerDNS01ProviderWebhook
+
+	GRPC *ACMEIssuerDNS01ProviderGRPC
 }
 
 // CNAMEStrategy configures how the DNS01 provider should handle CNAME records
@@ -329,6 +414,15 @@ type ACMEIssuerDNS01ProviderAcmeDNS struct {
 	Host string
 
 	AccountSecret cmmeta.SecretKeySelector
+
+	// AllowRegistration enables automatic registration of acme-dns accounts
+	// for domains that do not have an entry in the accountSecretRef Secret.
+	// Newly registered credentials are stored in a Secret managed by
+	// cert-manager, named after the accountSecretRef Secret with the suffix
+	// '-acme-dns-registrations', and the challenge will not be presented
+	// until a CNAME record pointing to the registered acme-dns subdomain
+	// exists.
+	AllowRegistration bool
 }
 
 // ACMEIssuerDNS01ProviderRFC2136 is a structure containing the
@@ -380,6 +474,39 @@ type ACMEIssuerDNS01ProviderWebhook struct {
 	Config *apiext.JSON
 }
 
+// ACMEIssuerDNS01ProviderGRPC specifies configuration for an out-of-process
+// DNS01 provider that implements the cert-manager solver gRPC service.
+type ACMEIssuerDNS01ProviderGRPC struct {
+	// The address of the solver.
+	// Use the form 'unix:///path/to/socket' to connect over a Unix domain
+	// socket, or 'host:port' to connect over TCP using TLS.
+	Endpoint string
+
+	// The name of the solver to use, as returned by the solver's Initialize
+	// call.
+	SolverName string
+
+	// PEM encoded CA bundle used to verify the solver's serving certificate
+	// when connecting over TCP.
+	// If not set, the system root certificates will be used.
+	CABundle []byte
+
+	// ClientCertSecretRef is a reference to a Secret of type
+	// `kubernetes.io/tls` containing a client certificate and private key
+	// that are presented to the solver when connecting over TCP.
+	// The Secret is read from the Issuer's namespace, or the cluster resource
+	// namespace in the case of a ClusterIssuer.
+	ClientCertSecretRef *cmmeta.LocalObjectReference
+
+	// Additional configuration that should be passed to the solver when
+	// challenges are processed.
+	// This can contain arbitrary JSON data.
+	// Secret values should not be specified in this stanza.
+	// For details on the schema of this field, consult the solver's
+	// documentation.
+	Config *apiext.JSON
+}
+
 type ACMEIssuerStatus struct {
 	// URI is the unique account identifier, which can also be used to retrieve
 	// account details from the CA
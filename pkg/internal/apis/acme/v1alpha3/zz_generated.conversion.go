@@ -91,6 +91,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ACMEChallengeSolverHTTP01HTTPProxy)(nil), (*acme.ACMEChallengeSolverHTTP01HTTPProxy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ACMEChallengeSolverHTTP01HTTPProxy_To_acme_ACMEChallengeSolverHTTP01HTTPProxy(a.(*v1alpha3.ACMEChallengeSolverHTTP01HTTPProxy), b.(*acme.ACMEChallengeSolverHTTP01HTTPProxy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01HTTPProxy)(nil), (*v1alpha3.ACMEChallengeSolverHTTP01HTTPProxy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01HTTPProxy_To_v1alpha3_ACMEChallengeSolverHTTP01HTTPProxy(a.(*acme.ACMEChallengeSolverHTTP01HTTPProxy), b.(*v1alpha3.ACMEChallengeSolverHTTP01HTTPProxy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.ACMEChallengeSolverHTTP01Ingress)(nil), (*acme.ACMEChallengeSolverHTTP01Ingress)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(a.(*v1alpha3.ACMEChallengeSolverHTTP01Ingress), b.(*acme.ACMEChallengeSolverHTTP01Ingress), scope)
 	}); err != nil {
@@ -141,6 +151,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ACMEChallengeSolverHTTP01IngressRoute)(nil), (*acme.ACMEChallengeSolverHTTP01IngressRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ACMEChallengeSolverHTTP01IngressRoute_To_acme_ACMEChallengeSolverHTTP01IngressRoute(a.(*v1alpha3.ACMEChallengeSolverHTTP01IngressRoute), b.(*acme.ACMEChallengeSolverHTTP01IngressRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01IngressRoute)(nil), (*v1alpha3.ACMEChallengeSolverHTTP01IngressRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01IngressRoute_To_v1alpha3_ACMEChallengeSolverHTTP01IngressRoute(a.(*acme.ACMEChallengeSolverHTTP01IngressRoute), b.(*v1alpha3.ACMEChallengeSolverHTTP01IngressRoute), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.ACMEChallengeSolverHTTP01IngressTemplate)(nil), (*acme.ACMEChallengeSolverHTTP01IngressTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ACMEChallengeSolverHTTP01IngressTemplate_To_acme_ACMEChallengeSolverHTTP01IngressTemplate(a.(*v1alpha3.ACMEChallengeSolverHTTP01IngressTemplate), b.(*acme.ACMEChallengeSolverHTTP01IngressTemplate), scope)
 	}); err != nil {
@@ -231,6 +251,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.ACMEIssuerDNS01ProviderGRPC)(nil), (*acme.ACMEIssuerDNS01ProviderGRPC)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ACMEIssuerDNS01ProviderGRPC_To_acme_ACMEIssuerDNS01ProviderGRPC(a.(*v1alpha3.ACMEIssuerDNS01ProviderGRPC), b.(*acme.ACMEIssuerDNS01ProviderGRPC), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderGRPC)(nil), (*v1alpha3.ACMEIssuerDNS01ProviderGRPC)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderGRPC_To_v1alpha3_ACMEIssuerDNS01ProviderGRPC(a.(*acme.ACMEIssuerDNS01ProviderGRPC), b.(*v1alpha3.ACMEIssuerDNS01ProviderGRPC), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.ACMEIssuerDNS01ProviderRFC2136)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(a.(*v1alpha3.ACMEIssuerDNS01ProviderRFC2136), b.(*acme.ACMEIssuerDNS01ProviderRFC2136), scope)
 	}); err != nil {
@@ -451,6 +481,7 @@ func autoConvert_v1alpha3_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDN
 	out.AcmeDNS = (*acme.ACMEIssuerDNS01ProviderAcmeDNS)(unsafe.Pointer(in.AcmeDNS))
 	out.RFC2136 = (*acme.ACMEIssuerDNS01ProviderRFC2136)(unsafe.Pointer(in.RFC2136))
 	out.Webhook = (*acme.ACMEIssuerDNS01ProviderWebhook)(unsafe.Pointer(in.Webhook))
+	out.GRPC = (*acme.ACMEIssuerDNS01ProviderGRPC)(unsafe.Pointer(in.GRPC))
 	return nil
 }
 
@@ -470,6 +501,7 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1alpha3_ACMEChallengeSolverDN
 	out.AcmeDNS = (*v1alpha3.ACMEIssuerDNS01ProviderAcmeDNS)(unsafe.Pointer(in.AcmeDNS))
 	out.RFC2136 = (*v1alpha3.ACMEIssuerDNS01ProviderRFC2136)(unsafe.Pointer(in.RFC2136))
 	out.Webhook = (*v1alpha3.ACMEIssuerDNS01ProviderWebhook)(unsafe.Pointer(in.Webhook))
+	out.GRPC = (*v1alpha3.ACMEIssuerDNS01ProviderGRPC)(unsafe.Pointer(in.GRPC))
 	return nil
 }
 
@@ -480,6 +512,8 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1alpha3_ACMEChallengeSolverDNS01(
 
 func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *v1alpha3.ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
+	out.HTTPProxy = (*acme.ACMEChallengeSolverHTTP01HTTPProxy)(unsafe.Pointer(in.HTTPProxy))
+	out.IngressRoute = (*acme.ACMEChallengeSolverHTTP01IngressRoute)(unsafe.Pointer(in.IngressRoute))
 	return nil
 }
 
@@ -490,6 +524,8 @@ func Convert_v1alpha3_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP0
 
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1alpha3_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *v1alpha3.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*v1alpha3.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
+	out.HTTPProxy = (*v1alpha3.ACMEChallengeSolverHTTP01HTTPProxy)(unsafe.Pointer(in.HTTPProxy))
+	out.IngressRoute = (*v1alpha3.ACMEChallengeSolverHTTP01IngressRoute)(unsafe.Pointer(in.IngressRoute))
 	return nil
 }
 
@@ -498,9 +534,34 @@ func Convert_acme_ACMEChallengeSolverHTTP01_To_v1alpha3_ACMEChallengeSolverHTTP0
 	return autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1alpha3_ACMEChallengeSolverHTTP01(in, out, s)
 }
 
+func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01HTTPProxy_To_acme_ACMEChallengeSolverHTTP01HTTPProxy(in *v1alpha3.ACMEChallengeSolverHTTP01HTTPProxy, out *acme.ACMEChallengeSolverHTTP01HTTPProxy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1alpha3_ACMEChallengeSolverHTTP01HTTPProxy_To_acme_ACMEChallengeSolverHTTP01HTTPProxy is an autogenerated conversion function.
+func Convert_v1alpha3_ACMEChallengeSolverHTTP01HTTPProxy_To_acme_ACMEChallengeSolverHTTP01HTTPProxy(in *v1alpha3.ACMEChallengeSolverHTTP01HTTPProxy, out *acme.ACMEChallengeSolverHTTP01HTTPProxy, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ACMEChallengeSolverHTTP01HTTPProxy_To_acme_ACMEChallengeSolverHTTP01HTTPProxy(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01HTTPProxy_To_v1alpha3_ACMEChallengeSolverHTTP01HTTPProxy(in *acme.ACMEChallengeSolverHTTP01HTTPProxy, out *v1alpha3.ACMEChallengeSolverHTTP01HTTPProxy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*v1alpha3.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01HTTPProxy_To_v1alpha3_ACMEChallengeSolverHTTP01HTTPProxy is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01HTTPProxy_To_v1alpha3_ACMEChallengeSolverHTTP01HTTPProxy(in *acme.ACMEChallengeSolverHTTP01HTTPProxy, out *v1alpha3.ACMEChallengeSolverHTTP01HTTPProxy, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01HTTPProxy_To_v1alpha3_ACMEChallengeSolverHTTP01HTTPProxy(in, out, s)
+}
+
 func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *v1alpha3.ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = v1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*acme.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
@@ -515,6 +576,7 @@ func Convert_v1alpha3_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolv
 func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1alpha3_ACMEChallengeSolverHTTP01Ingress(in *acme.ACMEChallengeSolverHTTP01Ingress, out *v1alpha3.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = v1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
 	out.Name = in.Name
 	out.PodTemplate = (*v1alpha3.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*v1alpha3.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
@@ -574,6 +636,13 @@ func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMECh
 	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
 	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
 	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.TopologySpreadConstraints = *(*[]v1.TopologySpreadConstraint)(unsafe.Pointer(&in.TopologySpreadConstraints))
+	out.PriorityClassName = in.PriorityClassName
+	out.ServiceAccountName = in.ServiceAccountName
+	out.ImagePullSecrets = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.SecurityContext = (*v1.PodSecurityContext)(unsafe.Pointer(in.SecurityContext))
+	out.ContainerSecurityContext = (*v1.SecurityContext)(unsafe.Pointer(in.ContainerSecurityContext))
+	out.Resources = (*v1.ResourceRequirements)(unsafe.Pointer(in.Resources))
 	return nil
 }
 
@@ -586,6 +655,13 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodSpec_To_v1alpha3_ACMECh
 	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
 	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
 	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.TopologySpreadConstraints = *(*[]v1.TopologySpreadConstraint)(unsafe.Pointer(&in.TopologySpreadConstraints))
+	out.PriorityClassName = in.PriorityClassName
+	out.ServiceAccountName = in.ServiceAccountName
+	out.ImagePullSecrets = *(*[]v1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.SecurityContext = (*v1.PodSecurityContext)(unsafe.Pointer(in.SecurityContext))
+	out.ContainerSecurityContext = (*v1.SecurityContext)(unsafe.Pointer(in.ContainerSecurityContext))
+	out.Resources = (*v1.ResourceRequirements)(unsafe.Pointer(in.Resources))
 	return nil
 }
 
@@ -624,6 +700,30 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressPodTemplate_To_v1alpha3_ACMECh
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodTemplate_To_v1alpha3_ACMEChallengeSolverHTTP01IngressPodTemplate(in, out, s)
 }
 
+func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01IngressRoute_To_acme_ACMEChallengeSolverHTTP01IngressRoute(in *v1alpha3.ACMEChallengeSolverHTTP01IngressRoute, out *acme.ACMEChallengeSolverHTTP01IngressRoute, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1alpha3_ACMEChallengeSolverHTTP01IngressRoute_To_acme_ACMEChallengeSolverHTTP01IngressRoute is an autogenerated conversion function.
+func Convert_v1alpha3_ACMEChallengeSolverHTTP01IngressRoute_To_acme_ACMEChallengeSolverHTTP01IngressRoute(in *v1alpha3.ACMEChallengeSolverHTTP01IngressRoute, out *acme.ACMEChallengeSolverHTTP01IngressRoute, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ACMEChallengeSolverHTTP01IngressRoute_To_acme_ACMEChallengeSolverHTTP01IngressRoute(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01IngressRoute_To_v1alpha3_ACMEChallengeSolverHTTP01IngressRoute(in *acme.ACMEChallengeSolverHTTP01IngressRoute, out *v1alpha3.ACMEChallengeSolverHTTP01IngressRoute, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*v1alpha3.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01IngressRoute_To_v1alpha3_ACMEChallengeSolverHTTP01IngressRoute is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01IngressRoute_To_v1alpha3_ACMEChallengeSolverHTTP01IngressRoute(in *acme.ACMEChallengeSolverHTTP01IngressRoute, out *v1alpha3.ACMEChallengeSolverHTTP01IngressRoute, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressRoute_To_v1alpha3_ACMEChallengeSolverHTTP01IngressRoute(in, out, s)
+}
+
 func autoConvert_v1alpha3_ACMEChallengeSolverHTTP01IngressTemplate_To_acme_ACMEChallengeSolverHTTP01IngressTemplate(in *v1alpha3.ACMEChallengeSolverHTTP01IngressTemplate, out *acme.ACMEChallengeSolverHTTP01IngressTemplate, s conversion.Scope) error {
 	if err := Convert_v1alpha3_ACMEChallengeSolverHTTP01IngressObjectMeta_To_acme_ACMEChallengeSolverHTTP01IngressObjectMeta(&in.ACMEChallengeSolverHTTP01IngressObjectMeta, &out.ACMEChallengeSolverHTTP01IngressObjectMeta, s); err != nil {
 		return err
@@ -720,6 +820,7 @@ func autoConvert_v1alpha3_ACMEIssuerDNS01ProviderAcmeDNS_To_acme_ACMEIssuerDNS01
 	if err := s.Convert(&in.AccountSecret, &out.AccountSecret, 0); err != nil {
 		return err
 	}
+	out.AllowRegistration = in.AllowRegistration
 	return nil
 }
 
@@ -734,6 +835,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAcmeDNS_To_v1alpha3_ACMEIssuerDNS01
 	if err := s.Convert(&in.AccountSecret, &out.AccountSecret, 0); err != nil {
 		return err
 	}
+	out.AllowRegistration = in.AllowRegistration
 	return nil
 }
 
@@ -890,6 +992,34 @@ func Convert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1alpha3_ACMEIssuerDNS0
 	return autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1alpha3_ACMEIssuerDNS01ProviderDigitalOcean(in, out, s)
 }
 
+func autoConvert_v1alpha3_ACMEIssuerDNS01ProviderGRPC_To_acme_ACMEIssuerDNS01ProviderGRPC(in *v1alpha3.ACMEIssuerDNS01ProviderGRPC, out *acme.ACMEIssuerDNS01ProviderGRPC, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.SolverName = in.SolverName
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ClientCertSecretRef = (*meta.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.Config = (*v1beta1.JSON)(unsafe.Pointer(in.Config))
+	return nil
+}
+
+// Convert_v1alpha3_ACMEIssuerDNS01ProviderGRPC_To_acme_ACMEIssuerDNS01ProviderGRPC is an autogenerated conversion function.
+func Convert_v1alpha3_ACMEIssuerDNS01ProviderGRPC_To_acme_ACMEIssuerDNS01ProviderGRPC(in *v1alpha3.ACMEIssuerDNS01ProviderGRPC, out *acme.ACMEIssuerDNS01ProviderGRPC, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ACMEIssuerDNS01ProviderGRPC_To_acme_ACMEIssuerDNS01ProviderGRPC(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderGRPC_To_v1alpha3_ACMEIssuerDNS01ProviderGRPC(in *acme.ACMEIssuerDNS01ProviderGRPC, out *v1alpha3.ACMEIssuerDNS01ProviderGRPC, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.SolverName = in.SolverName
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ClientCertSecretRef = (*metav1.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.Config = (*v1beta1.JSON)(unsafe.Pointer(in.Config))
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderGRPC_To_v1alpha3_ACMEIssuerDNS01ProviderGRPC is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderGRPC_To_v1alpha3_ACMEIssuerDNS01ProviderGRPC(in *acme.ACMEIssuerDNS01ProviderGRPC, out *v1alpha3.ACMEIssuerDNS01ProviderGRPC, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderGRPC_To_v1alpha3_ACMEIssuerDNS01ProviderGRPC(in, out, s)
+}
+
 func autoConvert_v1alpha3_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(in *v1alpha3.ACMEIssuerDNS01ProviderRFC2136, out *acme.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
 	// TODO: Inefficient conversion - can we improve it?
@@ -27,6 +27,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/v1alpha2"
 	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/v1alpha3"
 	cmvalidation "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/validation"
+	metainstall "github.com/jetstack/cert-manager/pkg/internal/apis/meta/install"
 )
 
 // Install registers the API group and adds types to a scheme
@@ -34,6 +35,10 @@ func Install(scheme *runtime.Scheme) {
 	utilruntime.Must(certmanager.AddToScheme(scheme))
 	utilruntime.Must(v1alpha2.AddToScheme(scheme))
 	utilruntime.Must(v1alpha3.AddToScheme(scheme))
+
+	// types in this group embed cert-manager meta types, so their conversion
+	// functions must be registered too
+	metainstall.Install(scheme)
 }
 
 // InstallValidation registers validation functions for the API group with a
@@ -35,11 +35,19 @@ const (
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
 )
 
+// Label names for ConfigMaps
+const (
+	// BundleNameLabelKey is set on the ConfigMaps that a Bundle is synced to,
+	// and holds the name of the Bundle.
+	BundleNameLabelKey = "cert-manager.io/bundle-name"
+)
+
 const (
 	ClusterIssuerKind      = "ClusterIssuer"
 	IssuerKind             = "Issuer"
 	CertificateKind        = "Certificate"
 	CertificateRequestKind = "CertificateRequest"
+	BundleKind             = "Bundle"
 )
 
 const (
@@ -59,12 +67,35 @@ const (
 	// as namespace/name.
 	WantInjectFromSecretAnnotation = "cert-manager.io/inject-ca-from-secret"
 
+	// WantInjectFromIssuerAnnotation is the annotation that specifies that a
+	// particular object wants injection of the CA of an Issuer. It takes the
+	// form of a reference to an Issuer as namespace/name, or to a
+	// ClusterIssuer as name. Only CA, SelfSigned and Vault issuers are
+	// supported.
+	WantInjectFromIssuerAnnotation = "cert-manager.io/inject-ca-from-issuer"
+
 	// AllowsInjectionFromSecretAnnotation is an annotation that must be added
 	// to Secret resource that want to denote that they can be directly
 	// injected into injectables that have a `inject-ca-from-secret` annotation.
 	// If an injectable references a Secret that does NOT have this annotation,
 	// the cainjector will refuse to inject the secret.
 	AllowsInjectionFromSecretAnnotation = "cert-manager.io/allow-direct-injection"
+
+	// InjectCAKeyAnnotation is the annotation that specifies the data key of
+	// a ConfigMap that CA data should be injected into. Defaults to 'ca.crt'.
+	InjectCAKeyAnnotation = "cert-manager.io/inject-ca-key"
+
+	// InjectCAPathAnnotation is the annotation that specifies the field that
+	// CA data should be injected into for resources that are injected into
+	// using the unstructured injector. It takes the form of a JSONPath to a
+	// single field, such as '.spec.caBundle'.
+	InjectCAPathAnnotation = "cert-manager.io/inject-ca-path"
+
+	// InjectCAFormatAnnotation is the annotation that specifies how CA data
+	// is written to the field named by the inject-ca-path annotation. It may
+	// be 'base64' (the default, as used by Kubernetes caBundle fields) or
+	// 'pem' to write the PEM encoded CA data as a plain string.
+	InjectCAFormatAnnotation = "cert-manager.io/inject-ca-format"
 )
 
 // KeyUsage specifies valid usage contexts for keys.
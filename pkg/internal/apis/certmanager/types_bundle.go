@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// A Bundle is a set of trusted CA certificates gathered from a number of
+// sources, which is synced into a ConfigMap in each selected namespace.
+type Bundle struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+
+	Spec   BundleSpec
+	Status BundleStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BundleList is a list of Bundles
+type BundleList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []Bundle
+}
+
+// BundleSpec defines the sources of the CA certificates in a Bundle, and
+// where the Bundle should be synced to.
+type BundleSpec struct {
+	// Sources is the list of sources of PEM encoded CA certificates that make
+	// up the bundle. Certificates found in more than one source are only
+	// included in the bundle once, and expired certificates are omitted.
+	Sources []BundleSource
+
+	// Target configures where the bundle is synced to.
+	Target BundleTarget
+}
+
+// BundleSource is a source of PEM encoded CA certificates.
+// Exactly one field must be set.
+type BundleSource struct {
+	// Secret selects a key of a Secret containing PEM encoded certificates.
+	Secret *BundleSourceObjectKeySelector
+
+	// ConfigMap selects a key of a ConfigMap containing PEM encoded
+	// certificates.
+	ConfigMap *BundleSourceObjectKeySelector
+
+	// Issuer references a CA Issuer or ClusterIssuer whose CA certificate
+	// should be included in the bundle.
+	Issuer *BundleSourceIssuerReference
+
+	// InLine is a PEM encoded certificate bundle.
+	InLine *string
+}
+
+// BundleSourceObjectKeySelector selects a key of a namespaced resource.
+type BundleSourceObjectKeySelector struct {
+	// Namespace of the resource being referred to.
+	Namespace string
+
+	// Name of the resource being referred to.
+	Name string
+
+	// Key of the entry in the resource's `data` field to be used.
+	Key string
+}
+
+// BundleSourceIssuerReference is a reference to an Issuer or ClusterIssuer.
+type BundleSourceIssuerReference struct {
+	// Name of the issuer being referred to.
+	Name string
+
+	// Kind of the issuer being referred to, either Issuer or ClusterIssuer.
+	// Defaults to Issuer.
+	Kind string
+
+	// Namespace of the Issuer being referred to. Required if the Kind is
+	// Issuer.
+	Namespace string
+}
+
+// BundleTarget configures where a Bundle is synced to.
+type BundleTarget struct {
+	// ConfigMap configures the ConfigMap that the bundle is written to in
+	// each selected namespace.
+	ConfigMap BundleTargetConfigMap
+
+	// NamespaceSelector selects the namespaces that the bundle is synced to.
+	// If not set, the bundle is synced to all namespaces.
+	NamespaceSelector *metav1.LabelSelector
+
+	// AdditionalFormats configures other encodings of the bundle to be
+	// written to the target ConfigMap as binary data.
+	AdditionalFormats *BundleTargetAdditionalFormats
+}
+
+// DefaultBundleTargetKey is the key of a Bundle's target ConfigMap that the
+// PEM encoded bundle is written to if no key is specified.
+const DefaultBundleTargetKey = "ca.crt"
+
+// BundleTargetConfigMap configures the ConfigMap a Bundle is written to.
+type BundleTargetConfigMap struct {
+	// Name of the ConfigMap.
+	Name string
+
+	// Key of the ConfigMap's `data` field that the PEM encoded bundle is
+	// written to. Defaults to `ca.crt`.
+	Key string
+}
+
+// BundleTargetAdditionalFormats configures other encodings of a Bundle.
+type BundleTargetAdditionalFormats struct {
+	// JKS configures the bundle to be written as a Java keystore containing
+	// each certificate as a trusted certificate entry. The keystore is
+	// encrypted with the password `changeit`.
+	JKS *BundleTargetKeystore
+
+	// PKCS12 configures the bundle to be written as a PKCS#12 truststore
+	// containing each certificate as a trusted certificate. The truststore is
+	// encrypted with the password `changeit`.
+	PKCS12 *BundleTargetKeystore
+}
+
+// BundleTargetKeystore configures a keystore encoding of a Bundle.
+type BundleTargetKeystore struct {
+	// Key of the ConfigMap's `binaryData` field that the keystore is written
+	// to.
+	Key string
+}
+
+// BundleStatus defines the observed state of a Bundle.
+type BundleStatus struct {
+	Conditions []BundleCondition
+
+	// NotAfter is the earliest expiry time of the certificates in the
+	// bundle.
+	NotAfter *metav1.Time
+}
+
+// BundleCondition contains condition information for a Bundle.
+type BundleCondition struct {
+	// Type of the condition, currently ('Ready').
+	Type BundleConditionType
+
+	// Status of the condition, one of ('True', 'False', 'Unknown').
+	Status cmmeta.ConditionStatus
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	LastTransitionTime *metav1.Time
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	Reason string
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	Message string
+}
+
+// BundleConditionType represents a Bundle condition value.
+type BundleConditionType string
+
+const (
+	// BundleConditionReady indicates that a bundle has been synced to all
+	// of its selected namespaces.
+	BundleConditionReady BundleConditionType = "Ready"
+)
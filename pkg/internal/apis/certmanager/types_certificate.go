@@ -98,6 +98,11 @@ type CertificateSpec struct {
 	// +optional
 	Keystores *CertificateKeystores
 
+	// SecretReplication configures other namespaces that the `secretName`
+	// Secret resource is copied into.
+	// +optional
+	SecretReplication *CertificateSecretReplication
+
 	// IssuerRef is a reference to the issuer for this certificate.
 	// If the 'kind' field is not set, or set to 'Issuer', an Issuer resource
 	// with the given name in the same namespace as the Certificate will be used.
@@ -178,6 +183,14 @@ type X509Subject struct {
 	SerialNumber string
 }
 
+// CertificateSecretReplication configures the namespaces that a
+// Certificate's Secret resource is replicated into.
+type CertificateSecretReplication struct {
+	Namespaces []string
+
+	NamespaceSelector *metav1.LabelSelector
+}
+
 // CertificateKeystores configures additional keystore output formats to be
 // created in the Certificate's output Secret.
 type CertificateKeystores struct {
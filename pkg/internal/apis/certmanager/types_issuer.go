@@ -79,6 +79,10 @@ type IssuerConfig struct {
 	SelfSigned *SelfSignedIssuer
 
 	Venafi *VenafiIssuer
+
+	EST *ESTIssuer
+
+	KubernetesCSR *KubernetesCSRIssuer
 }
 
 // VenafiIssuer describes issuer configuration details for Venafi Cloud.
@@ -103,9 +107,18 @@ type VenafiTPP struct {
 	// URL is the base URL for the Venafi TPP instance
 	URL string
 
-	// CredentialsRef is a reference to a Secret containing the username and
-	// password for the TPP server.
-	// The secret must contain two keys, 'username' and 'password'.
+	// CredentialsRef is a reference to a Secret containing the credentials for
+	// the TPP server.
+	// The secret must contain either the keys 'username' and 'password', or an
+	// OAuth access token in the key 'access-token'.
+	// The username and password are used to obtain OAuth tokens, which are
+	// stored in the secret.
+	// When using an access token, the keys 'refresh-token' and
+	// 'access-token-expiry' (an RFC3339 timestamp) may be set so that the
+	// access token is refreshed before it expires, and 'client-id' may be set
+	// to the ID of the TPP API integration the tokens were issued for.
+	// Refreshed tokens are written back to the secret. If refreshing fails,
+	// the username and password are used to obtain new tokens if they are set.
 	CredentialsRef cmmeta.LocalObjectReference
 
 	// CABundle is a PEM encoded TLS certificate to use to verify connections to
@@ -115,6 +128,12 @@ type VenafiTPP struct {
 	// If not specified, the connection will be verified using the cert-manager
 	// system root certificates.
 	CABundle []byte
+
+	// LegacyAPIKeyAuth authenticates with the username and password using the
+	// legacy TPP API key login instead of OAuth. The API key login has been
+	// disabled in newer versions of TPP, and should only be used with older
+	// versions that do not support OAuth.
+	LegacyAPIKeyAuth bool
 }
 
 // VenafiCloud defines connection configuration details for Venafi Cloud
@@ -144,11 +163,25 @@ type VaultIssuer struct {
 	// Vault URL path to the certificate role
 	Path string
 
+	// IssuerRef is the name or ID of the issuer within a multi-issuer PKI
+	// mount that should sign certificates.
+	IssuerRef string
+
 	// Base64 encoded CA bundle to validate Vault server certificate. Only used
 	// if the Server URL is using HTTPS protocol. This parameter is ignored for
 	// plain HTTP protocol connection. If not set the system root certificates
 	// are used to validate the TLS connection.
 	CABundle []byte
+
+	// ClientCertSecretRef is a reference to a Secret of type
+	// `kubernetes.io/tls` containing a client certificate and private key
+	// that are presented to the Vault server when establishing TLS
+	// connections.
+	ClientCertSecretRef *cmmeta.LocalObjectReference
+
+	// Namespace is the Vault Enterprise namespace that the issuer operates
+	// in.
+	Namespace string
 }
 
 // Vault authentication  can be configured:
@@ -167,6 +200,14 @@ type VaultAuth struct {
 	// This contains a Role and Secret with a ServiceAccount token to
 	// authenticate with vault.
 	Kubernetes *VaultKubernetesAuth
+
+	// TLSCert authenticates with Vault using the client certificate
+	// configured by `clientCertSecretRef`.
+	TLSCert *VaultTLSCertAuth
+
+	// JWT authenticates with Vault using a bound ServiceAccount token
+	// requested using the Kubernetes TokenRequest API.
+	JWT *VaultJWTAuth
 }
 
 // Authenticate against Vault using an AppRole that is stored in a Secret.
@@ -197,9 +238,104 @@ type VaultKubernetesAuth struct {
 	Role string
 }
 
+// Authenticate against Vault using the TLS certificate auth method and the
+// client certificate configured by `clientCertSecretRef`.
+type VaultTLSCertAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. If unspecified, the default value "/v1/auth/cert" will be used.
+	Path string
+
+	// Name of the certificate role to authenticate against.
+	Name string
+}
+
+// Authenticate against Vault using the JWT auth method and a short-lived,
+// audience scoped ServiceAccount token requested using the TokenRequest API.
+type VaultJWTAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. If unspecified, the default value "/v1/auth/jwt" will be used.
+	Path string
+
+	// A required field containing the Vault Role to assume.
+	Role string
+
+	// The ServiceAccount that tokens will be requested for. The ServiceAccount
+	// must exist in the same namespace as the Issuer, or in the cluster
+	// resource namespace for ClusterIssuers.
+	ServiceAccountRef cmmeta.LocalObjectReference
+
+	// Additional audiences to request the token for. The audience
+	// "vault://<namespace>/<issuer-name>" for Issuers, or
+	// "vault://<issuer-name>" for ClusterIssuers, is always requested.
+	// Audiences accepted by the Kubernetes API server are not allowed.
+	Audiences []string
+}
+
+// ESTIssuer configures an issuer to request certificates from a server
+// implementing the Enrollment over Secure Transport (EST) protocol defined in
+// RFC 7030.
+type ESTIssuer struct {
+	// URL is the base URL of the EST server's API, for example
+	// `https://est.example.com/.well-known/est`. If the server uses a label to
+	// select the CA that signs certificates, it should be included in the URL,
+	// for example `https://est.example.com/.well-known/est/label`.
+	URL string
+
+	// CABundle is a PEM encoded bundle of CA certificates used to verify the
+	// TLS certificate of the EST server. If not set, the cert-manager system
+	// root certificates are used.
+	CABundle []byte
+
+	// Auth configures how cert-manager authenticates with the EST server.
+	Auth ESTAuth
+}
+
+// ESTAuth configures authentication with an EST server. At least one of
+// basicAuth or clientCertSecretRef must be set. If both are set, both are
+// presented to the server.
+type ESTAuth struct {
+	// BasicAuth authenticates enrollment requests using HTTP basic
+	// authentication.
+	BasicAuth *ESTBasicAuth
+
+	// ClientCertSecretRef is a reference to a Secret of type
+	// `kubernetes.io/tls` containing a client certificate and private key
+	// that are presented to the EST server when establishing TLS connections.
+	ClientCertSecretRef *cmmeta.LocalObjectReference
+}
+
+// ESTBasicAuth configures HTTP basic authentication with an EST server.
+type ESTBasicAuth struct {
+	// Username is the username used to authenticate with the EST server.
+	Username string
+
+	// PasswordSecretRef is a reference to a key in a Secret containing the
+	// password used to authenticate with the EST server.
+	PasswordSecretRef cmmeta.SecretKeySelector
+}
+
+// KubernetesCSRIssuer configures an issuer to request certificates from a
+// signer using the Kubernetes CertificateSigningRequest API
+// (certificates.k8s.io). The CertificateSigningRequests created by
+// cert-manager must be approved before they are signed, either manually or by
+// an approver running in the cluster.
+type KubernetesCSRIssuer struct {
+	// SignerName is the name of the signer that CertificateSigningRequests
+	// are addressed to, for example `example.com/my-signer` or one of the
+	// `kubernetes.io` signers.
+	SignerName string
+
+	// CABundle is a PEM encoded bundle of the signer's CA certificates. It is
+	// stored as `ca.crt` in the Secrets of Certificates issued by this
+	// issuer. The CertificateSigningRequest API does not return the CA that
+	// signed a certificate, so if this is not set `ca.crt` will be empty.
+	CABundle []byte
+}
+
 type CAIssuer struct {
 	// SecretName is the name of the secret used to sign Certificates issued
-	// by this Issuer.
+	// by this Issuer. If PKCS11 is set, the secret only needs to contain the
+	// CA certificate.
 	SecretName string
 
 	// The CRL distribution points is an X.509 v3 certificate extension which identifies
@@ -207,6 +343,41 @@ type CAIssuer struct {
 	// If not set certificate will be issued without CDP. Values are strings.
 	// +optional
 	CRLDistributionPoints []string
+
+	// PKCS11 configures the Issuer to sign Certificates using a private key
+	// stored in a PKCS#11 token, such as a hardware security module, instead
+	// of the private key stored in the secret. This requires a custom build of
+	// cert-manager with cgo enabled.
+	// +optional
+	PKCS11 *CAPKCS11Key
+}
+
+// CAPKCS11Key references a private key stored in a PKCS#11 token.
+// Exactly one of slot or tokenLabel must be set to select the token.
+// PKCS#11 tokens are not supported by the released cert-manager images, which
+// are built without cgo. A custom build of cert-manager with cgo enabled is
+// required to use them.
+type CAPKCS11Key struct {
+	// ModulePath is the path to the PKCS#11 module used to access the token.
+	// The module must be available on the filesystem of the cert-manager
+	// controller, and be listed in the --pkcs11-allowed-modules flag of the
+	// cert-manager controller and webhook.
+	ModulePath string
+
+	// Slot is the ID of the slot containing the token.
+	// +optional
+	Slot *int64
+
+	// TokenLabel is the label of the token containing the private key.
+	// +optional
+	TokenLabel string
+
+	// KeyLabel is the label of the private key in the token.
+	KeyLabel string
+
+	// PINSecretRef is a reference to a key in a Secret containing the user
+	// PIN used to log in to the token.
+	PINSecretRef cmmeta.SecretKeySelector
 }
 
 // IssuerStatus contains status information about an Issuer
@@ -214,6 +385,23 @@ type IssuerStatus struct {
 	Conditions []IssuerCondition
 
 	ACME *cmacme.ACMEIssuerStatus
+
+	// CA contains details of the CA certificate used by the issuer to sign
+	// certificates. It is only set for issuers where the CA is known.
+	CA *CAIssuerStatus
+}
+
+// CAIssuerStatus describes the CA certificate used by an issuer.
+type CAIssuerStatus struct {
+	// Subject is the distinguished name of the CA certificate.
+	Subject string
+
+	// NotAfter is the time at which the CA certificate expires.
+	NotAfter *metav1.Time
+
+	// Chain is the PEM encoded certificate chain of the CA, starting with the
+	// CA certificate.
+	Chain []byte
 }
 
 // IssuerCondition contains condition information for an Issuer.
@@ -41,6 +41,126 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.Bundle)(nil), (*certmanager.Bundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_Bundle_To_certmanager_Bundle(a.(*v1alpha2.Bundle), b.(*certmanager.Bundle), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.Bundle)(nil), (*v1alpha2.Bundle)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_Bundle_To_v1alpha2_Bundle(a.(*certmanager.Bundle), b.(*v1alpha2.Bundle), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleCondition)(nil), (*certmanager.BundleCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleCondition_To_certmanager_BundleCondition(a.(*v1alpha2.BundleCondition), b.(*certmanager.BundleCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleCondition)(nil), (*v1alpha2.BundleCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleCondition_To_v1alpha2_BundleCondition(a.(*certmanager.BundleCondition), b.(*v1alpha2.BundleCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleList)(nil), (*certmanager.BundleList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleList_To_certmanager_BundleList(a.(*v1alpha2.BundleList), b.(*certmanager.BundleList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleList)(nil), (*v1alpha2.BundleList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleList_To_v1alpha2_BundleList(a.(*certmanager.BundleList), b.(*v1alpha2.BundleList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleSource)(nil), (*certmanager.BundleSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleSource_To_certmanager_BundleSource(a.(*v1alpha2.BundleSource), b.(*certmanager.BundleSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleSource)(nil), (*v1alpha2.BundleSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleSource_To_v1alpha2_BundleSource(a.(*certmanager.BundleSource), b.(*v1alpha2.BundleSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleSourceIssuerReference)(nil), (*certmanager.BundleSourceIssuerReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleSourceIssuerReference_To_certmanager_BundleSourceIssuerReference(a.(*v1alpha2.BundleSourceIssuerReference), b.(*certmanager.BundleSourceIssuerReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleSourceIssuerReference)(nil), (*v1alpha2.BundleSourceIssuerReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleSourceIssuerReference_To_v1alpha2_BundleSourceIssuerReference(a.(*certmanager.BundleSourceIssuerReference), b.(*v1alpha2.BundleSourceIssuerReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleSourceObjectKeySelector)(nil), (*certmanager.BundleSourceObjectKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleSourceObjectKeySelector_To_certmanager_BundleSourceObjectKeySelector(a.(*v1alpha2.BundleSourceObjectKeySelector), b.(*certmanager.BundleSourceObjectKeySelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleSourceObjectKeySelector)(nil), (*v1alpha2.BundleSourceObjectKeySelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleSourceObjectKeySelector_To_v1alpha2_BundleSourceObjectKeySelector(a.(*certmanager.BundleSourceObjectKeySelector), b.(*v1alpha2.BundleSourceObjectKeySelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleSpec)(nil), (*certmanager.BundleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleSpec_To_certmanager_BundleSpec(a.(*v1alpha2.BundleSpec), b.(*certmanager.BundleSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleSpec)(nil), (*v1alpha2.BundleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleSpec_To_v1alpha2_BundleSpec(a.(*certmanager.BundleSpec), b.(*v1alpha2.BundleSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleStatus)(nil), (*certmanager.BundleStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleStatus_To_certmanager_BundleStatus(a.(*v1alpha2.BundleStatus), b.(*certmanager.BundleStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleStatus)(nil), (*v1alpha2.BundleStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleStatus_To_v1alpha2_BundleStatus(a.(*certmanager.BundleStatus), b.(*v1alpha2.BundleStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleTarget)(nil), (*certmanager.BundleTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleTarget_To_certmanager_BundleTarget(a.(*v1alpha2.BundleTarget), b.(*certmanager.BundleTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleTarget)(nil), (*v1alpha2.BundleTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleTarget_To_v1alpha2_BundleTarget(a.(*certmanager.BundleTarget), b.(*v1alpha2.BundleTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleTargetAdditionalFormats)(nil), (*certmanager.BundleTargetAdditionalFormats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleTargetAdditionalFormats_To_certmanager_BundleTargetAdditionalFormats(a.(*v1alpha2.BundleTargetAdditionalFormats), b.(*certmanager.BundleTargetAdditionalFormats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleTargetAdditionalFormats)(nil), (*v1alpha2.BundleTargetAdditionalFormats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleTargetAdditionalFormats_To_v1alpha2_BundleTargetAdditionalFormats(a.(*certmanager.BundleTargetAdditionalFormats), b.(*v1alpha2.BundleTargetAdditionalFormats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleTargetConfigMap)(nil), (*certmanager.BundleTargetConfigMap)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleTargetConfigMap_To_certmanager_BundleTargetConfigMap(a.(*v1alpha2.BundleTargetConfigMap), b.(*certmanager.BundleTargetConfigMap), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleTargetConfigMap)(nil), (*v1alpha2.BundleTargetConfigMap)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleTargetConfigMap_To_v1alpha2_BundleTargetConfigMap(a.(*certmanager.BundleTargetConfigMap), b.(*v1alpha2.BundleTargetConfigMap), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.BundleTargetKeystore)(nil), (*certmanager.BundleTargetKeystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_BundleTargetKeystore_To_certmanager_BundleTargetKeystore(a.(*v1alpha2.BundleTargetKeystore), b.(*certmanager.BundleTargetKeystore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.BundleTargetKeystore)(nil), (*v1alpha2.BundleTargetKeystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_BundleTargetKeystore_To_v1alpha2_BundleTargetKeystore(a.(*certmanager.BundleTargetKeystore), b.(*v1alpha2.BundleTargetKeystore), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(a.(*v1alpha2.CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -51,6 +171,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CAIssuerStatus)(nil), (*certmanager.CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CAIssuerStatus_To_certmanager_CAIssuerStatus(a.(*v1alpha2.CAIssuerStatus), b.(*certmanager.CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAIssuerStatus)(nil), (*v1alpha2.CAIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAIssuerStatus_To_v1alpha2_CAIssuerStatus(a.(*certmanager.CAIssuerStatus), b.(*v1alpha2.CAIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CAPKCS11Key)(nil), (*certmanager.CAPKCS11Key)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CAPKCS11Key_To_certmanager_CAPKCS11Key(a.(*v1alpha2.CAPKCS11Key), b.(*certmanager.CAPKCS11Key), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAPKCS11Key)(nil), (*v1alpha2.CAPKCS11Key)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAPKCS11Key_To_v1alpha2_CAPKCS11Key(a.(*certmanager.CAPKCS11Key), b.(*v1alpha2.CAPKCS11Key), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Certificate_To_certmanager_Certificate(a.(*v1alpha2.Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -151,6 +291,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateSecretReplication)(nil), (*certmanager.CertificateSecretReplication)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateSecretReplication_To_certmanager_CertificateSecretReplication(a.(*v1alpha2.CertificateSecretReplication), b.(*certmanager.CertificateSecretReplication), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateSecretReplication)(nil), (*v1alpha2.CertificateSecretReplication)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateSecretReplication_To_v1alpha2_CertificateSecretReplication(a.(*certmanager.CertificateSecretReplication), b.(*v1alpha2.CertificateSecretReplication), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.CertificateStatus)(nil), (*certmanager.CertificateStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateStatus_To_certmanager_CertificateStatus(a.(*v1alpha2.CertificateStatus), b.(*certmanager.CertificateStatus), scope)
 	}); err != nil {
@@ -181,6 +331,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ESTAuth)(nil), (*certmanager.ESTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ESTAuth_To_certmanager_ESTAuth(a.(*v1alpha2.ESTAuth), b.(*certmanager.ESTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTAuth)(nil), (*v1alpha2.ESTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTAuth_To_v1alpha2_ESTAuth(a.(*certmanager.ESTAuth), b.(*v1alpha2.ESTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ESTBasicAuth)(nil), (*certmanager.ESTBasicAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ESTBasicAuth_To_certmanager_ESTBasicAuth(a.(*v1alpha2.ESTBasicAuth), b.(*certmanager.ESTBasicAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTBasicAuth)(nil), (*v1alpha2.ESTBasicAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTBasicAuth_To_v1alpha2_ESTBasicAuth(a.(*certmanager.ESTBasicAuth), b.(*v1alpha2.ESTBasicAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ESTIssuer)(nil), (*certmanager.ESTIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ESTIssuer_To_certmanager_ESTIssuer(a.(*v1alpha2.ESTIssuer), b.(*certmanager.ESTIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.ESTIssuer)(nil), (*v1alpha2.ESTIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_ESTIssuer_To_v1alpha2_ESTIssuer(a.(*certmanager.ESTIssuer), b.(*v1alpha2.ESTIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.Issuer)(nil), (*certmanager.Issuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Issuer_To_certmanager_Issuer(a.(*v1alpha2.Issuer), b.(*certmanager.Issuer), scope)
 	}); err != nil {
@@ -251,6 +431,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.KubernetesCSRIssuer)(nil), (*certmanager.KubernetesCSRIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(a.(*v1alpha2.KubernetesCSRIssuer), b.(*certmanager.KubernetesCSRIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.KubernetesCSRIssuer)(nil), (*v1alpha2.KubernetesCSRIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_KubernetesCSRIssuer_To_v1alpha2_KubernetesCSRIssuer(a.(*certmanager.KubernetesCSRIssuer), b.(*v1alpha2.KubernetesCSRIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*v1alpha2.PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -301,6 +491,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.VaultJWTAuth)(nil), (*certmanager.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VaultJWTAuth_To_certmanager_VaultJWTAuth(a.(*v1alpha2.VaultJWTAuth), b.(*certmanager.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultJWTAuth)(nil), (*v1alpha2.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultJWTAuth_To_v1alpha2_VaultJWTAuth(a.(*certmanager.VaultJWTAuth), b.(*v1alpha2.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.VaultKubernetesAuth)(nil), (*certmanager.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(a.(*v1alpha2.VaultKubernetesAuth), b.(*certmanager.VaultKubernetesAuth), scope)
 	}); err != nil {
@@ -311,6 +511,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.VaultTLSCertAuth)(nil), (*certmanager.VaultTLSCertAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VaultTLSCertAuth_To_certmanager_VaultTLSCertAuth(a.(*v1alpha2.VaultTLSCertAuth), b.(*certmanager.VaultTLSCertAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultTLSCertAuth)(nil), (*v1alpha2.VaultTLSCertAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultTLSCertAuth_To_v1alpha2_VaultTLSCertAuth(a.(*certmanager.VaultTLSCertAuth), b.(*v1alpha2.VaultTLSCertAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VenafiCloud_To_certmanager_VenafiCloud(a.(*v1alpha2.VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -364,9 +574,306 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_Bundle_To_certmanager_Bundle(in *v1alpha2.Bundle, out *certmanager.Bundle, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha2_BundleSpec_To_certmanager_BundleSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha2_BundleStatus_To_certmanager_BundleStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_Bundle_To_certmanager_Bundle is an autogenerated conversion function.
+func Convert_v1alpha2_Bundle_To_certmanager_Bundle(in *v1alpha2.Bundle, out *certmanager.Bundle, s conversion.Scope) error {
+	return autoConvert_v1alpha2_Bundle_To_certmanager_Bundle(in, out, s)
+}
+
+func autoConvert_certmanager_Bundle_To_v1alpha2_Bundle(in *certmanager.Bundle, out *v1alpha2.Bundle, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_BundleSpec_To_v1alpha2_BundleSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_certmanager_BundleStatus_To_v1alpha2_BundleStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_Bundle_To_v1alpha2_Bundle is an autogenerated conversion function.
+func Convert_certmanager_Bundle_To_v1alpha2_Bundle(in *certmanager.Bundle, out *v1alpha2.Bundle, s conversion.Scope) error {
+	return autoConvert_certmanager_Bundle_To_v1alpha2_Bundle(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleCondition_To_certmanager_BundleCondition(in *v1alpha2.BundleCondition, out *certmanager.BundleCondition, s conversion.Scope) error {
+	out.Type = certmanager.BundleConditionType(in.Type)
+	out.Status = meta.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha2_BundleCondition_To_certmanager_BundleCondition is an autogenerated conversion function.
+func Convert_v1alpha2_BundleCondition_To_certmanager_BundleCondition(in *v1alpha2.BundleCondition, out *certmanager.BundleCondition, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleCondition_To_certmanager_BundleCondition(in, out, s)
+}
+
+func autoConvert_certmanager_BundleCondition_To_v1alpha2_BundleCondition(in *certmanager.BundleCondition, out *v1alpha2.BundleCondition, s conversion.Scope) error {
+	out.Type = v1alpha2.BundleConditionType(in.Type)
+	out.Status = metav1.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_certmanager_BundleCondition_To_v1alpha2_BundleCondition is an autogenerated conversion function.
+func Convert_certmanager_BundleCondition_To_v1alpha2_BundleCondition(in *certmanager.BundleCondition, out *v1alpha2.BundleCondition, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleCondition_To_v1alpha2_BundleCondition(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleList_To_certmanager_BundleList(in *v1alpha2.BundleList, out *certmanager.BundleList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]certmanager.Bundle)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha2_BundleList_To_certmanager_BundleList is an autogenerated conversion function.
+func Convert_v1alpha2_BundleList_To_certmanager_BundleList(in *v1alpha2.BundleList, out *certmanager.BundleList, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleList_To_certmanager_BundleList(in, out, s)
+}
+
+func autoConvert_certmanager_BundleList_To_v1alpha2_BundleList(in *certmanager.BundleList, out *v1alpha2.BundleList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1alpha2.Bundle)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_certmanager_BundleList_To_v1alpha2_BundleList is an autogenerated conversion function.
+func Convert_certmanager_BundleList_To_v1alpha2_BundleList(in *certmanager.BundleList, out *v1alpha2.BundleList, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleList_To_v1alpha2_BundleList(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleSource_To_certmanager_BundleSource(in *v1alpha2.BundleSource, out *certmanager.BundleSource, s conversion.Scope) error {
+	out.Secret = (*certmanager.BundleSourceObjectKeySelector)(unsafe.Pointer(in.Secret))
+	out.ConfigMap = (*certmanager.BundleSourceObjectKeySelector)(unsafe.Pointer(in.ConfigMap))
+	out.Issuer = (*certmanager.BundleSourceIssuerReference)(unsafe.Pointer(in.Issuer))
+	out.InLine = (*string)(unsafe.Pointer(in.InLine))
+	return nil
+}
+
+// Convert_v1alpha2_BundleSource_To_certmanager_BundleSource is an autogenerated conversion function.
+func Convert_v1alpha2_BundleSource_To_certmanager_BundleSource(in *v1alpha2.BundleSource, out *certmanager.BundleSource, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleSource_To_certmanager_BundleSource(in, out, s)
+}
+
+func autoConvert_certmanager_BundleSource_To_v1alpha2_BundleSource(in *certmanager.BundleSource, out *v1alpha2.BundleSource, s conversion.Scope) error {
+	out.Secret = (*v1alpha2.BundleSourceObjectKeySelector)(unsafe.Pointer(in.Secret))
+	out.ConfigMap = (*v1alpha2.BundleSourceObjectKeySelector)(unsafe.Pointer(in.ConfigMap))
+	out.Issuer = (*v1alpha2.BundleSourceIssuerReference)(unsafe.Pointer(in.Issuer))
+	out.InLine = (*string)(unsafe.Pointer(in.InLine))
+	return nil
+}
+
+// Convert_certmanager_BundleSource_To_v1alpha2_BundleSource is an autogenerated conversion function.
+func Convert_certmanager_BundleSource_To_v1alpha2_BundleSource(in *certmanager.BundleSource, out *v1alpha2.BundleSource, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleSource_To_v1alpha2_BundleSource(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleSourceIssuerReference_To_certmanager_BundleSourceIssuerReference(in *v1alpha2.BundleSourceIssuerReference, out *certmanager.BundleSourceIssuerReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1alpha2_BundleSourceIssuerReference_To_certmanager_BundleSourceIssuerReference is an autogenerated conversion function.
+func Convert_v1alpha2_BundleSourceIssuerReference_To_certmanager_BundleSourceIssuerReference(in *v1alpha2.BundleSourceIssuerReference, out *certmanager.BundleSourceIssuerReference, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleSourceIssuerReference_To_certmanager_BundleSourceIssuerReference(in, out, s)
+}
+
+func autoConvert_certmanager_BundleSourceIssuerReference_To_v1alpha2_BundleSourceIssuerReference(in *certmanager.BundleSourceIssuerReference, out *v1alpha2.BundleSourceIssuerReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_certmanager_BundleSourceIssuerReference_To_v1alpha2_BundleSourceIssuerReference is an autogenerated conversion function.
+func Convert_certmanager_BundleSourceIssuerReference_To_v1alpha2_BundleSourceIssuerReference(in *certmanager.BundleSourceIssuerReference, out *v1alpha2.BundleSourceIssuerReference, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleSourceIssuerReference_To_v1alpha2_BundleSourceIssuerReference(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleSourceObjectKeySelector_To_certmanager_BundleSourceObjectKeySelector(in *v1alpha2.BundleSourceObjectKeySelector, out *certmanager.BundleSourceObjectKeySelector, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1alpha2_BundleSourceObjectKeySelector_To_certmanager_BundleSourceObjectKeySelector is an autogenerated conversion function.
+func Convert_v1alpha2_BundleSourceObjectKeySelector_To_certmanager_BundleSourceObjectKeySelector(in *v1alpha2.BundleSourceObjectKeySelector, out *certmanager.BundleSourceObjectKeySelector, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleSourceObjectKeySelector_To_certmanager_BundleSourceObjectKeySelector(in, out, s)
+}
+
+func autoConvert_certmanager_BundleSourceObjectKeySelector_To_v1alpha2_BundleSourceObjectKeySelector(in *certmanager.BundleSourceObjectKeySelector, out *v1alpha2.BundleSourceObjectKeySelector, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_certmanager_BundleSourceObjectKeySelector_To_v1alpha2_BundleSourceObjectKeySelector is an autogenerated conversion function.
+func Convert_certmanager_BundleSourceObjectKeySelector_To_v1alpha2_BundleSourceObjectKeySelector(in *certmanager.BundleSourceObjectKeySelector, out *v1alpha2.BundleSourceObjectKeySelector, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleSourceObjectKeySelector_To_v1alpha2_BundleSourceObjectKeySelector(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleSpec_To_certmanager_BundleSpec(in *v1alpha2.BundleSpec, out *certmanager.BundleSpec, s conversion.Scope) error {
+	out.Sources = *(*[]certmanager.BundleSource)(unsafe.Pointer(&in.Sources))
+	if err := Convert_v1alpha2_BundleTarget_To_certmanager_BundleTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_BundleSpec_To_certmanager_BundleSpec is an autogenerated conversion function.
+func Convert_v1alpha2_BundleSpec_To_certmanager_BundleSpec(in *v1alpha2.BundleSpec, out *certmanager.BundleSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleSpec_To_certmanager_BundleSpec(in, out, s)
+}
+
+func autoConvert_certmanager_BundleSpec_To_v1alpha2_BundleSpec(in *certmanager.BundleSpec, out *v1alpha2.BundleSpec, s conversion.Scope) error {
+	out.Sources = *(*[]v1alpha2.BundleSource)(unsafe.Pointer(&in.Sources))
+	if err := Convert_certmanager_BundleTarget_To_v1alpha2_BundleTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_BundleSpec_To_v1alpha2_BundleSpec is an autogenerated conversion function.
+func Convert_certmanager_BundleSpec_To_v1alpha2_BundleSpec(in *certmanager.BundleSpec, out *v1alpha2.BundleSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleSpec_To_v1alpha2_BundleSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleStatus_To_certmanager_BundleStatus(in *v1alpha2.BundleStatus, out *certmanager.BundleStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]certmanager.BundleCondition)(unsafe.Pointer(&in.Conditions))
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	return nil
+}
+
+// Convert_v1alpha2_BundleStatus_To_certmanager_BundleStatus is an autogenerated conversion function.
+func Convert_v1alpha2_BundleStatus_To_certmanager_BundleStatus(in *v1alpha2.BundleStatus, out *certmanager.BundleStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleStatus_To_certmanager_BundleStatus(in, out, s)
+}
+
+func autoConvert_certmanager_BundleStatus_To_v1alpha2_BundleStatus(in *certmanager.BundleStatus, out *v1alpha2.BundleStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]v1alpha2.BundleCondition)(unsafe.Pointer(&in.Conditions))
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	return nil
+}
+
+// Convert_certmanager_BundleStatus_To_v1alpha2_BundleStatus is an autogenerated conversion function.
+func Convert_certmanager_BundleStatus_To_v1alpha2_BundleStatus(in *certmanager.BundleStatus, out *v1alpha2.BundleStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleStatus_To_v1alpha2_BundleStatus(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleTarget_To_certmanager_BundleTarget(in *v1alpha2.BundleTarget, out *certmanager.BundleTarget, s conversion.Scope) error {
+	if err := Convert_v1alpha2_BundleTargetConfigMap_To_certmanager_BundleTargetConfigMap(&in.ConfigMap, &out.ConfigMap, s); err != nil {
+		return err
+	}
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.AdditionalFormats = (*certmanager.BundleTargetAdditionalFormats)(unsafe.Pointer(in.AdditionalFormats))
+	return nil
+}
+
+// Convert_v1alpha2_BundleTarget_To_certmanager_BundleTarget is an autogenerated conversion function.
+func Convert_v1alpha2_BundleTarget_To_certmanager_BundleTarget(in *v1alpha2.BundleTarget, out *certmanager.BundleTarget, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleTarget_To_certmanager_BundleTarget(in, out, s)
+}
+
+func autoConvert_certmanager_BundleTarget_To_v1alpha2_BundleTarget(in *certmanager.BundleTarget, out *v1alpha2.BundleTarget, s conversion.Scope) error {
+	if err := Convert_certmanager_BundleTargetConfigMap_To_v1alpha2_BundleTargetConfigMap(&in.ConfigMap, &out.ConfigMap, s); err != nil {
+		return err
+	}
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	out.AdditionalFormats = (*v1alpha2.BundleTargetAdditionalFormats)(unsafe.Pointer(in.AdditionalFormats))
+	return nil
+}
+
+// Convert_certmanager_BundleTarget_To_v1alpha2_BundleTarget is an autogenerated conversion function.
+func Convert_certmanager_BundleTarget_To_v1alpha2_BundleTarget(in *certmanager.BundleTarget, out *v1alpha2.BundleTarget, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleTarget_To_v1alpha2_BundleTarget(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleTargetAdditionalFormats_To_certmanager_BundleTargetAdditionalFormats(in *v1alpha2.BundleTargetAdditionalFormats, out *certmanager.BundleTargetAdditionalFormats, s conversion.Scope) error {
+	out.JKS = (*certmanager.BundleTargetKeystore)(unsafe.Pointer(in.JKS))
+	out.PKCS12 = (*certmanager.BundleTargetKeystore)(unsafe.Pointer(in.PKCS12))
+	return nil
+}
+
+// Convert_v1alpha2_BundleTargetAdditionalFormats_To_certmanager_BundleTargetAdditionalFormats is an autogenerated conversion function.
+func Convert_v1alpha2_BundleTargetAdditionalFormats_To_certmanager_BundleTargetAdditionalFormats(in *v1alpha2.BundleTargetAdditionalFormats, out *certmanager.BundleTargetAdditionalFormats, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleTargetAdditionalFormats_To_certmanager_BundleTargetAdditionalFormats(in, out, s)
+}
+
+func autoConvert_certmanager_BundleTargetAdditionalFormats_To_v1alpha2_BundleTargetAdditionalFormats(in *certmanager.BundleTargetAdditionalFormats, out *v1alpha2.BundleTargetAdditionalFormats, s conversion.Scope) error {
+	out.JKS = (*v1alpha2.BundleTargetKeystore)(unsafe.Pointer(in.JKS))
+	out.PKCS12 = (*v1alpha2.BundleTargetKeystore)(unsafe.Pointer(in.PKCS12))
+	return nil
+}
+
+// Convert_certmanager_BundleTargetAdditionalFormats_To_v1alpha2_BundleTargetAdditionalFormats is an autogenerated conversion function.
+func Convert_certmanager_BundleTargetAdditionalFormats_To_v1alpha2_BundleTargetAdditionalFormats(in *certmanager.BundleTargetAdditionalFormats, out *v1alpha2.BundleTargetAdditionalFormats, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleTargetAdditionalFormats_To_v1alpha2_BundleTargetAdditionalFormats(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleTargetConfigMap_To_certmanager_BundleTargetConfigMap(in *v1alpha2.BundleTargetConfigMap, out *certmanager.BundleTargetConfigMap, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1alpha2_BundleTargetConfigMap_To_certmanager_BundleTargetConfigMap is an autogenerated conversion function.
+func Convert_v1alpha2_BundleTargetConfigMap_To_certmanager_BundleTargetConfigMap(in *v1alpha2.BundleTargetConfigMap, out *certmanager.BundleTargetConfigMap, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleTargetConfigMap_To_certmanager_BundleTargetConfigMap(in, out, s)
+}
+
+func autoConvert_certmanager_BundleTargetConfigMap_To_v1alpha2_BundleTargetConfigMap(in *certmanager.BundleTargetConfigMap, out *v1alpha2.BundleTargetConfigMap, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_certmanager_BundleTargetConfigMap_To_v1alpha2_BundleTargetConfigMap is an autogenerated conversion function.
+func Convert_certmanager_BundleTargetConfigMap_To_v1alpha2_BundleTargetConfigMap(in *certmanager.BundleTargetConfigMap, out *v1alpha2.BundleTargetConfigMap, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleTargetConfigMap_To_v1alpha2_BundleTargetConfigMap(in, out, s)
+}
+
+func autoConvert_v1alpha2_BundleTargetKeystore_To_certmanager_BundleTargetKeystore(in *v1alpha2.BundleTargetKeystore, out *certmanager.BundleTargetKeystore, s conversion.Scope) error {
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1alpha2_BundleTargetKeystore_To_certmanager_BundleTargetKeystore is an autogenerated conversion function.
+func Convert_v1alpha2_BundleTargetKeystore_To_certmanager_BundleTargetKeystore(in *v1alpha2.BundleTargetKeystore, out *certmanager.BundleTargetKeystore, s conversion.Scope) error {
+	return autoConvert_v1alpha2_BundleTargetKeystore_To_certmanager_BundleTargetKeystore(in, out, s)
+}
+
+func autoConvert_certmanager_BundleTargetKeystore_To_v1alpha2_BundleTargetKeystore(in *certmanager.BundleTargetKeystore, out *v1alpha2.BundleTargetKeystore, s conversion.Scope) error {
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_certmanager_BundleTargetKeystore_To_v1alpha2_BundleTargetKeystore is an autogenerated conversion function.
+func Convert_certmanager_BundleTargetKeystore_To_v1alpha2_BundleTargetKeystore(in *certmanager.BundleTargetKeystore, out *v1alpha2.BundleTargetKeystore, s conversion.Scope) error {
+	return autoConvert_certmanager_BundleTargetKeystore_To_v1alpha2_BundleTargetKeystore(in, out, s)
+}
+
 func autoConvert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(in *v1alpha2.CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.PKCS11 = (*certmanager.CAPKCS11Key)(unsafe.Pointer(in.PKCS11))
 	return nil
 }
 
@@ -378,6 +885,7 @@ func Convert_v1alpha2_CAIssuer_To_certmanager_CAIssuer(in *v1alpha2.CAIssuer, ou
 func autoConvert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(in *certmanager.CAIssuer, out *v1alpha2.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
+	out.PKCS11 = (*v1alpha2.CAPKCS11Key)(unsafe.Pointer(in.PKCS11))
 	return nil
 }
 
@@ -386,6 +894,64 @@ func Convert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(in *certmanager.CAIssuer,
 	return autoConvert_certmanager_CAIssuer_To_v1alpha2_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha2_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *v1alpha2.CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	out.Subject = in.Subject
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	out.Chain = *(*[]byte)(unsafe.Pointer(&in.Chain))
+	return nil
+}
+
+// Convert_v1alpha2_CAIssuerStatus_To_certmanager_CAIssuerStatus is an autogenerated conversion function.
+func Convert_v1alpha2_CAIssuerStatus_To_certmanager_CAIssuerStatus(in *v1alpha2.CAIssuerStatus, out *certmanager.CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CAIssuerStatus_To_certmanager_CAIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_CAIssuerStatus_To_v1alpha2_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *v1alpha2.CAIssuerStatus, s conversion.Scope) error {
+	out.Subject = in.Subject
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	out.Chain = *(*[]byte)(unsafe.Pointer(&in.Chain))
+	return nil
+}
+
+// Convert_certmanager_CAIssuerStatus_To_v1alpha2_CAIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_CAIssuerStatus_To_v1alpha2_CAIssuerStatus(in *certmanager.CAIssuerStatus, out *v1alpha2.CAIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_CAIssuerStatus_To_v1alpha2_CAIssuerStatus(in, out, s)
+}
+
+func autoConvert_v1alpha2_CAPKCS11Key_To_certmanager_CAPKCS11Key(in *v1alpha2.CAPKCS11Key, out *certmanager.CAPKCS11Key, s conversion.Scope) error {
+	out.ModulePath = in.ModulePath
+	out.Slot = (*int64)(unsafe.Pointer(in.Slot))
+	out.TokenLabel = in.TokenLabel
+	out.KeyLabel = in.KeyLabel
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PINSecretRef, &out.PINSecretRef, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_CAPKCS11Key_To_certmanager_CAPKCS11Key is an autogenerated conversion function.
+func Convert_v1alpha2_CAPKCS11Key_To_certmanager_CAPKCS11Key(in *v1alpha2.CAPKCS11Key, out *certmanager.CAPKCS11Key, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CAPKCS11Key_To_certmanager_CAPKCS11Key(in, out, s)
+}
+
+func autoConvert_certmanager_CAPKCS11Key_To_v1alpha2_CAPKCS11Key(in *certmanager.CAPKCS11Key, out *v1alpha2.CAPKCS11Key, s conversion.Scope) error {
+	out.ModulePath = in.ModulePath
+	out.Slot = (*int64)(unsafe.Pointer(in.Slot))
+	out.TokenLabel = in.TokenLabel
+	out.KeyLabel = in.KeyLabel
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PINSecretRef, &out.PINSecretRef, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CAPKCS11Key_To_v1alpha2_CAPKCS11Key is an autogenerated conversion function.
+func Convert_certmanager_CAPKCS11Key_To_v1alpha2_CAPKCS11Key(in *certmanager.CAPKCS11Key, out *v1alpha2.CAPKCS11Key, s conversion.Scope) error {
+	return autoConvert_certmanager_CAPKCS11Key_To_v1alpha2_CAPKCS11Key(in, out, s)
+}
+
 func autoConvert_v1alpha2_Certificate_To_certmanager_Certificate(in *v1alpha2.Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -672,6 +1238,28 @@ func Convert_certmanager_CertificateRequestStatus_To_v1alpha2_CertificateRequest
 	return autoConvert_certmanager_CertificateRequestStatus_To_v1alpha2_CertificateRequestStatus(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateSecretReplication_To_certmanager_CertificateSecretReplication(in *v1alpha2.CertificateSecretReplication, out *certmanager.CertificateSecretReplication, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	return nil
+}
+
+// Convert_v1alpha2_CertificateSecretReplication_To_certmanager_CertificateSecretReplication is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateSecretReplication_To_certmanager_CertificateSecretReplication(in *v1alpha2.CertificateSecretReplication, out *certmanager.CertificateSecretReplication, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateSecretReplication_To_certmanager_CertificateSecretReplication(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateSecretReplication_To_v1alpha2_CertificateSecretReplication(in *certmanager.CertificateSecretReplication, out *v1alpha2.CertificateSecretReplication, s conversion.Scope) error {
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.NamespaceSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NamespaceSelector))
+	return nil
+}
+
+// Convert_certmanager_CertificateSecretReplication_To_v1alpha2_CertificateSecretReplication is an autogenerated conversion function.
+func Convert_certmanager_CertificateSecretReplication_To_v1alpha2_CertificateSecretReplication(in *certmanager.CertificateSecretReplication, out *v1alpha2.CertificateSecretReplication, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateSecretReplication_To_v1alpha2_CertificateSecretReplication(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(in *v1alpha2.CertificateSpec, out *certmanager.CertificateSpec, s conversion.Scope) error {
 	if in.Subject != nil {
 		in, out := &in.Subject, &out.Subject
@@ -692,6 +1280,7 @@ func autoConvert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(in *v1a
 	out.EmailSANs = *(*[]string)(unsafe.Pointer(&in.EmailSANs))
 	out.SecretName = in.SecretName
 	out.Keystores = (*certmanager.CertificateKeystores)(unsafe.Pointer(in.Keystores))
+	out.SecretReplication = (*certmanager.CertificateSecretReplication)(unsafe.Pointer(in.SecretReplication))
 	// TODO: Inefficient conversion - can we improve it?
 	if err := s.Convert(&in.IssuerRef, &out.IssuerRef, 0); err != nil {
 		return err
@@ -724,6 +1313,7 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha2_CertificateSpec(in *cer
 	out.EmailSANs = *(*[]string)(unsafe.Pointer(&in.EmailSANs))
 	out.SecretName = in.SecretName
 	out.Keystores = (*v1alpha2.CertificateKeystores)(unsafe.Pointer(in.Keystores))
+	out.SecretReplication = (*v1alpha2.CertificateSecretReplication)(unsafe.Pointer(in.SecretReplication))
 	// TODO: Inefficient conversion - can we improve it?
 	if err := s.Convert(&in.IssuerRef, &out.IssuerRef, 0); err != nil {
 		return err
@@ -819,6 +1409,84 @@ func Convert_certmanager_ClusterIssuerList_To_v1alpha2_ClusterIssuerList(in *cer
 	return autoConvert_certmanager_ClusterIssuerList_To_v1alpha2_ClusterIssuerList(in, out, s)
 }
 
+func autoConvert_v1alpha2_ESTAuth_To_certmanager_ESTAuth(in *v1alpha2.ESTAuth, out *certmanager.ESTAuth, s conversion.Scope) error {
+	out.BasicAuth = (*certmanager.ESTBasicAuth)(unsafe.Pointer(in.BasicAuth))
+	out.ClientCertSecretRef = (*meta.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	return nil
+}
+
+// Convert_v1alpha2_ESTAuth_To_certmanager_ESTAuth is an autogenerated conversion function.
+func Convert_v1alpha2_ESTAuth_To_certmanager_ESTAuth(in *v1alpha2.ESTAuth, out *certmanager.ESTAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ESTAuth_To_certmanager_ESTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTAuth_To_v1alpha2_ESTAuth(in *certmanager.ESTAuth, out *v1alpha2.ESTAuth, s conversion.Scope) error {
+	out.BasicAuth = (*v1alpha2.ESTBasicAuth)(unsafe.Pointer(in.BasicAuth))
+	out.ClientCertSecretRef = (*metav1.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	return nil
+}
+
+// Convert_certmanager_ESTAuth_To_v1alpha2_ESTAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTAuth_To_v1alpha2_ESTAuth(in *certmanager.ESTAuth, out *v1alpha2.ESTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTAuth_To_v1alpha2_ESTAuth(in, out, s)
+}
+
+func autoConvert_v1alpha2_ESTBasicAuth_To_certmanager_ESTBasicAuth(in *v1alpha2.ESTBasicAuth, out *certmanager.ESTBasicAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PasswordSecretRef, &out.PasswordSecretRef, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ESTBasicAuth_To_certmanager_ESTBasicAuth is an autogenerated conversion function.
+func Convert_v1alpha2_ESTBasicAuth_To_certmanager_ESTBasicAuth(in *v1alpha2.ESTBasicAuth, out *certmanager.ESTBasicAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ESTBasicAuth_To_certmanager_ESTBasicAuth(in, out, s)
+}
+
+func autoConvert_certmanager_ESTBasicAuth_To_v1alpha2_ESTBasicAuth(in *certmanager.ESTBasicAuth, out *v1alpha2.ESTBasicAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PasswordSecretRef, &out.PasswordSecretRef, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTBasicAuth_To_v1alpha2_ESTBasicAuth is an autogenerated conversion function.
+func Convert_certmanager_ESTBasicAuth_To_v1alpha2_ESTBasicAuth(in *certmanager.ESTBasicAuth, out *v1alpha2.ESTBasicAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTBasicAuth_To_v1alpha2_ESTBasicAuth(in, out, s)
+}
+
+func autoConvert_v1alpha2_ESTIssuer_To_certmanager_ESTIssuer(in *v1alpha2.ESTIssuer, out *certmanager.ESTIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if err := Convert_v1alpha2_ESTAuth_To_certmanager_ESTAuth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ESTIssuer_To_certmanager_ESTIssuer is an autogenerated conversion function.
+func Convert_v1alpha2_ESTIssuer_To_certmanager_ESTIssuer(in *v1alpha2.ESTIssuer, out *certmanager.ESTIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ESTIssuer_To_certmanager_ESTIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_ESTIssuer_To_v1alpha2_ESTIssuer(in *certmanager.ESTIssuer, out *v1alpha2.ESTIssuer, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if err := Convert_certmanager_ESTAuth_To_v1alpha2_ESTAuth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_ESTIssuer_To_v1alpha2_ESTIssuer is an autogenerated conversion function.
+func Convert_certmanager_ESTIssuer_To_v1alpha2_ESTIssuer(in *certmanager.ESTIssuer, out *v1alpha2.ESTIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_ESTIssuer_To_v1alpha2_ESTIssuer(in, out, s)
+}
+
 func autoConvert_v1alpha2_Issuer_To_certmanager_Issuer(in *v1alpha2.Issuer, out *certmanager.Issuer, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_IssuerSpec_To_certmanager_IssuerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -885,6 +1553,8 @@ func autoConvert_v1alpha2_IssuerConfig_To_certmanager_IssuerConfig(in *v1alpha2.
 	out.Vault = (*certmanager.VaultIssuer)(unsafe.Pointer(in.Vault))
 	out.SelfSigned = (*certmanager.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
 	out.Venafi = (*certmanager.VenafiIssuer)(unsafe.Pointer(in.Venafi))
+	out.EST = (*certmanager.ESTIssuer)(unsafe.Pointer(in.EST))
+	out.KubernetesCSR = (*certmanager.KubernetesCSRIssuer)(unsafe.Pointer(in.KubernetesCSR))
 	return nil
 }
 
@@ -899,6 +1569,8 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha2_IssuerConfig(in *certmanag
 	out.Vault = (*v1alpha2.VaultIssuer)(unsafe.Pointer(in.Vault))
 	out.SelfSigned = (*v1alpha2.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
 	out.Venafi = (*v1alpha2.VenafiIssuer)(unsafe.Pointer(in.Venafi))
+	out.EST = (*v1alpha2.ESTIssuer)(unsafe.Pointer(in.EST))
+	out.KubernetesCSR = (*v1alpha2.KubernetesCSRIssuer)(unsafe.Pointer(in.KubernetesCSR))
 	return nil
 }
 
@@ -956,6 +1628,7 @@ func Convert_certmanager_IssuerSpec_To_v1alpha2_IssuerSpec(in *certmanager.Issue
 func autoConvert_v1alpha2_IssuerStatus_To_certmanager_IssuerStatus(in *v1alpha2.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*certmanager.CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -967,6 +1640,7 @@ func Convert_v1alpha2_IssuerStatus_To_certmanager_IssuerStatus(in *v1alpha2.Issu
 func autoConvert_certmanager_IssuerStatus_To_v1alpha2_IssuerStatus(in *certmanager.IssuerStatus, out *v1alpha2.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1alpha2.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acmev1alpha2.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.CA = (*v1alpha2.CAIssuerStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1003,6 +1677,28 @@ func Convert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore(in *certmanager.JKS
 	return autoConvert_certmanager_JKSKeystore_To_v1alpha2_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1alpha2_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in *v1alpha2.KubernetesCSRIssuer, out *certmanager.KubernetesCSRIssuer, s conversion.Scope) error {
+	out.SignerName = in.SignerName
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_v1alpha2_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer is an autogenerated conversion function.
+func Convert_v1alpha2_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in *v1alpha2.KubernetesCSRIssuer, out *certmanager.KubernetesCSRIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha2_KubernetesCSRIssuer_To_certmanager_KubernetesCSRIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_KubernetesCSRIssuer_To_v1alpha2_KubernetesCSRIssuer(in *certmanager.KubernetesCSRIssuer, out *v1alpha2.KubernetesCSRIssuer, s conversion.Scope) error {
+	out.SignerName = in.SignerName
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_certmanager_KubernetesCSRIssuer_To_v1alpha2_KubernetesCSRIssuer is an autogenerated conversion function.
+func Convert_certmanager_KubernetesCSRIssuer_To_v1alpha2_KubernetesCSRIssuer(in *certmanager.KubernetesCSRIssuer, out *v1alpha2.KubernetesCSRIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_KubernetesCSRIssuer_To_v1alpha2_KubernetesCSRIssuer(in, out, s)
+}
+
 func autoConvert_v1alpha2_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1alpha2.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
 	// TODO: Inefficient conversion - can we improve it?
@@ -1085,6 +1781,8 @@ func autoConvert_v1alpha2_VaultAuth_To_certmanager_VaultAuth(in *v1alpha2.VaultA
 	out.TokenSecretRef = (*meta.SecretKeySelector)(unsafe.Pointer(in.TokenSecretRef))
 	out.AppRole = (*certmanager.VaultAppRole)(unsafe.Pointer(in.AppRole))
 	out.Kubernetes = (*certmanager.VaultKubernetesAuth)(unsafe.Pointer(in.Kubernetes))
+	out.TLSCert = (*certmanager.VaultTLSCertAuth)(unsafe.Pointer(in.TLSCert))
+	out.JWT = (*certmanager.VaultJWTAuth)(unsafe.Pointer(in.JWT))
 	return nil
 }
 
@@ -1097,6 +1795,8 @@ func autoConvert_certmanager_VaultAuth_To_v1alpha2_VaultAuth(in *certmanager.Vau
 	out.TokenSecretRef = (*metav1.SecretKeySelector)(unsafe.Pointer(in.TokenSecretRef))
 	out.AppRole = (*v1alpha2.VaultAppRole)(unsafe.Pointer(in.AppRole))
 	out.Kubernetes = (*v1alpha2.VaultKubernetesAuth)(unsafe.Pointer(in.Kubernetes))
+	out.TLSCert = (*v1alpha2.VaultTLSCertAuth)(unsafe.Pointer(in.TLSCert))
+	out.JWT = (*v1alpha2.VaultJWTAuth)(unsafe.Pointer(in.JWT))
 	return nil
 }
 
@@ -1111,7 +1811,10 @@ func autoConvert_v1alpha2_VaultIssuer_To_certmanager_VaultIssuer(in *v1alpha2.Va
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.IssuerRef = in.IssuerRef
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ClientCertSecretRef = (*meta.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.Namespace = in.Namespace
 	return nil
 }
 
@@ -1126,7 +1829,10 @@ func autoConvert_certmanager_VaultIssuer_To_v1alpha2_VaultIssuer(in *certmanager
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.IssuerRef = in.IssuerRef
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ClientCertSecretRef = (*metav1.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.Namespace = in.Namespace
 	return nil
 }
 
@@ -1135,6 +1841,38 @@ func Convert_certmanager_VaultIssuer_To_v1alpha2_VaultIssuer(in *certmanager.Vau
 	return autoConvert_certmanager_VaultIssuer_To_v1alpha2_VaultIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha2_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1alpha2.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ServiceAccountRef, &out.ServiceAccountRef, 0); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_v1alpha2_VaultJWTAuth_To_certmanager_VaultJWTAuth is an autogenerated conversion function.
+func Convert_v1alpha2_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1alpha2.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VaultJWTAuth_To_certmanager_VaultJWTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultJWTAuth_To_v1alpha2_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1alpha2.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ServiceAccountRef, &out.ServiceAccountRef, 0); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_certmanager_VaultJWTAuth_To_v1alpha2_VaultJWTAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultJWTAuth_To_v1alpha2_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1alpha2.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultJWTAuth_To_v1alpha2_VaultJWTAuth(in, out, s)
+}
+
 func autoConvert_v1alpha2_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1alpha2.VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
 	// TODO: Inefficient conversion - can we improve it?
@@ -1165,6 +1903,28 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1alpha2_VaultKubernetesAuth(in
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1alpha2_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1alpha2_VaultTLSCertAuth_To_certmanager_VaultTLSCertAuth(in *v1alpha2.VaultTLSCertAuth, out *certmanager.VaultTLSCertAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_VaultTLSCertAuth_To_certmanager_VaultTLSCertAuth is an autogenerated conversion function.
+func Convert_v1alpha2_VaultTLSCertAuth_To_certmanager_VaultTLSCertAuth(in *v1alpha2.VaultTLSCertAuth, out *certmanager.VaultTLSCertAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VaultTLSCertAuth_To_certmanager_VaultTLSCertAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultTLSCertAuth_To_v1alpha2_VaultTLSCertAuth(in *certmanager.VaultTLSCertAuth, out *v1alpha2.VaultTLSCertAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_VaultTLSCertAuth_To_v1alpha2_VaultTLSCertAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultTLSCertAuth_To_v1alpha2_VaultTLSCertAuth(in *certmanager.VaultTLSCertAuth, out *v1alpha2.VaultTLSCertAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultTLSCertAuth_To_v1alpha2_VaultTLSCertAuth(in, out, s)
+}
+
 func autoConvert_v1alpha2_VenafiCloud_To_certmanager_VenafiCloud(in *v1alpha2.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	// TODO: Inefficient conversion - can we improve it?
@@ -1224,6 +1984,7 @@ func autoConvert_v1alpha2_VenafiTPP_To_certmanager_VenafiTPP(in *v1alpha2.Venafi
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.LegacyAPIKeyAuth = in.LegacyAPIKeyAuth
 	return nil
 }
 
@@ -1239,6 +2000,7 @@ func autoConvert_certmanager_VenafiTPP_To_v1alpha2_VenafiTPP(in *certmanager.Ven
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.LegacyAPIKeyAuth = in.LegacyAPIKeyAuth
 	return nil
 }
 
@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// Validation functions for cert-manager v1alpha2 Bundle types
+
+func ValidateBundle(obj runtime.Object) field.ErrorList {
+	bundle := obj.(*certmanager.Bundle)
+	return ValidateBundleSpec(&bundle.Spec, field.NewPath("spec"))
+}
+
+func ValidateBundleSpec(spec *certmanager.BundleSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if len(spec.Sources) == 0 {
+		el = append(el, field.Required(fldPath.Child("sources"), "at least one source must be configured"))
+	}
+	for i, source := range spec.Sources {
+		el = append(el, ValidateBundleSource(&source, fldPath.Child("sources").Index(i))...)
+	}
+
+	el = append(el, ValidateBundleTarget(&spec.Target, fldPath.Child("target"))...)
+
+	return el
+}
+
+func ValidateBundleSource(source *certmanager.BundleSource, fldPath *field.Path) field.ErrorList {
+	numSources := 0
+	el := field.ErrorList{}
+	if source.Secret != nil {
+		numSources++
+		el = append(el, validateBundleSourceObjectKeySelector(source.Secret, fldPath.Child("secret"))...)
+	}
+	if source.ConfigMap != nil {
+		if numSources > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("configMap"), "may not specify more than one source type"))
+		} else {
+			numSources++
+			el = append(el, validateBundleSourceObjectKeySelector(source.ConfigMap, fldPath.Child("configMap"))...)
+		}
+	}
+	if source.Issuer != nil {
+		if numSources > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("issuer"), "may not specify more than one source type"))
+		} else {
+			numSources++
+			el = append(el, validateBundleSourceIssuerReference(source.Issuer, fldPath.Child("issuer"))...)
+		}
+	}
+	if source.InLine != nil {
+		if numSources > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("inLine"), "may not specify more than one source type"))
+		} else {
+			numSources++
+			if _, err := pki.DecodeX509CertificateChainBytes([]byte(*source.InLine)); err != nil {
+				el = append(el, field.Invalid(fldPath.Child("inLine"), "", err.Error()))
+			}
+		}
+	}
+	if numSources == 0 {
+		el = append(el, field.Required(fldPath, "at least one source type must be configured"))
+	}
+	return el
+}
+
+func validateBundleSourceObjectKeySelector(sel *certmanager.BundleSourceObjectKeySelector, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(sel.Namespace) == 0 {
+		el = append(el, field.Required(fldPath.Child("namespace"), "namespace must be specified"))
+	}
+	if len(sel.Name) == 0 {
+		el = append(el, field.Required(fldPath.Child("name"), "name must be specified"))
+	}
+	if len(sel.Key) == 0 {
+		el = append(el, field.Required(fldPath.Child("key"), "key must be specified"))
+	}
+	return el
+}
+
+func validateBundleSourceIssuerReference(ref *certmanager.BundleSourceIssuerReference, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(ref.Name) == 0 {
+		el = append(el, field.Required(fldPath.Child("name"), "name must be specified"))
+	}
+	switch ref.Kind {
+	case "", certmanager.IssuerKind:
+		if len(ref.Namespace) == 0 {
+			el = append(el, field.Required(fldPath.Child("namespace"), "namespace must be specified when referencing an Issuer"))
+		}
+	case certmanager.ClusterIssuerKind:
+		if len(ref.Namespace) > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("namespace"), "namespace may not be specified when referencing a ClusterIssuer"))
+		}
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("kind"), ref.Kind, []string{certmanager.IssuerKind, certmanager.ClusterIssuerKind}))
+	}
+	return el
+}
+
+func ValidateBundleTarget(target *certmanager.BundleTarget, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	cmPath := fldPath.Child("configMap")
+	if len(target.ConfigMap.Name) == 0 {
+		el = append(el, field.Required(cmPath.Child("name"), "name must be specified"))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(target.ConfigMap.Name) {
+			el = append(el, field.Invalid(cmPath.Child("name"), target.ConfigMap.Name, msg))
+		}
+	}
+	el = append(el, validateConfigMapKey(target.ConfigMap.Key, cmPath.Child("key"))...)
+
+	if target.NamespaceSelector != nil {
+		el = append(el, metav1validation.ValidateLabelSelector(target.NamespaceSelector, fldPath.Child("namespaceSelector"))...)
+	}
+
+	if formats := target.AdditionalFormats; formats != nil {
+		formatsPath := fldPath.Child("additionalFormats")
+		// keys already used by the target ConfigMap
+		usedKeys := map[string]string{bundleTargetKey(target): "the configMap key"}
+		if formats.JKS != nil {
+			el = append(el, validateBundleTargetKeystore(formats.JKS, formatsPath.Child("jks", "key"), usedKeys)...)
+			usedKeys[formats.JKS.Key] = "the jks key"
+		}
+		if formats.PKCS12 != nil {
+			el = append(el, validateBundleTargetKeystore(formats.PKCS12, formatsPath.Child("pkcs12", "key"), usedKeys)...)
+		}
+	}
+
+	return el
+}
+
+func validateBundleTargetKeystore(keystore *certmanager.BundleTargetKeystore, fldPath *field.Path, usedKeys map[string]string) field.ErrorList {
+	el := field.ErrorList{}
+	if len(keystore.Key) == 0 {
+		return append(el, field.Required(fldPath, "key must be specified"))
+	}
+	if usedBy, ok := usedKeys[keystore.Key]; ok {
+		return append(el, field.Invalid(fldPath, keystore.Key, "must not be the same as "+usedBy))
+	}
+	return append(el, validateConfigMapKey(keystore.Key, fldPath)...)
+}
+
+// bundleTargetKey returns the key of the target ConfigMap that the PEM
+// encoded bundle is written to.
+func bundleTargetKey(target *certmanager.BundleTarget) string {
+	if len(target.ConfigMap.Key) == 0 {
+		return certmanager.DefaultBundleTargetKey
+	}
+	return target.ConfigMap.Key
+}
+
+func validateConfigMapKey(key string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(key) == 0 {
+		return el
+	}
+	for _, msg := range validation.IsConfigMapKey(key) {
+		el = append(el, field.Invalid(fldPath, key, msg))
+	}
+	return el
+}
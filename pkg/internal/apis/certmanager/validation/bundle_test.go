@@ -0,0 +1,176 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+)
+
+var (
+	validBundleSource = cmapi.BundleSource{
+		Secret: &cmapi.BundleSourceObjectKeySelector{
+			Namespace: "valid",
+			Name:      "valid",
+			Key:       "ca.crt",
+		},
+	}
+	validBundleTarget = cmapi.BundleTarget{
+		ConfigMap: cmapi.BundleTargetConfigMap{Name: "valid"},
+	}
+)
+
+func TestValidateBundleSpec(t *testing.T) {
+	fldPath := field.NewPath("")
+	invalidPEM := "invalid"
+	scenarios := map[string]struct {
+		spec *cmapi.BundleSpec
+		errs []*field.Error
+	}{
+		"valid bundle": {
+			spec: &cmapi.BundleSpec{
+				Sources: []cmapi.BundleSource{
+					validBundleSource,
+					{Issuer: &cmapi.BundleSourceIssuerReference{Name: "ca", Kind: cmapi.ClusterIssuerKind}},
+				},
+				Target: cmapi.BundleTarget{
+					ConfigMap:         cmapi.BundleTargetConfigMap{Name: "valid", Key: "bundle.pem"},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"trust": "true"}},
+					AdditionalFormats: &cmapi.BundleTargetAdditionalFormats{
+						JKS:    &cmapi.BundleTargetKeystore{Key: "bundle.jks"},
+						PKCS12: &cmapi.BundleTargetKeystore{Key: "bundle.p12"},
+					},
+				},
+			},
+		},
+		"bundle with missing fields": {
+			spec: &cmapi.BundleSpec{},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("sources"), "at least one source must be configured"),
+				field.Required(fldPath.Child("target", "configMap", "name"), "name must be specified"),
+			},
+		},
+		"bundle source without any type": {
+			spec: &cmapi.BundleSpec{
+				Sources: []cmapi.BundleSource{{}},
+				Target:  validBundleTarget,
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("sources").Index(0), "at least one source type must be configured"),
+			},
+		},
+		"bundle source with multiple types": {
+			spec: &cmapi.BundleSpec{
+				Sources: []cmapi.BundleSource{{
+					Secret:    validBundleSource.Secret,
+					ConfigMap: validBundleSource.Secret,
+				}},
+				Target: validBundleTarget,
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("sources").Index(0).Child("configMap"), "may not specify more than one source type"),
+			},
+		},
+		"bundle source with invalid inline certificates": {
+			spec: &cmapi.BundleSpec{
+				Sources: []cmapi.BundleSource{{InLine: &invalidPEM}},
+				Target:  validBundleTarget,
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("sources").Index(0).Child("inLine"), "", "error decoding certificate PEM block"),
+			},
+		},
+		"bundle source with invalid issuer references": {
+			spec: &cmapi.BundleSpec{
+				Sources: []cmapi.BundleSource{
+					{Issuer: &cmapi.BundleSourceIssuerReference{Name: "ca"}},
+					{Issuer: &cmapi.BundleSourceIssuerReference{Name: "ca", Kind: cmapi.ClusterIssuerKind, Namespace: "ns"}},
+					{Issuer: &cmapi.BundleSourceIssuerReference{Name: "ca", Kind: "Other"}},
+				},
+				Target: validBundleTarget,
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("sources").Index(0).Child("issuer", "namespace"), "namespace must be specified when referencing an Issuer"),
+				field.Forbidden(fldPath.Child("sources").Index(1).Child("issuer", "namespace"), "namespace may not be specified when referencing a ClusterIssuer"),
+				field.NotSupported(fldPath.Child("sources").Index(2).Child("issuer", "kind"), "Other", []string{cmapi.IssuerKind, cmapi.ClusterIssuerKind}),
+			},
+		},
+		"bundle target with jks key the same as the configmap key": {
+			spec: &cmapi.BundleSpec{
+				Sources: []cmapi.BundleSource{validBundleSource},
+				Target: cmapi.BundleTarget{
+					ConfigMap: cmapi.BundleTargetConfigMap{Name: "valid"},
+					AdditionalFormats: &cmapi.BundleTargetAdditionalFormats{
+						JKS: &cmapi.BundleTargetKeystore{Key: cmapi.DefaultBundleTargetKey},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("target", "additionalFormats", "jks", "key"), cmapi.DefaultBundleTargetKey, "must not be the same as the configMap key"),
+			},
+		},
+		"bundle target with pkcs12 key the same as the jks key": {
+			spec: &cmapi.BundleSpec{
+				Sources: []cmapi.BundleSource{validBundleSource},
+				Target: cmapi.BundleTarget{
+					ConfigMap: cmapi.BundleTargetConfigMap{Name: "valid"},
+					AdditionalFormats: &cmapi.BundleTargetAdditionalFormats{
+						JKS:    &cmapi.BundleTargetKeystore{Key: "bundle.jks"},
+						PKCS12: &cmapi.BundleTargetKeystore{Key: "bundle.jks"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("target", "additionalFormats", "pkcs12", "key"), "bundle.jks", "must not be the same as the jks key"),
+			},
+		},
+		"bundle target with missing pkcs12 key": {
+			spec: &cmapi.BundleSpec{
+				Sources: []cmapi.BundleSource{validBundleSource},
+				Target: cmapi.BundleTarget{
+					ConfigMap: cmapi.BundleTargetConfigMap{Name: "valid"},
+					AdditionalFormats: &cmapi.BundleTargetAdditionalFormats{
+						PKCS12: &cmapi.BundleTargetKeystore{},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("target", "additionalFormats", "pkcs12", "key"), "key must be specified"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateBundleSpec(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
@@ -21,7 +21,9 @@ import (
 	"net"
 	"net/mail"
 
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/jetstack/cert-manager/pkg/api/util"
@@ -76,6 +78,9 @@ func ValidateCertificateSpec(crt *cmapi.CertificateSpec, fldPath *field.Path) fi
 	if len(crt.Usages) > 0 {
 		el = append(el, validateUsages(crt, fldPath)...)
 	}
+	if crt.SecretReplication != nil {
+		el = append(el, validateSecretReplication(crt.SecretReplication, fldPath.Child("secretReplication"))...)
+	}
 	return el
 }
 
@@ -147,6 +152,22 @@ func validateUsages(a *cmapi.CertificateSpec, fldPath *field.Path) field.ErrorLi
 	return el
 }
 
+func validateSecretReplication(r *cmapi.CertificateSecretReplication, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(r.Namespaces) == 0 && r.NamespaceSelector == nil {
+		el = append(el, field.Required(fldPath, "at least one of namespaces or namespaceSelector must be set"))
+	}
+	for i, ns := range r.Namespaces {
+		for _, msg := range validation.IsDNS1123Label(ns) {
+			el = append(el, field.Invalid(fldPath.Child("namespaces").Index(i), ns, msg))
+		}
+	}
+	if r.NamespaceSelector != nil {
+		el = append(el, metav1validation.ValidateLabelSelector(r.NamespaceSelector, fldPath.Child("namespaceSelector"))...)
+	}
+	return el
+}
+
 func ValidateDuration(crt *cmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
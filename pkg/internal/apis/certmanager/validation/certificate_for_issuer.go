@@ -24,6 +24,11 @@ import (
 	cmapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
 )
 
+// ValidateCertificateForIssuer validates the Certificate against the
+// restrictions of the type of the given issuer. Policies that are enforced by
+// an issuer's backend, such as the zone policy of Venafi issuers, cannot be
+// checked here and are instead checked by the controllers using issuers that
+// implement issuer.CertificateValidator.
 func ValidateCertificateForIssuer(crt *cmapi.Certificate, issuerObj cmapi.GenericIssuer) field.ErrorList {
 	el := field.ErrorList{}
 
@@ -37,6 +42,8 @@ func ValidateCertificateForIssuer(crt *cmapi.Certificate, issuerObj cmapi.Generi
 		el = append(el, ValidateCertificateForVaultIssuer(&crt.Spec, issuerObj.GetSpec(), path)...)
 	case issuerObj.GetSpec().SelfSigned != nil:
 	case issuerObj.GetSpec().Venafi != nil:
+	case issuerObj.GetSpec().EST != nil:
+	case issuerObj.GetSpec().KubernetesCSR != nil:
 	default:
 		el = append(el, field.Invalid(path, "", fmt.Sprintf("no issuer specified for Issuer '%s/%s'", issuerObj.GetObjectMeta().Namespace, issuerObj.GetObjectMeta().Name)))
 	}
@@ -458,6 +458,47 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("emailSANs").Index(0), "mailto:alice@example.com", "invalid email address: mail: expected comma"),
 			},
 		},
+		"valid certificate with secret replication": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					SecretReplication: &cmapi.CertificateSecretReplication{
+						Namespaces:        []string{"ns1", "ns2"},
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"wildcard": "true"}},
+					},
+				},
+			},
+		},
+		"invalid certificate with empty secret replication": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName:        "testcn",
+					SecretName:        "abc",
+					IssuerRef:         validIssuerRef,
+					SecretReplication: &cmapi.CertificateSecretReplication{},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("secretReplication"), "at least one of namespaces or namespaceSelector must be set"),
+			},
+		},
+		"invalid certificate with invalid secret replication namespace": {
+			cfg: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					SecretReplication: &cmapi.CertificateSecretReplication{
+						Namespaces: []string{"Invalid_Namespace"},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("secretReplication", "namespaces").Index(0), "Invalid_Namespace", "a DNS-1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
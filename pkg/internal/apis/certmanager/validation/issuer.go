@@ -19,18 +19,33 @@ package validation
 import (
 	"crypto/x509"
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
 	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/validation/util"
 	cmmeta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+	"github.com/jetstack/cert-manager/pkg/internal/pkcs11"
 )
 
+// pkcs11AllowedModules is the list of paths to the PKCS#11 modules that CA
+// issuers may use. If empty, CA issuers may not use PKCS#11 tokens.
+var pkcs11AllowedModules []string
+
+// SetPKCS11AllowedModules sets the paths to the PKCS#11 modules that CA
+// issuers may use, as configured by the --pkcs11-allowed-modules flag of the
+// webhook. It must be called before any Issuers are validated.
+func SetPKCS11AllowedModules(modules []string) {
+	pkcs11AllowedModules = modules
+}
+
 // Validation functions for cert-manager v1alpha2 Issuer types
 
 func ValidateIssuer(obj runtime.Object) field.ErrorList {
@@ -88,6 +103,22 @@ func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) fi
 			el = append(el, ValidateVenafiIssuerConfig(iss.Venafi, fldPath.Child("venafi"))...)
 		}
 	}
+	if iss.EST != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("est"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateESTIssuerConfig(iss.EST, fldPath.Child("est"))...)
+		}
+	}
+	if iss.KubernetesCSR != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("kubernetesCSR"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidateKubernetesCSRIssuerConfig(iss.KubernetesCSR, fldPath.Child("kubernetesCSR"))...)
+		}
+	}
 	if numConfigs == 0 {
 		el = append(el, field.Required(fldPath, "at least one issuer must be configured"))
 	}
@@ -155,6 +186,24 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 		numDefined++
 		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(http01.Ingress, fldPath.Child("ingress"))...)
 	}
+	if http01.HTTPProxy != nil {
+		if numDefined > 0 {
+			el = append(el, field.Forbidden(fldPath, "may not specify more than one HTTP01 solver type"))
+		} else {
+			numDefined++
+			el = append(el, validateACMEIssuerChallengeSolverHTTP01ExistingResourceConfig(http01.HTTPProxy.Name, http01.HTTPProxy.ServiceType, fldPath.Child("httpProxy"))...)
+			el = append(el, validateACMEIssuerChallengeSolverHTTP01PodTemplate(http01.HTTPProxy.PodTemplate, fldPath.Child("httpProxy", "podTemplate"))...)
+		}
+	}
+	if http01.IngressRoute != nil {
+		if numDefined > 0 {
+			el = append(el, field.Forbidden(fldPath, "may not specify more than one HTTP01 solver type"))
+		} else {
+			numDefined++
+			el = append(el, validateACMEIssuerChallengeSolverHTTP01ExistingResourceConfig(http01.IngressRoute.Name, http01.IngressRoute.ServiceType, fldPath.Child("ingressRoute"))...)
+			el = append(el, validateACMEIssuerChallengeSolverHTTP01PodTemplate(http01.IngressRoute.PodTemplate, fldPath.Child("ingressRoute", "podTemplate"))...)
+		}
+	}
 	if numDefined == 0 {
 		el = append(el, field.Required(fldPath, "no HTTP01 solver type configured"))
 	}
@@ -165,23 +214,104 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 func ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(ingress *cmacme.ACMEChallengeSolverHTTP01Ingress, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
-	if ingress.Class != nil && len(ingress.Name) > 0 {
-		el = append(el, field.Forbidden(fldPath, "only one of 'name' or 'class' should be specified"))
+	numDefined := 0
+	if ingress.Class != nil {
+		numDefined++
+	}
+	if ingress.IngressClassName != nil {
+		numDefined++
+	}
+	if len(ingress.Name) > 0 {
+		numDefined++
+	}
+	if numDefined > 1 {
+		el = append(el, field.Forbidden(fldPath, "only one of 'name', 'class' or 'ingressClassName' should be specified"))
 	}
 	switch ingress.ServiceType {
 	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort:
 	default:
 		el = append(el, field.Invalid(fldPath.Child("serviceType"), ingress.ServiceType, `must be empty, "ClusterIP" or "NodePort"`))
 	}
+	el = append(el, validateACMEIssuerChallengeSolverHTTP01PodTemplate(ingress.PodTemplate, fldPath.Child("podTemplate"))...)
 
 	return el
 }
 
+// validateACMEIssuerChallengeSolverHTTP01PodTemplate validates the overrides
+// for HTTP01 solver pods. Requests that are set without a corresponding limit
+// are checked against the controller's defaults when the pod is created.
+func validateACMEIssuerChallengeSolverHTTP01PodTemplate(podTempl *cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if podTempl == nil || podTempl.Spec.Resources == nil {
+		return el
+	}
+
+	resources := podTempl.Spec.Resources
+	var names []string
+	for name := range resources.Requests {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		request := resources.Requests[corev1.ResourceName(name)]
+		limit, ok := resources.Limits[corev1.ResourceName(name)]
+		if ok && request.Cmp(limit) > 0 {
+			el = append(el, field.Invalid(fldPath.Child("spec", "resources", "requests").Key(name), request.String(), fmt.Sprintf("must be less than or equal to %s limit", name)))
+		}
+	}
+	return el
+}
+
+// validateACMEIssuerChallengeSolverHTTP01ExistingResourceConfig validates
+// the config of HTTP01 solvers that add routes to an existing resource.
+func validateACMEIssuerChallengeSolverHTTP01ExistingResourceConfig(name string, serviceType corev1.ServiceType, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(name) == 0 {
+		el = append(el, field.Required(fldPath.Child("name"), ""))
+	}
+	switch serviceType {
+	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort:
+	default:
+		el = append(el, field.Invalid(fldPath.Child("serviceType"), serviceType, `must be empty, "ClusterIP" or "NodePort"`))
+	}
+	return el
+}
+
 func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if len(iss.SecretName) == 0 {
 		el = append(el, field.Required(fldPath.Child("secretName"), ""))
 	}
+	if iss.PKCS11 != nil {
+		el = append(el, ValidateCAPKCS11Key(iss.PKCS11, fldPath.Child("pkcs11"))...)
+	}
+	return el
+}
+
+func ValidateCAPKCS11Key(key *certmanager.CAPKCS11Key, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(key.ModulePath) == 0 {
+		el = append(el, field.Required(fldPath.Child("modulePath"), ""))
+	} else if !strings.HasPrefix(key.ModulePath, "/") {
+		el = append(el, field.Invalid(fldPath.Child("modulePath"), key.ModulePath, "must be an absolute path"))
+	} else if len(pkcs11AllowedModules) == 0 {
+		el = append(el, field.Forbidden(fldPath, "PKCS#11 tokens may not be used as no modules are allowed by the --pkcs11-allowed-modules flag. "+
+			"PKCS#11 also requires a custom build of cert-manager with cgo enabled, as the released images are built without cgo"))
+	} else if !pkcs11.ModuleAllowed(key.ModulePath, pkcs11AllowedModules) {
+		el = append(el, field.NotSupported(fldPath.Child("modulePath"), key.ModulePath, pkcs11AllowedModules))
+	}
+	switch {
+	case key.Slot == nil && len(key.TokenLabel) == 0:
+		el = append(el, field.Required(fldPath, "one of slot or tokenLabel must be set"))
+	case key.Slot != nil && len(key.TokenLabel) > 0:
+		el = append(el, field.Forbidden(fldPath, "only one of slot or tokenLabel may be set"))
+	case key.Slot != nil && *key.Slot < 0:
+		el = append(el, field.Invalid(fldPath.Child("slot"), *key.Slot, "must not be negative"))
+	}
+	if len(key.KeyLabel) == 0 {
+		el = append(el, field.Required(fldPath.Child("keyLabel"), ""))
+	}
+	el = append(el, ValidateSecretKeySelector(&key.PINSecretRef, fldPath.Child("pinSecretRef"))...)
 	return el
 }
 
@@ -208,6 +338,34 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 		}
 	}
 
+	if len(iss.IssuerRef) > 0 {
+		if strings.Contains(iss.IssuerRef, "/") {
+			el = append(el, field.Invalid(fldPath.Child("issuerRef"), iss.IssuerRef, "must not contain '/'"))
+		}
+		if !strings.Contains(iss.Path, "/sign/") && !strings.Contains(iss.Path, "/sign-verbatim/") {
+			el = append(el, field.Invalid(fldPath.Child("path"), iss.Path, "must be of the form <mount>/sign/<role> when issuerRef is set"))
+		}
+	}
+
+	if iss.Auth.TLSCert != nil && iss.ClientCertSecretRef == nil {
+		el = append(el, field.Required(fldPath.Child("clientCertSecretRef"), "required when using tlsCert authentication"))
+	}
+
+	if jwt := iss.Auth.JWT; jwt != nil {
+		jwtPath := fldPath.Child("auth", "jwt")
+		if len(jwt.Role) == 0 {
+			el = append(el, field.Required(jwtPath.Child("role"), ""))
+		}
+		if len(jwt.ServiceAccountRef.Name) == 0 {
+			el = append(el, field.Required(jwtPath.Child("serviceAccountRef", "name"), ""))
+		}
+		for i, aud := range jwt.Audiences {
+			if len(aud) == 0 {
+				el = append(el, field.Invalid(jwtPath.Child("audiences").Index(i), aud, "audience must not be empty"))
+			}
+		}
+	}
+
 	return el
 	// TODO: add validation for Vault authentication types
 }
@@ -217,6 +375,67 @@ func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Pa
 	return nil
 }
 
+func ValidateESTIssuerConfig(iss *certmanager.ESTIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(iss.URL) == 0 {
+		el = append(el, field.Required(fldPath.Child("url"), ""))
+	} else if u, err := url.Parse(iss.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+		// RFC 7030 requires EST servers to be accessed over TLS
+		el = append(el, field.Invalid(fldPath.Child("url"), iss.URL, "must be a valid https URL"))
+	}
+
+	if len(iss.CABundle) > 0 {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM(iss.CABundle); !ok {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"))
+		}
+	}
+
+	authPath := fldPath.Child("auth")
+	if iss.Auth.BasicAuth == nil && iss.Auth.ClientCertSecretRef == nil {
+		el = append(el, field.Required(authPath, "one of basicAuth or clientCertSecretRef must be set"))
+	}
+	if basicAuth := iss.Auth.BasicAuth; basicAuth != nil {
+		if len(basicAuth.Username) == 0 {
+			el = append(el, field.Required(authPath.Child("basicAuth", "username"), ""))
+		}
+		el = append(el, ValidateSecretKeySelector(&basicAuth.PasswordSecretRef, authPath.Child("basicAuth", "passwordSecretRef"))...)
+	}
+	if ref := iss.Auth.ClientCertSecretRef; ref != nil && len(ref.Name) == 0 {
+		el = append(el, field.Required(authPath.Child("clientCertSecretRef", "name"), ""))
+	}
+
+	return el
+}
+
+func ValidateKubernetesCSRIssuerConfig(iss *certmanager.KubernetesCSRIssuer, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(iss.SignerName) == 0 {
+		el = append(el, field.Required(fldPath.Child("signerName"), ""))
+		return el
+	}
+
+	// Signer names have the form <domain>/<path>, as validated by the
+	// Kubernetes API server when CertificateSigningRequests are created.
+	parts := strings.SplitN(iss.SignerName, "/", 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		el = append(el, field.Invalid(fldPath.Child("signerName"), iss.SignerName, "must be a fully qualified domain and path of the form 'example.com/signer-name'"))
+		return el
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(parts[0]) {
+		el = append(el, field.Invalid(fldPath.Child("signerName"), iss.SignerName, "domain: "+msg))
+	}
+
+	if len(iss.CABundle) > 0 {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM(iss.CABundle); !ok {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"))
+		}
+	}
+
+	return el
+}
+
 // This list must be kept in sync with pkg/issuer/acme/dns/rfc2136/rfc2136.go
 var supportedTSIGAlgorithms = []string{
 	"HMACMD5",
@@ -394,6 +613,26 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			}
 		}
 	}
+	if p.GRPC != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("grpc"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			if len(p.GRPC.Endpoint) == 0 {
+				el = append(el, field.Required(fldPath.Child("grpc", "endpoint"), "solver endpoint must be specified"))
+			}
+			if len(p.GRPC.SolverName) == 0 {
+				el = append(el, field.Required(fldPath.Child("grpc", "solverName"), "solver name must be specified"))
+			}
+			if ref := p.GRPC.ClientCertSecretRef; ref != nil {
+				if strings.HasPrefix(p.GRPC.Endpoint, "unix://") {
+					el = append(el, field.Forbidden(fldPath.Child("grpc", "clientCertSecretRef"), "client certificates can only be used with TCP endpoints"))
+				} else if len(ref.Name) == 0 {
+					el = append(el, field.Required(fldPath.Child("grpc", "clientCertSecretRef", "name"), "secret name is required"))
+				}
+			}
+		}
+	}
 	if numProviders == 0 {
 		el = append(el, field.Required(fldPath, "no DNS01 provider configured"))
 	}
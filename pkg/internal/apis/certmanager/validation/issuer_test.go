@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
@@ -83,6 +85,58 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
 			},
 		},
+		"valid vault issuer with issuerRef": {
+			spec: &cmapi.VaultIssuer{
+				Server:    "something",
+				Path:      "pki/sign/role",
+				IssuerRef: "intermediate-2020",
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &validSecretKeyRef,
+				},
+			},
+		},
+		"vault issuer with issuerRef and a path that is not a sign path": {
+			spec: &cmapi.VaultIssuer{
+				Server:    "something",
+				Path:      "a/b/c",
+				IssuerRef: "a/b",
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuerRef"), "a/b", "must not contain '/'"),
+				field.Invalid(fldPath.Child("path"), "a/b/c", "must be of the form <mount>/sign/<role> when issuerRef is set"),
+			},
+		},
+		"vault issuer with jwt auth missing required fields": {
+			spec: &cmapi.VaultIssuer{
+				Server: "something",
+				Path:   "a/b/c",
+				Auth: cmapi.VaultAuth{
+					JWT: &cmapi.VaultJWTAuth{
+						Audiences: []string{""},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("auth", "jwt", "role"), ""),
+				field.Required(fldPath.Child("auth", "jwt", "serviceAccountRef", "name"), ""),
+				field.Invalid(fldPath.Child("auth", "jwt", "audiences").Index(0), "", "audience must not be empty"),
+			},
+		},
+		"vault issuer with tls cert auth and no client certificate": {
+			spec: &cmapi.VaultIssuer{
+				Server: "something",
+				Path:   "a/b/c",
+				Auth: cmapi.VaultAuth{
+					TLSCert: &cmapi.VaultTLSCertAuth{},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("clientCertSecretRef"), "required when using tlsCert authentication"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -101,6 +155,275 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 	}
 }
 
+func TestValidateESTIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("")
+	scenarios := map[string]struct {
+		spec *cmapi.ESTIssuer
+		errs []*field.Error
+	}{
+		"valid est issuer with basic auth": {
+			spec: &cmapi.ESTIssuer{
+				URL: "https://est.example.com/.well-known/est",
+				Auth: cmapi.ESTAuth{
+					BasicAuth: &cmapi.ESTBasicAuth{
+						Username:          "cert-manager",
+						PasswordSecretRef: validSecretKeyRef,
+					},
+				},
+			},
+		},
+		"valid est issuer with client certificate auth": {
+			spec: &cmapi.ESTIssuer{
+				URL: "https://est.example.com/.well-known/est/label",
+				Auth: cmapi.ESTAuth{
+					ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "est-client"},
+				},
+			},
+		},
+		"est issuer with missing fields": {
+			spec: &cmapi.ESTIssuer{},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("url"), ""),
+				field.Required(fldPath.Child("auth"), "one of basicAuth or clientCertSecretRef must be set"),
+			},
+		},
+		"est issuer with invalid fields": {
+			spec: &cmapi.ESTIssuer{
+				URL:      "http://est.example.com/.well-known/est",
+				CABundle: []byte("invalid"),
+				Auth: cmapi.ESTAuth{
+					BasicAuth:           &cmapi.ESTBasicAuth{},
+					ClientCertSecretRef: &cmmeta.LocalObjectReference{},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("url"), "http://est.example.com/.well-known/est", "must be a valid https URL"),
+				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
+				field.Required(fldPath.Child("auth", "basicAuth", "username"), ""),
+				field.Required(fldPath.Child("auth", "basicAuth", "passwordSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("auth", "basicAuth", "passwordSecretRef", "key"), "secret key is required"),
+				field.Required(fldPath.Child("auth", "clientCertSecretRef", "name"), ""),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateESTIssuerConfig(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCAIssuerConfig(t *testing.T) {
+	SetPKCS11AllowedModules([]string{"/usr/lib/softhsm/libsofthsm2.so"})
+	defer SetPKCS11AllowedModules(nil)
+
+	fldPath := field.NewPath("")
+	slot := int64(0)
+	negativeSlot := int64(-1)
+	scenarios := map[string]struct {
+		spec *cmapi.CAIssuer
+		errs []*field.Error
+	}{
+		"valid ca issuer": {
+			spec: &cmapi.CAIssuer{
+				SecretName: "ca-key-pair",
+			},
+		},
+		"valid ca issuer with a pkcs11 key selected by slot": {
+			spec: &cmapi.CAIssuer{
+				SecretName: "ca-cert",
+				PKCS11: &cmapi.CAPKCS11Key{
+					ModulePath:   "/usr/lib/softhsm/libsofthsm2.so",
+					Slot:         &slot,
+					KeyLabel:     "ca-key",
+					PINSecretRef: validSecretKeyRef,
+				},
+			},
+		},
+		"valid ca issuer with a pkcs11 key selected by token label": {
+			spec: &cmapi.CAIssuer{
+				SecretName: "ca-cert",
+				PKCS11: &cmapi.CAPKCS11Key{
+					ModulePath:   "/usr/lib/softhsm/libsofthsm2.so",
+					TokenLabel:   "cert-manager",
+					KeyLabel:     "ca-key",
+					PINSecretRef: validSecretKeyRef,
+				},
+			},
+		},
+		"ca issuer with missing fields": {
+			spec: &cmapi.CAIssuer{
+				PKCS11: &cmapi.CAPKCS11Key{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("secretName"), ""),
+				field.Required(fldPath.Child("pkcs11", "modulePath"), ""),
+				field.Required(fldPath.Child("pkcs11"), "one of slot or tokenLabel must be set"),
+				field.Required(fldPath.Child("pkcs11", "keyLabel"), ""),
+				field.Required(fldPath.Child("pkcs11", "pinSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("pkcs11", "pinSecretRef", "key"), "secret key is required"),
+			},
+		},
+		"ca issuer with both slot and token label set": {
+			spec: &cmapi.CAIssuer{
+				SecretName: "ca-cert",
+				PKCS11: &cmapi.CAPKCS11Key{
+					ModulePath:   "libsofthsm2.so",
+					Slot:         &slot,
+					TokenLabel:   "cert-manager",
+					KeyLabel:     "ca-key",
+					PINSecretRef: validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("pkcs11", "modulePath"), "libsofthsm2.so", "must be an absolute path"),
+				field.Forbidden(fldPath.Child("pkcs11"), "only one of slot or tokenLabel may be set"),
+			},
+		},
+		"ca issuer with a negative slot": {
+			spec: &cmapi.CAIssuer{
+				SecretName: "ca-cert",
+				PKCS11: &cmapi.CAPKCS11Key{
+					ModulePath:   "/usr/lib/softhsm/libsofthsm2.so",
+					Slot:         &negativeSlot,
+					KeyLabel:     "ca-key",
+					PINSecretRef: validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("pkcs11", "slot"), int64(-1), "must not be negative"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateCAIssuerConfig(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCAPKCS11KeyAllowedModules(t *testing.T) {
+	fldPath := field.NewPath("")
+	key := &cmapi.CAPKCS11Key{
+		ModulePath:   "/usr/lib/softhsm/libsofthsm2.so",
+		TokenLabel:   "cert-manager",
+		KeyLabel:     "ca-key",
+		PINSecretRef: validSecretKeyRef,
+	}
+	scenarios := map[string]struct {
+		allowedModules []string
+		errs           []*field.Error
+	}{
+		"pkcs11 key using an allowed module": {
+			allowedModules: []string{"/usr/lib/other/libother.so", "/usr/lib/softhsm/libsofthsm2.so"},
+		},
+		"pkcs11 key using a module that is not allowed": {
+			allowedModules: []string{"/usr/lib/other/libother.so"},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("modulePath"), "/usr/lib/softhsm/libsofthsm2.so", []string{"/usr/lib/other/libother.so"}),
+			},
+		},
+		"pkcs11 key when no modules are allowed": {
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "PKCS#11 tokens may not be used as no modules are allowed by the --pkcs11-allowed-modules flag. "+
+					"PKCS#11 also requires a custom build of cert-manager with cgo enabled, as the released images are built without cgo"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			SetPKCS11AllowedModules(s.allowedModules)
+			defer SetPKCS11AllowedModules(nil)
+
+			errs := ValidateCAPKCS11Key(key, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateKubernetesCSRIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("")
+	scenarios := map[string]struct {
+		spec *cmapi.KubernetesCSRIssuer
+		errs []*field.Error
+	}{
+		"valid kubernetes csr issuer": {
+			spec: &cmapi.KubernetesCSRIssuer{SignerName: "example.com/my-signer"},
+		},
+		"valid kubernetes csr issuer using a kubernetes.io signer": {
+			spec: &cmapi.KubernetesCSRIssuer{SignerName: "kubernetes.io/legacy-unknown"},
+		},
+		"kubernetes csr issuer with missing signer name": {
+			spec: &cmapi.KubernetesCSRIssuer{},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("signerName"), ""),
+			},
+		},
+		"kubernetes csr issuer with signer name missing a path": {
+			spec: &cmapi.KubernetesCSRIssuer{SignerName: "example.com"},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("signerName"), "example.com", "must be a fully qualified domain and path of the form 'example.com/signer-name'"),
+			},
+		},
+		"kubernetes csr issuer with signer name with an invalid domain": {
+			spec: &cmapi.KubernetesCSRIssuer{SignerName: "Example_com/my-signer"},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("signerName"), "Example_com/my-signer", "domain: "+validation.IsDNS1123Subdomain("Example_com")[0]),
+			},
+		},
+		"kubernetes csr issuer with invalid ca bundle": {
+			spec: &cmapi.KubernetesCSRIssuer{SignerName: "example.com/my-signer", CABundle: []byte("invalid")},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateKubernetesCSRIssuerConfig(s.spec, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateACMEIssuerConfig(t *testing.T) {
 	fldPath := field.NewPath("")
 	scenarios := map[string]struct {
@@ -393,6 +716,11 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("abc")},
 			},
 		},
+		"ingressClassName field specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{IngressClassName: strPtr("abc")},
+			},
+		},
 		"neither field specified": {
 			cfg: &cmacme.ACMEChallengeSolverHTTP01{
 				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
@@ -412,7 +740,112 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				},
 			},
 			errs: []*field.Error{
-				field.Forbidden(fldPath.Child("ingress"), "only one of 'name' or 'class' should be specified"),
+				field.Forbidden(fldPath.Child("ingress"), "only one of 'name', 'class' or 'ingressClassName' should be specified"),
+			},
+		},
+		"httpProxy field specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				HTTPProxy: &cmacme.ACMEChallengeSolverHTTP01HTTPProxy{Name: "abc"},
+			},
+		},
+		"ingressRoute field specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				IngressRoute: &cmacme.ACMEChallengeSolverHTTP01IngressRoute{Name: "abc"},
+			},
+		},
+		"httpProxy without a name": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				HTTPProxy: &cmacme.ACMEChallengeSolverHTTP01HTTPProxy{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("httpProxy", "name"), ""),
+			},
+		},
+		"ingressRoute with an invalid serviceType": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				IngressRoute: &cmacme.ACMEChallengeSolverHTTP01IngressRoute{
+					Name:        "abc",
+					ServiceType: corev1.ServiceType("LoadBalancer"),
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ingressRoute", "serviceType"), corev1.ServiceType("LoadBalancer"), `must be empty, "ClusterIP" or "NodePort"`),
+			},
+		},
+		"ingress and httpProxy specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress:   &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				HTTPProxy: &cmacme.ACMEChallengeSolverHTTP01HTTPProxy{Name: "abc"},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "may not specify more than one HTTP01 solver type"),
+			},
+		},
+		"both class and ingressClassName specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					Class:            strPtr("abc"),
+					IngressClassName: strPtr("abc"),
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("ingress"), "only one of 'name', 'class' or 'ingressClassName' should be specified"),
+			},
+		},
+		"ingress pod template with requests within limits": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+						Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+							Resources: &corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+								Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+							},
+						},
+					},
+				},
+			},
+		},
+		"ingress pod template with requests exceeding limits": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+						Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+							Resources: &corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("200m"),
+									corev1.ResourceMemory: resource.MustParse("128Mi"),
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("100m"),
+									corev1.ResourceMemory: resource.MustParse("64Mi"),
+								},
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ingress", "podTemplate", "spec", "resources", "requests").Key("cpu"), "200m", "must be less than or equal to cpu limit"),
+				field.Invalid(fldPath.Child("ingress", "podTemplate", "spec", "resources", "requests").Key("memory"), "128Mi", "must be less than or equal to memory limit"),
+			},
+		},
+		"httpProxy pod template with requests exceeding limits": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				HTTPProxy: &cmacme.ACMEChallengeSolverHTTP01HTTPProxy{
+					Name: "abc",
+					PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+						Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+							Resources: &corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+								Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("httpProxy", "podTemplate", "spec", "resources", "requests").Key("cpu"), "1", "must be less than or equal to cpu limit"),
 			},
 		},
 		"acme issuer with valid http01 service config serviceType ClusterIP": {
@@ -883,6 +1316,56 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Forbidden(fldPath.Child("cloudflare"), "may not specify more than one provider type"),
 			},
 		},
+		"valid grpc provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				GRPC: &cmacme.ACMEIssuerDNS01ProviderGRPC{
+					Endpoint:   "unix:///var/run/solver.sock",
+					SolverName: "example",
+				},
+			},
+		},
+		"valid grpc provider with client certificate": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				GRPC: &cmacme.ACMEIssuerDNS01ProviderGRPC{
+					Endpoint:            "solver.example.com:443",
+					SolverName:          "example",
+					ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "client-cert"},
+				},
+			},
+		},
+		"grpc provider with client certificate for unix endpoint": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				GRPC: &cmacme.ACMEIssuerDNS01ProviderGRPC{
+					Endpoint:            "unix:///var/run/solver.sock",
+					SolverName:          "example",
+					ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "client-cert"},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("grpc", "clientCertSecretRef"), "client certificates can only be used with TCP endpoints"),
+			},
+		},
+		"grpc provider with missing client certificate secret name": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				GRPC: &cmacme.ACMEIssuerDNS01ProviderGRPC{
+					Endpoint:            "solver.example.com:443",
+					SolverName:          "example",
+					ClientCertSecretRef: &cmmeta.LocalObjectReference{},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("grpc", "clientCertSecretRef", "name"), "secret name is required"),
+			},
+		},
+		"grpc provider with missing endpoint and solver name": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				GRPC: &cmacme.ACMEIssuerDNS01ProviderGRPC{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("grpc", "endpoint"), "solver endpoint must be specified"),
+				field.Required(fldPath.Child("grpc", "solverName"), "solver name must be specified"),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -22,6 +22,9 @@ import (
 )
 
 func AddToValidationRegistry(reg *validation.Registry) error {
+	if err := reg.AddValidateFunc(&cmapi.Bundle{}, ValidateBundle); err != nil {
+		return err
+	}
 	if err := reg.AddValidateFunc(&cmapi.Certificate{}, ValidateCertificate); err != nil {
 		return err
 	}
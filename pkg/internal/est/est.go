@@ -0,0 +1,360 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package est implements a client for the simple enrollment and CA
+// certificates operations of the Enrollment over Secure Transport (EST)
+// protocol defined in RFC 7030.
+package est
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	simpleEnrollPath = "/simpleenroll"
+	caCertsPath      = "/cacerts"
+
+	// requestTimeout is the timeout for each request made to the EST server
+	requestTimeout = time.Second * 30
+
+	// maxErrorBodySize is the maximum number of bytes of an error response
+	// body that are included in errors
+	maxErrorBodySize = 1024
+
+	// idleConnTimeout is how long idle connections to the EST server are
+	// kept open for reuse by later requests
+	idleConnTimeout = time.Second * 90
+)
+
+// transports holds the HTTP transport of each EST issuer, keyed by the UID of
+// the issuer. A client is built every time an issuer is used, so transports
+// are shared between clients to reuse connections to the EST server rather
+// than leaking a set of idle connections per client.
+var (
+	transports     = make(map[types.UID]*cachedTransport)
+	transportsLock sync.Mutex
+)
+
+// cachedTransport is a transport along with a hash of the TLS configuration
+// it was created with.
+type cachedTransport struct {
+	*http.Transport
+	tlsConfigHash string
+}
+
+var _ Interface = &EST{}
+
+type ClientBuilder func(namespace string, secretsLister corelisters.SecretLister, issuer v1alpha2.GenericIssuer) (Interface, error)
+
+type Interface interface {
+	// Sign enrolls the PEM encoded CSR with the EST server, returning the
+	// PEM encoded certificate chain and the CA that issued it.
+	Sign(ctx context.Context, csrPEM []byte) (certPEM []byte, caPEM []byte, err error)
+
+	// CACerts returns the current CA certificates of the EST server.
+	CACerts(ctx context.Context) ([]*x509.Certificate, error)
+}
+
+// EnrollmentPendingError is returned when the EST server has accepted an
+// enrollment request but not yet issued the certificate. The same request
+// should be sent again after RetryAfter.
+type EnrollmentPendingError struct {
+	RetryAfter time.Duration
+}
+
+func (e *EnrollmentPendingError) Error() string {
+	return fmt.Sprintf("enrollment is pending manual approval on the EST server, retry after %s", e.RetryAfter)
+}
+
+type EST struct {
+	url      string
+	username string
+	password string
+
+	client *http.Client
+}
+
+// New returns an EST client for the issuer, configured to authenticate with
+// the credentials referenced by the issuer. Secrets are read from the given
+// namespace.
+func New(namespace string, secretsLister corelisters.SecretLister, issuer v1alpha2.GenericIssuer) (Interface, error) {
+	estCfg := issuer.GetSpec().EST
+	if estCfg == nil {
+		return nil, fmt.Errorf("issuer %q is not an EST issuer", issuer.GetObjectMeta().Name)
+	}
+
+	tlsConfig := &tls.Config{}
+	tlsConfigHash := sha256.New()
+	tlsConfigHash.Write(estCfg.CABundle)
+	if len(estCfg.CABundle) > 0 {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM(estCfg.CABundle); !ok {
+			return nil, fmt.Errorf("error loading EST CA bundle")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	e := &EST{
+		url: strings.TrimSuffix(estCfg.URL, "/"),
+	}
+
+	if ref := estCfg.Auth.ClientCertSecretRef; ref != nil {
+		secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		clientCert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("error loading EST client certificate from secret '%s/%s': %s", namespace, ref.Name, err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+		for _, der := range clientCert.Certificate {
+			tlsConfigHash.Write(der)
+		}
+	}
+
+	if basicAuth := estCfg.Auth.BasicAuth; basicAuth != nil {
+		ref := basicAuth.PasswordSecretRef
+		secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		password, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("no data for %q in secret '%s/%s'", ref.Key, namespace, ref.Name)
+		}
+
+		e.username = basicAuth.Username
+		e.password = string(password)
+	}
+
+	e.client = &http.Client{
+		Timeout:   requestTimeout,
+		Transport: transportFor(issuer.GetObjectMeta().UID, tlsConfig, fmt.Sprintf("%x", tlsConfigHash.Sum(nil))),
+	}
+
+	return e, nil
+}
+
+// transportFor returns the transport of the issuer with the given UID,
+// creating a new one if the TLS configuration of the issuer has changed. Idle
+// connections of a replaced transport are closed.
+func transportFor(uid types.UID, tlsConfig *tls.Config, tlsConfigHash string) *http.Transport {
+	transportsLock.Lock()
+	defer transportsLock.Unlock()
+
+	cached, ok := transports[uid]
+	if ok && cached.tlsConfigHash == tlsConfigHash {
+		return cached.Transport
+	}
+
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+		IdleConnTimeout: idleConnTimeout,
+	}
+	transports[uid] = &cachedTransport{Transport: transport, tlsConfigHash: tlsConfigHash}
+	if ok {
+		cached.CloseIdleConnections()
+	}
+
+	return transport
+}
+
+func (e *EST) Sign(ctx context.Context, csrPEM []byte) ([]byte, []byte, error) {
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
+	}
+
+	issued, err := e.simpleEnroll(ctx, csr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The response should only contain the issued certificate, but some
+	// servers include the chain, so find the certificate for the CSR.
+	var cert *x509.Certificate
+	var intermediates []*x509.Certificate
+	for _, c := range issued {
+		if cert == nil {
+			if matches, err := pki.PublicKeyMatchesCertificate(csr.PublicKey, c); err == nil && matches {
+				cert = c
+				continue
+			}
+		}
+		intermediates = append(intermediates, c)
+	}
+	if cert == nil {
+		return nil, nil, fmt.Errorf("EST server did not return a certificate for the requested public key")
+	}
+
+	caCerts, err := e.CACerts(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return certificateChain(cert, append(intermediates, caCerts...))
+}
+
+func (e *EST) simpleEnroll(ctx context.Context, csr *x509.CertificateRequest) ([]*x509.Certificate, error) {
+	body := base64.StdEncoding.EncodeToString(csr.Raw)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url+simpleEnrollPath, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/pkcs10")
+	req.Header.Set("Content-Transfer-Encoding", "base64")
+
+	resp, err := e.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusAccepted {
+		return nil, &EnrollmentPendingError{RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	}
+
+	return readCertsOnlyResponse(resp)
+}
+
+func (e *EST) CACerts(ctx context.Context) ([]*x509.Certificate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url+caCertsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := e.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return readCertsOnlyResponse(resp)
+}
+
+func (e *EST) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Accept", "application/pkcs7-mime")
+	if e.username != "" {
+		req.SetBasicAuth(e.username, e.password)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to EST server: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return nil, fmt.Errorf("EST server responded to %s with status %d: %s",
+			req.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
+
+// readCertsOnlyResponse reads the base64 encoded PKCS#7 certs-only message
+// from the body of a response.
+func readCertsOnlyResponse(resp *http.Response) ([]*x509.Certificate, error) {
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err != nil || mediaType != "application/pkcs7-mime" {
+		return nil, fmt.Errorf("unexpected content type %q in EST server response", resp.Header.Get("Content-Type"))
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading EST server response: %v", err)
+	}
+
+	// The body may be split across multiple lines
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(body)), ""))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding EST server response: %v", err)
+	}
+
+	return parseCertsOnly(der)
+}
+
+// retryAfter parses the value of a Retry-After header given in seconds.
+// The HTTP-date form is not supported as it is not used by EST servers.
+func retryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// certificateChain returns the PEM encoded chain of cert, ordered from cert
+// upwards using the given CA certificates, and the CA that issued it. A
+// self-signed root CA is returned as the CA and not included in the chain.
+// If the chain cannot be built, all of the CA certificates are returned as
+// the CA.
+func certificateChain(cert *x509.Certificate, caCerts []*x509.Certificate) ([]byte, []byte, error) {
+	issuers := pki.OrderCAChain(cert, caCerts)
+	if len(issuers) == 0 {
+		certPEM, err := pki.EncodeX509(cert)
+		if err != nil {
+			return nil, nil, err
+		}
+		var caPEM []byte
+		for _, ca := range caCerts {
+			caPEM = append(caPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})...)
+		}
+		return certPEM, caPEM, nil
+	}
+
+	chain := append([]*x509.Certificate{cert}, issuers...)
+	ca := chain[len(chain)-1]
+	if pki.IsSelfSigned(ca) {
+		chain = chain[:len(chain)-1]
+	}
+
+	certPEM, err := pki.EncodeX509Chain(chain)
+	if err != nil {
+		return nil, nil, err
+	}
+	caPEM, err := pki.EncodeX509(ca)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return certPEM, caPEM, nil
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	clientcorev1 "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+	testlisters "github.com/jetstack/cert-manager/test/unit/listers"
+)
+
+var serial int64
+
+func mustCreateCertificate(t *testing.T, template *x509.Certificate, pub crypto.PublicKey, parent *x509.Certificate, parentKey crypto.Signer) *x509.Certificate {
+	serial++
+	template.SerialNumber = big.NewInt(serial)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	_, cert, err := pki.SignCertificate(template, parent, pub, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// mustCreateCA creates a CA certificate signed by parent, or a self-signed
+// root CA if parent is nil.
+func mustCreateCA(t *testing.T, cn string, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer) {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: cn},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	return mustCreateCertificate(t, template, key.Public(), parent, parentKey), key
+}
+
+func mustCreateCSR(t *testing.T, cn string) []byte {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(nil, &x509.CertificateRequest{Subject: pkix.Name{CommonName: cn}}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+}
+
+func writeCertsOnly(t *testing.T, w http.ResponseWriter, certs ...*x509.Certificate) {
+	w.Header().Set("Content-Type", "application/pkcs7-mime; smime-type=certs-only")
+	w.Header().Set("Content-Transfer-Encoding", "base64")
+	w.Write([]byte(base64.StdEncoding.EncodeToString(encodeCertsOnly(t, certs...))))
+}
+
+func mustEncodePEM(t *testing.T, certs ...*x509.Certificate) string {
+	var out []byte
+	for _, cert := range certs {
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return string(out)
+}
+
+type testServer struct {
+	t *testing.T
+
+	issuerCert *x509.Certificate
+	issuerKey  crypto.Signer
+
+	// enrollChain are certificates returned after the issued certificate in
+	// simpleenroll responses
+	enrollChain []*x509.Certificate
+	caCerts     []*x509.Certificate
+
+	username, password string
+	requireClientCert  bool
+	pending            bool
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.username != "" {
+		if user, pass, ok := r.BasicAuth(); !ok || user != s.username || pass != s.password {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+	if s.requireClientCert && len(r.TLS.PeerCertificates) == 0 {
+		http.Error(w, "client certificate required", http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/.well-known/est/cacerts":
+		writeCertsOnly(s.t, w, s.caCerts...)
+
+	case r.Method == http.MethodPost && r.URL.Path == "/.well-known/est/simpleenroll":
+		if r.Header.Get("Content-Type") != "application/pkcs10" {
+			http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		if s.pending {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		der, err := base64.StdEncoding.DecodeString(string(body))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		csr, err := x509.ParseCertificateRequest(der)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		serial++
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      csr.Subject,
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		_, cert, err := pki.SignCertificate(template, s.issuerCert, csr.PublicKey, s.issuerKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeCertsOnly(s.t, w, append([]*x509.Certificate{cert}, s.enrollChain...)...)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestSign(t *testing.T) {
+	root, rootKey := mustCreateCA(t, "root", nil, nil)
+	intermediate, intermediateKey := mustCreateCA(t, "intermediate", root, rootKey)
+	otherRoot, _ := mustCreateCA(t, "other-root", nil, nil)
+
+	clientCA, clientCAKey := mustCreateCA(t, "client-ca", nil, nil)
+	clientKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCert := mustCreateCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "client"}}, clientKey.Public(), clientCA, clientCAKey)
+	clientKeyPEM, err := pki.EncodePKCS8PrivateKey(clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secretsLister := &testlisters.FakeSecretLister{
+		SecretsFn: func(string) clientcorev1.SecretNamespaceLister {
+			return &testlisters.FakeSecretNamespaceLister{
+				GetFn: func(name string) (*corev1.Secret, error) {
+					switch name {
+					case "est-password":
+						return &corev1.Secret{Data: map[string][]byte{"password": []byte("secret")}}, nil
+					case "est-client":
+						return &corev1.Secret{Data: map[string][]byte{
+							corev1.TLSCertKey:       []byte(mustEncodePEM(t, clientCert)),
+							corev1.TLSPrivateKeyKey: clientKeyPEM,
+						}}, nil
+					}
+					return nil, errors.New("not found")
+				},
+			}
+		},
+	}
+
+	basicAuth := cmapi.ESTAuth{
+		BasicAuth: &cmapi.ESTBasicAuth{
+			Username: "cert-manager",
+			PasswordSecretRef: cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: "est-password"},
+				Key:                  "password",
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		server *testServer
+		auth   cmapi.ESTAuth
+
+		expectedCertCNs []string
+		expectedCA      string
+		expectedPending bool
+		expectedErr     bool
+	}{
+		"should order the chain using the CA certificates of the server": {
+			server: &testServer{
+				issuerCert: intermediate, issuerKey: intermediateKey,
+				caCerts:  []*x509.Certificate{root, intermediate},
+				username: "cert-manager", password: "secret",
+			},
+			auth:            basicAuth,
+			expectedCertCNs: []string{"example.com", "intermediate"},
+			expectedCA:      mustEncodePEM(t, root),
+		},
+		"should use the chain returned in the enrollment response": {
+			server: &testServer{
+				issuerCert: intermediate, issuerKey: intermediateKey,
+				enrollChain: []*x509.Certificate{intermediate},
+				caCerts:     []*x509.Certificate{root},
+				username:    "cert-manager", password: "secret",
+			},
+			auth:            basicAuth,
+			expectedCertCNs: []string{"example.com", "intermediate"},
+			expectedCA:      mustEncodePEM(t, root),
+		},
+		"should return all CA certificates if the chain cannot be built": {
+			server: &testServer{
+				issuerCert: intermediate, issuerKey: intermediateKey,
+				caCerts:  []*x509.Certificate{otherRoot},
+				username: "cert-manager", password: "secret",
+			},
+			auth:            basicAuth,
+			expectedCertCNs: []string{"example.com"},
+			expectedCA:      mustEncodePEM(t, otherRoot),
+		},
+		"should authenticate using a client certificate": {
+			server: &testServer{
+				issuerCert: root, issuerKey: rootKey,
+				caCerts:           []*x509.Certificate{root},
+				requireClientCert: true,
+			},
+			auth:            cmapi.ESTAuth{ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "est-client"}},
+			expectedCertCNs: []string{"example.com"},
+			expectedCA:      mustEncodePEM(t, root),
+		},
+		"should error if the server rejects the credentials": {
+			server: &testServer{
+				issuerCert: root, issuerKey: rootKey,
+				caCerts:  []*x509.Certificate{root},
+				username: "cert-manager", password: "other",
+			},
+			auth:        basicAuth,
+			expectedErr: true,
+		},
+		"should return an EnrollmentPendingError if the server has not issued the certificate": {
+			server: &testServer{
+				issuerCert: root, issuerKey: rootKey,
+				caCerts:  []*x509.Certificate{root},
+				username: "cert-manager", password: "secret",
+				pending: true,
+			},
+			auth:            basicAuth,
+			expectedPending: true,
+			expectedErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.server.t = t
+			server := httptest.NewUnstartedServer(test.server)
+			server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+			server.StartTLS()
+			defer server.Close()
+
+			issuer := gen.Issuer("est-issuer",
+				gen.SetIssuerEST(cmapi.ESTIssuer{
+					URL:      server.URL + "/.well-known/est/",
+					CABundle: []byte(mustEncodePEM(t, server.Certificate())),
+					Auth:     test.auth,
+				}),
+			)
+
+			client, err := New(gen.DefaultTestNamespace, secretsLister, issuer)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			certPEM, caPEM, err := client.Sign(context.TODO(), mustCreateCSR(t, "example.com"))
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+
+			var pendingErr *EnrollmentPendingError
+			if pending := errors.As(err, &pendingErr); pending != test.expectedPending {
+				t.Errorf("unexpected pending error, exp=%t got=%v", test.expectedPending, err)
+			}
+			if pendingErr != nil && pendingErr.RetryAfter != time.Minute {
+				t.Errorf("unexpected retry after, exp=%s got=%s", time.Minute, pendingErr.RetryAfter)
+			}
+
+			var cns []string
+			if len(certPEM) > 0 {
+				certs, err := pki.DecodeX509CertificateChainBytes(certPEM)
+				if err != nil {
+					t.Fatalf("failed to decode certificate chain: %v", err)
+				}
+				for _, cert := range certs {
+					cns = append(cns, cert.Subject.CommonName)
+				}
+			}
+			if strings.Join(cns, ",") != strings.Join(test.expectedCertCNs, ",") {
+				t.Errorf("unexpected certificate chain, exp=%v got=%v", test.expectedCertCNs, cns)
+			}
+			if string(caPEM) != test.expectedCA {
+				t.Errorf("unexpected CA, exp=%q got=%q", test.expectedCA, caPEM)
+			}
+		})
+	}
+}
+
+func TestTransportFor(t *testing.T) {
+	first := transportFor("issuer-a", &tls.Config{}, "hash-1")
+	if second := transportFor("issuer-a", &tls.Config{}, "hash-1"); second != first {
+		t.Errorf("expected the transport to be reused for an unchanged TLS configuration")
+	}
+	if other := transportFor("issuer-b", &tls.Config{}, "hash-1"); other == first {
+		t.Errorf("expected a different issuer to get its own transport")
+	}
+	replaced := transportFor("issuer-a", &tls.Config{}, "hash-2")
+	if replaced == first {
+		t.Errorf("expected the transport to be replaced after the TLS configuration changed")
+	}
+	if got := transportFor("issuer-a", &tls.Config{}, "hash-2"); got != replaced {
+		t.Errorf("expected the replaced transport to be reused")
+	}
+}
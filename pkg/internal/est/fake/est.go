@@ -0,0 +1,35 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"crypto/x509"
+)
+
+type EST struct {
+	SignFn    func(context.Context, []byte) ([]byte, []byte, error)
+	CACertsFn func(context.Context) ([]*x509.Certificate, error)
+}
+
+func (e *EST) Sign(ctx context.Context, csrPEM []byte) ([]byte, []byte, error) {
+	return e.SignFn(ctx, csrPEM)
+}
+
+func (e *EST) CACerts(ctx context.Context) ([]*x509.Certificate, error) {
+	return e.CACertsFn(ctx)
+}
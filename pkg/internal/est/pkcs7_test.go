@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// certsOnlyFixture is a certs-only message containing a single self-signed
+// certificate, generated using `openssl crl2pkcs7 -nocrl -outform DER`.
+const certsOnlyFixture = `
+MIIBtwYJKoZIhvcNAQcCoIIBqDCCAaQCAQExADALBgkqhkiG9w0BBwGgggGMMIIB
+iDCCAS+gAwIBAgIUHWDfplsY7HMjcaxrhFR++tY3pRYwCgYIKoZIzj0EAwIwGTEX
+MBUGA1UEAwwOZXN0LWZpeHR1cmUtY2EwIBcNMjYxMDE5MTY1OTE0WhgPMjEyNjA5
+MjUxNjU5MTRaMBkxFzAVBgNVBAMMDmVzdC1maXh0dXJlLWNhMFkwEwYHKoZIzj0C
+AQYIKoZIzj0DAQcDQgAEk4up6iZ2ng8NnHkdlPYM8/UmQem8WdtCoTLbIWjsVPdV
+BMbXrzzEt5J1zr0vFZHbtYDoeCBOI5PblHXohQN7DKNTMFEwHQYDVR0OBBYEFFCw
+DFFIRcArmUWVnEt+/r4gSFtQMB8GA1UdIwQYMBaAFFCwDFFIRcArmUWVnEt+/r4g
+SFtQMA8GA1UdEwEB/wQFMAMBAf8wCgYIKoZIzj0EAwIDRwAwRAIgPBr5pdMPS1X/
+UEY3DpI3XBJXUPbX/37CXn1OGeMvWF8CIBNxP1nDYkGAzMU8Hmrco9EHpJ9LLJ8U
+ZDctef8HDcHaMQA=
+`
+
+// encodeCertsOnly encodes the certificates as a DER encoded PKCS#7
+// certs-only message.
+func encodeCertsOnly(t *testing.T, certs ...*x509.Certificate) []byte {
+	var raw []byte
+	for _, cert := range certs {
+		raw = append(raw, cert.Raw...)
+	}
+
+	emptySet := asn1.RawValue{FullBytes: []byte{0x31, 0x00}}
+	sd, err := asn1.Marshal(struct {
+		Version          int
+		DigestAlgorithms asn1.RawValue
+		ContentInfo      struct{ ContentType asn1.ObjectIdentifier }
+		Certificates     asn1.RawValue
+		SignerInfos      asn1.RawValue
+	}{
+		Version:          1,
+		DigestAlgorithms: emptySet,
+		ContentInfo:      struct{ ContentType asn1.ObjectIdentifier }{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: raw},
+		SignerInfos:      emptySet,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := asn1.Marshal(struct {
+		ContentType asn1.ObjectIdentifier
+		Content     asn1.RawValue
+	}{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return der
+}
+
+func TestParseCertsOnly(t *testing.T) {
+	fixture, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(certsOnlyFixture), ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, rootKey := mustCreateCA(t, "root", nil, nil)
+	intermediate, _ := mustCreateCA(t, "intermediate", root, rootKey)
+
+	tests := map[string]struct {
+		der []byte
+
+		expectedCNs []string
+		expectedErr bool
+	}{
+		"should parse a message generated by openssl": {
+			der:         fixture,
+			expectedCNs: []string{"est-fixture-ca"},
+		},
+		"should parse all certificates in order": {
+			der:         encodeCertsOnly(t, intermediate, root),
+			expectedCNs: []string{"intermediate", "root"},
+		},
+		"should error if the message contains no certificates": {
+			der:         encodeCertsOnly(t),
+			expectedErr: true,
+		},
+		"should error if the message is not PKCS#7": {
+			der:         root.Raw,
+			expectedErr: true,
+		},
+		"should error if the message is truncated": {
+			der:         fixture[:len(fixture)-10],
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			certs, err := parseCertsOnly(test.der)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+
+			var cns []string
+			for _, cert := range certs {
+				cns = append(cns, cert.Subject.CommonName)
+			}
+			if strings.Join(cns, ",") != strings.Join(test.expectedCNs, ",") {
+				t.Errorf("unexpected certificates, exp=%v got=%v", test.expectedCNs, cns)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	extv1beta1client "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	networkingv1client "k8s.io/client-go/kubernetes/typed/networking/v1"
+	networkingv1beta1client "k8s.io/client-go/kubernetes/typed/networking/v1beta1"
+	extv1beta1listers "k8s.io/client-go/listers/extensions/v1beta1"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
+	networkingv1beta1listers "k8s.io/client-go/listers/networking/v1beta1"
+)
+
+// InternalIngressClient is used to manage Ingress resources regardless of
+// the API version served by the apiserver.
+type InternalIngressClient interface {
+	Ingresses(namespace string) InternalIngressInterface
+}
+
+// InternalIngressInterface has methods to work with Ingress resources in a
+// single namespace.
+type InternalIngressInterface interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*networkingv1beta1.Ingress, error)
+	Create(ctx context.Context, ing *networkingv1beta1.Ingress, opts metav1.CreateOptions) (*networkingv1beta1.Ingress, error)
+	Update(ctx context.Context, ing *networkingv1beta1.Ingress, opts metav1.UpdateOptions) (*networkingv1beta1.Ingress, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+type networkingClient struct {
+	client networkingv1beta1client.IngressesGetter
+}
+
+func (c *networkingClient) Ingresses(namespace string) InternalIngressInterface {
+	return c.client.Ingresses(namespace)
+}
+
+type extensionsClient struct {
+	client extv1beta1client.IngressesGetter
+}
+
+func (c *extensionsClient) Ingresses(namespace string) InternalIngressInterface {
+	return &extensionsIngresses{client: c.client.Ingresses(namespace)}
+}
+
+type extensionsIngresses struct {
+	client extv1beta1client.IngressInterface
+}
+
+func (c *extensionsIngresses) Get(ctx context.Context, name string, opts metav1.GetOptions) (*networkingv1beta1.Ingress, error) {
+	ing, err := c.client.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return convertFromExtensions(ing), nil
+}
+
+func (c *extensionsIngresses) Create(ctx context.Context, ing *networkingv1beta1.Ingress, opts metav1.CreateOptions) (*networkingv1beta1.Ingress, error) {
+	out, err := c.client.Create(ctx, convertToExtensions(ing), opts)
+	if err != nil {
+		return nil, err
+	}
+	return convertFromExtensions(out), nil
+}
+
+func (c *extensionsIngresses) Update(ctx context.Context, ing *networkingv1beta1.Ingress, opts metav1.UpdateOptions) (*networkingv1beta1.Ingress, error) {
+	out, err := c.client.Update(ctx, convertToExtensions(ing), opts)
+	if err != nil {
+		return nil, err
+	}
+	return convertFromExtensions(out), nil
+}
+
+func (c *extensionsIngresses) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+// extensionsLister implements the networking.k8s.io/v1beta1 IngressLister
+// interface using an extensions/v1beta1 lister.
+type extensionsLister struct {
+	lister extv1beta1listers.IngressLister
+}
+
+func (l *extensionsLister) List(selector labels.Selector) ([]*networkingv1beta1.Ingress, error) {
+	ings, err := l.lister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return convertListFromExtensions(ings), nil
+}
+
+func (l *extensionsLister) Ingresses(namespace string) networkingv1beta1listers.IngressNamespaceLister {
+	return &extensionsNamespaceLister{lister: l.lister.Ingresses(namespace)}
+}
+
+type extensionsNamespaceLister struct {
+	lister extv1beta1listers.IngressNamespaceLister
+}
+
+func (l *extensionsNamespaceLister) List(selector labels.Selector) ([]*networkingv1beta1.Ingress, error) {
+	ings, err := l.lister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return convertListFromExtensions(ings), nil
+}
+
+func (l *extensionsNamespaceLister) Get(name string) (*networkingv1beta1.Ingress, error) {
+	ing, err := l.lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return convertFromExtensions(ing), nil
+}
+
+func convertListFromExtensions(ings []*extv1beta1.Ingress) []*networkingv1beta1.Ingress {
+	out := make([]*networkingv1beta1.Ingress, len(ings))
+	for i, ing := range ings {
+		out[i] = convertFromExtensions(ing)
+	}
+	return out
+}
+
+type networkingV1Client struct {
+	client networkingv1client.IngressesGetter
+}
+
+func (c *networkingV1Client) Ingresses(namespace string) InternalIngressInterface {
+	return &networkingV1Ingresses{client: c.client.Ingresses(namespace)}
+}
+
+type networkingV1Ingresses struct {
+	client networkingv1client.IngressInterface
+}
+
+func (c *networkingV1Ingresses) Get(ctx context.Context, name string, opts metav1.GetOptions) (*networkingv1beta1.Ingress, error) {
+	ing, err := c.client.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return convertFromV1(ing), nil
+}
+
+func (c *networkingV1Ingresses) Create(ctx context.Context, ing *networkingv1beta1.Ingress, opts metav1.CreateOptions) (*networkingv1beta1.Ingress, error) {
+	out, err := c.client.Create(ctx, convertToV1(ing), opts)
+	if err != nil {
+		return nil, err
+	}
+	return convertFromV1(out), nil
+}
+
+func (c *networkingV1Ingresses) Update(ctx context.Context, ing *networkingv1beta1.Ingress, opts metav1.UpdateOptions) (*networkingv1beta1.Ingress, error) {
+	out, err := c.client.Update(ctx, convertToV1(ing), opts)
+	if err != nil {
+		return nil, err
+	}
+	return convertFromV1(out), nil
+}
+
+func (c *networkingV1Ingresses) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete(ctx, name, opts)
+}
+
+// networkingV1Lister implements the networking.k8s.io/v1beta1 IngressLister
+// interface using a networking.k8s.io/v1 lister.
+type networkingV1Lister struct {
+	lister networkingv1listers.IngressLister
+}
+
+func (l *networkingV1Lister) List(selector labels.Selector) ([]*networkingv1beta1.Ingress, error) {
+	ings, err := l.lister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return convertListFromV1(ings), nil
+}
+
+func (l *networkingV1Lister) Ingresses(namespace string) networkingv1beta1listers.IngressNamespaceLister {
+	return &networkingV1NamespaceLister{lister: l.lister.Ingresses(namespace)}
+}
+
+type networkingV1NamespaceLister struct {
+	lister networkingv1listers.IngressNamespaceLister
+}
+
+func (l *networkingV1NamespaceLister) List(selector labels.Selector) ([]*networkingv1beta1.Ingress, error) {
+	ings, err := l.lister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return convertListFromV1(ings), nil
+}
+
+func (l *networkingV1NamespaceLister) Get(name string) (*networkingv1beta1.Ingress, error) {
+	ing, err := l.lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return convertFromV1(ing), nil
+}
+
+func convertListFromV1(ings []*networkingv1.Ingress) []*networkingv1beta1.Ingress {
+	out := make([]*networkingv1beta1.Ingress, len(ings))
+	for i, ing := range ings {
+		out[i] = convertFromV1(ing)
+	}
+	return out
+}
+
+// networkingV1ClassLister implements the networking.k8s.io/v1beta1
+// IngressClassLister interface using a networking.k8s.io/v1 lister.
+type networkingV1ClassLister struct {
+	lister networkingv1listers.IngressClassLister
+}
+
+func (l *networkingV1ClassLister) List(selector labels.Selector) ([]*networkingv1beta1.IngressClass, error) {
+	classes, err := l.lister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*networkingv1beta1.IngressClass, len(classes))
+	for i, class := range classes {
+		out[i] = convertClassFromV1(class)
+	}
+	return out, nil
+}
+
+func (l *networkingV1ClassLister) Get(name string) (*networkingv1beta1.IngressClass, error) {
+	class, err := l.lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return convertClassFromV1(class), nil
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// convertFromExtensions converts an extensions/v1beta1 Ingress to a
+// networking.k8s.io/v1beta1 Ingress. The input is deep copied, so the result
+// may be safely modified.
+func convertFromExtensions(in *extv1beta1.Ingress) *networkingv1beta1.Ingress {
+	in = in.DeepCopy()
+	out := &networkingv1beta1.Ingress{
+		ObjectMeta: in.ObjectMeta,
+		Spec: networkingv1beta1.IngressSpec{
+			IngressClassName: in.Spec.IngressClassName,
+		},
+		Status: networkingv1beta1.IngressStatus{
+			LoadBalancer: in.Status.LoadBalancer,
+		},
+	}
+	if in.Spec.Backend != nil {
+		backend := backendFromExtensions(*in.Spec.Backend)
+		out.Spec.Backend = &backend
+	}
+	for _, tls := range in.Spec.TLS {
+		out.Spec.TLS = append(out.Spec.TLS, networkingv1beta1.IngressTLS{
+			Hosts:      tls.Hosts,
+			SecretName: tls.SecretName,
+		})
+	}
+	for _, rule := range in.Spec.Rules {
+		outRule := networkingv1beta1.IngressRule{Host: rule.Host}
+		if rule.HTTP != nil {
+			outRule.HTTP = &networkingv1beta1.HTTPIngressRuleValue{}
+			for _, path := range rule.HTTP.Paths {
+				outRule.HTTP.Paths = append(outRule.HTTP.Paths, networkingv1beta1.HTTPIngressPath{
+					Path:     path.Path,
+					PathType: (*networkingv1beta1.PathType)(path.PathType),
+					Backend:  backendFromExtensions(path.Backend),
+				})
+			}
+		}
+		out.Spec.Rules = append(out.Spec.Rules, outRule)
+	}
+	return out
+}
+
+// convertToExtensions converts a networking.k8s.io/v1beta1 Ingress to an
+// extensions/v1beta1 Ingress. The input is deep copied, so the result may be
+// safely modified.
+func convertToExtensions(in *networkingv1beta1.Ingress) *extv1beta1.Ingress {
+	in = in.DeepCopy()
+	out := &extv1beta1.Ingress{
+		ObjectMeta: in.ObjectMeta,
+		Spec: extv1beta1.IngressSpec{
+			IngressClassName: in.Spec.IngressClassName,
+		},
+		Status: extv1beta1.IngressStatus{
+			LoadBalancer: in.Status.LoadBalancer,
+		},
+	}
+	if in.Spec.Backend != nil {
+		backend := backendToExtensions(*in.Spec.Backend)
+		out.Spec.Backend = &backend
+	}
+	for _, tls := range in.Spec.TLS {
+		out.Spec.TLS = append(out.Spec.TLS, extv1beta1.IngressTLS{
+			Hosts:      tls.Hosts,
+			SecretName: tls.SecretName,
+		})
+	}
+	for _, rule := range in.Spec.Rules {
+		outRule := extv1beta1.IngressRule{Host: rule.Host}
+		if rule.HTTP != nil {
+			outRule.HTTP = &extv1beta1.HTTPIngressRuleValue{}
+			for _, path := range rule.HTTP.Paths {
+				outRule.HTTP.Paths = append(outRule.HTTP.Paths, extv1beta1.HTTPIngressPath{
+					Path:     path.Path,
+					PathType: (*extv1beta1.PathType)(path.PathType),
+					Backend:  backendToExtensions(path.Backend),
+				})
+			}
+		}
+		out.Spec.Rules = append(out.Spec.Rules, outRule)
+	}
+	return out
+}
+
+func backendFromExtensions(in extv1beta1.IngressBackend) networkingv1beta1.IngressBackend {
+	return networkingv1beta1.IngressBackend{
+		ServiceName: in.ServiceName,
+		ServicePort: in.ServicePort,
+		Resource:    in.Resource,
+	}
+}
+
+func backendToExtensions(in networkingv1beta1.IngressBackend) extv1beta1.IngressBackend {
+	return extv1beta1.IngressBackend{
+		ServiceName: in.ServiceName,
+		ServicePort: in.ServicePort,
+		Resource:    in.Resource,
+	}
+}
+
+// convertFromV1 converts a networking.k8s.io/v1 Ingress to a
+// networking.k8s.io/v1beta1 Ingress. The input is deep copied, so the result
+// may be safely modified.
+func convertFromV1(in *networkingv1.Ingress) *networkingv1beta1.Ingress {
+	in = in.DeepCopy()
+	out := &networkingv1beta1.Ingress{
+		ObjectMeta: in.ObjectMeta,
+		Spec: networkingv1beta1.IngressSpec{
+			IngressClassName: in.Spec.IngressClassName,
+		},
+		Status: networkingv1beta1.IngressStatus{
+			LoadBalancer: in.Status.LoadBalancer,
+		},
+	}
+	if in.Spec.DefaultBackend != nil {
+		backend := backendFromV1(*in.Spec.DefaultBackend)
+		out.Spec.Backend = &backend
+	}
+	for _, tls := range in.Spec.TLS {
+		out.Spec.TLS = append(out.Spec.TLS, networkingv1beta1.IngressTLS{
+			Hosts:      tls.Hosts,
+			SecretName: tls.SecretName,
+		})
+	}
+	for _, rule := range in.Spec.Rules {
+		outRule := networkingv1beta1.IngressRule{Host: rule.Host}
+		if rule.HTTP != nil {
+			outRule.HTTP = &networkingv1beta1.HTTPIngressRuleValue{}
+			for _, path := range rule.HTTP.Paths {
+				outRule.HTTP.Paths = append(outRule.HTTP.Paths, networkingv1beta1.HTTPIngressPath{
+					Path:     path.Path,
+					PathType: (*networkingv1beta1.PathType)(path.PathType),
+					Backend:  backendFromV1(path.Backend),
+				})
+			}
+		}
+		out.Spec.Rules = append(out.Spec.Rules, outRule)
+	}
+	return out
+}
+
+// convertToV1 converts a networking.k8s.io/v1beta1 Ingress to a
+// networking.k8s.io/v1 Ingress. The input is deep copied, so the result may
+// be safely modified. Paths without a path type are given the
+// ImplementationSpecific path type, as networking.k8s.io/v1 requires one to be
+// set whereas networking.k8s.io/v1beta1 defaults it.
+func convertToV1(in *networkingv1beta1.Ingress) *networkingv1.Ingress {
+	in = in.DeepCopy()
+	out := &networkingv1.Ingress{
+		ObjectMeta: in.ObjectMeta,
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: in.Spec.IngressClassName,
+		},
+		Status: networkingv1.IngressStatus{
+			LoadBalancer: in.Status.LoadBalancer,
+		},
+	}
+	if in.Spec.Backend != nil {
+		backend := backendToV1(*in.Spec.Backend)
+		out.Spec.DefaultBackend = &backend
+	}
+	for _, tls := range in.Spec.TLS {
+		out.Spec.TLS = append(out.Spec.TLS, networkingv1.IngressTLS{
+			Hosts:      tls.Hosts,
+			SecretName: tls.SecretName,
+		})
+	}
+	for _, rule := range in.Spec.Rules {
+		outRule := networkingv1.IngressRule{Host: rule.Host}
+		if rule.HTTP != nil {
+			outRule.HTTP = &networkingv1.HTTPIngressRuleValue{}
+			for _, path := range rule.HTTP.Paths {
+				pathType := networkingv1.PathTypeImplementationSpecific
+				if path.PathType != nil {
+					pathType = networkingv1.PathType(*path.PathType)
+				}
+				outRule.HTTP.Paths = append(outRule.HTTP.Paths, networkingv1.HTTPIngressPath{
+					Path:     path.Path,
+					PathType: &pathType,
+					Backend:  backendToV1(path.Backend),
+				})
+			}
+		}
+		out.Spec.Rules = append(out.Spec.Rules, outRule)
+	}
+	return out
+}
+
+func backendFromV1(in networkingv1.IngressBackend) networkingv1beta1.IngressBackend {
+	out := networkingv1beta1.IngressBackend{
+		Resource: in.Resource,
+	}
+	if in.Service != nil {
+		out.ServiceName = in.Service.Name
+		if in.Service.Port.Name != "" {
+			out.ServicePort = intstr.FromString(in.Service.Port.Name)
+		} else {
+			out.ServicePort = intstr.FromInt(int(in.Service.Port.Number))
+		}
+	}
+	return out
+}
+
+func backendToV1(in networkingv1beta1.IngressBackend) networkingv1.IngressBackend {
+	out := networkingv1.IngressBackend{
+		Resource: in.Resource,
+	}
+	if in.ServiceName != "" {
+		out.Service = &networkingv1.IngressServiceBackend{Name: in.ServiceName}
+		if in.ServicePort.Type == intstr.String {
+			out.Service.Port.Name = in.ServicePort.StrVal
+		} else {
+			out.Service.Port.Number = in.ServicePort.IntVal
+		}
+	}
+	return out
+}
+
+// convertClassFromV1 converts a networking.k8s.io/v1 IngressClass to a
+// networking.k8s.io/v1beta1 IngressClass. The input is deep copied, so the
+// result may be safely modified.
+func convertClassFromV1(in *networkingv1.IngressClass) *networkingv1beta1.IngressClass {
+	in = in.DeepCopy()
+	return &networkingv1beta1.IngressClass{
+		ObjectMeta: in.ObjectMeta,
+		Spec: networkingv1beta1.IngressClassSpec{
+			Controller: in.Spec.Controller,
+			Parameters: in.Spec.Parameters,
+		},
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ingress provides access to Ingress resources independently of the
+// API version served by the apiserver.
+// Ingresses are always represented using the networking.k8s.io/v1beta1 types.
+// Ingresses are read and written using networking.k8s.io/v1 if it is served
+// by the apiserver, falling back to networking.k8s.io/v1beta1 and then
+// extensions/v1beta1, and are converted transparently.
+package ingress
+
+import (
+	"fmt"
+
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	networkingv1beta1listers "k8s.io/client-go/listers/networking/v1beta1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Resources provides access to Ingress and IngressClass resources using the
+// newest API versions served by the apiserver.
+type Resources struct {
+	// GroupVersionKind is the GroupVersionKind used to access Ingresses. It
+	// should be used when constructing owner references to Ingresses.
+	GroupVersionKind schema.GroupVersionKind
+
+	// Informer is the shared informer for Ingresses. Objects passed to its
+	// event handlers are of the served API version, and so should only be
+	// used to compute keys. Use Lister to read Ingresses.
+	Informer cache.SharedIndexInformer
+	Lister   networkingv1beta1listers.IngressLister
+	Client   InternalIngressClient
+
+	// IngressClassInformer and IngressClassLister are nil if the apiserver
+	// does not serve IngressClass resources.
+	IngressClassInformer cache.SharedIndexInformer
+	IngressClassLister   networkingv1beta1listers.IngressClassLister
+}
+
+// NewResources uses discovery to determine the API versions served by the
+// apiserver, and returns Resources backed by informers from the given
+// factory.
+// Informers are only registered with the factory for the served API
+// versions, so that the factory does not attempt to watch resources which
+// do not exist.
+func NewResources(client kubernetes.Interface, factory kubeinformers.SharedInformerFactory) (*Resources, error) {
+	v1Resources, err := servedResources(client.Discovery(), networkingv1.SchemeGroupVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering ingress API versions: %v", err)
+	}
+
+	if v1Resources["ingresses"] {
+		informer := factory.Networking().V1().Ingresses()
+		r := &Resources{
+			GroupVersionKind: networkingv1.SchemeGroupVersion.WithKind("Ingress"),
+			Informer:         informer.Informer(),
+			Lister:           &networkingV1Lister{lister: informer.Lister()},
+			Client:           &networkingV1Client{client: client.NetworkingV1()},
+		}
+
+		if v1Resources["ingressclasses"] {
+			classInformer := factory.Networking().V1().IngressClasses()
+			r.IngressClassInformer = classInformer.Informer()
+			r.IngressClassLister = &networkingV1ClassLister{lister: classInformer.Lister()}
+		}
+
+		return r, nil
+	}
+
+	resources, err := servedResources(client.Discovery(), networkingv1beta1.SchemeGroupVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering ingress API versions: %v", err)
+	}
+
+	if !resources["ingresses"] {
+		informer := factory.Extensions().V1beta1().Ingresses()
+		return &Resources{
+			GroupVersionKind: extv1beta1.SchemeGroupVersion.WithKind("Ingress"),
+			Informer:         informer.Informer(),
+			Lister:           &extensionsLister{lister: informer.Lister()},
+			Client:           &extensionsClient{client: client.ExtensionsV1beta1()},
+		}, nil
+	}
+
+	informer := factory.Networking().V1beta1().Ingresses()
+	r := &Resources{
+		GroupVersionKind: networkingv1beta1.SchemeGroupVersion.WithKind("Ingress"),
+		Informer:         informer.Informer(),
+		Lister:           informer.Lister(),
+		Client:           &networkingClient{client: client.NetworkingV1beta1()},
+	}
+
+	if resources["ingressclasses"] {
+		classInformer := factory.Networking().V1beta1().IngressClasses()
+		r.IngressClassInformer = classInformer.Informer()
+		r.IngressClassLister = classInformer.Lister()
+	}
+
+	return r, nil
+}
+
+// HasSynced returns true once all of the informers used by r have synced.
+func (r *Resources) HasSynced() bool {
+	if r.IngressClassInformer != nil && !r.IngressClassInformer.HasSynced() {
+		return false
+	}
+	return r.Informer.HasSynced()
+}
+
+// servedResources returns the set of resource names served for the given
+// group version. An empty set is returned if the group version is not served.
+func servedResources(d discovery.DiscoveryInterface, gv schema.GroupVersion) (map[string]bool, error) {
+	groups, err := d.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+	if !servesGroupVersion(groups, gv) {
+		return map[string]bool{}, nil
+	}
+
+	list, err := d.ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make(map[string]bool, len(list.APIResources))
+	for _, r := range list.APIResources {
+		resources[r.Name] = true
+	}
+
+	return resources, nil
+}
+
+func servesGroupVersion(groups *metav1.APIGroupList, gv schema.GroupVersion) bool {
+	for _, g := range groups.Groups {
+		if g.Name != gv.Group {
+			continue
+		}
+		for _, v := range g.Versions {
+			if v.Version == gv.Version {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,342 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNewResources(t *testing.T) {
+	tests := map[string]struct {
+		resources            []*metav1.APIResourceList
+		expectedGVK          string
+		expectIngressClasses bool
+	}{
+		"should use extensions/v1beta1 if networking.k8s.io/v1beta1 is not served": {
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "extensions/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "ingresses"}},
+				},
+			},
+			expectedGVK: "extensions/v1beta1, Kind=Ingress",
+		},
+		"should use networking.k8s.io/v1beta1 without IngressClasses": {
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "networking.k8s.io/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "ingresses"}},
+				},
+			},
+			expectedGVK: "networking.k8s.io/v1beta1, Kind=Ingress",
+		},
+		"should use networking.k8s.io/v1beta1 with IngressClasses": {
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "networking.k8s.io/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "ingresses"}, {Name: "ingressclasses"}},
+				},
+			},
+			expectedGVK:          "networking.k8s.io/v1beta1, Kind=Ingress",
+			expectIngressClasses: true,
+		},
+		"should prefer networking.k8s.io/v1 if it is served": {
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "networking.k8s.io/v1",
+					APIResources: []metav1.APIResource{{Name: "ingresses"}, {Name: "ingressclasses"}},
+				},
+				{
+					GroupVersion: "networking.k8s.io/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "ingresses"}, {Name: "ingressclasses"}},
+				},
+				{
+					GroupVersion: "extensions/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "ingresses"}},
+				},
+			},
+			expectedGVK:          "networking.k8s.io/v1, Kind=Ingress",
+			expectIngressClasses: true,
+		},
+		"should fall back to networking.k8s.io/v1beta1 if networking.k8s.io/v1 does not serve ingresses": {
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "networking.k8s.io/v1",
+					APIResources: []metav1.APIResource{{Name: "networkpolicies"}},
+				},
+				{
+					GroupVersion: "networking.k8s.io/v1beta1",
+					APIResources: []metav1.APIResource{{Name: "ingresses"}},
+				},
+			},
+			expectedGVK: "networking.k8s.io/v1beta1, Kind=Ingress",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cl := kubefake.NewSimpleClientset()
+			cl.Resources = test.resources
+			factory := kubeinformers.NewSharedInformerFactory(cl, time.Minute)
+
+			r, err := NewResources(cl, factory)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if r.GroupVersionKind.String() != test.expectedGVK {
+				t.Errorf("expected GroupVersionKind %q, got %q", test.expectedGVK, r.GroupVersionKind.String())
+			}
+			if (r.IngressClassLister != nil) != test.expectIngressClasses {
+				t.Errorf("expected IngressClassLister to be set=%t, got %v", test.expectIngressClasses, r.IngressClassLister)
+			}
+		})
+	}
+}
+
+func TestExtensionsClientAndLister(t *testing.T) {
+	cl := kubefake.NewSimpleClientset()
+	cl.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "extensions/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "ingresses"}},
+		},
+	}
+	factory := kubeinformers.NewSharedInformerFactory(cl, time.Minute)
+	r, err := NewResources(cl, factory)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	factory.Start(stopCh)
+
+	ing := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: []networkingv1beta1.IngressRule{{Host: "example.com"}},
+		},
+	}
+	if _, err := r.Client.Ingresses("default").Create(context.TODO(), ing, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("unexpected error creating ingress: %v", err)
+	}
+
+	// the Ingress must be stored using the extensions API
+	if _, err := cl.ExtensionsV1beta1().Ingresses("default").Get(context.TODO(), "test", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected ingress to be created using extensions/v1beta1: %v", err)
+	}
+
+	if !cache.WaitForCacheSync(stopCh, r.HasSynced) {
+		t.Fatalf("timed out waiting for caches to sync")
+	}
+	err = wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		_, err := r.Lister.Ingresses("default").Get("test")
+		return err == nil, nil
+	})
+	if err != nil {
+		t.Fatalf("ingress was not observed by lister: %v", err)
+	}
+
+	got, err := r.Lister.Ingresses("default").Get("test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Spec.Rules) != 1 || got.Spec.Rules[0].Host != "example.com" {
+		t.Errorf("unexpected ingress returned by lister: %+v", got)
+	}
+}
+
+func TestNetworkingV1ClientAndLister(t *testing.T) {
+	cl := kubefake.NewSimpleClientset(&networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+		Spec:       networkingv1.IngressClassSpec{Controller: "k8s.io/ingress-nginx"},
+	})
+	cl.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{{Name: "ingresses"}, {Name: "ingressclasses"}},
+		},
+	}
+	factory := kubeinformers.NewSharedInformerFactory(cl, time.Minute)
+	r, err := NewResources(cl, factory)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	factory.Start(stopCh)
+
+	ing := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: networkingv1beta1.IngressSpec{
+			Backend: &networkingv1beta1.IngressBackend{
+				ServiceName: "svc",
+				ServicePort: intstr.FromInt(8080),
+			},
+		},
+	}
+	if _, err := r.Client.Ingresses("default").Create(context.TODO(), ing, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("unexpected error creating ingress: %v", err)
+	}
+
+	// the Ingress must be stored using the networking.k8s.io/v1 API
+	stored, err := cl.NetworkingV1().Ingresses("default").Get(context.TODO(), "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected ingress to be created using networking.k8s.io/v1: %v", err)
+	}
+	if b := stored.Spec.DefaultBackend; b == nil || b.Service == nil || b.Service.Name != "svc" || b.Service.Port.Number != 8080 {
+		t.Errorf("unexpected default backend stored: %+v", stored.Spec.DefaultBackend)
+	}
+
+	if !cache.WaitForCacheSync(stopCh, r.HasSynced) {
+		t.Fatalf("timed out waiting for caches to sync")
+	}
+	err = wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		_, err := r.Lister.Ingresses("default").Get("test")
+		return err == nil, nil
+	})
+	if err != nil {
+		t.Fatalf("ingress was not observed by lister: %v", err)
+	}
+
+	got, err := r.Lister.Ingresses("default").Get("test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !apiequality.Semantic.DeepEqual(ing.Spec, got.Spec) {
+		t.Errorf("unexpected ingress returned by lister\nexp=%+v\ngot=%+v", ing.Spec, got.Spec)
+	}
+
+	class, err := r.IngressClassLister.Get("nginx")
+	if err != nil {
+		t.Fatalf("unexpected error getting ingress class: %v", err)
+	}
+	if class.Spec.Controller != "k8s.io/ingress-nginx" {
+		t.Errorf("unexpected ingress class returned by lister: %+v", class)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	pathType := networkingv1beta1.PathTypePrefix
+	className := "nginx"
+	in := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Annotations: map[string]string{"a": "b"},
+		},
+		Spec: networkingv1beta1.IngressSpec{
+			IngressClassName: &className,
+			Backend: &networkingv1beta1.IngressBackend{
+				ServiceName: "default",
+				ServicePort: intstr.FromInt(80),
+			},
+			TLS: []networkingv1beta1.IngressTLS{
+				{Hosts: []string{"example.com"}, SecretName: "tls"},
+			},
+			Rules: []networkingv1beta1.IngressRule{
+				{
+					Host: "example.com",
+					IngressRuleValue: networkingv1beta1.IngressRuleValue{
+						HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+							Paths: []networkingv1beta1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1beta1.IngressBackend{
+										ServiceName: "svc",
+										ServicePort: intstr.FromString("http"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ext := convertToExtensions(in)
+	if *ext.Spec.Rules[0].HTTP.Paths[0].PathType != extv1beta1.PathTypePrefix {
+		t.Errorf("expected path type to be converted, got %v", *ext.Spec.Rules[0].HTTP.Paths[0].PathType)
+	}
+
+	out := convertFromExtensions(ext)
+	if !apiequality.Semantic.DeepEqual(in, out) {
+		t.Errorf("round trip conversion did not match\nexp=%+v\ngot=%+v", in, out)
+	}
+
+	v1 := convertToV1(in)
+	if port := v1.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port; port.Name != "http" || port.Number != 0 {
+		t.Errorf("expected named service port to be converted, got %+v", port)
+	}
+	if port := v1.Spec.DefaultBackend.Service.Port; port.Number != 80 || port.Name != "" {
+		t.Errorf("expected numbered service port to be converted, got %+v", port)
+	}
+
+	out = convertFromV1(v1)
+	if !apiequality.Semantic.DeepEqual(in, out) {
+		t.Errorf("round trip conversion via networking.k8s.io/v1 did not match\nexp=%+v\ngot=%+v", in, out)
+	}
+}
+
+func TestConvertToV1DefaultsPathType(t *testing.T) {
+	exact := networkingv1beta1.PathTypeExact
+	in := &networkingv1beta1.Ingress{
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: []networkingv1beta1.IngressRule{
+				{
+					Host: "example.com",
+					IngressRuleValue: networkingv1beta1.IngressRuleValue{
+						HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+							Paths: []networkingv1beta1.HTTPIngressPath{
+								{Path: "/unset"},
+								{Path: "/exact", PathType: &exact},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	paths := convertToV1(in).Spec.Rules[0].HTTP.Paths
+	if pt := paths[0].PathType; pt == nil || *pt != networkingv1.PathTypeImplementationSpecific {
+		t.Errorf("expected unset path type to default to %q, got %v", networkingv1.PathTypeImplementationSpecific, pt)
+	}
+	if pt := paths[1].PathType; pt == nil || *pt != networkingv1.PathTypeExact {
+		t.Errorf("expected path type %q to be preserved, got %v", networkingv1.PathTypeExact, pt)
+	}
+	if in.Spec.Rules[0].HTTP.Paths[0].PathType != nil {
+		t.Errorf("expected input ingress to not be modified")
+	}
+}
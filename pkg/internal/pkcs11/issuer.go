@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"crypto"
+	"fmt"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+)
+
+// SignerBuilder builds a signer for the PKCS#11 key of a CA issuer.
+type SignerBuilder func(namespace string, secretsLister corelisters.SecretLister, key *v1alpha2.CAPKCS11Key, pub crypto.PublicKey) (crypto.Signer, error)
+
+var _ SignerBuilder = SignerForKey
+
+// SignerForKey returns a signer for the PKCS#11 key of a CA issuer, where
+// pub is the public key of the CA certificate. The PIN is read from the
+// referenced Secret in the given namespace.
+func SignerForKey(namespace string, secretsLister corelisters.SecretLister, key *v1alpha2.CAPKCS11Key, pub crypto.PublicKey) (crypto.Signer, error) {
+	ref := key.PINSecretRef
+	secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	pin, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("no data for %q in secret '%s/%s'", ref.Key, namespace, ref.Name)
+	}
+
+	cfg := Config{
+		ModulePath: key.ModulePath,
+		TokenLabel: key.TokenLabel,
+		KeyLabel:   key.KeyLabel,
+		PIN:        string(pin),
+	}
+	if key.Slot != nil {
+		slot := uint(*key.Slot)
+		cfg.Slot = &slot
+	}
+
+	return NewSigner(cfg, pub)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pkcs11 implements a crypto.Signer for private keys stored in
+// PKCS#11 tokens, such as hardware security modules.
+//
+// PKCS#11 modules are loaded using cgo. If cert-manager is built without cgo,
+// as the released cert-manager images are, creating a signer returns
+// ErrNotSupported. Only modules listed in the --pkcs11-allowed-modules flag of
+// the controller and webhook may be used.
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+)
+
+// ErrNotSupported is returned when creating a signer if cert-manager has been
+// built without cgo, which is required to load PKCS#11 modules.
+var ErrNotSupported = errors.New("PKCS#11 is not supported as cert-manager was built without cgo, a custom build of cert-manager with cgo enabled is required")
+
+// ModuleAllowed returns true if modulePath is one of the allowed PKCS#11
+// module paths. Paths are compared after being cleaned, so that a module
+// outside of the allowed paths cannot be referenced using '..' elements.
+func ModuleAllowed(modulePath string, allowedModules []string) bool {
+	modulePath = filepath.Clean(modulePath)
+	for _, allowed := range allowedModules {
+		if filepath.Clean(allowed) == modulePath {
+			return true
+		}
+	}
+	return false
+}
+
+// Config identifies a private key stored in a PKCS#11 token.
+type Config struct {
+	// ModulePath is the path to the PKCS#11 module used to access the token.
+	ModulePath string
+
+	// Slot is the ID of the slot containing the token. If nil, the token is
+	// found using TokenLabel.
+	Slot *uint
+
+	// TokenLabel is the label of the token, used if Slot is nil.
+	TokenLabel string
+
+	// KeyLabel is the label of the private key in the token.
+	KeyLabel string
+
+	// PIN is the user PIN used to log in to the token.
+	PIN string
+}
+
+// VerifySigner checks that the signer is able to sign using the private key
+// of its public key, by creating and verifying a signature.
+func VerifySigner(signer crypto.Signer) error {
+	digest := sha256.Sum256([]byte("cert-manager"))
+	sig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("error creating test signature: %v", err)
+	}
+
+	switch pub := signer.Public().(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+			return errors.New("private key does not match the public key")
+		}
+	case *ecdsa.PublicKey:
+		var ecdsaSig struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(sig, &ecdsaSig); err != nil {
+			return fmt.Errorf("error decoding test signature: %v", err)
+		}
+		if !ecdsa.Verify(pub, digest[:], ecdsaSig.R, ecdsaSig.S) {
+			return errors.New("private key does not match the public key")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+
+	return nil
+}
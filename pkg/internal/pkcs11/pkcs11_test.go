@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"testing"
+)
+
+// mismatchedSigner signs using key, but returns the public key of another key.
+type mismatchedSigner struct {
+	crypto.Signer
+	public crypto.PublicKey
+}
+
+func (m *mismatchedSigner) Public() crypto.PublicKey {
+	return m.public
+}
+
+func (m *mismatchedSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return m.Signer.Sign(rand, digest, opts)
+}
+
+func TestVerifySigner(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherECKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		signer      crypto.Signer
+		expectedErr bool
+	}{
+		"should verify an RSA signer": {
+			signer: rsaKey,
+		},
+		"should verify an ECDSA signer": {
+			signer: ecKey,
+		},
+		"should error if an RSA signer does not match its public key": {
+			signer:      &mismatchedSigner{Signer: rsaKey, public: otherRSAKey.Public()},
+			expectedErr: true,
+		},
+		"should error if an ECDSA signer does not match its public key": {
+			signer:      &mismatchedSigner{Signer: ecKey, public: otherECKey.Public()},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifySigner(test.signer)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+		})
+	}
+}
+
+func TestModuleAllowed(t *testing.T) {
+	allowedModules := []string{"/usr/lib/softhsm/libsofthsm2.so", "/opt/hsm/lib/../lib/libhsm.so"}
+
+	tests := map[string]struct {
+		modulePath string
+		expected   bool
+	}{
+		"should allow a listed module": {
+			modulePath: "/usr/lib/softhsm/libsofthsm2.so",
+			expected:   true,
+		},
+		"should allow a listed module given with a different but equivalent path": {
+			modulePath: "/opt/hsm/lib/libhsm.so",
+			expected:   true,
+		},
+		"should not allow a module that is not listed": {
+			modulePath: "/usr/lib/other/libother.so",
+		},
+		"should not allow a module outside of a listed path using '..' elements": {
+			modulePath: "/usr/lib/softhsm/libsofthsm2.so/../../other/libother.so",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if allowed := ModuleAllowed(test.modulePath, allowedModules); allowed != test.expected {
+				t.Errorf("expected ModuleAllowed to return %t, got %t", test.expected, allowed)
+			}
+		})
+	}
+
+	if ModuleAllowed("/usr/lib/softhsm/libsofthsm2.so", nil) {
+		t.Errorf("expected no modules to be allowed if the allowed modules are empty")
+	}
+}
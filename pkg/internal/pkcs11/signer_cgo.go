@@ -0,0 +1,275 @@
+// +build cgo
+
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+// module is the subset of the PKCS#11 API used by Signer.
+type module interface {
+	GetSlotList(tokenPresent bool) ([]uint, error)
+	GetTokenInfo(slotID uint) (pkcs11.TokenInfo, error)
+	OpenSession(slotID uint, flags uint) (pkcs11.SessionHandle, error)
+	CloseSession(sh pkcs11.SessionHandle) error
+	Login(sh pkcs11.SessionHandle, userType uint, pin string) error
+	FindObjectsInit(sh pkcs11.SessionHandle, temp []*pkcs11.Attribute) error
+	FindObjects(sh pkcs11.SessionHandle, max int) ([]pkcs11.ObjectHandle, bool, error)
+	FindObjectsFinal(sh pkcs11.SessionHandle) error
+	SignInit(sh pkcs11.SessionHandle, m []*pkcs11.Mechanism, o pkcs11.ObjectHandle) error
+	Sign(sh pkcs11.SessionHandle, message []byte) ([]byte, error)
+}
+
+var (
+	modulesLock sync.Mutex
+	// modules holds the loaded PKCS#11 modules by path. Modules may only be
+	// initialized once per process, so they are kept loaded once used.
+	modules = make(map[string]module)
+)
+
+// hashPrefixes are the DER encoded DigestInfo prefixes of each hash, which
+// are prepended to the digest when creating PKCS #1 v1.5 signatures.
+var hashPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA1:   {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA224: {0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x04, 0x05, 0x00, 0x04, 0x1c},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+// Signer is a crypto.Signer for a private key stored in a PKCS#11 token.
+// A new session is opened for each signature, so a Signer may be used
+// concurrently.
+type Signer struct {
+	module   module
+	slot     uint
+	keyLabel string
+	pin      string
+	public   crypto.PublicKey
+}
+
+var _ crypto.Signer = &Signer{}
+
+// NewSigner returns a signer for the private key in the token identified by
+// cfg. The public key of the private key must be given, as it is usually not
+// stored in the token and is read from the CA certificate instead.
+func NewSigner(cfg Config, pub crypto.PublicKey) (crypto.Signer, error) {
+	mod, err := loadModule(cfg.ModulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSigner(mod, cfg, pub)
+}
+
+func loadModule(path string) (module, error) {
+	modulesLock.Lock()
+	defer modulesLock.Unlock()
+
+	if mod, ok := modules[path]; ok {
+		return mod, nil
+	}
+
+	ctx := pkcs11.New(path)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %q", path)
+	}
+	if err := ctx.Initialize(); err != nil && err != pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		ctx.Destroy()
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module %q: %v", path, err)
+	}
+
+	modules[path] = ctx
+	return ctx, nil
+}
+
+func newSigner(mod module, cfg Config, pub crypto.PublicKey) (*Signer, error) {
+	switch pub.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+
+	slot, err := findSlot(mod, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Signer{
+		module:   mod,
+		slot:     slot,
+		keyLabel: cfg.KeyLabel,
+		pin:      cfg.PIN,
+		public:   pub,
+	}
+
+	// Check that the key exists so that misconfiguration is reported when
+	// creating the signer rather than when signing.
+	err = s.withSession(func(sh pkcs11.SessionHandle) error {
+		_, err := s.findKey(sh)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func findSlot(mod module, cfg Config) (uint, error) {
+	if cfg.Slot != nil {
+		return *cfg.Slot, nil
+	}
+
+	slots, err := mod.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("error listing PKCS#11 slots: %v", err)
+	}
+
+	for _, slot := range slots {
+		info, err := mod.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("error getting PKCS#11 token info for slot %d: %v", slot, err)
+		}
+		if info.Label == cfg.TokenLabel {
+			return slot, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no PKCS#11 token found with label %q", cfg.TokenLabel)
+}
+
+// withSession calls fn with a new session logged in to the token.
+func (s *Signer) withSession(fn func(pkcs11.SessionHandle) error) error {
+	sh, err := s.module.OpenSession(s.slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("error opening PKCS#11 session on slot %d: %v", s.slot, err)
+	}
+	defer s.module.CloseSession(sh)
+
+	// The login state is shared by all sessions of the application, so
+	// another session may already be logged in.
+	if err := s.module.Login(sh, pkcs11.CKU_USER, s.pin); err != nil && err != pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		return fmt.Errorf("error logging in to PKCS#11 token on slot %d: %v", s.slot, err)
+	}
+
+	return fn(sh)
+}
+
+func (s *Signer) findKey(sh pkcs11.SessionHandle) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, s.keyLabel),
+	}
+	if err := s.module.FindObjectsInit(sh, template); err != nil {
+		return 0, fmt.Errorf("error finding private key %q: %v", s.keyLabel, err)
+	}
+	objs, _, err := s.module.FindObjects(sh, 2)
+	if finalErr := s.module.FindObjectsFinal(sh); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error finding private key %q: %v", s.keyLabel, err)
+	}
+
+	switch len(objs) {
+	case 0:
+		return 0, fmt.Errorf("no private key found with label %q", s.keyLabel)
+	case 1:
+		return objs[0], nil
+	default:
+		return 0, fmt.Errorf("multiple private keys found with label %q", s.keyLabel)
+	}
+}
+
+// Public returns the public key of the private key.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign signs the digest using the private key in the token. RSA keys create
+// PKCS #1 v1.5 signatures, and ECDSA keys create ASN.1 encoded signatures.
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var mechanism uint
+	var data []byte
+	switch s.public.(type) {
+	case *rsa.PublicKey:
+		if _, ok := opts.(*rsa.PSSOptions); ok {
+			return nil, errors.New("RSA-PSS signatures are not supported")
+		}
+		prefix, ok := hashPrefixes[opts.HashFunc()]
+		if !ok {
+			return nil, fmt.Errorf("unsupported hash function %v", opts.HashFunc())
+		}
+		mechanism = pkcs11.CKM_RSA_PKCS
+		data = append(append([]byte{}, prefix...), digest...)
+	case *ecdsa.PublicKey:
+		mechanism = pkcs11.CKM_ECDSA
+		data = digest
+	}
+
+	var sig []byte
+	err := s.withSession(func(sh pkcs11.SessionHandle) error {
+		key, err := s.findKey(sh)
+		if err != nil {
+			return err
+		}
+		if err := s.module.SignInit(sh, []*pkcs11.Mechanism{pkcs11.NewMechanism(mechanism, nil)}, key); err != nil {
+			return fmt.Errorf("error signing with private key %q: %v", s.keyLabel, err)
+		}
+		sig, err = s.module.Sign(sh, data)
+		if err != nil {
+			return fmt.Errorf("error signing with private key %q: %v", s.keyLabel, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.public.(*ecdsa.PublicKey); ok {
+		return encodeECDSASignature(sig)
+	}
+
+	return sig, nil
+}
+
+// encodeECDSASignature converts a PKCS#11 ECDSA signature, which is the
+// concatenation of r and s, to the ASN.1 encoding used by crypto/ecdsa.
+func encodeECDSASignature(sig []byte) ([]byte, error) {
+	if len(sig) == 0 || len(sig)%2 != 0 {
+		return nil, fmt.Errorf("invalid ECDSA signature length %d", len(sig))
+	}
+
+	n := len(sig) / 2
+	return asn1.Marshal(struct{ R, S *big.Int }{
+		R: new(big.Int).SetBytes(sig[:n]),
+		S: new(big.Int).SetBytes(sig[n:]),
+	})
+}
@@ -0,0 +1,367 @@
+// +build cgo
+
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/miekg/pkcs11"
+)
+
+const testPIN = "1234"
+
+// fakeModule is a module with a single token, storing private keys in memory.
+type fakeModule struct {
+	tokenLabel string
+	keys       map[pkcs11.ObjectHandle]fakeKey
+
+	loggedIn  bool
+	found     []pkcs11.ObjectHandle
+	signKey   pkcs11.ObjectHandle
+	mechanism uint
+}
+
+type fakeKey struct {
+	label string
+	key   crypto.Signer
+}
+
+func (f *fakeModule) GetSlotList(bool) ([]uint, error) {
+	return []uint{1, 2}, nil
+}
+
+func (f *fakeModule) GetTokenInfo(slot uint) (pkcs11.TokenInfo, error) {
+	if slot == 2 {
+		return pkcs11.TokenInfo{Label: f.tokenLabel}, nil
+	}
+	return pkcs11.TokenInfo{Label: "other"}, nil
+}
+
+func (f *fakeModule) OpenSession(slot uint, _ uint) (pkcs11.SessionHandle, error) {
+	if slot != 2 {
+		return 0, pkcs11.Error(pkcs11.CKR_SLOT_ID_INVALID)
+	}
+	return 1, nil
+}
+
+func (f *fakeModule) CloseSession(pkcs11.SessionHandle) error {
+	f.loggedIn = false
+	return nil
+}
+
+func (f *fakeModule) Login(_ pkcs11.SessionHandle, _ uint, pin string) error {
+	if f.loggedIn {
+		return pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)
+	}
+	if pin != testPIN {
+		return pkcs11.Error(pkcs11.CKR_PIN_INCORRECT)
+	}
+	f.loggedIn = true
+	return nil
+}
+
+func (f *fakeModule) FindObjectsInit(_ pkcs11.SessionHandle, temp []*pkcs11.Attribute) error {
+	if !f.loggedIn {
+		return pkcs11.Error(pkcs11.CKR_USER_NOT_LOGGED_IN)
+	}
+	var label string
+	for _, attr := range temp {
+		if attr.Type == pkcs11.CKA_LABEL {
+			label = string(attr.Value)
+		}
+	}
+	f.found = nil
+	for h, k := range f.keys {
+		if k.label == label {
+			f.found = append(f.found, h)
+		}
+	}
+	return nil
+}
+
+func (f *fakeModule) FindObjects(_ pkcs11.SessionHandle, max int) ([]pkcs11.ObjectHandle, bool, error) {
+	if len(f.found) > max {
+		return f.found[:max], false, nil
+	}
+	return f.found, false, nil
+}
+
+func (f *fakeModule) FindObjectsFinal(pkcs11.SessionHandle) error {
+	f.found = nil
+	return nil
+}
+
+func (f *fakeModule) SignInit(_ pkcs11.SessionHandle, m []*pkcs11.Mechanism, o pkcs11.ObjectHandle) error {
+	f.signKey = o
+	f.mechanism = m[0].Mechanism
+	return nil
+}
+
+// Sign implements the CKM_RSA_PKCS and CKM_ECDSA mechanisms.
+func (f *fakeModule) Sign(_ pkcs11.SessionHandle, message []byte) ([]byte, error) {
+	switch key := f.keys[f.signKey].key.(type) {
+	case *rsa.PrivateKey:
+		if f.mechanism != pkcs11.CKM_RSA_PKCS {
+			return nil, pkcs11.Error(pkcs11.CKR_KEY_TYPE_INCONSISTENT)
+		}
+		// A zero hash signs the DigestInfo prefixed message as is
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.Hash(0), message)
+	case *ecdsa.PrivateKey:
+		if f.mechanism != pkcs11.CKM_ECDSA {
+			return nil, pkcs11.Error(pkcs11.CKR_KEY_TYPE_INCONSISTENT)
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, key, message)
+		if err != nil {
+			return nil, err
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		sig := make([]byte, 2*size)
+		rBytes, sBytes := r.Bytes(), s.Bytes()
+		copy(sig[size-len(rBytes):size], rBytes)
+		copy(sig[2*size-len(sBytes):], sBytes)
+		return sig, nil
+	default:
+		return nil, pkcs11.Error(pkcs11.CKR_KEY_HANDLE_INVALID)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newModule := func() *fakeModule {
+		return &fakeModule{
+			tokenLabel: "cert-manager",
+			keys: map[pkcs11.ObjectHandle]fakeKey{
+				1: {label: "rsa", key: rsaKey},
+				2: {label: "ecdsa", key: ecKey},
+				3: {label: "duplicate", key: rsaKey},
+				4: {label: "duplicate", key: rsaKey},
+			},
+		}
+	}
+
+	slot := uint(2)
+	invalidSlot := uint(1)
+
+	tests := map[string]struct {
+		cfg Config
+		pub crypto.PublicKey
+
+		expectedErr bool
+	}{
+		"should sign using an RSA key in a token found by label": {
+			cfg: Config{TokenLabel: "cert-manager", KeyLabel: "rsa", PIN: testPIN},
+			pub: rsaKey.Public(),
+		},
+		"should sign using an ECDSA key in a token found by slot": {
+			cfg: Config{Slot: &slot, KeyLabel: "ecdsa", PIN: testPIN},
+			pub: ecKey.Public(),
+		},
+		"should error if no token has the label": {
+			cfg:         Config{TokenLabel: "missing", KeyLabel: "rsa", PIN: testPIN},
+			pub:         rsaKey.Public(),
+			expectedErr: true,
+		},
+		"should error if the slot does not exist": {
+			cfg:         Config{Slot: &invalidSlot, KeyLabel: "rsa", PIN: testPIN},
+			pub:         rsaKey.Public(),
+			expectedErr: true,
+		},
+		"should error if the PIN is incorrect": {
+			cfg:         Config{TokenLabel: "cert-manager", KeyLabel: "rsa", PIN: "0000"},
+			pub:         rsaKey.Public(),
+			expectedErr: true,
+		},
+		"should error if no key has the label": {
+			cfg:         Config{TokenLabel: "cert-manager", KeyLabel: "missing", PIN: testPIN},
+			pub:         rsaKey.Public(),
+			expectedErr: true,
+		},
+		"should error if multiple keys have the label": {
+			cfg:         Config{TokenLabel: "cert-manager", KeyLabel: "duplicate", PIN: testPIN},
+			pub:         rsaKey.Public(),
+			expectedErr: true,
+		},
+		"should error if the public key type is not supported": {
+			cfg:         Config{TokenLabel: "cert-manager", KeyLabel: "rsa", PIN: testPIN},
+			pub:         "not a key",
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signer, err := newSigner(newModule(), test.cfg, test.pub)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+			if err != nil {
+				return
+			}
+
+			if err := VerifySigner(signer); err != nil {
+				t.Errorf("failed to verify signer: %v", err)
+			}
+			mustSignCertificate(t, signer)
+		})
+	}
+}
+
+func TestEncodeECDSASignature(t *testing.T) {
+	if _, err := encodeECDSASignature(nil); err == nil {
+		t.Errorf("expected an error encoding an empty signature")
+	}
+	if _, err := encodeECDSASignature([]byte{1, 2, 3}); err == nil {
+		t.Errorf("expected an error encoding a signature of odd length")
+	}
+}
+
+// mustSignCertificate creates a self-signed certificate using the signer,
+// and checks its signature.
+func mustSignCertificate(t *testing.T, signer crypto.Signer) {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "pkcs11-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cert.CheckSignatureFrom(cert); err != nil {
+		t.Errorf("invalid certificate signature: %v", err)
+	}
+}
+
+// TestSoftHSM signs using a key generated in a SoftHSM token. It requires an
+// initialized token, configured using the SOFTHSM2_MODULE,
+// SOFTHSM2_TOKEN_LABEL and SOFTHSM2_PIN environment variables, e.g.:
+//
+//	softhsm2-util --init-token --free --label cert-manager --so-pin 0000 --pin 1234
+func TestSoftHSM(t *testing.T) {
+	modulePath := os.Getenv("SOFTHSM2_MODULE")
+	tokenLabel := os.Getenv("SOFTHSM2_TOKEN_LABEL")
+	pin := os.Getenv("SOFTHSM2_PIN")
+	if len(modulePath) == 0 || len(tokenLabel) == 0 {
+		t.Skip("skipping SoftHSM test as SOFTHSM2_MODULE and SOFTHSM2_TOKEN_LABEL are not set")
+	}
+
+	mod, err := loadModule(modulePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := mod.(*pkcs11.Ctx)
+
+	slot, err := findSlot(mod, Config{TokenLabel: tokenLabel})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sh, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.CloseSession(sh)
+	if err := ctx.Login(sh, pkcs11.CKU_USER, pin); err != nil {
+		t.Fatal(err)
+	}
+
+	keyLabel := "cert-manager-test-" + time.Now().Format("20060102150405.000000000")
+	pubHandle, privHandle, err := ctx.GenerateKeyPair(sh,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+			pkcs11.NewAttribute(pkcs11.CKA_MODULUS_BITS, 2048),
+			pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, []byte{1, 0, 1}),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+		},
+		[]*pkcs11.Attribute{
+			// The key must be a token object to be found from other sessions
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.DestroyObject(sh, privHandle)
+
+	attrs, err := ctx.GetAttributeValue(sh, pubHandle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(attrs[0].Value),
+		E: int(new(big.Int).SetBytes(attrs[1].Value).Int64()),
+	}
+
+	signer, err := NewSigner(Config{ModulePath: modulePath, TokenLabel: tokenLabel, KeyLabel: keyLabel, PIN: pin}, pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySigner(signer); err != nil {
+		t.Errorf("failed to verify signer: %v", err)
+	}
+	mustSignCertificate(t, signer)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err = NewSigner(Config{ModulePath: modulePath, TokenLabel: tokenLabel, KeyLabel: keyLabel, PIN: pin}, otherKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySigner(signer); err == nil {
+		t.Errorf("expected verifying a signer with a mismatched public key to fail")
+	}
+}
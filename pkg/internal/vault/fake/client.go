@@ -49,8 +49,12 @@ func (c *Client) WithRawRequest(resp *vault.Response, err error) *Client {
 	return c
 }
 
+// NewRequest returns a copy of NewRequestS that, like the requests created by
+// the Vault client, carries the token set on the client when it was created.
 func (c *Client) NewRequest(method, requestPath string) *vault.Request {
-	return c.NewRequestS
+	r := *c.NewRequestS
+	r.ClientToken = c.token
+	return &r
 }
 
 func (c *Client) SetToken(v string) {
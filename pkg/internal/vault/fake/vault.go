@@ -17,16 +17,23 @@ limitations under the License.
 package fake
 
 import (
+	"context"
 	"time"
 
 	vault "github.com/hashicorp/vault/api"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 )
 
+// createToken has the same underlying type as vault.CreateToken. The vault
+// package cannot be imported here as its tests depend on this package.
+type createToken = func(context.Context, string, *authv1.TokenRequest, metav1.CreateOptions) (*authv1.TokenRequest, error)
+
 type Vault struct {
-	NewFn  func(string, corelisters.SecretLister, v1alpha2.GenericIssuer) (*Vault, error)
+	NewFn  func(string, corelisters.SecretLister, createToken, v1alpha2.GenericIssuer) (*Vault, error)
 	SignFn func([]byte, time.Duration) ([]byte, []byte, error)
 }
 
@@ -37,7 +44,7 @@ func New() *Vault {
 		},
 	}
 
-	v.NewFn = func(string, corelisters.SecretLister, v1alpha2.GenericIssuer) (*Vault, error) {
+	v.NewFn = func(string, corelisters.SecretLister, createToken, v1alpha2.GenericIssuer) (*Vault, error) {
 		return v, nil
 	}
 
@@ -55,13 +62,13 @@ func (v *Vault) WithSign(certPEM, caPEM []byte, err error) *Vault {
 	return v
 }
 
-func (v *Vault) WithNew(f func(string, corelisters.SecretLister, v1alpha2.GenericIssuer) (*Vault, error)) *Vault {
+func (v *Vault) WithNew(f func(string, corelisters.SecretLister, createToken, v1alpha2.GenericIssuer) (*Vault, error)) *Vault {
 	v.NewFn = f
 	return v
 }
 
-func (v *Vault) New(ns string, sl corelisters.SecretLister, iss v1alpha2.GenericIssuer) (*Vault, error) {
-	_, err := v.NewFn(ns, sl, iss)
+func (v *Vault) New(ns string, sl corelisters.SecretLister, ct createToken, iss v1alpha2.GenericIssuer) (*Vault, error) {
+	_, err := v.NewFn(ns, sl, ct, iss)
 	if err != nil {
 		return nil, err
 	}
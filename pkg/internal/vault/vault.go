@@ -17,6 +17,8 @@ limitations under the License.
 package vault
 
 import (
+	"context"
+	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -28,16 +30,31 @@ import (
 
 	vault "github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/issuer/vault/tokencache"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 var _ Interface = &Vault{}
 
 type VaultClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
-	issuer v1alpha2.GenericIssuer) (Interface, error)
+	createToken CreateToken, tokenCache *tokencache.Cache, issuer v1alpha2.GenericIssuer) (Interface, error)
+
+// CreateToken requests a token for the named ServiceAccount using the
+// TokenRequest API. It is satisfied by the CreateToken method of a
+// ServiceAccountInterface for the issuer's resource namespace.
+type CreateToken func(ctx context.Context, serviceAccountName string,
+	tokenRequest *authv1.TokenRequest, opts metav1.CreateOptions) (*authv1.TokenRequest, error)
+
+// jwtAuthTokenExpirationSeconds is the lifetime requested for ServiceAccount
+// tokens used for JWT authentication. This is the minimum lifetime accepted by
+// the TokenRequest API, as tokens are only used to log in to Vault.
+const jwtAuthTokenExpirationSeconds = 600
 
 type Interface interface {
 	Sign(csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, err error)
@@ -54,16 +71,24 @@ type Client interface {
 
 type Vault struct {
 	secretsLister corelisters.SecretLister
+	createToken   CreateToken
+	tokenCache    *tokencache.Cache
 	issuer        v1alpha2.GenericIssuer
 	namespace     string
 
 	client Client
 }
 
+// New returns a Vault client for the issuer, authenticated using the
+// issuer's configured auth method. If tokenCache is not nil, tokens obtained
+// by logging in to Vault are cached and reused by later clients for the same
+// issuer.
 func New(namespace string, secretsLister corelisters.SecretLister,
-	issuer v1alpha2.GenericIssuer) (Interface, error) {
+	createToken CreateToken, tokenCache *tokencache.Cache, issuer v1alpha2.GenericIssuer) (Interface, error) {
 	v := &Vault{
 		secretsLister: secretsLister,
+		createToken:   createToken,
+		tokenCache:    tokenCache,
 		namespace:     namespace,
 		issuer:        issuer,
 	}
@@ -78,6 +103,12 @@ func New(namespace string, secretsLister corelisters.SecretLister,
 		return nil, fmt.Errorf("error initializing Vault client: %s", err.Error())
 	}
 
+	// Set the Vault namespace before authenticating, as the auth method is
+	// mounted inside the namespace too.
+	if vaultNamespace := v.issuer.GetSpec().Vault.Namespace; vaultNamespace != "" {
+		client.SetNamespace(vaultNamespace)
+	}
+
 	if err := v.setToken(client); err != nil {
 		return nil, err
 	}
@@ -104,15 +135,36 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 		"exclude_cn_from_sans": "true",
 	}
 
-	url := path.Join("/v1", v.issuer.GetSpec().Vault.Path)
+	signPath := v.issuer.GetSpec().Vault.Path
+	if issuerRef := v.issuer.GetSpec().Vault.IssuerRef; issuerRef != "" {
+		signPath, err = IssuerSignPath(signPath, issuerRef)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
-	request := v.client.NewRequest("POST", url)
+	url := path.Join("/v1", signPath)
 
-	if err := request.SetJSONBody(parameters); err != nil {
-		return nil, nil, fmt.Errorf("failed to build vault request: %s", err)
+	// The request is built again for each attempt, as the client's token is
+	// copied into the request when it is created.
+	sign := func() (*vault.Response, error) {
+		request := v.client.NewRequest("POST", url)
+		if err := request.SetJSONBody(parameters); err != nil {
+			return nil, fmt.Errorf("failed to build vault request: %s", err)
+		}
+		return v.client.RawRequest(request)
 	}
 
-	resp, err := v.client.RawRequest(request)
+	resp, err := sign()
+	if isForbidden(err) && v.tokenCache != nil && v.issuer.GetSpec().Vault.Auth.TokenSecretRef == nil {
+		// The cached token may have been revoked, so log in again and retry
+		// the request once with the new token.
+		v.tokenCache.Invalidate(v.issuer)
+		if err := v.setToken(v.client); err != nil {
+			return nil, nil, err
+		}
+		resp, err = sign()
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %s", err)
 	}
@@ -135,12 +187,61 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 		return nil, nil, fmt.Errorf("unable to convert certificate bundle to PEM bundle: %s", err.Error())
 	}
 
+	// Order the CA chain returned by Vault from the issuer of the signed
+	// certificate upwards, so that intermediates are included in the
+	// certificate chain in the correct order even whilst the mount has more
+	// than one issuer. If the chain cannot be built, for example as the
+	// response could not be verified, the order returned by Vault is used.
+	caCerts := make([]*x509.Certificate, len(parsedBundle.CAChain))
+	caPEMs := make(map[*x509.Certificate]string, len(parsedBundle.CAChain))
+	for i, block := range parsedBundle.CAChain {
+		caCerts[i] = block.Certificate
+		caPEMs[block.Certificate] = bundle.CAChain[i]
+	}
+	chain := pki.OrderCAChain(parsedBundle.Certificate, caCerts)
+	if len(chain) == 0 {
+		chain = caCerts
+	}
+
+	certPEMs := []string{bundle.Certificate}
 	var caPem []byte = nil
-	if len(bundle.CAChain) > 0 {
-		caPem = []byte(bundle.CAChain[0])
+	for i, ca := range chain {
+		blockPEM := caPEMs[ca]
+		// A self-signed root CA is returned as the CA, and not included in
+		// the certificate chain.
+		if i == len(chain)-1 && pki.IsSelfSigned(ca) {
+			caPem = []byte(blockPEM)
+			break
+		}
+		certPEMs = append(certPEMs, blockPEM)
+		caPem = []byte(blockPEM)
 	}
 
-	return []byte(bundle.ToPEMBundle()), caPem, nil
+	return []byte(strings.Join(certPEMs, "\n")), caPem, nil
+}
+
+// IssuerSignPath returns the path used to sign certificates with the named
+// issuer of a multi-issuer PKI mount, given the path used to sign with the
+// mount's default issuer, of the form `<mount>/sign/<role>`.
+func IssuerSignPath(signPath, issuerRef string) (string, error) {
+	mount, endpoint, err := SplitSignPath(signPath)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(mount, "issuer", issuerRef, endpoint), nil
+}
+
+// SplitSignPath splits a path of the form `<mount>/sign/<role>` or
+// `<mount>/sign-verbatim[/<role>]` into the mount path of the PKI backend and
+// the sign endpoint relative to that mount.
+func SplitSignPath(signPath string) (mount, endpoint string, err error) {
+	segments := strings.Split(strings.Trim(signPath, "/"), "/")
+	for i := len(segments) - 1; i > 0; i-- {
+		if segments[i] == "sign" || segments[i] == "sign-verbatim" {
+			return strings.Join(segments[:i], "/"), strings.Join(segments[i:], "/"), nil
+		}
+	}
+	return "", "", fmt.Errorf("vault path %q must be of the form <mount>/sign/<role>", signPath)
 }
 
 func (v *Vault) setToken(client Client) error {
@@ -155,34 +256,76 @@ func (v *Vault) setToken(client Client) error {
 		return nil
 	}
 
+	login, err := v.loginFunc(client)
+	if err != nil {
+		return err
+	}
+
+	if v.tokenCache != nil {
+		return v.tokenCache.SetToken(client, v.issuer, login)
+	}
+
+	token, err := login()
+	if err != nil {
+		return err
+	}
+	client.SetToken(token)
+
+	return nil
+}
+
+// loginFunc returns a function that logs in to Vault using the auth method
+// configured on the issuer, returning the new token.
+func (v *Vault) loginFunc(client Client) (func() (string, error), error) {
 	appRole := v.issuer.GetSpec().Vault.Auth.AppRole
 	if appRole != nil {
-		token, err := v.requestTokenWithAppRoleRef(client, appRole)
-		if err != nil {
-			return err
-		}
-		client.SetToken(token)
-
-		return nil
+		return func() (string, error) {
+			return v.requestTokenWithAppRoleRef(client, appRole)
+		}, nil
 	}
 
 	kubernetesAuth := v.issuer.GetSpec().Vault.Auth.Kubernetes
 	if kubernetesAuth != nil {
-		token, err := v.requestTokenWithKubernetesAuth(client, kubernetesAuth)
-		if err != nil {
-			return fmt.Errorf("error reading Kubernetes service account token from %s: %s", kubernetesAuth.SecretRef.Name, err.Error())
-		}
-		client.SetToken(token)
-		return nil
+		return func() (string, error) {
+			token, err := v.requestTokenWithKubernetesAuth(client, kubernetesAuth)
+			if err != nil {
+				return "", fmt.Errorf("error reading Kubernetes service account token from %s: %s", kubernetesAuth.SecretRef.Name, err.Error())
+			}
+			return token, nil
+		}, nil
+	}
+
+	tlsCertAuth := v.issuer.GetSpec().Vault.Auth.TLSCert
+	if tlsCertAuth != nil {
+		return func() (string, error) {
+			return v.requestTokenWithTLSCertAuth(client, tlsCertAuth)
+		}, nil
+	}
+
+	jwtAuth := v.issuer.GetSpec().Vault.Auth.JWT
+	if jwtAuth != nil {
+		return func() (string, error) {
+			return v.requestTokenWithJWTAuth(client, jwtAuth)
+		}, nil
 	}
 
-	return fmt.Errorf("error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set")
+	return nil, fmt.Errorf("error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role, TLS certificate or JWT auth not set")
 }
 
 func (v *Vault) newConfig() (*vault.Config, error) {
 	cfg := vault.DefaultConfig()
 	cfg.Address = v.issuer.GetSpec().Vault.Server
 
+	tlsConfig := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig
+
+	if ref := v.issuer.GetSpec().Vault.ClientCertSecretRef; ref != nil {
+		clientCert, err := v.clientCertificate(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
 	certs := v.issuer.GetSpec().Vault.CABundle
 	if len(certs) == 0 {
 		return cfg, nil
@@ -194,11 +337,27 @@ func (v *Vault) newConfig() (*vault.Config, error) {
 		return nil, fmt.Errorf("error loading Vault CA bundle")
 	}
 
-	cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs = caCertPool
+	tlsConfig.RootCAs = caCertPool
 
 	return cfg, nil
 }
 
+// clientCertificate loads the client certificate and private key used for
+// mutual TLS from the named Secret.
+func (v *Vault) clientCertificate(name string) (tls.Certificate, error) {
+	secret, err := v.secretsLister.Secrets(v.namespace).Get(name)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	clientCert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("error loading Vault client certificate from secret '%s/%s': %s", v.namespace, name, err.Error())
+	}
+
+	return clientCert, nil
+}
+
 func (v *Vault) tokenRef(name, namespace, key string) (string, error) {
 	secret, err := v.secretsLister.Secrets(namespace).Get(name)
 	if err != nil {
@@ -345,6 +504,160 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 	return token, nil
 }
 
+func (v *Vault) requestTokenWithTLSCertAuth(client Client, tlsCertAuth *v1alpha2.VaultTLSCertAuth) (string, error) {
+	if v.issuer.GetSpec().Vault.ClientCertSecretRef == nil {
+		return "", errors.New("clientCertSecretRef must be set to use TLS certificate authentication")
+	}
+
+	parameters := map[string]string{}
+	if tlsCertAuth.Name != "" {
+		parameters["name"] = tlsCertAuth.Name
+	}
+
+	mountPath := tlsCertAuth.Path
+	if mountPath == "" {
+		mountPath = v1alpha2.DefaultVaultTLSCertAuthMountPath
+	}
+
+	url := filepath.Join(mountPath, "login")
+	request := client.NewRequest("POST", url)
+	err := request.SetJSONBody(parameters)
+	if err != nil {
+		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+	}
+
+	resp, err := client.RawRequest(request)
+	if err != nil {
+		return "", fmt.Errorf("error logging in to Vault server: %s", err.Error())
+	}
+
+	defer resp.Body.Close()
+	vaultResult := vault.Secret{}
+	err = resp.DecodeJSON(&vaultResult)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	token, err := vaultResult.TokenID()
+	if err != nil {
+		return "", fmt.Errorf("unable to read token: %s", err.Error())
+	}
+
+	if token == "" {
+		return "", errors.New("no token returned")
+	}
+
+	return token, nil
+}
+
 func (v *Vault) Sys() *vault.Sys {
 	return v.client.Sys()
 }
+
+func (v *Vault) requestTokenWithJWTAuth(client Client, jwtAuth *v1alpha2.VaultJWTAuth) (string, error) {
+	// The default audience is always requested so that Vault roles can be
+	// bound to it, regardless of any other audiences that are configured.
+	audiences := []string{v.defaultJWTAudience()}
+	for _, aud := range jwtAuth.Audiences {
+		if isAPIServerAudience(aud) {
+			return "", fmt.Errorf("refusing to request a token for service account '%s/%s' with the Kubernetes API server audience %q", v.namespace, jwtAuth.ServiceAccountRef.Name, aud)
+		}
+		if aud != audiences[0] {
+			audiences = append(audiences, aud)
+		}
+	}
+
+	expirationSeconds := int64(jwtAuthTokenExpirationSeconds)
+	tokenRequest := &authv1.TokenRequest{
+		Spec: authv1.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+
+	tokenResponse, err := v.createToken(context.TODO(), jwtAuth.ServiceAccountRef.Name, tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error requesting token for service account '%s/%s': %s", v.namespace, jwtAuth.ServiceAccountRef.Name, err.Error())
+	}
+
+	parameters := map[string]string{
+		"role": jwtAuth.Role,
+		"jwt":  tokenResponse.Status.Token,
+	}
+
+	mountPath := jwtAuth.Path
+	if mountPath == "" {
+		mountPath = v1alpha2.DefaultVaultJWTAuthMountPath
+	}
+
+	url := filepath.Join(mountPath, "login")
+	request := client.NewRequest("POST", url)
+	err = request.SetJSONBody(parameters)
+	if err != nil {
+		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+	}
+
+	resp, err := client.RawRequest(request)
+	if err != nil {
+		return "", fmt.Errorf("error calling Vault server: %s", err.Error())
+	}
+
+	defer resp.Body.Close()
+	vaultResult := vault.Secret{}
+	err = resp.DecodeJSON(&vaultResult)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	token, err := vaultResult.TokenID()
+	if err != nil {
+		return "", fmt.Errorf("unable to read token: %s", err.Error())
+	}
+
+	if token == "" {
+		return "", errors.New("no token returned")
+	}
+
+	return token, nil
+}
+
+// defaultJWTAudience returns the audience that ServiceAccount tokens are
+// always requested for, so that tokens requested for one issuer cannot be used
+// to log in to Vault as another.
+func (v *Vault) defaultJWTAudience() string {
+	if ns := v.issuer.GetObjectMeta().Namespace; ns != "" {
+		return fmt.Sprintf("vault://%s/%s", ns, v.issuer.GetObjectMeta().Name)
+	}
+	return fmt.Sprintf("vault://%s", v.issuer.GetObjectMeta().Name)
+}
+
+// apiServerAudiences are the audiences commonly accepted by the Kubernetes
+// API server. Tokens with these audiences would allow Vault, and anyone able
+// to read them from Vault, to authenticate to the cluster as the
+// ServiceAccount.
+var apiServerAudiences = []string{
+	"https://kubernetes.default.svc",
+	"https://kubernetes.default.svc.cluster.local",
+	"kubernetes.default.svc",
+	"kubernetes.default.svc.cluster.local",
+}
+
+// isAPIServerAudience returns true if aud is an audience accepted by the
+// Kubernetes API server.
+func isAPIServerAudience(aud string) bool {
+	aud = strings.TrimSuffix(aud, "/")
+	for _, apiServerAud := range apiServerAudiences {
+		if aud == apiServerAud {
+			return true
+		}
+	}
+	return false
+}
+
+// isForbidden returns true if err is an error response from Vault with the
+// status code 403, which is returned when the token used has expired or has
+// been revoked.
+func isForbidden(err error) bool {
+	var respErr *vault.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden
+}
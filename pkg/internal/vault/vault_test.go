@@ -18,6 +18,7 @@ package vault
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
@@ -28,7 +29,9 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -36,11 +39,15 @@ import (
 	vault "github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
+	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	vaultfake "github.com/jetstack/cert-manager/pkg/internal/vault/fake"
+	"github.com/jetstack/cert-manager/pkg/issuer/vault/tokencache"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
 	"github.com/jetstack/cert-manager/test/unit/gen"
 	"github.com/jetstack/cert-manager/test/unit/listers"
@@ -181,6 +188,94 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestSignReauthenticatesOnForbidden(t *testing.T) {
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+
+	bundleData, err := jsonutil.EncodeJSON(&certutil.Secret{
+		Data: map[string]interface{}{
+			"certificate": testCertBundle,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to encode bundle for testing: %s", err)
+	}
+
+	response := func(body string) *vault.Response {
+		return &vault.Response{
+			Response: &http.Response{Body: ioutil.NopCloser(strings.NewReader(body))},
+		}
+	}
+
+	// The first signing request is rejected as the cached token has been
+	// revoked. The client should then log in, look up the new token and
+	// retry the signing request.
+	responses := []*vault.Response{
+		nil,
+		response(`{"auth":{"client_token":"new-token"}}`),
+		response(`{"data":{"ttl":300,"renewable":true}}`),
+		response(string(bundleData)),
+	}
+	errs := []error{
+		&vault.ResponseError{StatusCode: http.StatusForbidden},
+		nil, nil, nil,
+	}
+	calls := 0
+	var tokens []string
+	client := vaultfake.NewFakeClient()
+	client.SetToken("revoked-token")
+	client.RawRequestFn = func(r *vault.Request) (*vault.Response, error) {
+		if calls >= len(responses) {
+			t.Fatalf("unexpected RawRequest call")
+		}
+		tokens = append(tokens, r.ClientToken)
+		resp, err := responses[calls], errs[calls]
+		calls++
+		return resp, err
+	}
+
+	v := &Vault{
+		namespace: "test-namespace",
+		secretsLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+			listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{
+				Data: map[string][]byte{"my-key": []byte("my-secret-id")},
+			}, nil),
+		),
+		tokenCache: tokencache.New(fakeclock.NewFakeClock(time.Now()), nil),
+		issuer: gen.Issuer("vault-issuer",
+			gen.SetIssuerVault(v1alpha2.VaultIssuer{
+				Auth: v1alpha2.VaultAuth{
+					AppRole: &v1alpha2.VaultAppRole{
+						RoleId:    "my-role-id",
+						SecretRef: cmmeta.SecretKeySelector{Key: "my-key"},
+					},
+				},
+			}),
+		),
+		client: client,
+	}
+
+	cert, _, err := v.Sign(csrPEM, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(cert) != testCertBundle {
+		t.Errorf("unexpected certificate in response bundle, exp=%s got=%s", testCertBundle, cert)
+	}
+	if client.Token() != "new-token" {
+		t.Errorf("expected client to use the new token, got=%s", client.Token())
+	}
+	if calls != len(responses) {
+		t.Fatalf("expected %d requests to be made, got=%d", len(responses), calls)
+	}
+	if tokens[0] != "revoked-token" {
+		t.Errorf("expected the first signing request to use the cached token, got=%s", tokens[0])
+	}
+	if tokens[3] != "new-token" {
+		t.Errorf("expected the retried signing request to use the new token, got=%s", tokens[3])
+	}
+}
+
 type testSetTokenT struct {
 	expectedToken string
 	expectedErr   error
@@ -220,7 +315,7 @@ func TestSetToken(t *testing.T) {
 			fakeClient:    vaultfake.NewFakeClient(),
 			expectedToken: "",
 			expectedErr: errors.New(
-				"error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+				"error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role, TLS certificate or JWT auth not set",
 			),
 		},
 
@@ -889,3 +984,394 @@ func TestRequestTokenWithAppRoleRef(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestTokenWithTLSCertAuth(t *testing.T) {
+	clientCertIssuer := gen.Issuer("vault-issuer",
+		gen.SetIssuerVault(v1alpha2.VaultIssuer{
+			ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "vault-client-cert"},
+		}),
+	)
+
+	tests := map[string]struct {
+		client      Client
+		issuer      *v1alpha2.Issuer
+		tlsCertAuth *v1alpha2.VaultTLSCertAuth
+
+		expectedToken string
+		expectedErr   error
+	}{
+		"if clientCertSecretRef is not set, should error": {
+			client:      vaultfake.NewFakeClient(),
+			issuer:      gen.Issuer("vault-issuer", gen.SetIssuerVault(v1alpha2.VaultIssuer{})),
+			tlsCertAuth: &v1alpha2.VaultTLSCertAuth{},
+
+			expectedToken: "",
+			expectedErr:   errors.New("clientCertSecretRef must be set to use TLS certificate authentication"),
+		},
+		"if the login request fails, should error": {
+			client:      vaultfake.NewFakeClient().WithRawRequest(nil, errors.New("permission denied")),
+			issuer:      clientCertIssuer,
+			tlsCertAuth: &v1alpha2.VaultTLSCertAuth{},
+
+			expectedToken: "",
+			expectedErr:   errors.New("error logging in to Vault server: permission denied"),
+		},
+		"a client_token in the JSON response should return that token": {
+			client: vaultfake.NewFakeClient().WithRawRequest(
+				&vault.Response{
+					Response: &http.Response{
+						Body: ioutil.NopCloser(
+							strings.NewReader(
+								`{"request_id":"","lease_id":"","lease_duration":0,"renewable":false,"data":null,"warnings":null,"auth":{"client_token":"my-client-token"}}`),
+						),
+					},
+				}, nil,
+			),
+			issuer:      clientCertIssuer,
+			tlsCertAuth: &v1alpha2.VaultTLSCertAuth{Name: "cert-manager"},
+
+			expectedToken: "my-client-token",
+			expectedErr:   nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &Vault{
+				namespace:     "test-namespace",
+				secretsLister: nil,
+				issuer:        test.issuer,
+			}
+
+			token, err := v.requestTokenWithTLSCertAuth(test.client, test.tlsCertAuth)
+			if ((test.expectedErr == nil) != (err == nil)) &&
+				test.expectedErr != nil &&
+				test.expectedErr.Error() != err.Error() {
+				t.Errorf("unexpected error, exp=%v got=%v",
+					test.expectedErr, err)
+			}
+
+			if test.expectedToken != token {
+				t.Errorf("got unexpected token, exp=%s got=%s",
+					test.expectedToken, token)
+			}
+		})
+	}
+}
+
+func TestRequestTokenWithJWTAuth(t *testing.T) {
+	loginResponse := func() *vault.Response {
+		return &vault.Response{
+			Response: &http.Response{
+				Body: ioutil.NopCloser(
+					strings.NewReader(
+						`{"request_id":"","lease_id":"","lease_duration":0,"renewable":false,"data":null,"warnings":null,"auth":{"client_token":"my-client-token"}}`),
+				),
+			},
+		}
+	}
+
+	// createToken returns a CreateToken func that checks the ServiceAccount
+	// name and audiences that a token is requested for.
+	createToken := func(expName string, expAudiences []string, err error) CreateToken {
+		return func(_ context.Context, name string, req *authv1.TokenRequest, _ metav1.CreateOptions) (*authv1.TokenRequest, error) {
+			if name != expName {
+				t.Errorf("unexpected service account name, exp=%s got=%s", expName, name)
+			}
+			if !reflect.DeepEqual(req.Spec.Audiences, expAudiences) {
+				t.Errorf("unexpected audiences, exp=%v got=%v", expAudiences, req.Spec.Audiences)
+			}
+			if err != nil {
+				return nil, err
+			}
+			return &authv1.TokenRequest{Status: authv1.TokenRequestStatus{Token: "sa-token"}}, nil
+		}
+	}
+
+	tests := map[string]struct {
+		client      Client
+		issuer      v1alpha2.GenericIssuer
+		jwtAuth     *v1alpha2.VaultJWTAuth
+		createToken CreateToken
+
+		expectedToken string
+		expectedErr   error
+	}{
+		"if the token request fails, should error": {
+			client:      vaultfake.NewFakeClient(),
+			issuer:      gen.Issuer("vault-issuer"),
+			jwtAuth:     &v1alpha2.VaultJWTAuth{Role: "role", ServiceAccountRef: cmmeta.LocalObjectReference{Name: "sa"}},
+			createToken: createToken("sa", []string{"vault://" + gen.DefaultTestNamespace + "/vault-issuer"}, errors.New("forbidden")),
+
+			expectedToken: "",
+			expectedErr:   errors.New("error requesting token for service account 'test-namespace/sa': forbidden"),
+		},
+		"should request a token with the default audience for an Issuer": {
+			client:      vaultfake.NewFakeClient().WithRawRequest(loginResponse(), nil),
+			issuer:      gen.Issuer("vault-issuer"),
+			jwtAuth:     &v1alpha2.VaultJWTAuth{Role: "role", ServiceAccountRef: cmmeta.LocalObjectReference{Name: "sa"}},
+			createToken: createToken("sa", []string{"vault://" + gen.DefaultTestNamespace + "/vault-issuer"}, nil),
+
+			expectedToken: "my-client-token",
+			expectedErr:   nil,
+		},
+		"should request a token with the default audience for a ClusterIssuer": {
+			client:      vaultfake.NewFakeClient().WithRawRequest(loginResponse(), nil),
+			issuer:      gen.ClusterIssuer("vault-issuer"),
+			jwtAuth:     &v1alpha2.VaultJWTAuth{Role: "role", ServiceAccountRef: cmmeta.LocalObjectReference{Name: "sa"}},
+			createToken: createToken("sa", []string{"vault://vault-issuer"}, nil),
+
+			expectedToken: "my-client-token",
+			expectedErr:   nil,
+		},
+		"should request a token with the configured audiences": {
+			client: vaultfake.NewFakeClient().WithRawRequest(loginResponse(), nil),
+			issuer: gen.Issuer("vault-issuer"),
+			jwtAuth: &v1alpha2.VaultJWTAuth{
+				Role:              "role",
+				ServiceAccountRef: cmmeta.LocalObjectReference{Name: "sa"},
+				Audiences:         []string{"https://vault.example.com"},
+			},
+			createToken: createToken("sa", []string{"vault://" + gen.DefaultTestNamespace + "/vault-issuer", "https://vault.example.com"}, nil),
+
+			expectedToken: "my-client-token",
+			expectedErr:   nil,
+		},
+		"should not request the default audience twice": {
+			client: vaultfake.NewFakeClient().WithRawRequest(loginResponse(), nil),
+			issuer: gen.Issuer("vault-issuer"),
+			jwtAuth: &v1alpha2.VaultJWTAuth{
+				Role:              "role",
+				ServiceAccountRef: cmmeta.LocalObjectReference{Name: "sa"},
+				Audiences:         []string{"vault://" + gen.DefaultTestNamespace + "/vault-issuer"},
+			},
+			createToken: createToken("sa", []string{"vault://" + gen.DefaultTestNamespace + "/vault-issuer"}, nil),
+
+			expectedToken: "my-client-token",
+			expectedErr:   nil,
+		},
+		"if the Kubernetes API server audience is configured, should error": {
+			client: vaultfake.NewFakeClient(),
+			issuer: gen.Issuer("vault-issuer"),
+			jwtAuth: &v1alpha2.VaultJWTAuth{
+				Role:              "role",
+				ServiceAccountRef: cmmeta.LocalObjectReference{Name: "sa"},
+				Audiences:         []string{"https://vault.example.com", "https://kubernetes.default.svc.cluster.local/"},
+			},
+			createToken: func(context.Context, string, *authv1.TokenRequest, metav1.CreateOptions) (*authv1.TokenRequest, error) {
+				t.Errorf("unexpected token request")
+				return nil, errors.New("unexpected token request")
+			},
+
+			expectedToken: "",
+			expectedErr:   errors.New(`refusing to request a token for service account 'test-namespace/sa' with the Kubernetes API server audience "https://kubernetes.default.svc.cluster.local/"`),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &Vault{
+				namespace:     "test-namespace",
+				secretsLister: nil,
+				createToken:   test.createToken,
+				issuer:        test.issuer,
+			}
+
+			token, err := v.requestTokenWithJWTAuth(test.client, test.jwtAuth)
+			if fmt.Sprint(test.expectedErr) != fmt.Sprint(err) {
+				t.Errorf("unexpected error, exp=%v got=%v",
+					test.expectedErr, err)
+			}
+
+			if test.expectedToken != token {
+				t.Errorf("got unexpected token, exp=%s got=%s",
+					test.expectedToken, token)
+			}
+		})
+	}
+}
+
+func TestIssuerSignPath(t *testing.T) {
+	tests := map[string]struct {
+		path        string
+		issuerRef   string
+		expectedErr bool
+		expected    string
+	}{
+		"should insert the issuer into a sign path": {
+			path:      "pki/sign/example-dot-com",
+			issuerRef: "intermediate-2020",
+			expected:  "pki/issuer/intermediate-2020/sign/example-dot-com",
+		},
+		"should support mounts containing slashes": {
+			path:      "/pki/int/sign/example-dot-com",
+			issuerRef: "intermediate-2020",
+			expected:  "pki/int/issuer/intermediate-2020/sign/example-dot-com",
+		},
+		"should support sign-verbatim paths": {
+			path:      "pki/sign-verbatim/example-dot-com",
+			issuerRef: "intermediate-2020",
+			expected:  "pki/issuer/intermediate-2020/sign-verbatim/example-dot-com",
+		},
+		"should error if the path is not a sign path": {
+			path:        "pki/issue/example-dot-com",
+			issuerRef:   "intermediate-2020",
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signPath, err := IssuerSignPath(test.path, test.issuerRef)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+			if signPath != test.expected {
+				t.Errorf("unexpected sign path, exp=%q got=%q", test.expected, signPath)
+			}
+		})
+	}
+}
+
+func TestSplitSignPath(t *testing.T) {
+	tests := map[string]struct {
+		path             string
+		expectedMount    string
+		expectedEndpoint string
+		expectedErr      bool
+	}{
+		"sign endpoint":            {path: "pki/sign/role", expectedMount: "pki", expectedEndpoint: "sign/role"},
+		"nested mount":             {path: "/team/pki_int/sign/role/", expectedMount: "team/pki_int", expectedEndpoint: "sign/role"},
+		"role named like sign":     {path: "pki/sign/signer", expectedMount: "pki", expectedEndpoint: "sign/signer"},
+		"sign-verbatim endpoint":   {path: "pki/sign-verbatim", expectedMount: "pki", expectedEndpoint: "sign-verbatim"},
+		"path without an endpoint": {path: "pki", expectedErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mount, endpoint, err := SplitSignPath(test.path)
+			if err != nil != test.expectedErr {
+				t.Fatalf("expected error=%t, got: %v", test.expectedErr, err)
+			}
+			if mount != test.expectedMount {
+				t.Errorf("unexpected mount, exp=%q got=%q", test.expectedMount, mount)
+			}
+			if endpoint != test.expectedEndpoint {
+				t.Errorf("unexpected endpoint, exp=%q got=%q", test.expectedEndpoint, endpoint)
+			}
+		})
+	}
+}
+
+func TestSignCAChain(t *testing.T) {
+	rootPK := generateRSAPrivateKey(t)
+	root := generateCertificate(t, "root", rootPK, nil, "", true)
+	oldIntPK := generateRSAPrivateKey(t)
+	oldInt := generateCertificate(t, "intermediate", oldIntPK, rootPK, root, true)
+	newIntPK := generateRSAPrivateKey(t)
+	newInt := generateCertificate(t, "intermediate", newIntPK, rootPK, root, true)
+	leaf := generateCertificate(t, "leaf", generateRSAPrivateKey(t), newIntPK, newInt, false)
+
+	csrPEM := generateCSR(t, generateRSAPrivateKey(t))
+
+	tests := map[string]struct {
+		data map[string]interface{}
+
+		expectedCert string
+		expectedCA   string
+	}{
+		"should return the issuing CA if there is no chain": {
+			data: map[string]interface{}{
+				"certificate": leaf,
+				"issuing_ca":  newInt,
+			},
+			expectedCert: leaf + "\n" + newInt,
+			expectedCA:   newInt,
+		},
+		"should return the root from ca_chain as the CA": {
+			data: map[string]interface{}{
+				"certificate": leaf,
+				"issuing_ca":  newInt,
+				"ca_chain":    []string{newInt, root},
+			},
+			expectedCert: leaf + "\n" + newInt,
+			expectedCA:   root,
+		},
+		"should order ca_chain and omit issuers that did not sign the certificate": {
+			data: map[string]interface{}{
+				"certificate": leaf,
+				"issuing_ca":  newInt,
+				"ca_chain":    []string{root, oldInt, newInt},
+			},
+			expectedCert: leaf + "\n" + newInt,
+			expectedCA:   root,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bundleData, err := jsonutil.EncodeJSON(&certutil.Secret{Data: test.data})
+			if err != nil {
+				t.Fatalf("failed to encode bundle for testing: %s", err)
+			}
+
+			v := &Vault{
+				namespace: "test-namespace",
+				issuer: gen.Issuer("vault-issuer",
+					gen.SetIssuerVault(v1alpha2.VaultIssuer{}),
+				),
+				client: vaultfake.NewFakeClient().WithRawRequest(&vault.Response{
+					Response: &http.Response{
+						Body: ioutil.NopCloser(bytes.NewReader(bundleData))},
+				}, nil),
+			}
+
+			cert, ca, err := v.Sign(csrPEM, time.Minute)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(cert) != test.expectedCert {
+				t.Errorf("unexpected certificate chain, exp=%s got=%s", test.expectedCert, cert)
+			}
+			if string(ca) != test.expectedCA {
+				t.Errorf("unexpected CA, exp=%s got=%s", test.expectedCA, ca)
+			}
+		})
+	}
+}
+
+// generateCertificate returns a PEM encoded certificate for key, signed by
+// the parent certificate and key, or self-signed if parentPEM is empty.
+func generateCertificate(t *testing.T, cn string, key, parentKey *rsa.PrivateKey, parentPEM string, isCA bool) string {
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+
+	parent, signer := template, key
+	if parentPEM != "" {
+		parent, err = pki.DecodeX509CertificateBytes([]byte(parentPEM))
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer = parentKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+}
@@ -26,7 +26,8 @@ import (
 
 type Venafi struct {
 	PingFn                  func() error
-	SignFn                  func([]byte, time.Duration, []internalvanafiapi.CustomField) ([]byte, error)
+	RequestCertificateFn    func([]byte, time.Duration, []internalvanafiapi.CustomField) (string, error)
+	RetrieveCertificateFn   func(string) ([]byte, error)
 	ReadZoneConfigurationFn func() (*endpoint.ZoneConfiguration, error)
 }
 
@@ -34,8 +35,12 @@ func (v *Venafi) Ping() error {
 	return v.PingFn()
 }
 
-func (v *Venafi) Sign(b []byte, t time.Duration, f []internalvanafiapi.CustomField) ([]byte, error) {
-	return v.SignFn(b, t, f)
+func (v *Venafi) RequestCertificate(b []byte, t time.Duration, f []internalvanafiapi.CustomField) (string, error) {
+	return v.RequestCertificateFn(b, t, f)
+}
+
+func (v *Venafi) RetrieveCertificate(pickupID string) ([]byte, error) {
+	return v.RetrieveCertificateFn(pickupID)
 }
 
 func (v *Venafi) ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error) {
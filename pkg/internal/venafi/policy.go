@@ -0,0 +1,294 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/Venafi/vcert/pkg/certificate"
+	"github.com/Venafi/vcert/pkg/endpoint"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// zoneConfigurationTTL is how long a PolicyChecker caches the configuration
+// of a Venafi zone for.
+const zoneConfigurationTTL = time.Minute * 5
+
+// zoneConfigurationErrorTTL is how long a PolicyChecker caches an error
+// reading the configuration of a Venafi zone for, so that an unavailable
+// Venafi instance is not contacted each time a Certificate is synced.
+const zoneConfigurationErrorTTL = time.Second * 30
+
+// PolicyChecker validates Certificates against the zone policy of their
+// Venafi issuer. Zone configurations are cached so that Certificates can be
+// checked each time they are synced without contacting Venafi.
+type PolicyChecker struct {
+	secretsLister corelisters.SecretLister
+	secretsClient corev1client.SecretsGetter
+	clientBuilder VenafiClientBuilder
+	clock         clock.Clock
+
+	// lock guards the zones map only. Each cachedZone has its own lock so
+	// that reading the zone of one issuer does not block checks for others.
+	lock  sync.Mutex
+	zones map[string]*cachedZone
+}
+
+type cachedZone struct {
+	// lock is held while the zone configuration is read, so that concurrent
+	// checks for the same issuer result in a single request to Venafi.
+	lock sync.Mutex
+
+	venafi    cmapi.VenafiIssuer
+	zone      *endpoint.ZoneConfiguration
+	err       error
+	fetchedAt time.Time
+}
+
+func NewPolicyChecker(secretsLister corelisters.SecretLister, secretsClient corev1client.SecretsGetter, clock clock.Clock) *PolicyChecker {
+	return &PolicyChecker{
+		secretsLister: secretsLister,
+		secretsClient: secretsClient,
+		clientBuilder: New,
+		clock:         clock,
+		zones:         make(map[string]*cachedZone),
+	}
+}
+
+// ValidateCertificate validates the Certificate against the zone policy of
+// the Venafi issuer. resourceNamespace is the namespace that the issuer's
+// credentials are read from.
+func (p *PolicyChecker) ValidateCertificate(crt *cmapi.Certificate, issuer cmapi.GenericIssuer, resourceNamespace string) (field.ErrorList, error) {
+	zone, err := p.zoneConfiguration(issuer, resourceNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateCertificateForZone(crt, zone), nil
+}
+
+func (p *PolicyChecker) zoneConfiguration(issuer cmapi.GenericIssuer, resourceNamespace string) (*endpoint.ZoneConfiguration, error) {
+	venCfg := issuer.GetSpec().Venafi
+	if venCfg == nil {
+		return nil, fmt.Errorf("issuer %q is not a Venafi issuer", issuer.GetObjectMeta().Name)
+	}
+
+	// ClusterIssuers have no namespace, so cannot collide with Issuers
+	key := issuer.GetObjectMeta().Namespace + "/" + issuer.GetObjectMeta().Name
+
+	p.lock.Lock()
+	cached, ok := p.zones[key]
+	if !ok {
+		cached = &cachedZone{}
+		p.zones[key] = cached
+	}
+	p.lock.Unlock()
+
+	cached.lock.Lock()
+	defer cached.lock.Unlock()
+
+	ttl := zoneConfigurationTTL
+	if cached.err != nil {
+		ttl = zoneConfigurationErrorTTL
+	}
+	if !cached.fetchedAt.IsZero() && reflect.DeepEqual(cached.venafi, *venCfg) && p.clock.Since(cached.fetchedAt) < ttl {
+		return cached.zone, cached.err
+	}
+
+	cached.venafi = *venCfg.DeepCopy()
+	cached.zone, cached.err = p.readZoneConfiguration(issuer, resourceNamespace)
+	cached.fetchedAt = p.clock.Now()
+
+	return cached.zone, cached.err
+}
+
+func (p *PolicyChecker) readZoneConfiguration(issuer cmapi.GenericIssuer, resourceNamespace string) (*endpoint.ZoneConfiguration, error) {
+	client, err := p.clientBuilder(resourceNamespace, p.secretsLister, p.secretsClient, issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	zone, err := client.ReadZoneConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("error reading Venafi zone configuration: %v", err)
+	}
+
+	return zone, nil
+}
+
+// ValidateCertificateForZone validates the Certificate against the policy of
+// a Venafi zone, so that requests that would be rejected by Venafi can be
+// reported before they are sent.
+// The key type and size, subject fields and SANs are checked. Fields that
+// are not set on the Certificate are checked using the defaults that will be
+// applied from the zone when the request is sent. Policy fields that are not
+// set are treated as unrestricted.
+func ValidateCertificateForZone(crt *cmapi.Certificate, zone *endpoint.ZoneConfiguration) field.ErrorList {
+	el := field.ErrorList{}
+
+	specPath := field.NewPath("spec")
+	spec := &crt.Spec
+	policy := &zone.Policy
+
+	if spec.CommonName != "" {
+		el = append(el, validateAgainstRegexes(specPath.Child("commonName"), []string{spec.CommonName}, policy.SubjectCNRegexes)...)
+	}
+	el = append(el, validateAgainstRegexes(specPath.Child("dnsNames"), spec.DNSNames, policy.DnsSanRegExs)...)
+	el = append(el, validateAgainstRegexes(specPath.Child("ipAddresses"), spec.IPAddresses, policy.IpSanRegExs)...)
+	el = append(el, validateAgainstRegexes(specPath.Child("uriSANs"), spec.URISANs, policy.UriSanRegExs)...)
+	el = append(el, validateAgainstRegexes(specPath.Child("emailSANs"), spec.EmailSANs, policy.EmailSanRegExs)...)
+
+	// The organization defaults to 'cert-manager' when the request is sent
+	// to Venafi, rather than the organization of the zone.
+	organizations := pki.OrganizationForCertificate(crt)
+	if len(organizations) == 0 {
+		organizations = []string{"cert-manager"}
+	}
+	el = append(el, validateAgainstRegexes(specPath.Child("organization"), organizations, policy.SubjectORegexes)...)
+
+	subject := pki.SubjectForCertificate(crt)
+	subjectPath := specPath.Child("subject")
+	el = append(el, validateAgainstRegexes(subjectPath.Child("organizationalUnits"),
+		withZoneDefault(subject.OrganizationalUnits, zone.OrganizationalUnit...), policy.SubjectOURegexes)...)
+	el = append(el, validateAgainstRegexes(subjectPath.Child("countries"),
+		withZoneDefault(subject.Countries, zone.Country), policy.SubjectCRegexes)...)
+	el = append(el, validateAgainstRegexes(subjectPath.Child("provinces"),
+		withZoneDefault(subject.Provinces, zone.Province), policy.SubjectSTRegexes)...)
+	el = append(el, validateAgainstRegexes(subjectPath.Child("localities"),
+		withZoneDefault(subject.Localities, zone.Locality), policy.SubjectLRegexes)...)
+
+	el = append(el, validateKeyForZone(specPath, spec, policy.AllowedKeyConfigurations)...)
+
+	return el
+}
+
+// validateAgainstRegexes checks that each value matches at least one of the
+// regexes. If no regexes are given, all values are allowed.
+func validateAgainstRegexes(fldPath *field.Path, values []string, regexes []string) field.ErrorList {
+	el := field.ErrorList{}
+	if len(regexes) == 0 {
+		return el
+	}
+
+	for i, value := range values {
+		if !matchesAnyRegex(value, regexes) {
+			el = append(el, field.Invalid(fldPath.Index(i), value,
+				fmt.Sprintf("does not match any of the values allowed by the Venafi zone policy: %v", regexes)))
+		}
+	}
+
+	return el
+}
+
+func matchesAnyRegex(value string, regexes []string) bool {
+	for _, r := range regexes {
+		if matched, err := regexp.MatchString(r, value); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// withZoneDefault returns values, or the zone defaults if values is empty.
+func withZoneDefault(values []string, defaults ...string) []string {
+	if len(values) > 0 {
+		return values
+	}
+	var nonEmpty []string
+	for _, d := range defaults {
+		if d != "" {
+			nonEmpty = append(nonEmpty, d)
+		}
+	}
+	return nonEmpty
+}
+
+func validateKeyForZone(specPath *field.Path, spec *cmapi.CertificateSpec, allowed []endpoint.AllowedKeyConfiguration) field.ErrorList {
+	el := field.ErrorList{}
+	if len(allowed) == 0 {
+		return el
+	}
+
+	var keyType certificate.KeyType
+	keySize := spec.KeySize
+	switch spec.KeyAlgorithm {
+	case cmapi.KeyAlgorithm(""), cmapi.RSAKeyAlgorithm:
+		keyType = certificate.KeyTypeRSA
+		if keySize == 0 {
+			keySize = pki.MinRSAKeySize
+		}
+	case cmapi.ECDSAKeyAlgorithm:
+		keyType = certificate.KeyTypeECDSA
+		if keySize == 0 {
+			keySize = pki.ECCurve256
+		}
+	default:
+		// invalid key algorithms are reported by the webhook
+		return el
+	}
+
+	for _, a := range allowed {
+		if a.KeyType != keyType {
+			continue
+		}
+		switch keyType {
+		case certificate.KeyTypeRSA:
+			for _, size := range a.KeySizes {
+				if size == keySize {
+					return el
+				}
+			}
+			el = append(el, field.Invalid(specPath.Child("keySize"), keySize,
+				fmt.Sprintf("RSA key size is not allowed by the Venafi zone policy, allowed sizes are: %v", a.KeySizes)))
+		case certificate.KeyTypeECDSA:
+			var curves []string
+			for _, curve := range a.KeyCurves {
+				if ecdsaKeySizeForCurve(curve) == keySize {
+					return el
+				}
+				curves = append(curves, curve.String())
+			}
+			el = append(el, field.Invalid(specPath.Child("keySize"), keySize,
+				fmt.Sprintf("ECDSA curve is not allowed by the Venafi zone policy, allowed curves are: %v", curves)))
+		}
+		return el
+	}
+
+	return append(el, field.Invalid(specPath.Child("keyAlgorithm"), spec.KeyAlgorithm,
+		"key algorithm is not allowed by the Venafi zone policy"))
+}
+
+func ecdsaKeySizeForCurve(curve certificate.EllipticCurve) int {
+	switch curve {
+	case certificate.EllipticCurveP256:
+		return pki.ECCurve256
+	case certificate.EllipticCurveP384:
+		return pki.ECCurve384
+	case certificate.EllipticCurveP521:
+		return pki.ECCurve521
+	}
+	return 0
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Venafi/vcert/pkg/certificate"
+	"github.com/Venafi/vcert/pkg/endpoint"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	internalfake "github.com/jetstack/cert-manager/pkg/internal/venafi/fake"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestValidateCertificateForZone(t *testing.T) {
+	specPath := field.NewPath("spec")
+	zone := &endpoint.ZoneConfiguration{
+		Country: "GB",
+		Policy: endpoint.Policy{
+			SubjectCNRegexes: []string{`^.*\.example\.com$`},
+			SubjectORegexes:  []string{`^cert-manager$`, `^Jetstack$`},
+			SubjectCRegexes:  []string{`^GB$`},
+			DnsSanRegExs:     []string{`^.*\.example\.com$`},
+			IpSanRegExs:      []string{`^10\.`},
+			AllowedKeyConfigurations: []endpoint.AllowedKeyConfiguration{
+				{KeyType: certificate.KeyTypeRSA, KeySizes: []int{2048, 4096}},
+				{KeyType: certificate.KeyTypeECDSA, KeyCurves: []certificate.EllipticCurve{certificate.EllipticCurveP384}},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		crt  *cmapi.Certificate
+		zone *endpoint.ZoneConfiguration
+
+		expectedErrs field.ErrorList
+	}{
+		"a certificate complying with the policy should be valid": {
+			crt: gen.Certificate("test",
+				gen.SetCertificateCommonName("www.example.com"),
+				gen.SetCertificateDNSNames("www.example.com", "api.example.com"),
+				gen.SetCertificateIPs("10.0.0.1"),
+				gen.SetCertificateOrganization("Jetstack"),
+			),
+			zone:         zone,
+			expectedErrs: field.ErrorList{},
+		},
+		"all fields should be allowed if the zone has no policy": {
+			crt: gen.Certificate("test",
+				gen.SetCertificateCommonName("www.other.com"),
+				gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+			),
+			zone:         &endpoint.ZoneConfiguration{},
+			expectedErrs: field.ErrorList{},
+		},
+		"names not matching the policy should be invalid": {
+			crt: gen.Certificate("test",
+				gen.SetCertificateCommonName("www.other.com"),
+				gen.SetCertificateDNSNames("www.example.com", "www.other.com"),
+				gen.SetCertificateIPs("192.168.0.1"),
+			),
+			zone: zone,
+			expectedErrs: field.ErrorList{
+				field.Invalid(specPath.Child("commonName").Index(0), "www.other.com",
+					"does not match any of the values allowed by the Venafi zone policy: [^.*\\.example\\.com$]"),
+				field.Invalid(specPath.Child("dnsNames").Index(1), "www.other.com",
+					"does not match any of the values allowed by the Venafi zone policy: [^.*\\.example\\.com$]"),
+				field.Invalid(specPath.Child("ipAddresses").Index(0), "192.168.0.1",
+					"does not match any of the values allowed by the Venafi zone policy: [^10\\.]"),
+			},
+		},
+		"subject fields not matching the policy should be invalid": {
+			crt: gen.Certificate("test",
+				gen.SetCertificateDNSNames("www.example.com"),
+				gen.SetCertificateOrganization("Other"),
+				func(crt *cmapi.Certificate) {
+					crt.Spec.Subject = &cmapi.X509Subject{Countries: []string{"US"}}
+				},
+			),
+			zone: zone,
+			expectedErrs: field.ErrorList{
+				field.Invalid(specPath.Child("organization").Index(0), "Other",
+					"does not match any of the values allowed by the Venafi zone policy: [^cert-manager$ ^Jetstack$]"),
+				field.Invalid(specPath.Child("subject", "countries").Index(0), "US",
+					"does not match any of the values allowed by the Venafi zone policy: [^GB$]"),
+			},
+		},
+		"a key size not allowed by the policy should be invalid": {
+			crt: gen.Certificate("test",
+				gen.SetCertificateDNSNames("www.example.com"),
+				gen.SetCertificateKeySize(3072),
+			),
+			zone: zone,
+			expectedErrs: field.ErrorList{
+				field.Invalid(specPath.Child("keySize"), 3072,
+					"RSA key size is not allowed by the Venafi zone policy, allowed sizes are: [2048 4096]"),
+			},
+		},
+		"an ECDSA curve not allowed by the policy should be invalid": {
+			crt: gen.Certificate("test",
+				gen.SetCertificateDNSNames("www.example.com"),
+				gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+			),
+			zone: zone,
+			expectedErrs: field.ErrorList{
+				field.Invalid(specPath.Child("keySize"), 256,
+					"ECDSA curve is not allowed by the Venafi zone policy, allowed curves are: [P384]"),
+			},
+		},
+		"a key algorithm not allowed by the policy should be invalid": {
+			crt: gen.Certificate("test",
+				gen.SetCertificateDNSNames("www.example.com"),
+				gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+			),
+			zone: &endpoint.ZoneConfiguration{
+				Policy: endpoint.Policy{
+					AllowedKeyConfigurations: []endpoint.AllowedKeyConfiguration{
+						{KeyType: certificate.KeyTypeRSA, KeySizes: []int{2048}},
+					},
+				},
+			},
+			expectedErrs: field.ErrorList{
+				field.Invalid(specPath.Child("keyAlgorithm"), cmapi.ECDSAKeyAlgorithm,
+					"key algorithm is not allowed by the Venafi zone policy"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := ValidateCertificateForZone(test.crt, test.zone)
+			if !reflect.DeepEqual(errs, test.expectedErrs) {
+				t.Errorf("unexpected errors, exp=%v got=%v", test.expectedErrs, errs)
+			}
+		})
+	}
+}
+
+func TestPolicyCheckerCachesZoneConfiguration(t *testing.T) {
+	issuer := gen.Issuer("venafi-issuer",
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test-zone", TPP: &cmapi.VenafiTPP{}}),
+	)
+	crt := gen.Certificate("test", gen.SetCertificateDNSNames("www.other.com"))
+
+	reads := 0
+	clock := fakeclock.NewFakeClock(time.Now())
+	checker := NewPolicyChecker(nil, nil, clock)
+	checker.clientBuilder = func(string, corelisters.SecretLister, corev1client.SecretsGetter, cmapi.GenericIssuer) (Interface, error) {
+		return &internalfake.Venafi{
+			ReadZoneConfigurationFn: func() (*endpoint.ZoneConfiguration, error) {
+				reads++
+				return &endpoint.ZoneConfiguration{
+					Policy: endpoint.Policy{DnsSanRegExs: []string{`^.*\.example\.com$`}},
+				}, nil
+			},
+		}, nil
+	}
+
+	validate := func() {
+		errs, err := checker.ValidateCertificate(crt, issuer, gen.DefaultTestNamespace)
+		if err != nil {
+			t.Fatalf("expected to not get an error, but got: %v", err)
+		}
+		if len(errs) != 1 {
+			t.Errorf("expected one policy violation, got: %v", errs)
+		}
+	}
+
+	validate()
+	validate()
+	if reads != 1 {
+		t.Errorf("expected zone configuration to be read once, got=%d", reads)
+	}
+
+	clock.Step(zoneConfigurationTTL)
+	validate()
+	if reads != 2 {
+		t.Errorf("expected zone configuration to be read again after it expired, got=%d", reads)
+	}
+
+	issuer = gen.IssuerFrom(issuer,
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "other-zone", TPP: &cmapi.VenafiTPP{}}),
+	)
+	validate()
+	if reads != 3 {
+		t.Errorf("expected zone configuration to be read again after the issuer changed, got=%d", reads)
+	}
+}
+
+func TestPolicyCheckerCachesZoneConfigurationErrors(t *testing.T) {
+	issuer := gen.Issuer("venafi-issuer",
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test-zone", TPP: &cmapi.VenafiTPP{}}),
+	)
+	crt := gen.Certificate("test", gen.SetCertificateDNSNames("www.example.com"))
+
+	reads := 0
+	clock := fakeclock.NewFakeClock(time.Now())
+	checker := NewPolicyChecker(nil, nil, clock)
+	checker.clientBuilder = func(string, corelisters.SecretLister, corev1client.SecretsGetter, cmapi.GenericIssuer) (Interface, error) {
+		return &internalfake.Venafi{
+			ReadZoneConfigurationFn: func() (*endpoint.ZoneConfiguration, error) {
+				reads++
+				return nil, errors.New("unavailable")
+			},
+		}, nil
+	}
+
+	validate := func() {
+		if _, err := checker.ValidateCertificate(crt, issuer, gen.DefaultTestNamespace); err == nil {
+			t.Errorf("expected to get an error but did not get one")
+		}
+	}
+
+	validate()
+	validate()
+	if reads != 1 {
+		t.Errorf("expected zone configuration to be read once, got=%d", reads)
+	}
+
+	clock.Step(zoneConfigurationErrorTTL)
+	validate()
+	if reads != 2 {
+		t.Errorf("expected zone configuration to be read again after the error expired, got=%d", reads)
+	}
+}
+
+func TestPolicyCheckerReadsZoneConfigurationOncePerIssuer(t *testing.T) {
+	setVenafi := gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test-zone", TPP: &cmapi.VenafiTPP{}})
+	issuerA := gen.Issuer("venafi-issuer-a", setVenafi)
+	issuerB := gen.Issuer("venafi-issuer-b", setVenafi)
+	crt := gen.Certificate("test", gen.SetCertificateDNSNames("www.example.com"))
+
+	// reads of the zone of issuer A block until issuer B has been checked,
+	// which would deadlock if zones were read while holding a global lock
+	readB := make(chan struct{})
+	var reads int32
+	checker := NewPolicyChecker(nil, nil, fakeclock.NewFakeClock(time.Now()))
+	checker.clientBuilder = func(_ string, _ corelisters.SecretLister, _ corev1client.SecretsGetter, issuer cmapi.GenericIssuer) (Interface, error) {
+		return &internalfake.Venafi{
+			ReadZoneConfigurationFn: func() (*endpoint.ZoneConfiguration, error) {
+				atomic.AddInt32(&reads, 1)
+				if issuer.GetObjectMeta().Name == issuerA.Name {
+					<-readB
+				}
+				return &endpoint.ZoneConfiguration{}, nil
+			},
+		}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := checker.ValidateCertificate(crt, issuerA, gen.DefaultTestNamespace); err != nil {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+		}()
+	}
+
+	if _, err := checker.ValidateCertificate(crt, issuerB, gen.DefaultTestNamespace); err != nil {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	close(readB)
+	wg.Wait()
+
+	if reads != 2 {
+		t.Errorf("expected zone configuration to be read once per issuer, got=%d", reads)
+	}
+}
@@ -38,21 +38,24 @@ func (err ErrCustomFieldsType) Error() string {
 	return fmt.Sprintf("certificate request contains an invalid Venafi custom fields type: %q", err.Type)
 }
 
-// This function sends a request to Venafi to for a signed certificate.
+// This function sends a request to Venafi to for a signed certificate, and
+// returns the pickup ID of the request. The pickup ID should be stored and
+// passed to RetrieveCertificate so that the same request is polled when
+// retrieving the certificate is retried, rather than a new one being sent.
 // The CSR will be decoded to be validated against the zone configuration policy.
 // Upon the template being successfully defaulted and validated, the CSR will be sent, as is.
-func (v *Venafi) Sign(csrPEM []byte, duration time.Duration, customFields []internalvanafiapi.CustomField) (cert []byte, err error) {
+func (v *Venafi) RequestCertificate(csrPEM []byte, duration time.Duration, customFields []internalvanafiapi.CustomField) (pickupID string, err error) {
 	// Retrieve a copy of the Venafi zone.
 	// This contains default values and policy control info that we can apply
 	// and check against locally.
 	zoneCfg, err := v.client.ReadZoneConfiguration()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	tmpl, err := pki.GenerateTemplateFromCSRPEM(csrPEM, duration, false)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// Create a vcert Request structure
@@ -78,7 +81,7 @@ func (v *Venafi) Sign(csrPEM []byte, duration time.Duration, customFields []inte
 				fieldType = certificate.CustomFieldPlain
 				break
 			default:
-				return nil, ErrCustomFieldsType{Type: field.Type}
+				return "", ErrCustomFieldsType{Type: field.Type}
 			}
 
 			vreq.CustomFields = append(vreq.CustomFields, certificate.CustomField{
@@ -97,14 +100,12 @@ func (v *Venafi) Sign(csrPEM []byte, duration time.Duration, customFields []inte
 	// however, as this will be done again server side.
 	err = zoneCfg.ValidateCertificateRequest(vreq)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	vreq.SetCSR(csrPEM)
 	// Set options on the request
 	vreq.CsrOrigin = certificate.UserProvidedCSR
-	//// TODO: better set the timeout here. Right now, we'll block for this amount of time.
-	vreq.Timeout = time.Minute * 5
 
 	// Set the 'ObjectName' through the request friendly name. This is set in
 	// order of precedence CN->DNS->URI.
@@ -119,23 +120,31 @@ func (v *Venafi) Sign(csrPEM []byte, duration time.Duration, customFields []inte
 		vreq.FriendlyName = tmpl.URIs[0].String()
 		break
 	default:
-		return nil, errors.New(
+		return "", errors.New(
 			"certificate request contains no Common Name, DNS Name, nor URI SAN, at least one must be supplied to be used as the Venafi certificate objects name")
 	}
 
 	// Set the request CSR with the passed value
 	if err := vreq.SetCSR(csrPEM); err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// Send the certificate signing request to Venafi
-	requestID, err := v.client.RequestCertificate(vreq)
-	if err != nil {
-		return nil, err
-	}
+	return v.client.RequestCertificate(vreq)
+}
 
+// RetrieveCertificate retrieves the certificate issued for the request with
+// the given pickup ID. If the certificate has not been issued yet, an
+// endpoint.ErrCertificatePending or endpoint.ErrRetrieveCertificateTimeout
+// error is returned and retrieving the certificate should be retried later.
+func (v *Venafi) RetrieveCertificate(pickupID string) (cert []byte, err error) {
 	// Set the PickupID so vcert does not have to look it up by the fingerprint
-	vreq.PickupID = requestID
+	vreq := &certificate.Request{
+		PickupID:  pickupID,
+		CsrOrigin: certificate.UserProvidedCSR,
+		//// TODO: better set the timeout here. Right now, we'll block for this amount of time.
+		Timeout: time.Minute * 5,
+	}
 
 	// Retrieve the certificate from request
 	pemCollection, err := v.client.RetrieveCertificate(vreq)
@@ -178,10 +178,10 @@ func TestSign(t *testing.T) {
 			csrPEM:       csrPEM,
 			customFields: []internalvanafiapi.CustomField{{Name: "test", Value: "ok"}},
 			client: internalfake.Connector{
-				RetrieveCertificateFunc: func(r *certificate.Request) (*certificate.PEMCollection, error) {
+				RequestCertificateFunc: func(r *certificate.Request) (string, error) {
 					// we set 1 field by default
 					if len(r.CustomFields) <= 1 {
-						return nil, errors.New("custom fields not set")
+						return "", errors.New("custom fields not set")
 					}
 					foundFields := false
 					for _, fieldSet := range r.CustomFields {
@@ -190,9 +190,9 @@ func TestSign(t *testing.T) {
 						}
 					}
 					if !foundFields {
-						return nil, errors.New("custom fields content not correct")
+						return "", errors.New("custom fields content not correct")
 					}
-					return internalfake.Connector{}.Default().RetrieveCertificate(r) // hack to return to normal
+					return internalfake.Connector{}.Default().RequestCertificate(r) // hack to return to normal
 				},
 			}.Default(),
 			checkFn:     checkCertificateIssued,
@@ -234,7 +234,11 @@ func (s *testSignT) runTest(t *testing.T) {
 		client: client,
 	}
 
-	resp, err := v.Sign(s.csrPEM, time.Minute, s.customFields)
+	var resp []byte
+	pickupID, err := v.RequestCertificate(s.csrPEM, time.Minute, s.customFields)
+	if err == nil {
+		resp, err = v.RetrieveCertificate(pickupID)
+	}
 	if err != nil && !s.expectedErr {
 		t.Errorf("expected to not get an error, but got: %v", err)
 	}
@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Venafi/vcert/pkg/endpoint"
+	"github.com/Venafi/vcert/pkg/venafi/tpp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+)
+
+const (
+	tppAccessTokenKey       = "access-token"
+	tppRefreshTokenKey      = "refresh-token"
+	tppAccessTokenExpiryKey = "access-token-expiry"
+	tppClientIDKey          = "client-id"
+
+	// tppAccessTokenRefreshBefore is how long before it expires that an
+	// access token will be refreshed.
+	tppAccessTokenRefreshBefore = time.Hour
+)
+
+// tppRefreshLock serializes refreshing OAuth tokens, as TPP refresh tokens
+// can only be used once and are shared by every user of an issuer.
+var tppRefreshLock sync.Mutex
+
+// tppTokenClient obtains OAuth tokens from TPP. It is implemented by the vcert
+// TPP connector.
+type tppTokenClient interface {
+	GetRefreshToken(auth *endpoint.Authentication) (tpp.OauthGetRefreshTokenResponse, error)
+	RefreshAccessToken(auth *endpoint.Authentication) (tpp.OauthRefreshAccessTokenResponse, error)
+}
+
+// tppTokens keeps the OAuth tokens stored in TPP credentials Secrets valid.
+// Tokens are obtained by logging in with the username and password if the
+// Secret does not contain an access token yet. Access tokens are refreshed
+// using the refresh token shortly before they expire, or by logging in again
+// if that fails, and the new tokens are written back to the Secret.
+type tppTokens struct {
+	secretsClient  corev1client.SecretsGetter
+	newTokenClient func(tppCfg *cmapi.VenafiTPP) (tppTokenClient, error)
+	clock          clock.Clock
+}
+
+func newTPPTokens(secretsClient corev1client.SecretsGetter) *tppTokens {
+	return &tppTokens{
+		secretsClient:  secretsClient,
+		newTokenClient: newTPPTokenClient,
+		clock:          clock.RealClock{},
+	}
+}
+
+func newTPPTokenClient(tppCfg *cmapi.VenafiTPP) (tppTokenClient, error) {
+	var trust *x509.CertPool
+	if len(tppCfg.CABundle) > 0 {
+		trust = x509.NewCertPool()
+		if !trust.AppendCertsFromPEM(tppCfg.CABundle) {
+			return nil, fmt.Errorf("failed to parse PEM trust bundle")
+		}
+	}
+	return tpp.NewConnector(tppCfg.URL, "", true, trust)
+}
+
+// credentials returns the credentials used to authenticate with TPP using
+// the given credentials Secret. The username and password are only used as
+// they are to obtain an API key if the issuer explicitly uses the legacy
+// authentication API.
+func (t *tppTokens) credentials(tppCfg *cmapi.VenafiTPP, secret *corev1.Secret) (*endpoint.Authentication, error) {
+	if tppCfg.LegacyAPIKeyAuth {
+		return &endpoint.Authentication{
+			User:     string(secret.Data[tppUsernameKey]),
+			Password: string(secret.Data[tppPasswordKey]),
+		}, nil
+	}
+
+	accessToken := string(secret.Data[tppAccessTokenKey])
+	clientID := string(secret.Data[tppClientIDKey])
+	if accessToken != "" && (t == nil || !t.needsRefresh(secret)) {
+		return &endpoint.Authentication{AccessToken: accessToken, ClientId: clientID}, nil
+	}
+	if t == nil {
+		return nil, fmt.Errorf("venafi TPP credentials secret %s/%s does not contain an access token", secret.Namespace, secret.Name)
+	}
+
+	tppRefreshLock.Lock()
+	defer tppRefreshLock.Unlock()
+
+	// Read the latest version of the Secret, as the tokens may have been
+	// refreshed since the Secret was read from the cache.
+	secret, err := t.secretsClient.Secrets(secret.Namespace).Get(context.TODO(), secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(secret.Data[tppAccessTokenKey]) > 0 && !t.needsRefresh(secret) {
+		return &endpoint.Authentication{AccessToken: string(secret.Data[tppAccessTokenKey]), ClientId: clientID}, nil
+	}
+
+	client, err := t.newTokenClient(tppCfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Venafi TPP client: %s", err)
+	}
+
+	accessToken, refreshToken, expires, err := t.refresh(client, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	secret = secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[tppAccessTokenKey] = []byte(accessToken)
+	secret.Data[tppRefreshTokenKey] = []byte(refreshToken)
+	secret.Data[tppAccessTokenExpiryKey] = []byte(expires.UTC().Format(time.RFC3339))
+	_, err = t.secretsClient.Secrets(secret.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error storing refreshed Venafi TPP tokens in secret %s/%s: %s", secret.Namespace, secret.Name, err)
+	}
+
+	return &endpoint.Authentication{AccessToken: accessToken, ClientId: clientID}, nil
+}
+
+// refresh obtains new tokens using the refresh token in the Secret, falling
+// back to the username and password if the refresh token is missing or has
+// expired.
+func (t *tppTokens) refresh(client tppTokenClient, secret *corev1.Secret) (accessToken, refreshToken string, expires time.Time, err error) {
+	clientID := string(secret.Data[tppClientIDKey])
+
+	if rt := string(secret.Data[tppRefreshTokenKey]); rt != "" {
+		resp, refreshErr := client.RefreshAccessToken(&endpoint.Authentication{RefreshToken: rt, ClientId: clientID})
+		if refreshErr == nil {
+			return resp.Access_token, resp.Refresh_token, time.Unix(int64(resp.Expires), 0), nil
+		}
+		err = fmt.Errorf("error refreshing Venafi TPP access token: %s", refreshErr)
+	}
+
+	username, password := string(secret.Data[tppUsernameKey]), string(secret.Data[tppPasswordKey])
+	if username == "" || password == "" {
+		if err == nil {
+			err = fmt.Errorf("venafi TPP access token is not set or has expired, and no refresh token or username and password are set")
+		}
+		return "", "", time.Time{}, err
+	}
+
+	resp, err := client.GetRefreshToken(&endpoint.Authentication{User: username, Password: password, ClientId: clientID})
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("error obtaining Venafi TPP access token using username and password: %s", err)
+	}
+
+	return resp.Access_token, resp.Refresh_token, time.Unix(int64(resp.Expires), 0), nil
+}
+
+// needsRefresh returns true if the access token in the Secret should be
+// refreshed, and there are credentials available to refresh it with.
+// Tokens with an unknown expiry are refreshed so that their expiry is stored.
+func (t *tppTokens) needsRefresh(secret *corev1.Secret) bool {
+	canRefresh := len(secret.Data[tppRefreshTokenKey]) > 0 ||
+		(len(secret.Data[tppUsernameKey]) > 0 && len(secret.Data[tppPasswordKey]) > 0)
+	if !canRefresh {
+		return false
+	}
+
+	expiry, err := time.Parse(time.RFC3339, string(secret.Data[tppAccessTokenExpiryKey]))
+	if err != nil {
+		return true
+	}
+
+	return !t.clock.Now().Add(tppAccessTokenRefreshBefore).Before(expiry)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Venafi/vcert/pkg/endpoint"
+	"github.com/Venafi/vcert/pkg/venafi/tpp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+)
+
+type fakeTokenClient struct {
+	refreshErr error
+	loginErr   error
+
+	refreshes []endpoint.Authentication
+	logins    []endpoint.Authentication
+}
+
+func (c *fakeTokenClient) GetRefreshToken(auth *endpoint.Authentication) (tpp.OauthGetRefreshTokenResponse, error) {
+	c.logins = append(c.logins, *auth)
+	if c.loginErr != nil {
+		return tpp.OauthGetRefreshTokenResponse{}, c.loginErr
+	}
+	return tpp.OauthGetRefreshTokenResponse{
+		Access_token:  "login-access-token",
+		Refresh_token: "login-refresh-token",
+		Expires:       int(fakeNow.Add(24 * time.Hour).Unix()),
+	}, nil
+}
+
+func (c *fakeTokenClient) RefreshAccessToken(auth *endpoint.Authentication) (tpp.OauthRefreshAccessTokenResponse, error) {
+	c.refreshes = append(c.refreshes, *auth)
+	if c.refreshErr != nil {
+		return tpp.OauthRefreshAccessTokenResponse{}, c.refreshErr
+	}
+	return tpp.OauthRefreshAccessTokenResponse{
+		Access_token:  "refreshed-access-token",
+		Refresh_token: "refreshed-refresh-token",
+		Expires:       int(fakeNow.Add(24 * time.Hour).Unix()),
+	}, nil
+}
+
+var fakeNow = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestTPPTokensCredentials(t *testing.T) {
+	tppSecret := func(data map[string]string) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "tpp-credentials"},
+			Data:       map[string][]byte{},
+		}
+		for k, v := range data {
+			s.Data[k] = []byte(v)
+		}
+		return s
+	}
+	validExpiry := fakeNow.Add(2 * time.Hour).Format(time.RFC3339)
+	expiringExpiry := fakeNow.Add(30 * time.Minute).Format(time.RFC3339)
+	refreshedExpiry := fakeNow.Add(24 * time.Hour).Format(time.RFC3339)
+
+	tests := map[string]struct {
+		secret      *corev1.Secret
+		tokenClient *fakeTokenClient
+		legacy      bool
+
+		expectedAuth      *endpoint.Authentication
+		expectedRefreshes int
+		expectedLogins    int
+		// expectedData is the data of the Secret after credentials returns,
+		// if it has been updated
+		expectedData map[string]string
+		expectedErr  bool
+	}{
+		"should log in with the username and password and store the tokens if no access token is set": {
+			secret:         tppSecret(map[string]string{tppUsernameKey: "user", tppPasswordKey: "pass"}),
+			tokenClient:    &fakeTokenClient{},
+			expectedAuth:   &endpoint.Authentication{AccessToken: "login-access-token"},
+			expectedLogins: 1,
+			expectedData: map[string]string{
+				tppAccessTokenKey:       "login-access-token",
+				tppRefreshTokenKey:      "login-refresh-token",
+				tppAccessTokenExpiryKey: refreshedExpiry,
+				tppUsernameKey:          "user",
+				tppPasswordKey:          "pass",
+			},
+		},
+		"should use the username and password as they are with legacy API key auth": {
+			secret:       tppSecret(map[string]string{tppUsernameKey: "user", tppPasswordKey: "pass"}),
+			tokenClient:  &fakeTokenClient{},
+			legacy:       true,
+			expectedAuth: &endpoint.Authentication{User: "user", Password: "pass"},
+		},
+		"should error if no access token or username and password are set": {
+			secret:      tppSecret(map[string]string{tppClientIDKey: "cert-manager"}),
+			tokenClient: &fakeTokenClient{},
+			expectedErr: true,
+		},
+		"should use an access token that is not about to expire": {
+			secret: tppSecret(map[string]string{
+				tppAccessTokenKey:       "access-token",
+				tppRefreshTokenKey:      "refresh-token",
+				tppAccessTokenExpiryKey: validExpiry,
+				tppClientIDKey:          "cert-manager",
+			}),
+			tokenClient:  &fakeTokenClient{},
+			expectedAuth: &endpoint.Authentication{AccessToken: "access-token", ClientId: "cert-manager"},
+		},
+		"should use an access token without an expiry if it cannot be refreshed": {
+			secret:       tppSecret(map[string]string{tppAccessTokenKey: "access-token"}),
+			tokenClient:  &fakeTokenClient{},
+			expectedAuth: &endpoint.Authentication{AccessToken: "access-token"},
+		},
+		"should refresh an access token that is about to expire and store the new tokens": {
+			secret: tppSecret(map[string]string{
+				tppAccessTokenKey:       "access-token",
+				tppRefreshTokenKey:      "refresh-token",
+				tppAccessTokenExpiryKey: expiringExpiry,
+				tppClientIDKey:          "cert-manager",
+			}),
+			tokenClient:       &fakeTokenClient{},
+			expectedAuth:      &endpoint.Authentication{AccessToken: "refreshed-access-token", ClientId: "cert-manager"},
+			expectedRefreshes: 1,
+			expectedData: map[string]string{
+				tppAccessTokenKey:       "refreshed-access-token",
+				tppRefreshTokenKey:      "refreshed-refresh-token",
+				tppAccessTokenExpiryKey: refreshedExpiry,
+				tppClientIDKey:          "cert-manager",
+			},
+		},
+		"should log in with the username and password if refreshing fails": {
+			secret: tppSecret(map[string]string{
+				tppAccessTokenKey:       "access-token",
+				tppRefreshTokenKey:      "refresh-token",
+				tppAccessTokenExpiryKey: expiringExpiry,
+				tppUsernameKey:          "user",
+				tppPasswordKey:          "pass",
+			}),
+			tokenClient:       &fakeTokenClient{refreshErr: errors.New("invalid grant")},
+			expectedAuth:      &endpoint.Authentication{AccessToken: "login-access-token"},
+			expectedRefreshes: 1,
+			expectedLogins:    1,
+			expectedData: map[string]string{
+				tppAccessTokenKey:       "login-access-token",
+				tppRefreshTokenKey:      "login-refresh-token",
+				tppAccessTokenExpiryKey: refreshedExpiry,
+				tppUsernameKey:          "user",
+				tppPasswordKey:          "pass",
+			},
+		},
+		"should error if refreshing fails and no username and password are set": {
+			secret: tppSecret(map[string]string{
+				tppAccessTokenKey:       "access-token",
+				tppRefreshTokenKey:      "refresh-token",
+				tppAccessTokenExpiryKey: expiringExpiry,
+			}),
+			tokenClient:       &fakeTokenClient{refreshErr: errors.New("invalid grant")},
+			expectedRefreshes: 1,
+			expectedErr:       true,
+		},
+		"should error if logging in with the username and password fails": {
+			secret: tppSecret(map[string]string{
+				tppAccessTokenKey:       "access-token",
+				tppAccessTokenExpiryKey: expiringExpiry,
+				tppUsernameKey:          "user",
+				tppPasswordKey:          "pass",
+			}),
+			tokenClient:    &fakeTokenClient{loginErr: errors.New("invalid credentials")},
+			expectedLogins: 1,
+			expectedErr:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(test.secret)
+			tokens := &tppTokens{
+				secretsClient: client.CoreV1(),
+				newTokenClient: func(*cmapi.VenafiTPP) (tppTokenClient, error) {
+					return test.tokenClient, nil
+				},
+				clock: fakeclock.NewFakeClock(fakeNow),
+			}
+
+			auth, err := tokens.credentials(&cmapi.VenafiTPP{URL: "https://tpp.example.com", LegacyAPIKeyAuth: test.legacy}, test.secret)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+			if !reflect.DeepEqual(auth, test.expectedAuth) {
+				t.Errorf("unexpected credentials, exp=%+v got=%+v", test.expectedAuth, auth)
+			}
+			if len(test.tokenClient.refreshes) != test.expectedRefreshes {
+				t.Errorf("unexpected number of token refreshes, exp=%d got=%d", test.expectedRefreshes, len(test.tokenClient.refreshes))
+			}
+			if len(test.tokenClient.logins) != test.expectedLogins {
+				t.Errorf("unexpected number of logins, exp=%d got=%d", test.expectedLogins, len(test.tokenClient.logins))
+			}
+
+			secret, err := client.CoreV1().Secrets(test.secret.Namespace).Get(context.TODO(), test.secret.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			expectedData := test.secret.Data
+			if test.expectedData != nil {
+				expectedData = tppSecret(test.expectedData).Data
+			}
+			if !reflect.DeepEqual(secret.Data, expectedData) {
+				t.Errorf("unexpected secret data, exp=%q got=%q", expectedData, secret.Data)
+			}
+		})
+	}
+}
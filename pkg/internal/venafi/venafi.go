@@ -23,6 +23,7 @@ import (
 	"github.com/Venafi/vcert"
 	"github.com/Venafi/vcert/pkg/certificate"
 	"github.com/Venafi/vcert/pkg/endpoint"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
@@ -37,10 +38,11 @@ const (
 )
 
 type VenafiClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
-	issuer cmapi.GenericIssuer) (Interface, error)
+	secretsClient corev1client.SecretsGetter, issuer cmapi.GenericIssuer) (Interface, error)
 
 type Interface interface {
-	Sign(csrPEM []byte, duration time.Duration, customFields []internalvanafiapi.CustomField) (cert []byte, err error)
+	RequestCertificate(csrPEM []byte, duration time.Duration, customFields []internalvanafiapi.CustomField) (pickupID string, err error)
+	RetrieveCertificate(pickupID string) (cert []byte, err error)
 	Ping() error
 	ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error)
 	SetClient(endpoint.Connector)
@@ -69,10 +71,12 @@ type connector interface {
 	RenewCertificate(req *certificate.RenewalRequest) (requestID string, err error)
 }
 
+// New returns a Venafi client for the issuer. secretsClient is used to store
+// refreshed OAuth tokens in the TPP credentials Secret.
 func New(namespace string, secretsLister corelisters.SecretLister,
-	issuer cmapi.GenericIssuer) (Interface, error) {
+	secretsClient corev1client.SecretsGetter, issuer cmapi.GenericIssuer) (Interface, error) {
 
-	cfg, err := configForIssuer(issuer, secretsLister, namespace)
+	cfg, err := configForIssuer(issuer, secretsLister, namespace, newTPPTokens(secretsClient))
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +95,9 @@ func New(namespace string, secretsLister corelisters.SecretLister,
 
 // configForIssuer will convert a cert-manager Venafi issuer into a vcert.Config
 // that can be used to instantiate an API client.
-func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLister, namespace string) (*vcert.Config, error) {
+// If the TPP credentials Secret contains OAuth tokens, tokens will be used to
+// refresh them when required. If tokens is nil, they are used as they are.
+func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLister, namespace string, tokens *tppTokens) (*vcert.Config, error) {
 	venCfg := iss.GetSpec().Venafi
 	switch {
 	case venCfg.TPP != nil:
@@ -101,8 +107,10 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 			return nil, err
 		}
 
-		username := tppSecret.Data[tppUsernameKey]
-		password := tppSecret.Data[tppPasswordKey]
+		credentials, err := tokens.credentials(tpp, tppSecret)
+		if err != nil {
+			return nil, err
+		}
 
 		caBundle := ""
 		if len(tpp.CABundle) > 0 {
@@ -116,10 +124,7 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 			// always enable verbose logging for now
 			LogVerbose:      true,
 			ConnectionTrust: caBundle,
-			Credentials: &endpoint.Authentication{
-				User:     string(username),
-				Password: string(password),
-			},
+			Credentials:     credentials,
 		}, nil
 
 	case venCfg.Cloud != nil:
@@ -78,6 +78,13 @@ func TestConfigForIssuerT(t *testing.T) {
 		}),
 	)
 
+	legacyTPPIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone: zone,
+			TPP:  &cmapi.VenafiTPP{LegacyAPIKeyAuth: true},
+		}),
+	)
+
 	cloudIssuer := gen.IssuerFrom(baseIssuer,
 		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
 			Zone:  zone,
@@ -108,8 +115,8 @@ func TestConfigForIssuerT(t *testing.T) {
 			CheckFn:       checkNoConfigReturned,
 			expectedErr:   true,
 		},
-		"if TPP and secret returns user/pass, should return config with those credentials": {
-			iss: tppIssuer,
+		"if TPP with legacy API key auth and secret returns user/pass, should return config with those credentials": {
+			iss: legacyTPPIssuer,
 			secretsLister: generateSecretLister(&corev1.Secret{
 				Data: map[string][]byte{
 					tppUsernameKey: []byte(username),
@@ -127,6 +134,39 @@ func TestConfigForIssuerT(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if TPP and secret returns user/pass but tokens cannot be obtained, should error": {
+			iss: tppIssuer,
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					tppUsernameKey: []byte(username),
+					tppPasswordKey: []byte(password),
+				},
+			}, nil),
+			CheckFn:     checkNoConfigReturned,
+			expectedErr: true,
+		},
+		"if TPP and secret returns an access token, should return config with the access token": {
+			iss: tppIssuer,
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					tppAccessTokenKey: []byte("test-access-token"),
+					tppClientIDKey:    []byte("test-client-id"),
+				},
+			}, nil),
+			CheckFn: func(t *testing.T, cnf *vcert.Config) {
+				if token := cnf.Credentials.AccessToken; token != "test-access-token" {
+					t.Errorf("got unexpected access token: %s", token)
+				}
+				if clientID := cnf.Credentials.ClientId; clientID != "test-client-id" {
+					t.Errorf("got unexpected client ID: %s", clientID)
+				}
+				if user := cnf.Credentials.User; user != "" {
+					t.Errorf("expected username to not be set, got: %s", user)
+				}
+				checkZone(t, zone, cnf)
+			},
+			expectedErr: false,
+		},
 		"if Cloud but getting secret fails, should error": {
 			iss:           cloudIssuer,
 			secretsLister: generateSecretLister(nil, errors.New("this is a network error")),
@@ -167,7 +207,7 @@ func TestConfigForIssuerT(t *testing.T) {
 			iss: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerVenafi(cmapi.VenafiIssuer{
 					Zone:  zone,
-					TPP:   &cmapi.VenafiTPP{},
+					TPP:   &cmapi.VenafiTPP{LegacyAPIKeyAuth: true},
 					Cloud: &cmapi.VenafiCloud{},
 				}),
 			),
@@ -207,7 +247,7 @@ type testConfigForIssuerT struct {
 }
 
 func (c *testConfigForIssuerT) runTest(t *testing.T) {
-	resp, err := configForIssuer(c.iss, c.secretsLister, "test-namespace")
+	resp, err := configForIssuer(c.iss, c.secretsLister, "test-namespace", nil)
 	if err != nil && !c.expectedErr {
 		t.Errorf("expected to not get an error, but got: %v", err)
 	}
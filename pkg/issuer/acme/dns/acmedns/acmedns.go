@@ -27,13 +27,30 @@ import (
 	"os"
 
 	"github.com/cpu/goacmedns"
+
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
 )
 
+// client is the subset of the goacmedns client used by the DNSProvider.
+type client interface {
+	RegisterAccount(allowFrom []string) (goacmedns.Account, error)
+	UpdateTXTRecord(account goacmedns.Account, value string) error
+}
+
+// AccountStore persists an acme-dns account that has been registered for a
+// domain.
+type AccountStore func(domain string, account goacmedns.Account) error
+
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers []string
-	client           goacmedns.Client
+	client           client
 	accounts         map[string]goacmedns.Account
+	store            AccountStore
+
+	// lookupFQDN resolves the record that acme-dns challenges for a domain
+	// are delegated to. It is overridden in tests.
+	lookupFQDN func(domain string, followCNAME bool, nameservers ...string) (string, error)
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for ACME DNS
@@ -41,13 +58,15 @@ type DNSProvider struct {
 func NewDNSProvider(dns01Nameservers []string) (*DNSProvider, error) {
 	host := os.Getenv("ACME_DNS_HOST")
 	accountJson := os.Getenv("ACME_DNS_ACCOUNT_JSON")
-	return NewDNSProviderHostBytes(host, []byte(accountJson), dns01Nameservers)
+	return NewDNSProviderHostBytes(host, []byte(accountJson), dns01Nameservers, nil)
 }
 
 // NewDNSProviderHostBytes returns a DNSProvider instance configured for ACME DNS
 // acme-dns server host is given in a string
 // credentials are stored in json in the given string
-func NewDNSProviderHostBytes(host string, accountJson []byte, dns01Nameservers []string) (*DNSProvider, error) {
+// If store is not nil, accounts will be registered with the acme-dns server
+// for any domain without existing credentials and passed to store.
+func NewDNSProviderHostBytes(host string, accountJson []byte, dns01Nameservers []string, store AccountStore) (*DNSProvider, error) {
 	client := goacmedns.NewClient(host)
 
 	var accounts map[string]goacmedns.Account
@@ -59,17 +78,78 @@ func NewDNSProviderHostBytes(host string, accountJson []byte, dns01Nameservers [
 		client:           client,
 		accounts:         accounts,
 		dns01Nameservers: dns01Nameservers,
+		store:            store,
+		lookupFQDN:       util.DNS01LookupFQDN,
 	}, nil
 }
 
 // Present creates a TXT record to fulfil the dns-01 challenge
 func (c *DNSProvider) Present(domain, fqdn, value string) error {
-	if account, exists := c.accounts[domain]; exists {
-		// Update the acme-dns TXT record.
-		return c.client.UpdateTXTRecord(account, value)
+	account, exists := c.accounts[domain]
+	if !exists && c.store == nil {
+		return fmt.Errorf("account credentials not found for domain %s", domain)
+	}
+
+	if !exists {
+		var err error
+		account, err = c.register(domain)
+		if err != nil {
+			return err
+		}
 	}
 
-	return fmt.Errorf("account credentials not found for domain %s", domain)
+	// Update the acme-dns TXT record.
+	if err := c.client.UpdateTXTRecord(account, value); err != nil {
+		return err
+	}
+
+	// When accounts are registered automatically the user still has to
+	// delegate the challenge record to acme-dns, so make sure this has
+	// happened before reporting the challenge as presented.
+	if c.store != nil {
+		return c.checkCNAME(domain, account)
+	}
+
+	return nil
+}
+
+// register creates a new acme-dns account for the given domain and persists
+// it using the provider's AccountStore.
+func (c *DNSProvider) register(domain string) (goacmedns.Account, error) {
+	account, err := c.client.RegisterAccount(nil)
+	if err != nil {
+		return goacmedns.Account{}, fmt.Errorf("error registering acme-dns account for domain %s: %v", domain, err)
+	}
+
+	if err := c.store(domain, account); err != nil {
+		return goacmedns.Account{}, fmt.Errorf("error storing acme-dns account for domain %s: %v", domain, err)
+	}
+
+	if c.accounts == nil {
+		c.accounts = make(map[string]goacmedns.Account)
+	}
+	c.accounts[domain] = account
+
+	return account, nil
+}
+
+// checkCNAME returns a util.CNAMERequiredError describing the CNAME record that
+// must be created if the challenge record for domain does not point at the acme-dns
+// subdomain of account.
+func (c *DNSProvider) checkCNAME(domain string, account goacmedns.Account) error {
+	target, err := c.lookupFQDN(domain, true, c.dns01Nameservers...)
+	if err != nil {
+		return err
+	}
+
+	if target != util.ToFqdn(account.FullDomain) {
+		return &util.CNAMERequiredError{
+			Record: "_acme-challenge." + domain,
+			Target: account.FullDomain,
+		}
+	}
+
+	return nil
 }
 
 // CleanUp removes the record matching the specified parameters. It is not
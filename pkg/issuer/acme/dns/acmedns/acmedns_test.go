@@ -17,9 +17,11 @@ limitations under the License.
 package acmedns
 
 import (
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/cpu/goacmedns"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
 	"github.com/stretchr/testify/assert"
 )
@@ -49,28 +51,103 @@ func TestValidJsonAccount(t *testing.T) {
             "username": "usernom"
         }
     }`)
-	provider, err := NewDNSProviderHostBytes("http://localhost/", accountJson, util.RecursiveNameservers)
+	provider, err := NewDNSProviderHostBytes("http://localhost/", accountJson, util.RecursiveNameservers, nil)
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 	assert.Equal(t, provider.accounts["domain"].FullDomain, "fooldom")
 }
 
 func TestNoValidJsonAccount(t *testing.T) {
 	accountJson := []byte(`{"duck": "quack"}`)
-	_, err := NewDNSProviderHostBytes("http://localhost/", accountJson, util.RecursiveNameservers)
+	_, err := NewDNSProviderHostBytes("http://localhost/", accountJson, util.RecursiveNameservers, nil)
 	assert.Error(t, err, "Expected error constructing DNSProvider from invalid accountJson")
 }
 
 func TestNoValidJson(t *testing.T) {
 	accountJson := []byte("b00m")
-	_, err := NewDNSProviderHostBytes("http://localhost/", accountJson, util.RecursiveNameservers)
+	_, err := NewDNSProviderHostBytes("http://localhost/", accountJson, util.RecursiveNameservers, nil)
 	assert.Error(t, err, "Expected error constructing DNSProvider from invalid JSON")
 }
 
+type fakeClient struct {
+	registered goacmedns.Account
+	updated    map[string]string
+}
+
+func (f *fakeClient) RegisterAccount(allowFrom []string) (goacmedns.Account, error) {
+	return f.registered, nil
+}
+
+func (f *fakeClient) UpdateTXTRecord(account goacmedns.Account, value string) error {
+	f.updated[account.SubDomain] = value
+	return nil
+}
+
+func fakeLookup(target string) func(string, bool, ...string) (string, error) {
+	return func(domain string, followCNAME bool, nameservers ...string) (string, error) {
+		return target, nil
+	}
+}
+
+func TestPresentWithoutAccount(t *testing.T) {
+	provider, err := NewDNSProviderHostBytes("http://localhost/", []byte("{}"), util.RecursiveNameservers, nil)
+	assert.NoError(t, err)
+	cl := &fakeClient{updated: make(map[string]string)}
+	provider.client = cl
+
+	err = provider.Present("example.com", "_acme-challenge.example.com.", "value")
+	assert.Error(t, err, "Expected error presenting for domain without account credentials")
+	assert.Empty(t, cl.updated)
+}
+
+func TestPresentRegistersAccount(t *testing.T) {
+	var stored map[string]goacmedns.Account
+	store := func(domain string, account goacmedns.Account) error {
+		stored = map[string]goacmedns.Account{domain: account}
+		return nil
+	}
+	provider, err := NewDNSProviderHostBytes("http://localhost/", []byte("{}"), util.RecursiveNameservers, store)
+	assert.NoError(t, err)
+	registered := goacmedns.Account{FullDomain: "abc.acme-dns.example.net", SubDomain: "abc", Username: "user", Password: "pass"}
+	cl := &fakeClient{registered: registered, updated: make(map[string]string)}
+	provider.client = cl
+
+	// the CNAME record has not been created yet
+	provider.lookupFQDN = fakeLookup("_acme-challenge.example.com.")
+	err = provider.Present("example.com", "_acme-challenge.example.com.", "value")
+	assert.Equal(t, &util.CNAMERequiredError{Record: "_acme-challenge.example.com", Target: "abc.acme-dns.example.net"}, err,
+		"Expected error whilst CNAME record does not exist")
+	assert.Equal(t, map[string]goacmedns.Account{"example.com": registered}, stored)
+	assert.Equal(t, "value", cl.updated["abc"])
+
+	// once the CNAME exists, the stored account is reused
+	stored = nil
+	provider.lookupFQDN = fakeLookup("abc.acme-dns.example.net.")
+	err = provider.Present("example.com", "_acme-challenge.example.com.", "value2")
+	assert.NoError(t, err)
+	assert.Nil(t, stored, "Expected account to not be registered a second time")
+	assert.Equal(t, "value2", cl.updated["abc"])
+}
+
+func TestPresentRegistrationStoreError(t *testing.T) {
+	store := func(domain string, account goacmedns.Account) error {
+		return errors.New("forbidden")
+	}
+	provider, err := NewDNSProviderHostBytes("http://localhost/", []byte("{}"), util.RecursiveNameservers, store)
+	assert.NoError(t, err)
+	cl := &fakeClient{registered: goacmedns.Account{SubDomain: "abc"}, updated: make(map[string]string)}
+	provider.client = cl
+
+	err = provider.Present("example.com", "_acme-challenge.example.com.", "value")
+	assert.Error(t, err)
+	assert.Empty(t, provider.accounts)
+	assert.Empty(t, cl.updated)
+}
+
 func TestLiveAcmeDnsPresent(t *testing.T) {
 	if !acmednsLiveTest {
 		t.Skip("skipping live test")
 	}
-	provider, err := NewDNSProviderHostBytes(acmednsHost, acmednsAccountJson, util.RecursiveNameservers)
+	provider, err := NewDNSProviderHostBytes(acmednsHost, acmednsAccountJson, util.RecursiveNameservers, nil)
 	assert.NoError(t, err)
 
 	// ACME-DNS requires 43 character keys or it throws a bad TXT error
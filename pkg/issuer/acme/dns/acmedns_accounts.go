@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cpu/goacmedns"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/acmedns"
+	"github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	// acmeDNSRegistrationsSecretSuffix is appended to the name of the
+	// accountSecretRef Secret to give the name of the Secret that acme-dns
+	// accounts registered by cert-manager are stored in.
+	acmeDNSRegistrationsSecretSuffix = "-acme-dns-registrations"
+
+	// acmeDNSRegistrationsKey is the key of the registrations Secret the
+	// registered accounts are stored under, in the same JSON format as the
+	// accountSecretRef Secret.
+	acmeDNSRegistrationsKey = "acmedns.json"
+)
+
+// acmeDNSAccounts returns the accounts JSON to construct an acme-dns provider
+// with, and an acmedns.AccountStore that persists accounts registered by the
+// provider.
+// Accounts are read from the user supplied accountJson as well as the
+// registrations Secret managed by cert-manager, with the user supplied
+// accounts taking precedence.
+// Accounts that were registered previously but could not be stored are
+// stored first, so that an account is never registered twice for a domain.
+func (s *Solver) acmeDNSAccounts(ctx context.Context, namespace string, cfg *cmacme.ACMEIssuerDNS01ProviderAcmeDNS, accountJson []byte) ([]byte, acmedns.AccountStore, error) {
+	var accounts map[string]goacmedns.Account
+	if err := json.Unmarshal(accountJson, &accounts); err != nil {
+		return nil, nil, fmt.Errorf("error decoding acmedns accounts: %v", err)
+	}
+
+	secretName := cfg.AccountSecret.Name + acmeDNSRegistrationsSecretSuffix
+	pendingKey := namespace + "/" + secretName
+
+	s.acmeDNSPendingLock.Lock()
+	pending := s.acmeDNSPending[pendingKey]
+	s.acmeDNSPendingLock.Unlock()
+	if len(pending) > 0 {
+		if err := s.storeACMEDNSAccounts(ctx, namespace, secretName, pending); err != nil {
+			return nil, nil, fmt.Errorf("error storing previously registered acme-dns accounts in secret %q: %v", secretName, err)
+		}
+		s.acmeDNSPendingLock.Lock()
+		delete(s.acmeDNSPending, pendingKey)
+		s.acmeDNSPendingLock.Unlock()
+	}
+
+	// Read the registrations from the API server rather than the lister, as
+	// an out of date cache would cause accounts to be registered again.
+	registered, _, err := s.loadACMEDNSAccounts(ctx, namespace, secretName)
+	if err != nil {
+		return nil, nil, err
+	}
+	for domain, account := range accounts {
+		registered[domain] = account
+	}
+
+	accountJson, err = json.Marshal(registered)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	store := func(domain string, account goacmedns.Account) error {
+		accounts := map[string]goacmedns.Account{domain: account}
+		if err := s.storeACMEDNSAccounts(ctx, namespace, secretName, accounts); err != nil {
+			// hold on to the account so that it is stored before any
+			// further accounts are registered
+			s.acmeDNSPendingLock.Lock()
+			defer s.acmeDNSPendingLock.Unlock()
+			if s.acmeDNSPending == nil {
+				s.acmeDNSPending = make(map[string]map[string]goacmedns.Account)
+			}
+			if s.acmeDNSPending[pendingKey] == nil {
+				s.acmeDNSPending[pendingKey] = make(map[string]goacmedns.Account)
+			}
+			s.acmeDNSPending[pendingKey][domain] = account
+			return err
+		}
+		return nil
+	}
+
+	return accountJson, store, nil
+}
+
+// loadACMEDNSAccounts returns the accounts stored in the named registrations
+// Secret, along with the Secret itself. If the Secret does not exist, no
+// accounts and a nil Secret are returned.
+func (s *Solver) loadACMEDNSAccounts(ctx context.Context, namespace, name string) (map[string]goacmedns.Account, *corev1.Secret, error) {
+	accounts := make(map[string]goacmedns.Account)
+
+	secret, err := s.Client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return accounts, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting acme-dns registrations secret %q: %v", name, err)
+	}
+
+	if data, ok := secret.Data[acmeDNSRegistrationsKey]; ok {
+		if err := json.Unmarshal(data, &accounts); err != nil {
+			return nil, nil, fmt.Errorf("error decoding acme-dns registrations secret %q: %v", name, err)
+		}
+	}
+
+	return accounts, secret, nil
+}
+
+// storeACMEDNSAccounts adds the given accounts to the named registrations
+// Secret, creating the Secret if it does not exist.
+func (s *Solver) storeACMEDNSAccounts(ctx context.Context, namespace, name string, accounts map[string]goacmedns.Account) error {
+	log := logs.FromContext(ctx, "storeACMEDNSAccounts")
+
+	stored, secret, err := s.loadACMEDNSAccounts(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	for domain, account := range accounts {
+		stored[domain] = account
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	if secret == nil {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{acmeDNSRegistrationsKey: data},
+		}
+		_, err = s.Client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+	} else {
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[acmeDNSRegistrationsKey] = data
+		_, err = s.Client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Info("stored registered acme-dns accounts", "secret", namespace+"/"+name)
+	return nil
+}
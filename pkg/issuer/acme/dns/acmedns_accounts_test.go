@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cpu/goacmedns"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/test"
+)
+
+func TestACMEDNSAccounts(t *testing.T) {
+	accountsJSON := func(accounts map[string]goacmedns.Account) []byte {
+		data, err := json.Marshal(accounts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	userAccount := goacmedns.Account{FullDomain: "user.acme-dns.example.net"}
+	existingAccount := goacmedns.Account{FullDomain: "existing.acme-dns.example.net"}
+	newAccount := goacmedns.Account{FullDomain: "new.acme-dns.example.net"}
+
+	b := &test.Builder{
+		T: t,
+		KubeObjects: []runtime.Object{
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "acmedns-acme-dns-registrations"},
+				Data: map[string][]byte{
+					acmeDNSRegistrationsKey: accountsJSON(map[string]goacmedns.Account{
+						"existing.example.com": existingAccount,
+						"user.example.com":     existingAccount,
+					}),
+				},
+			},
+		},
+	}
+	s := buildFakeSolver(b, newFakeDNSProviders().constructors)
+	defer b.Stop()
+
+	failUpdates := true
+	b.FakeKubeClient().PrependReactor("update", "secrets", func(action coretesting.Action) (bool, runtime.Object, error) {
+		if failUpdates {
+			return true, nil, errors.New("forbidden")
+		}
+		return false, nil, nil
+	})
+
+	ctx := context.Background()
+	cfg := &cmacme.ACMEIssuerDNS01ProviderAcmeDNS{
+		AccountSecret: cmmeta.SecretKeySelector{
+			LocalObjectReference: cmmeta.LocalObjectReference{Name: "acmedns"},
+			Key:                  "acmedns.json",
+		},
+	}
+	userJSON := accountsJSON(map[string]goacmedns.Account{"user.example.com": userAccount})
+
+	accountJSON, store, err := s.acmeDNSAccounts(ctx, "default", cfg, userJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]goacmedns.Account{
+		"existing.example.com": existingAccount,
+		"user.example.com":     userAccount,
+	}
+	if string(accountJSON) != string(accountsJSON(expected)) {
+		t.Errorf("expected registered and user accounts to be merged, got: %s", accountJSON)
+	}
+
+	if err := store("new.example.com", newAccount); err == nil {
+		t.Fatalf("expected an error storing the registered account")
+	}
+	if _, _, err := s.acmeDNSAccounts(ctx, "default", cfg, userJSON); err == nil {
+		t.Errorf("expected an error whilst the registered account cannot be stored")
+	}
+
+	failUpdates = false
+	accountJSON, _, err = s.acmeDNSAccounts(ctx, "default", cfg, userJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected["new.example.com"] = newAccount
+	if string(accountJSON) != string(accountsJSON(expected)) {
+		t.Errorf("expected the previously registered account to be stored and used, got: %s", accountJSON)
+	}
+	if len(s.acmeDNSPending) != 0 {
+		t.Errorf("expected no accounts to be pending, got: %v", s.acmeDNSPending)
+	}
+
+	// accounts are stored in a new Secret if one does not exist
+	cfg.AccountSecret.Name = "other"
+	_, store, err = s.acmeDNSAccounts(ctx, "default", cfg, []byte("{}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store("new.example.com", newAccount); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stored, _, err := s.loadACMEDNSAccounts(ctx, "default", "other-acme-dns-registrations")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(stored, map[string]goacmedns.Account{"new.example.com": newAccount}) {
+		t.Errorf("expected the registered account to be stored in a new secret, got: %v", stored)
+	}
+}
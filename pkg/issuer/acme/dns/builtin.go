@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+
+	"github.com/jetstack/cert-manager/pkg/acme/webhook"
+	whapi "github.com/jetstack/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// builtinSolverNames are the names of the built-in DNS providers, which are
+// the same as the names of their fields in an ACMEChallengeSolverDNS01.
+var builtinSolverNames = map[string]bool{
+	"acmedns":      true,
+	"akamai":       true,
+	"azuredns":     true,
+	"clouddns":     true,
+	"cloudflare":   true,
+	"digitalocean": true,
+	"route53":      true,
+}
+
+// BuiltinSolverNames returns the sorted names of the built-in DNS providers
+// that can be used with NewBuiltinSolver.
+func BuiltinSolverNames() []string {
+	var names []string
+	for name := range builtinSolverNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// builtinSolver exposes one of the built-in DNS providers as a
+// webhook.Solver. The config of the challenge requests passed to it has the
+// same format as the provider's stanza in an Issuer, e.g. the contents of
+// 'dns01.cloudflare' for the cloudflare provider.
+type builtinSolver struct {
+	name   string
+	solver *Solver
+}
+
+var _ webhook.Solver = &builtinSolver{}
+
+// NewBuiltinSolver returns a webhook.Solver for the named built-in DNS
+// provider, so that the provider can be used outside of the controller, for
+// example to run the DNS01 conformance suite against it.
+func NewBuiltinSolver(name string) (webhook.Solver, error) {
+	if !builtinSolverNames[name] {
+		return nil, fmt.Errorf("unknown built-in DNS provider %q", name)
+	}
+	return &builtinSolver{name: name}, nil
+}
+
+func (b *builtinSolver) Name() string {
+	return b.name
+}
+
+func (b *builtinSolver) Initialize(kubeClientConfig *restclient.Config, stopCh <-chan struct{}) error {
+	cl, err := kubernetes.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return err
+	}
+
+	factory := informers.NewSharedInformerFactory(cl, time.Minute*5)
+	secretLister := factory.Core().V1().Secrets().Lister()
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+
+	b.solver = &Solver{
+		Context: &controller.Context{
+			Client: cl,
+			ACMEOptions: controller.ACMEOptions{
+				DNS01Nameservers: util.RecursiveNameservers,
+			},
+		},
+		secretLister:            secretLister,
+		dnsProviderConstructors: defaultDNSProviderConstructors,
+	}
+
+	return nil
+}
+
+func (b *builtinSolver) Present(ch *whapi.ChallengeRequest) error {
+	slv, err := b.providerFor(ch)
+	if err != nil {
+		return err
+	}
+
+	return slv.Present(ch.DNSName, ch.ResolvedFQDN, ch.Key)
+}
+
+func (b *builtinSolver) CleanUp(ch *whapi.ChallengeRequest) error {
+	slv, err := b.providerFor(ch)
+	if err != nil {
+		return err
+	}
+
+	return slv.CleanUp(ch.DNSName, ch.ResolvedFQDN, ch.Key)
+}
+
+// providerFor constructs the DNS provider for the given challenge request.
+func (b *builtinSolver) providerFor(ch *whapi.ChallengeRequest) (solver, error) {
+	if ch.Config == nil {
+		return nil, fmt.Errorf("no challenge solver config provided")
+	}
+
+	// decode the config into the provider's field of a solver config
+	cfgJSON, err := json.Marshal(map[string]json.RawMessage{b.name: ch.Config.Raw})
+	if err != nil {
+		return nil, err
+	}
+	providerConfig := &cmacme.ACMEChallengeSolverDNS01{}
+	if err := json.Unmarshal(cfgJSON, providerConfig); err != nil {
+		return nil, fmt.Errorf("error decoding solver config: %v", err)
+	}
+
+	return b.solver.solverForConfig(context.TODO(), providerConfig, ch.ResourceNamespace, ch.AllowAmbientCredentials)
+}
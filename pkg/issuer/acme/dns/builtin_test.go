@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dns
+
+import (
+	"reflect"
+	"testing"
+
+	extapi "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	whapi "github.com/jetstack/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+func TestBuiltinSolverProviderFor(t *testing.T) {
+	tests := map[string]struct {
+		name   string
+		config string
+
+		expectedCalls []fakeDNSProviderCall
+		expectErr     bool
+	}{
+		"loads the credentials of a cloudflare provider from the resource namespace": {
+			name:   "cloudflare",
+			config: `{"email": "test", "apiTokenSecretRef": {"name": "cloudflare-token", "key": "api-token"}}`,
+			expectedCalls: []fakeDNSProviderCall{
+				{name: "cloudflare", args: []interface{}{"test", "", "a-cloudflare-api-token", util.RecursiveNameservers}},
+			},
+		},
+		"passes whether ambient credentials are allowed to route53": {
+			name:   "route53",
+			config: `{"region": "us-west-2"}`,
+			expectedCalls: []fakeDNSProviderCall{
+				{name: "route53", args: []interface{}{"", "", "", "us-west-2", "", true, util.RecursiveNameservers}},
+			},
+		},
+		"fails if the config is invalid": {
+			name:      "cloudflare",
+			config:    `{"email": 1}`,
+			expectErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &solverFixture{
+				Builder: &test.Builder{
+					KubeObjects: []runtime.Object{
+						newSecret("cloudflare-token", "default", map[string][]byte{
+							"api-token": []byte("a-cloudflare-api-token"),
+						}),
+					},
+				},
+			}
+			f.Setup(t)
+			defer f.Finish(t)
+
+			b := &builtinSolver{name: tt.name, solver: f.Solver}
+			_, err := b.providerFor(&whapi.ChallengeRequest{
+				ResourceNamespace:       "default",
+				AllowAmbientCredentials: true,
+				Config:                  &extapi.JSON{Raw: []byte(tt.config)},
+			})
+			if err != nil && !tt.expectErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && tt.expectErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+			if !tt.expectErr && !reflect.DeepEqual(f.dnsProviders.calls, tt.expectedCalls) {
+				t.Errorf("unexpected provider calls, exp=%v got=%v", tt.expectedCalls, f.dnsProviders.calls)
+			}
+		})
+	}
+}
+
+func TestNewBuiltinSolver(t *testing.T) {
+	for _, name := range BuiltinSolverNames() {
+		if _, err := NewBuiltinSolver(name); err != nil {
+			t.Errorf("unexpected error creating built-in solver %q: %v", name, err)
+		}
+	}
+	if _, err := NewBuiltinSolver("rfc2136"); err == nil {
+		t.Errorf("expected an error creating a solver for a provider that is not built-in")
+	}
+}
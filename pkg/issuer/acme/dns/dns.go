@@ -21,8 +21,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/cpu/goacmedns"
 	"github.com/pkg/errors"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -39,6 +41,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/clouddns"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/cloudflare"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/digitalocean"
+	grpcslv "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/grpc"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/rfc2136"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
@@ -65,10 +68,20 @@ type dnsProviderConstructors struct {
 	cloudFlare   func(email, apikey, apiToken string, dns01Nameservers []string) (*cloudflare.DNSProvider, error)
 	route53      func(accessKey, secretKey, hostedZoneID, region, role string, ambient bool, dns01Nameservers []string) (*route53.DNSProvider, error)
 	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool) (*azuredns.DNSProvider, error)
-	acmeDNS      func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error)
+	acmeDNS      func(host string, accountJson []byte, dns01Nameservers []string, store acmedns.AccountStore) (*acmedns.DNSProvider, error)
 	digitalOcean func(token string, dns01Nameservers []string) (*digitalocean.DNSProvider, error)
 }
 
+// defaultDNSProviderConstructors constructs the real DNS providers.
+var defaultDNSProviderConstructors = dnsProviderConstructors{
+	clouddns.NewDNSProvider,
+	cloudflare.NewDNSProviderCredentials,
+	route53.NewDNSProvider,
+	azuredns.NewDNSProviderCredentials,
+	acmedns.NewDNSProviderHostBytes,
+	digitalocean.NewDNSProviderCredentials,
+}
+
 // Solver is a solver for the acme dns01 challenge.
 // Given a Certificate object, it determines the correct DNS provider based on
 // the certificate, and configures it based on the referenced issuer.
@@ -77,6 +90,12 @@ type Solver struct {
 	secretLister            corev1listers.SecretLister
 	dnsProviderConstructors dnsProviderConstructors
 	webhookSolvers          map[string]webhook.Solver
+
+	// acmeDNSPending holds acme-dns accounts that have been registered but
+	// could not be stored, keyed by the namespaced name of the registrations
+	// Secret they belong in and then by domain.
+	acmeDNSPendingLock sync.Mutex
+	acmeDNSPending     map[string]map[string]goacmedns.Account
 }
 
 // Present performs the work to configure DNS to resolve a DNS01 challenge.
@@ -184,35 +203,45 @@ func extractChallengeSolverConfig(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSo
 // The providerName is the name of an ACME DNS-01 challenge provider as
 // specified on the Issuer resource for the Solver.
 func (s *Solver) solverForChallenge(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *cmacme.Challenge) (solver, *cmacme.ACMEChallengeSolverDNS01, error) {
-	log := logs.FromContext(ctx, "solverForChallenge")
-	dbg := log.V(logs.DebugLevel)
-	ctx = logs.NewContext(ctx, log)
-
-	resourceNamespace := s.ResourceNamespace(issuer)
-	canUseAmbientCredentials := s.CanUseAmbientCredentials(issuer)
-
 	providerConfig, err := extractChallengeSolverConfig(ch)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	impl, err := s.solverForConfig(ctx, providerConfig, s.ResourceNamespace(issuer), s.CanUseAmbientCredentials(issuer))
+	if err != nil {
+		return nil, providerConfig, err
+	}
+
+	return impl, providerConfig, nil
+}
+
+// solverForConfig returns a Solver for the provider configured in
+// providerConfig, loading any credentials it references from
+// resourceNamespace.
+func (s *Solver) solverForConfig(ctx context.Context, providerConfig *cmacme.ACMEChallengeSolverDNS01, resourceNamespace string, canUseAmbientCredentials bool) (solver, error) {
+	log := logs.FromContext(ctx, "solverForConfig")
+	dbg := log.V(logs.DebugLevel)
+	ctx = logs.NewContext(ctx, log)
+
 	var impl solver
+	var err error
 	switch {
 	case providerConfig.Akamai != nil:
 		dbg.Info("preparing to create Akamai provider")
 		clientToken, err := s.loadSecretData(&providerConfig.Akamai.ClientToken, resourceNamespace)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "error getting akamai client token")
+			return nil, errors.Wrap(err, "error getting akamai client token")
 		}
 
 		clientSecret, err := s.loadSecretData(&providerConfig.Akamai.ClientSecret, resourceNamespace)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "error getting akamai client secret")
+			return nil, errors.Wrap(err, "error getting akamai client secret")
 		}
 
 		accessToken, err := s.loadSecretData(&providerConfig.Akamai.AccessToken, resourceNamespace)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "error getting akamai access token")
+			return nil, errors.Wrap(err, "error getting akamai access token")
 		}
 
 		impl, err = akamai.NewDNSProvider(
@@ -222,7 +251,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1alpha2.Generic
 			string(accessToken),
 			s.DNS01Nameservers)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "error instantiating akamai challenge solver")
+			return nil, errors.Wrap(err, "error instantiating akamai challenge solver")
 		}
 	case providerConfig.CloudDNS != nil:
 		dbg.Info("preparing to create CloudDNS provider")
@@ -234,25 +263,25 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1alpha2.Generic
 		if providerConfig.CloudDNS.ServiceAccount != nil {
 			saSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.CloudDNS.ServiceAccount.Name)
 			if err != nil {
-				return nil, nil, fmt.Errorf("error getting clouddns service account: %s", err)
+				return nil, fmt.Errorf("error getting clouddns service account: %s", err)
 			}
 
 			saKey := providerConfig.CloudDNS.ServiceAccount.Key
 			keyData = saSecret.Data[saKey]
 			if len(keyData) == 0 {
-				return nil, nil, fmt.Errorf("specified key %q not found in secret %s/%s", saKey, saSecret.Namespace, saSecret.Name)
+				return nil, fmt.Errorf("specified key %q not found in secret %s/%s", saKey, saSecret.Namespace, saSecret.Name)
 			}
 		}
 
 		// attempt to construct the cloud dns provider
-		impl, err = s.dnsProviderConstructors.cloudDNS(providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, s.CanUseAmbientCredentials(issuer))
+		impl, err = s.dnsProviderConstructors.cloudDNS(providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, canUseAmbientCredentials)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error instantiating google clouddns challenge solver: %s", err)
+			return nil, fmt.Errorf("error instantiating google clouddns challenge solver: %s", err)
 		}
 	case providerConfig.Cloudflare != nil:
 		dbg.Info("preparing to create Cloudflare provider")
 		if providerConfig.Cloudflare.APIKey != nil && providerConfig.Cloudflare.APIToken != nil {
-			return nil, nil, fmt.Errorf("API key and API token secret references are both present")
+			return nil, fmt.Errorf("API key and API token secret references are both present")
 		}
 
 		var saSecretName, saSecretKey string
@@ -266,12 +295,12 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1alpha2.Generic
 
 		saSecret, err := s.secretLister.Secrets(resourceNamespace).Get(saSecretName)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error getting cloudflare secret: %s", err)
+			return nil, fmt.Errorf("error getting cloudflare secret: %s", err)
 		}
 
 		keyData, ok := saSecret.Data[saSecretKey]
 		if !ok {
-			return nil, nil, fmt.Errorf("specified key %q not found in secret %s/%s", saSecretKey, saSecret.Namespace, saSecret.Name)
+			return nil, fmt.Errorf("specified key %q not found in secret %s/%s", saSecretKey, saSecret.Namespace, saSecret.Name)
 		}
 
 		var apiKey, apiToken string
@@ -284,20 +313,20 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1alpha2.Generic
 		email := providerConfig.Cloudflare.Email
 		impl, err = s.dnsProviderConstructors.cloudFlare(email, apiKey, apiToken, s.DNS01Nameservers)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error instantiating cloudflare challenge solver: %s", err)
+			return nil, fmt.Errorf("error instantiating cloudflare challenge solver: %s", err)
 		}
 	case providerConfig.DigitalOcean != nil:
 		dbg.Info("preparing to create DigitalOcean provider")
 		apiTokenSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.DigitalOcean.Token.Name)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error getting digitalocean token: %s", err)
+			return nil, fmt.Errorf("error getting digitalocean token: %s", err)
 		}
 
 		apiToken := string(apiTokenSecret.Data[providerConfig.DigitalOcean.Token.Key])
 
 		impl, err = s.dnsProviderConstructors.digitalOcean(strings.TrimSpace(apiToken), s.DNS01Nameservers)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error instantiating digitalocean challenge solver: %s", err.Error())
+			return nil, fmt.Errorf("error instantiating digitalocean challenge solver: %s", err.Error())
 		}
 	case providerConfig.Route53 != nil:
 		dbg.Info("preparing to create Route53 provider")
@@ -305,12 +334,12 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1alpha2.Generic
 		if providerConfig.Route53.SecretAccessKey.Name != "" {
 			secretAccessKeySecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.Route53.SecretAccessKey.Name)
 			if err != nil {
-				return nil, nil, fmt.Errorf("error getting route53 secret access key: %s", err)
+				return nil, fmt.Errorf("error getting route53 secret access key: %s", err)
 			}
 
 			secretAccessKeyBytes, ok := secretAccessKeySecret.Data[providerConfig.Route53.SecretAccessKey.Key]
 			if !ok {
-				return nil, nil, fmt.Errorf("error getting route53 secret access key: key '%s' not found in secret", providerConfig.Route53.SecretAccessKey.Key)
+				return nil, fmt.Errorf("error getting route53 secret access key: key '%s' not found in secret", providerConfig.Route53.SecretAccessKey.Key)
 			}
 			secretAccessKey = string(secretAccessKeyBytes)
 		}
@@ -325,7 +354,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1alpha2.Generic
 			s.DNS01Nameservers,
 		)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error instantiating route53 challenge solver: %s", err)
+			return nil, fmt.Errorf("error instantiating route53 challenge solver: %s", err)
 		}
 	case providerConfig.AzureDNS != nil:
 		dbg.Info("preparing to create AzureDNS provider")
@@ -335,12 +364,12 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1alpha2.Generic
 		if providerConfig.AzureDNS.ClientID != "" {
 			clientSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.AzureDNS.ClientSecret.Name)
 			if err != nil {
-				return nil, nil, fmt.Errorf("error getting azuredns client secret: %s", err)
+				return nil, fmt.Errorf("error getting azuredns client secret: %s", err)
 			}
 
 			clientSecretBytes, ok := clientSecret.Data[providerConfig.AzureDNS.ClientSecret.Key]
 			if !ok {
-				return nil, nil, fmt.Errorf("error getting azure dns client secret: key '%s' not found in secret", providerConfig.AzureDNS.ClientSecret.Key)
+				return nil, fmt.Errorf("error getting azure dns client secret: key '%s' not found in secret", providerConfig.AzureDNS.ClientSecret.Key)
 			}
 			secret = string(clientSecretBytes)
 		}
@@ -356,33 +385,46 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1alpha2.Generic
 			canUseAmbientCredentials,
 		)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error instantiating azuredns challenge solver: %s", err)
+			return nil, fmt.Errorf("error instantiating azuredns challenge solver: %s", err)
 		}
 	case providerConfig.AcmeDNS != nil:
 		dbg.Info("preparing to create ACMEDNS provider")
 		accountSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.AcmeDNS.AccountSecret.Name)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error getting acmedns accounts secret: %s", err)
+			return nil, fmt.Errorf("error getting acmedns accounts secret: %s", err)
 		}
 
 		accountSecretBytes, ok := accountSecret.Data[providerConfig.AcmeDNS.AccountSecret.Key]
-		if !ok {
-			return nil, nil, fmt.Errorf("error getting acmedns accounts secret: key '%s' not found in secret", providerConfig.AcmeDNS.AccountSecret.Key)
+		switch {
+		case !ok && providerConfig.AcmeDNS.AllowRegistration:
+			// accounts will be registered and written to the key as needed
+			accountSecretBytes = []byte("{}")
+		case !ok:
+			return nil, fmt.Errorf("error getting acmedns accounts secret: key '%s' not found in secret", providerConfig.AcmeDNS.AccountSecret.Key)
+		}
+
+		var store acmedns.AccountStore
+		if providerConfig.AcmeDNS.AllowRegistration {
+			accountSecretBytes, store, err = s.acmeDNSAccounts(ctx, resourceNamespace, providerConfig.AcmeDNS, accountSecretBytes)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		impl, err = s.dnsProviderConstructors.acmeDNS(
 			providerConfig.AcmeDNS.Host,
 			accountSecretBytes,
 			s.DNS01Nameservers,
+			store,
 		)
 		if err != nil {
-			return nil, providerConfig, fmt.Errorf("error instantiating acmedns challenge solver: %s", err)
+			return nil, fmt.Errorf("error instantiating acmedns challenge solver: %s", err)
 		}
 	default:
-		return nil, providerConfig, fmt.Errorf("no dns provider config specified for challenge")
+		return nil, fmt.Errorf("no dns provider config specified for challenge")
 	}
 
-	return impl, providerConfig, nil
+	return impl, nil
 }
 
 func (s *Solver) prepareChallengeRequest(issuer v1alpha2.GenericIssuer, ch *cmacme.Challenge) (webhook.Solver, *whapi.ChallengeRequest, error) {
@@ -443,6 +485,9 @@ func (s *Solver) dns01SolverForConfig(config *cmacme.ACMEChallengeSolverDNS01) (
 	case config.RFC2136 != nil:
 		solverName = "rfc2136"
 		c = config.RFC2136
+	case config.GRPC != nil:
+		solverName = "grpc"
+		c = config.GRPC
 	}
 	if solverName == "" {
 		return nil, nil, errNotFound
@@ -459,6 +504,7 @@ func (s *Solver) dns01SolverForConfig(config *cmacme.ACMEChallengeSolverDNS01) (
 func NewSolver(ctx *controller.Context) (*Solver, error) {
 	webhookSolvers := []webhook.Solver{
 		&webhookslv.Webhook{},
+		grpcslv.New(grpcslv.WithNamespace(ctx.Namespace)),
 		rfc2136.New(rfc2136.WithNamespace(ctx.Namespace)),
 	}
 
@@ -478,17 +524,10 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 	}
 
 	return &Solver{
-		Context:      ctx,
-		secretLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
-		dnsProviderConstructors: dnsProviderConstructors{
-			clouddns.NewDNSProvider,
-			cloudflare.NewDNSProviderCredentials,
-			route53.NewDNSProvider,
-			azuredns.NewDNSProviderCredentials,
-			acmedns.NewDNSProviderHostBytes,
-			digitalocean.NewDNSProviderCredentials,
-		},
-		webhookSolvers: initialized,
+		Context:                 ctx,
+		secretLister:            ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		dnsProviderConstructors: defaultDNSProviderConstructors,
+		webhookSolvers:          initialized,
 	}, nil
 }
 
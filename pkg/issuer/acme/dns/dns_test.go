@@ -277,6 +277,65 @@ func TestSolverFor(t *testing.T) {
 			domain:             "example.com",
 			expectedSolverType: reflect.TypeOf(&acmedns.DNSProvider{}),
 		},
+		"loads acmedns provider with a missing accounts key if registration is allowed": {
+			solverFixture: &solverFixture{
+				Builder: &test.Builder{
+					KubeObjects: []runtime.Object{
+						newSecret("acmedns-key", "default", nil),
+					},
+				},
+				Issuer: newIssuer("test", "default"),
+				Challenge: &cmacme.Challenge{
+					Spec: cmacme.ChallengeSpec{
+						Solver: cmacme.ACMEChallengeSolver{
+							DNS01: &cmacme.ACMEChallengeSolverDNS01{
+								AcmeDNS: &cmacme.ACMEIssuerDNS01ProviderAcmeDNS{
+									Host: "http://127.0.0.1/",
+									AccountSecret: cmmeta.SecretKeySelector{
+										LocalObjectReference: cmmeta.LocalObjectReference{
+											Name: "acmedns-key",
+										},
+										Key: "acmedns.json",
+									},
+									AllowRegistration: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			domain:             "example.com",
+			expectedSolverType: reflect.TypeOf(&acmedns.DNSProvider{}),
+		},
+		"fails to load acmedns provider with a missing accounts key": {
+			solverFixture: &solverFixture{
+				Builder: &test.Builder{
+					KubeObjects: []runtime.Object{
+						newSecret("acmedns-key", "default", nil),
+					},
+				},
+				Issuer: newIssuer("test", "default"),
+				Challenge: &cmacme.Challenge{
+					Spec: cmacme.ChallengeSpec{
+						Solver: cmacme.ACMEChallengeSolver{
+							DNS01: &cmacme.ACMEChallengeSolverDNS01{
+								AcmeDNS: &cmacme.ACMEIssuerDNS01ProviderAcmeDNS{
+									Host: "http://127.0.0.1/",
+									AccountSecret: cmmeta.SecretKeySelector{
+										LocalObjectReference: cmmeta.LocalObjectReference{
+											Name: "acmedns-key",
+										},
+										Key: "acmedns.json",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			domain:    "example.com",
+			expectErr: true,
+		},
 	}
 	testFn := func(test testT) func(*testing.T) {
 		return func(t *testing.T) {
@@ -0,0 +1,249 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package grpc implements a DNS01 solver that forwards challenge requests to
+// out-of-process solvers implementing the cert-manager solver gRPC service.
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/grpcsolver"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+)
+
+const dialTimeout = time.Second * 30
+
+// GRPC is a webhook.Solver that dispatches challenge requests to the solver
+// endpoint named in the challenge's grpc provider config.
+type GRPC struct {
+	lock    sync.Mutex
+	clients map[string]*cachedClient
+
+	// secretLister is used to read client certificates for solvers served
+	// over TCP.
+	secretLister corelisters.SecretLister
+
+	// If specified, namespace will cause the grpc provider to limit the
+	// scope of the lister/watcher to a single namespace, to allow for
+	// namespace restricted instances of cert-manager.
+	namespace string
+
+	// dial is used to connect to solver endpoints. It is overridden in tests.
+	dial func(cfg *cmacme.ACMEIssuerDNS01ProviderGRPC, clientCert *tls.Certificate) (*grpcsolver.Client, error)
+}
+
+// cachedClient is a client for a solver along with a hash of the
+// credentials it was dialled with.
+type cachedClient struct {
+	*grpcsolver.Client
+	credentialsHash string
+}
+
+type Option func(*GRPC)
+
+func WithNamespace(ns string) Option {
+	return func(g *GRPC) {
+		g.namespace = ns
+	}
+}
+
+func New(opts ...Option) *GRPC {
+	g := &GRPC{}
+	for _, o := range opts {
+		o(g)
+	}
+	return g
+}
+
+func (g *GRPC) Name() string {
+	return "grpc"
+}
+
+// Present creates a TXT record using the specified parameters
+func (g *GRPC) Present(ch *v1alpha1.ChallengeRequest) error {
+	cl, req, err := g.buildRequest(ch)
+	if err != nil {
+		return err
+	}
+
+	return cl.Present(req)
+}
+
+// CleanUp removes the TXT record matching the specified parameters
+func (g *GRPC) CleanUp(ch *v1alpha1.ChallengeRequest) error {
+	cl, req, err := g.buildRequest(ch)
+	if err != nil {
+		return err
+	}
+
+	return cl.CleanUp(req)
+}
+
+// Initialize prepares the connection cache and starts the Secret informer
+// used to read client certificates. Connections to solver endpoints are
+// established lazily, as endpoints are only known once challenges are
+// processed.
+func (g *GRPC) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	cl, err := kubernetes.NewForConfig(kubeClientConfig)
+	if err != nil {
+		return err
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(cl, time.Minute*5, informers.WithNamespace(g.namespace))
+	g.secretLister = factory.Core().V1().Secrets().Lister()
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.clients = make(map[string]*cachedClient)
+	if g.dial == nil {
+		g.dial = dial
+	}
+
+	return nil
+}
+
+func (g *GRPC) buildRequest(ch *v1alpha1.ChallengeRequest) (*grpcsolver.Client, *v1alpha1.ChallengeRequest, error) {
+	// create a copy just to be certain we don't modify something unexpectedly
+	req := ch.DeepCopy()
+
+	cfg, err := loadConfig(*req.Config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientCert, err := g.clientCertificate(req.ResourceNamespace, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cl, err := g.clientFor(cfg, clientCert)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Only pass the solver's own config along, not the endpoint details.
+	req.Config = cfg.Config
+
+	return cl, req, nil
+}
+
+// clientCertificate loads the client certificate referenced by cfg from the
+// given namespace. It returns nil if no client certificate is configured.
+func (g *GRPC) clientCertificate(namespace string, cfg *cmacme.ACMEIssuerDNS01ProviderGRPC) (*tls.Certificate, error) {
+	if cfg.ClientCertSecretRef == nil {
+		return nil, nil
+	}
+
+	secretName := cfg.ClientCertSecretRef.Name
+	secret, err := g.secretLister.Secrets(namespace).Get(secretName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting client certificate secret %q: %v", secretName, err)
+	}
+
+	cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("error loading client certificate from secret %q: %v", secretName, err)
+	}
+
+	return &cert, nil
+}
+
+// clientFor returns a cached client for the solver described by cfg,
+// connecting to the endpoint if required.
+// If the CA bundle or client certificate for the endpoint have changed, for
+// example because the certificate has been renewed, a new connection is
+// established using the new credentials and the previous one is closed.
+func (g *GRPC) clientFor(cfg *cmacme.ACMEIssuerDNS01ProviderGRPC, clientCert *tls.Certificate) (*grpcsolver.Client, error) {
+	h := sha256.New()
+	h.Write(cfg.CABundle)
+	if clientCert != nil {
+		for _, der := range clientCert.Certificate {
+			h.Write(der)
+		}
+	}
+	credentialsHash := fmt.Sprintf("%x", h.Sum(nil))
+	key := cfg.Endpoint + "/" + cfg.SolverName
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	existing, ok := g.clients[key]
+	if ok && existing.credentialsHash == credentialsHash {
+		return existing.Client, nil
+	}
+
+	cl, err := g.dial(cfg, clientCert)
+	if err != nil {
+		return nil, err
+	}
+
+	g.clients[key] = &cachedClient{Client: cl, credentialsHash: credentialsHash}
+	if ok {
+		if err := existing.Close(); err != nil {
+			klog.Warningf("Error closing connection to solver %q at endpoint %q: %v", cfg.SolverName, cfg.Endpoint, err)
+		}
+	}
+
+	return cl, nil
+}
+
+// dial connects to the solver endpoint and checks it serves the configured
+// solver.
+func dial(cfg *cmacme.ACMEIssuerDNS01ProviderGRPC, clientCert *tls.Certificate) (*grpcsolver.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	conn, err := grpcsolver.Dial(ctx, cfg.Endpoint, cfg.CABundle, clientCert)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to solver endpoint %q: %v", cfg.Endpoint, err)
+	}
+
+	cl := grpcsolver.NewClient(conn, cfg.SolverName)
+	if err := cl.Initialize(nil, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error initializing solver %q at endpoint %q: %v", cfg.SolverName, cfg.Endpoint, err)
+	}
+
+	return cl, nil
+}
+
+func loadConfig(cfgJSON apiext.JSON) (*cmacme.ACMEIssuerDNS01ProviderGRPC, error) {
+	cfg := cmacme.ACMEIssuerDNS01ProviderGRPC{}
+	if err := json.Unmarshal(cfgJSON.Raw, &cfg); err != nil {
+		return nil, fmt.Errorf("error decoding solver config: %v", err)
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	whapi "github.com/jetstack/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/grpcsolver"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/grpcsolver/v1alpha1"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+type fakeSolver struct {
+	err       error
+	presented *whapi.ChallengeRequest
+}
+
+func (f *fakeSolver) Name() string { return "test" }
+
+func (f *fakeSolver) Present(ch *whapi.ChallengeRequest) error {
+	f.presented = ch
+	return f.err
+}
+
+func (f *fakeSolver) CleanUp(ch *whapi.ChallengeRequest) error { return f.err }
+
+func (f *fakeSolver) Initialize(_ *restclient.Config, _ <-chan struct{}) error { return nil }
+
+// serve serves slv over a Unix domain socket, returning the endpoint and a
+// function to stop the server.
+func serve(t *testing.T, slv *fakeSolver) (string, func()) {
+	dir, err := ioutil.TempDir("", "grpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoint := "unix://" + filepath.Join(dir, "solver.sock")
+
+	lis, err := grpcsolver.Listen(endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := ggrpc.NewServer()
+	v1alpha1.RegisterSolverServer(srv, grpcsolver.NewServer(slv))
+	go srv.Serve(lis)
+
+	return endpoint, func() {
+		srv.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+type dialRecorder struct {
+	calls       int
+	clientCerts []*tls.Certificate
+	clients     []*grpcsolver.Client
+}
+
+func (d *dialRecorder) dial(cfg *cmacme.ACMEIssuerDNS01ProviderGRPC, clientCert *tls.Certificate) (*grpcsolver.Client, error) {
+	d.calls++
+	d.clientCerts = append(d.clientCerts, clientCert)
+	if cfg.ClientCertSecretRef != nil {
+		// TCP endpoints are not served in tests, so connect over the Unix
+		// domain socket given in the solver config instead.
+		var sockCfg struct {
+			Socket string `json:"socket"`
+		}
+		if err := json.Unmarshal(cfg.Config.Raw, &sockCfg); err != nil {
+			return nil, err
+		}
+		c := cfg.DeepCopy()
+		c.Endpoint = sockCfg.Socket
+		return d.record(dial(c, nil))
+	}
+	return d.record(dial(cfg, clientCert))
+}
+
+func (d *dialRecorder) record(cl *grpcsolver.Client, err error) (*grpcsolver.Client, error) {
+	if cl != nil {
+		d.clients = append(d.clients, cl)
+	}
+	return cl, err
+}
+
+func newGRPC(t *testing.T, secrets ...*corev1.Secret) (*GRPC, *dialRecorder) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, s := range secrets {
+		if err := indexer.Add(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := &dialRecorder{}
+	g := New()
+	g.clients = make(map[string]*cachedClient)
+	g.secretLister = corelisters.NewSecretLister(indexer)
+	g.dial = rec.dial
+	return g, rec
+}
+
+func challengeRequest(t *testing.T, cfg *cmacme.ACMEIssuerDNS01ProviderGRPC) *whapi.ChallengeRequest {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &whapi.ChallengeRequest{
+		UID:               "uid",
+		Type:              "dns-01",
+		DNSName:           "example.com",
+		Key:               "key",
+		ResourceNamespace: "ns",
+		Config:            &apiext.JSON{Raw: raw},
+	}
+}
+
+func TestClientCaching(t *testing.T) {
+	endpoint, stop := serve(t, &fakeSolver{})
+	defer stop()
+
+	g, rec := newGRPC(t)
+	cfg := &cmacme.ACMEIssuerDNS01ProviderGRPC{Endpoint: endpoint, SolverName: "test"}
+
+	for i := 0; i < 2; i++ {
+		if err := g.Present(challengeRequest(t, cfg)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if rec.calls != 1 {
+		t.Errorf("expected the solver to be dialled once, but got %d calls", rec.calls)
+	}
+
+	if err := g.CleanUp(challengeRequest(t, cfg)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.calls != 1 {
+		t.Errorf("expected the cached client to be used for CleanUp, but got %d calls", rec.calls)
+	}
+
+	cfg.CABundle = []byte("ca")
+	if err := g.Present(challengeRequest(t, cfg)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.calls != 2 {
+		t.Fatalf("expected a changed CA bundle to dial the solver again, but got %d calls", rec.calls)
+	}
+	if len(g.clients) != 1 {
+		t.Errorf("expected the previous client to be replaced in the cache, but got %d clients", len(g.clients))
+	}
+	// closing an already closed connection returns an error
+	if err := rec.clients[0].Close(); err == nil {
+		t.Errorf("expected the connection of the replaced client to be closed")
+	}
+	if err := g.Present(challengeRequest(t, cfg)); err != nil {
+		t.Errorf("expected the new client to be usable, but got: %v", err)
+	}
+}
+
+func TestConfigStripping(t *testing.T) {
+	slv := &fakeSolver{}
+	endpoint, stop := serve(t, slv)
+	defer stop()
+
+	g, _ := newGRPC(t)
+	cfg := &cmacme.ACMEIssuerDNS01ProviderGRPC{
+		Endpoint:   endpoint,
+		SolverName: "test",
+		CABundle:   []byte("ca"),
+		Config:     &apiext.JSON{Raw: []byte(`{"a":"b"}`)},
+	}
+	if err := g.Present(challengeRequest(t, cfg)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if slv.presented == nil {
+		t.Fatalf("expected the challenge to be presented")
+	}
+	if got := string(slv.presented.Config.Raw); got != `{"a":"b"}` {
+		t.Errorf("expected only the solver config to be passed to the solver, but got %s", got)
+	}
+	if slv.presented.DNSName != "example.com" || slv.presented.Key != "key" {
+		t.Errorf("unexpected challenge request passed to the solver: %+v", slv.presented)
+	}
+}
+
+func TestErrorMapping(t *testing.T) {
+	endpoint, stop := serve(t, &fakeSolver{err: errors.New("record could not be created")})
+	defer stop()
+
+	g, _ := newGRPC(t)
+
+	err := g.Present(challengeRequest(t, &cmacme.ACMEIssuerDNS01ProviderGRPC{Endpoint: endpoint, SolverName: "test"}))
+	if err == nil || err.Error() != "record could not be created" {
+		t.Errorf("expected the solver's error message to be returned, but got: %v", err)
+	}
+	if code := status.Code(err); code != codes.Unknown {
+		t.Errorf("expected the status code of the error to be preserved, but got: %v", code)
+	}
+
+	err = g.Present(challengeRequest(t, &cmacme.ACMEIssuerDNS01ProviderGRPC{Endpoint: endpoint, SolverName: "other"}))
+	if err == nil || !strings.Contains(err.Error(), `error initializing solver "other"`) {
+		t.Errorf("expected an error initializing a solver that is not served, but got: %v", err)
+	}
+
+	err = g.Present(&whapi.ChallengeRequest{Config: &apiext.JSON{Raw: []byte("not json")}})
+	if err == nil || !strings.Contains(err.Error(), "error decoding solver config") {
+		t.Errorf("expected an error decoding invalid config, but got: %v", err)
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	endpoint, stop := serve(t, &fakeSolver{})
+	defer stop()
+
+	certPEM, keyPEM := generateCertificate(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "client-cert"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+	g, rec := newGRPC(t, secret)
+
+	cfg := &cmacme.ACMEIssuerDNS01ProviderGRPC{
+		Endpoint:            "solver.example.com:443",
+		SolverName:          "test",
+		ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "client-cert"},
+		Config:              &apiext.JSON{Raw: []byte(`{"socket":"` + endpoint + `"}`)},
+	}
+	if err := g.Present(challengeRequest(t, cfg)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.calls != 1 || rec.clientCerts[0] == nil {
+		t.Fatalf("expected the solver to be dialled with a client certificate")
+	}
+	expected, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rec.clientCerts[0].Certificate[0]) != string(expected.Certificate[0]) {
+		t.Errorf("expected the client certificate from the secret to be used")
+	}
+
+	// a renewed certificate must result in a new connection
+	certPEM, keyPEM = generateCertificate(t)
+	secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey] = certPEM, keyPEM
+	if err := g.Present(challengeRequest(t, cfg)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.calls != 2 {
+		t.Errorf("expected a renewed client certificate to dial the solver again, but got %d calls", rec.calls)
+	}
+
+	cfg.ClientCertSecretRef.Name = "missing"
+	err = g.Present(challengeRequest(t, cfg))
+	if err == nil || !strings.Contains(err.Error(), `error getting client certificate secret "missing"`) {
+		t.Errorf("expected an error getting a missing secret, but got: %v", err)
+	}
+}
+
+func generateCertificate(t *testing.T) ([]byte, []byte) {
+	pk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "cert-manager"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodePrivateKey(pk, cmapi.PKCS1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, keyPEM
+}
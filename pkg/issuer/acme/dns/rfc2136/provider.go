@@ -19,7 +19,7 @@ package rfc2136
 import (
 	"encoding/json"
 	"fmt"
-	"k8s.io/klog"
+	"k8s.io/klog/v2"
 	"time"
 
 	extapi "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
@@ -19,11 +19,11 @@ package rfc2136
 import (
 	"testing"
 
+	testserver "github.com/jetstack/cert-manager/pkg/acme/webhook/conformance/server"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/test/acme/dns"
-	testserver "github.com/jetstack/cert-manager/test/acme/dns/server"
 )
 
 func TestRunSuiteWithTSIG(t *testing.T) {
@@ -30,8 +30,8 @@ import (
 	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
 
+	testserver "github.com/jetstack/cert-manager/pkg/acme/webhook/conformance/server"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
-	testserver "github.com/jetstack/cert-manager/test/acme/dns/server"
 )
 
 var (
@@ -0,0 +1,33 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import "fmt"
+
+// CNAMERequiredError is returned by DNS providers when a challenge cannot be
+// presented until the user has delegated the challenge record for a domain
+// to another name using a CNAME record.
+type CNAMERequiredError struct {
+	// Record is the name of the CNAME record that must be created.
+	Record string
+	// Target is the name the record must point to.
+	Target string
+}
+
+func (e *CNAMERequiredError) Error() string {
+	return fmt.Sprintf("a CNAME record for %q pointing to %q must be created to solve challenges", e.Record, e.Target)
+}
@@ -154,7 +154,7 @@ func newFakeDNSProviders() *fakeDNSProviders {
 			f.call("azuredns", clientID, clientSecret, subscriptionID, tenentID, resourceGroupName, hostedZoneName, util.RecursiveNameservers, ambient)
 			return nil, nil
 		},
-		acmeDNS: func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error) {
+		acmeDNS: func(host string, accountJson []byte, dns01Nameservers []string, store acmedns.AccountStore) (*acmedns.DNSProvider, error) {
 			f.call("acmedns", host, accountJson, dns01Nameservers)
 			return nil, nil
 		},
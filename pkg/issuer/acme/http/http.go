@@ -26,13 +26,17 @@ import (
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
-	extv1beta1listers "k8s.io/client-go/listers/extensions/v1beta1"
+	networkingv1beta1listers "k8s.io/client-go/listers/networking/v1beta1"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmacmelisters "github.com/jetstack/cert-manager/pkg/client/listers/acme/v1alpha2"
 	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/internal/ingress"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/http/solver"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
@@ -59,7 +63,18 @@ type Solver struct {
 
 	podLister     corev1listers.PodLister
 	serviceLister corev1listers.ServiceLister
-	ingressLister extv1beta1listers.IngressLister
+	ingressLister networkingv1beta1listers.IngressLister
+	ingressClient ingress.InternalIngressClient
+
+	// ingressClassLister is nil if the apiserver does not serve IngressClass
+	// resources
+	ingressClassLister networkingv1beta1listers.IngressClassLister
+
+	// configMapLister, deploymentLister and challengeLister are only set
+	// when running in shared solver mode
+	configMapLister  corev1listers.ConfigMapLister
+	deploymentLister appsv1listers.DeploymentLister
+	challengeLister  cmacmelisters.ChallengeLister
 
 	testReachability reachabilityTest
 	requiredPasses   int
@@ -68,16 +83,30 @@ type Solver struct {
 type reachabilityTest func(ctx context.Context, url *url.URL, key string) error
 
 // NewSolver returns a new ACME HTTP01 solver for the given Issuer and client.
+// The Ingress API version used is determined using discovery.
 // TODO: refactor this to have fewer args
-func NewSolver(ctx *controller.Context) *Solver {
-	return &Solver{
-		Context:          ctx,
-		podLister:        ctx.KubeSharedInformerFactory.Core().V1().Pods().Lister(),
-		serviceLister:    ctx.KubeSharedInformerFactory.Core().V1().Services().Lister(),
-		ingressLister:    ctx.KubeSharedInformerFactory.Extensions().V1beta1().Ingresses().Lister(),
-		testReachability: testReachability,
-		requiredPasses:   5,
+func NewSolver(ctx *controller.Context) (*Solver, error) {
+	ingresses, err := ingress.NewResources(ctx.Client, ctx.KubeSharedInformerFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Solver{
+		Context:            ctx,
+		podLister:          ctx.KubeSharedInformerFactory.Core().V1().Pods().Lister(),
+		serviceLister:      ctx.KubeSharedInformerFactory.Core().V1().Services().Lister(),
+		ingressLister:      ingresses.Lister,
+		ingressClient:      ingresses.Client,
+		ingressClassLister: ingresses.IngressClassLister,
+		testReachability:   testReachability,
+		requiredPasses:     5,
 	}
+	if ctx.ACMEOptions.HTTP01SolverShared {
+		s.configMapLister = ctx.KubeSharedInformerFactory.Core().V1().ConfigMaps().Lister()
+		s.deploymentLister = ctx.KubeSharedInformerFactory.Apps().V1().Deployments().Lister()
+		s.challengeLister = ctx.SharedInformerFactory.Acme().V1alpha2().Challenges().Lister()
+	}
+	return s, nil
 }
 
 func http01LogCtx(ctx context.Context) context.Context {
@@ -92,19 +121,70 @@ func httpDomainCfgForChallenge(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolve
 	return ch.Spec.Solver.HTTP01.Ingress, nil
 }
 
+// solverPodCfgForChallenge returns the service type and pod template of the
+// challenge's HTTP01 solver, whichever type of HTTP01 solver is configured.
+func solverPodCfgForChallenge(ch *cmacme.Challenge) (corev1.ServiceType, *cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate, error) {
+	http01 := ch.Spec.Solver.HTTP01
+	switch {
+	case http01 == nil:
+	case http01.Ingress != nil:
+		return http01.Ingress.ServiceType, http01.Ingress.PodTemplate, nil
+	case http01.HTTPProxy != nil:
+		return http01.HTTPProxy.ServiceType, http01.HTTPProxy.PodTemplate, nil
+	case http01.IngressRoute != nil:
+		return http01.IngressRoute.ServiceType, http01.IngressRoute.PodTemplate, nil
+	}
+	return "", nil, fmt.Errorf("challenge's 'solver' field is specified but no HTTP01 solver config provided. " +
+		"Ensure one of solvers[].http01.ingress, solvers[].http01.httpProxy or solvers[].http01.ingressRoute " +
+		"is specified on your issuer resource")
+}
+
+// ensureRouting ensures requests for the challenge path are routed to the
+// named solver service, using an Ingress, Contour HTTPProxy or Traefik
+// IngressRoute depending on the type of HTTP01 solver configured.
+func (s *Solver) ensureRouting(ctx context.Context, ch *cmacme.Challenge, svcName string) error {
+	http01 := ch.Spec.Solver.HTTP01
+	switch {
+	case http01 != nil && http01.HTTPProxy != nil:
+		return s.ensureHTTPProxy(ctx, ch, svcName)
+	case http01 != nil && http01.IngressRoute != nil:
+		return s.ensureIngressRoute(ctx, ch, svcName)
+	default:
+		_, err := s.ensureIngress(ctx, ch, svcName)
+		return err
+	}
+}
+
+// cleanupRouting removes the routing configured by ensureRouting.
+func (s *Solver) cleanupRouting(ctx context.Context, ch *cmacme.Challenge) error {
+	http01 := ch.Spec.Solver.HTTP01
+	switch {
+	case http01 != nil && http01.HTTPProxy != nil:
+		return s.cleanupHTTPProxy(ctx, ch)
+	case http01 != nil && http01.IngressRoute != nil:
+		return s.cleanupIngressRoute(ctx, ch)
+	default:
+		return s.cleanupIngresses(ctx, ch)
+	}
+}
+
 // Present will realise the resources required to solve the given HTTP01
 // challenge validation in the apiserver. If those resources already exist, it
 // will return nil (i.e. this function is idempotent).
 func (s *Solver) Present(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *cmacme.Challenge) error {
 	ctx = http01LogCtx(ctx)
 
+	if s.ACMEOptions.HTTP01SolverShared {
+		return s.presentShared(ctx, ch)
+	}
+
 	_, podErr := s.ensurePod(ctx, ch)
 	svc, svcErr := s.ensureService(ctx, ch)
 	if svcErr != nil {
 		return utilerrors.NewAggregate([]error{podErr, svcErr})
 	}
-	_, ingressErr := s.ensureIngress(ctx, ch, svc.Name)
-	return utilerrors.NewAggregate([]error{podErr, svcErr, ingressErr})
+	routingErr := s.ensureRouting(ctx, ch, svc.Name)
+	return utilerrors.NewAggregate([]error{podErr, svcErr, routingErr})
 }
 
 func (s *Solver) Check(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *cmacme.Challenge) error {
@@ -147,12 +227,19 @@ func (s *Solver) Check(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *c
 }
 
 // CleanUp will ensure the created service, ingress and pod are clean/deleted of any
-// cert-manager created data.
+// cert-manager created data. Routes added to an existing HTTPProxy or
+// IngressRoute are removed from it.
+// In shared solver mode, the challenge's token is removed from the shared
+// solver instead. Per-challenge resources are always cleaned up, in case
+// they were created before shared solver mode was enabled.
 func (s *Solver) CleanUp(ctx context.Context, issuer v1alpha2.GenericIssuer, ch *cmacme.Challenge) error {
 	var errs []error
 	errs = append(errs, s.cleanupPods(ctx, ch))
 	errs = append(errs, s.cleanupServices(ctx, ch))
-	errs = append(errs, s.cleanupIngresses(ctx, ch))
+	errs = append(errs, s.cleanupRouting(ctx, ch))
+	if s.ACMEOptions.HTTP01SolverShared {
+		errs = append(errs, s.cleanupShared(ctx, ch))
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
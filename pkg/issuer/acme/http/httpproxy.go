@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// Contour HTTPProxy resources are accessed using the dynamic client so that
+// cert-manager does not depend on the Contour API types.
+var httpProxyGVR = schema.GroupVersionResource{Group: "projectcontour.io", Version: "v1", Resource: "httpproxies"}
+
+// ensureHTTPProxy ensures the existing HTTPProxy named in the challenge's
+// solver config has a route for the challenge path to the given service.
+func (s *Solver) ensureHTTPProxy(ctx context.Context, ch *cmacme.Challenge, svcName string) error {
+	name := ch.Spec.Solver.HTTP01.HTTPProxy.Name
+	log := logf.WithRelatedResourceName(logf.FromContext(ctx).WithName("ensureHTTPProxy"), name, ch.Namespace, "HTTPProxy")
+
+	client := s.DynamicClient.Resource(httpProxyGVR).Namespace(ch.Namespace)
+	proxy, err := client.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	fqdn, _, err := unstructured.NestedString(proxy.Object, "spec", "virtualhost", "fqdn")
+	if err != nil {
+		return err
+	}
+	if fqdn != ch.Spec.DNSName {
+		return fmt.Errorf("HTTPProxy %q has virtualhost fqdn %q, which does not match the challenge domain %q",
+			name, fqdn, ch.Spec.DNSName)
+	}
+
+	routes, _, err := unstructured.NestedSlice(proxy.Object, "spec", "routes")
+	if err != nil {
+		return err
+	}
+
+	routeToAdd := httpProxyRoute(ch.Spec.Token, svcName)
+	found := false
+	for i, route := range routes {
+		// if an existing route exists for the challenge path, we overwrite
+		// it else we'll confuse contour
+		if httpProxyRoutePrefix(route) != solverPathFn(ch.Spec.Token) {
+			continue
+		}
+		// httpproxy resource is already up to date
+		if reflect.DeepEqual(route, routeToAdd) {
+			return nil
+		}
+		routes[i] = routeToAdd
+		found = true
+		break
+	}
+	if !found {
+		routes = append([]interface{}{routeToAdd}, routes...)
+	}
+
+	log.Info("adding solver route to existing httpproxy resource")
+	if err := unstructured.SetNestedSlice(proxy.Object, routes, "spec", "routes"); err != nil {
+		return err
+	}
+	_, err = client.Update(context.TODO(), proxy, metav1.UpdateOptions{})
+	return err
+}
+
+// cleanupHTTPProxy removes the route added to the existing HTTPProxy named in
+// the challenge's solver config.
+func (s *Solver) cleanupHTTPProxy(ctx context.Context, ch *cmacme.Challenge) error {
+	name := ch.Spec.Solver.HTTP01.HTTPProxy.Name
+	log := logf.WithRelatedResourceName(logf.FromContext(ctx, "cleanupHTTPProxy"), name, ch.Namespace, "HTTPProxy")
+
+	client := s.DynamicClient.Resource(httpProxyGVR).Namespace(ch.Namespace)
+	proxy, err := client.Get(context.TODO(), name, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		log.Error(err, "named httpproxy resource not found, skipping cleanup")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	routes, _, err := unstructured.NestedSlice(proxy.Object, "spec", "routes")
+	if err != nil {
+		return err
+	}
+
+	var retained []interface{}
+	for _, route := range routes {
+		if httpProxyRoutePrefix(route) == solverPathFn(ch.Spec.Token) {
+			log.Info("deleting challenge solver route on httpproxy resource")
+			continue
+		}
+		retained = append(retained, route)
+	}
+	if len(retained) == len(routes) {
+		return nil
+	}
+
+	if err := unstructured.SetNestedSlice(proxy.Object, retained, "spec", "routes"); err != nil {
+		return err
+	}
+	_, err = client.Update(context.TODO(), proxy, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	log.Info("cleaned up all challenge solver routes on httpproxy resource")
+
+	return nil
+}
+
+// httpProxyRoute returns the HTTPProxy route needed to solve this challenge.
+// Plain HTTP is permitted as HTTP01 challenges are always validated over
+// port 80.
+func httpProxyRoute(token, serviceName string) interface{} {
+	return map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"prefix": solverPathFn(token),
+			},
+		},
+		"services": []interface{}{
+			map[string]interface{}{
+				"name": serviceName,
+				"port": int64(acmeSolverListenPort),
+			},
+		},
+		"permitInsecure": true,
+	}
+}
+
+// httpProxyRoutePrefix returns the path prefix condition of the given
+// HTTPProxy route, or an empty string if it has none.
+func httpProxyRoutePrefix(route interface{}) string {
+	r, ok := route.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	conditions, _, _ := unstructured.NestedSlice(r, "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if prefix, ok := condition["prefix"].(string); ok {
+			return prefix
+		}
+	}
+	return ""
+}
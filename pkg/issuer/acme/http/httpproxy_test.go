@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/controller/test"
+)
+
+func TestEnsureHTTPProxy(t *testing.T) {
+	otherRoute := map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"prefix": "/"}},
+		"services":   []interface{}{map[string]interface{}{"name": "app", "port": int64(80)}},
+	}
+
+	tests := map[string]struct {
+		fqdn           string
+		routes         []interface{}
+		expectedRoutes []interface{}
+		expectUpdate   bool
+		err            bool
+	}{
+		"should add the solver route to an existing httpproxy": {
+			fqdn:           "example.com",
+			routes:         []interface{}{otherRoute},
+			expectedRoutes: []interface{}{httpProxyRoute("token", "fakeservice"), otherRoute},
+			expectUpdate:   true,
+		},
+		"should not update an httpproxy that already has the solver route": {
+			fqdn:           "example.com",
+			routes:         []interface{}{httpProxyRoute("token", "fakeservice"), otherRoute},
+			expectedRoutes: []interface{}{httpProxyRoute("token", "fakeservice"), otherRoute},
+		},
+		"should replace a solver route for a different service": {
+			fqdn:           "example.com",
+			routes:         []interface{}{otherRoute, httpProxyRoute("token", "oldservice")},
+			expectedRoutes: []interface{}{otherRoute, httpProxyRoute("token", "fakeservice")},
+			expectUpdate:   true,
+		},
+		"should error if the httpproxy fqdn does not match the challenge domain": {
+			fqdn:           "other.example.com",
+			routes:         []interface{}{otherRoute},
+			expectedRoutes: []interface{}{otherRoute},
+			err:            true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := buildHTTPProxyChallenge()
+			s, b := buildDynamicFakeSolver(t, buildHTTPProxy(test.fqdn, test.routes))
+			defer b.Stop()
+
+			err := s.ensureHTTPProxy(context.TODO(), ch, "fakeservice")
+			if err != nil != test.err {
+				t.Errorf("expected error=%t, but got: %v", test.err, err)
+			}
+
+			checkDynamicRoutes(t, b, httpProxyGVR, "proxy", test.expectUpdate, test.expectedRoutes)
+		})
+	}
+}
+
+func TestCleanupHTTPProxy(t *testing.T) {
+	otherRoute := map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"prefix": "/"}},
+		"services":   []interface{}{map[string]interface{}{"name": "app", "port": int64(80)}},
+	}
+
+	tests := map[string]struct {
+		objects        []runtime.Object
+		expectedRoutes []interface{}
+		expectUpdate   bool
+	}{
+		"should remove the solver route from the httpproxy": {
+			objects:        []runtime.Object{buildHTTPProxy("example.com", []interface{}{httpProxyRoute("token", "fakeservice"), otherRoute})},
+			expectedRoutes: []interface{}{otherRoute},
+			expectUpdate:   true,
+		},
+		"should not update an httpproxy without a solver route": {
+			objects:        []runtime.Object{buildHTTPProxy("example.com", []interface{}{otherRoute})},
+			expectedRoutes: []interface{}{otherRoute},
+		},
+		"should not error if the httpproxy does not exist": {},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := buildHTTPProxyChallenge()
+			s, b := buildDynamicFakeSolver(t, test.objects...)
+			defer b.Stop()
+
+			if err := s.cleanupHTTPProxy(context.TODO(), ch); err != nil {
+				t.Errorf("expected no error, but got: %v", err)
+			}
+
+			if len(test.objects) > 0 {
+				checkDynamicRoutes(t, b, httpProxyGVR, "proxy", test.expectUpdate, test.expectedRoutes)
+			}
+		})
+	}
+}
+
+func buildHTTPProxyChallenge() *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{Namespace: defaultTestNamespace},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					HTTPProxy: &cmacme.ACMEChallengeSolverHTTP01HTTPProxy{
+						Name: "proxy",
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildHTTPProxy(fqdn string, routes []interface{}) *unstructured.Unstructured {
+	proxy := &unstructured.Unstructured{}
+	proxy.SetAPIVersion("projectcontour.io/v1")
+	proxy.SetKind("HTTPProxy")
+	proxy.SetName("proxy")
+	proxy.SetNamespace(defaultTestNamespace)
+	proxy.Object["spec"] = map[string]interface{}{
+		"virtualhost": map[string]interface{}{
+			"fqdn": fqdn,
+		},
+		"routes": routes,
+	}
+	return proxy
+}
+
+func buildDynamicFakeSolver(t *testing.T, objects ...runtime.Object) (*Solver, *test.Builder) {
+	b := &test.Builder{
+		T:              t,
+		DynamicObjects: objects,
+	}
+	return buildFakeSolver(b), b
+}
+
+// checkDynamicRoutes checks whether the named resource in the fake dynamic
+// client was updated, and that its routes are as expected.
+func checkDynamicRoutes(t *testing.T, b *test.Builder, gvr schema.GroupVersionResource, name string, expectUpdate bool, expectedRoutes []interface{}) {
+	var updated bool
+	for _, a := range b.FakeDynamicClient().Actions() {
+		if a.GetVerb() == "update" {
+			updated = true
+		}
+	}
+	if updated != expectUpdate {
+		t.Errorf("expected updated=%t, but got %t", expectUpdate, updated)
+	}
+
+	obj, err := b.FakeDynamicClient().Resource(gvr).Namespace(defaultTestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error getting %s %q: %v", gvr.Resource, name, err)
+	}
+	routes, _, _ := unstructured.NestedSlice(obj.Object, "spec", "routes")
+	if !reflect.DeepEqual(routes, expectedRoutes) {
+		t.Errorf("unexpected routes\nexp=%v\ngot=%v", expectedRoutes, routes)
+	}
+}
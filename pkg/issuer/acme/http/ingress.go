@@ -20,7 +20,7 @@ import (
 	"context"
 	"fmt"
 
-	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -36,7 +36,7 @@ import (
 
 // getIngressesForChallenge returns a list of Ingresses that were created to solve
 // http challenges for the given domain
-func (s *Solver) getIngressesForChallenge(ctx context.Context, ch *cmacme.Challenge) ([]*extv1beta1.Ingress, error) {
+func (s *Solver) getIngressesForChallenge(ctx context.Context, ch *cmacme.Challenge) ([]*networkingv1beta1.Ingress, error) {
 	log := logf.FromContext(ctx)
 
 	podLabels := podLabels(ch)
@@ -55,7 +55,7 @@ func (s *Solver) getIngressesForChallenge(ctx context.Context, ch *cmacme.Challe
 		return nil, err
 	}
 
-	var relevantIngresses []*extv1beta1.Ingress
+	var relevantIngresses []*networkingv1beta1.Ingress
 	for _, ingress := range ingressList {
 		if !metav1.IsControlledBy(ingress, ch) {
 			logf.WithRelatedResource(log, ingress).Info("found existing solver ingress for this challenge resource, however " +
@@ -71,7 +71,7 @@ func (s *Solver) getIngressesForChallenge(ctx context.Context, ch *cmacme.Challe
 // ensureIngress will ensure the ingress required to solve this challenge
 // exists, or if an existing ingress is specified on the secret will ensure
 // that the ingress has an appropriate challenge path configured
-func (s *Solver) ensureIngress(ctx context.Context, ch *cmacme.Challenge, svcName string) (ing *extv1beta1.Ingress, err error) {
+func (s *Solver) ensureIngress(ctx context.Context, ch *cmacme.Challenge, svcName string) (ing *networkingv1beta1.Ingress, err error) {
 	log := logf.FromContext(ctx).WithName("ensureIngress")
 	httpDomainCfg, err := httpDomainCfgForChallenge(ch)
 	if err != nil {
@@ -112,28 +112,32 @@ func (s *Solver) ensureIngress(ctx context.Context, ch *cmacme.Challenge, svcNam
 	return s.createIngress(ch, svcName)
 }
 
-func ingressServiceName(ing *extv1beta1.Ingress) string {
+func ingressServiceName(ing *networkingv1beta1.Ingress) string {
 	return ing.Spec.Rules[0].IngressRuleValue.HTTP.Paths[0].Backend.ServiceName
 }
 
 // createIngress will create a challenge solving ingress for the given certificate,
 // domain, token and key.
-func (s *Solver) createIngress(ch *cmacme.Challenge, svcName string) (*extv1beta1.Ingress, error) {
+func (s *Solver) createIngress(ch *cmacme.Challenge, svcName string) (*networkingv1beta1.Ingress, error) {
 	ing, err := buildIngressResource(ch, svcName)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.checkIngressClassExists(ing.Spec.IngressClassName); err != nil {
+		return nil, err
+	}
+
 	// Override the defaults if they have changed in the ingress template.
 	if ch.Spec.Solver.HTTP01 != nil &&
 		ch.Spec.Solver.HTTP01.Ingress != nil {
 		ing = s.mergeIngressObjectMetaWithIngressResourceTemplate(ing, ch.Spec.Solver.HTTP01.Ingress.IngressTemplate)
 	}
 
-	return s.Client.ExtensionsV1beta1().Ingresses(ch.Namespace).Create(context.TODO(), ing, metav1.CreateOptions{})
+	return s.ingressClient.Ingresses(ch.Namespace).Create(context.TODO(), ing, metav1.CreateOptions{})
 }
 
-func buildIngressResource(ch *cmacme.Challenge, svcName string) (*extv1beta1.Ingress, error) {
+func buildIngressResource(ch *cmacme.Challenge, svcName string) (*networkingv1beta1.Ingress, error) {
 	httpDomainCfg, err := httpDomainCfgForChallenge(ch)
 	if err != nil {
 		return nil, err
@@ -156,7 +160,7 @@ func buildIngressResource(ch *cmacme.Challenge, svcName string) (*extv1beta1.Ing
 
 	ingPathToAdd := ingressPath(ch.Spec.Token, svcName)
 
-	return &extv1beta1.Ingress{
+	return &networkingv1beta1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName:    "cm-acme-http-solver-",
 			Namespace:       ch.Namespace,
@@ -164,13 +168,14 @@ func buildIngressResource(ch *cmacme.Challenge, svcName string) (*extv1beta1.Ing
 			Annotations:     ingAnnotations,
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)},
 		},
-		Spec: extv1beta1.IngressSpec{
-			Rules: []extv1beta1.IngressRule{
+		Spec: networkingv1beta1.IngressSpec{
+			IngressClassName: httpDomainCfg.IngressClassName,
+			Rules: []networkingv1beta1.IngressRule{
 				{
 					Host: ch.Spec.DNSName,
-					IngressRuleValue: extv1beta1.IngressRuleValue{
-						HTTP: &extv1beta1.HTTPIngressRuleValue{
-							Paths: []extv1beta1.HTTPIngressPath{ingPathToAdd},
+					IngressRuleValue: networkingv1beta1.IngressRuleValue{
+						HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+							Paths: []networkingv1beta1.HTTPIngressPath{ingPathToAdd},
 						},
 					},
 				},
@@ -179,8 +184,24 @@ func buildIngressResource(ch *cmacme.Challenge, svcName string) (*extv1beta1.Ing
 	}, nil
 }
 
+// checkIngressClassExists returns an error if className is set and no
+// IngressClass with that name exists. The check is skipped if the apiserver
+// does not serve IngressClass resources.
+func (s *Solver) checkIngressClassExists(className *string) error {
+	if className == nil || s.ingressClassLister == nil {
+		return nil
+	}
+
+	_, err := s.ingressClassLister.Get(*className)
+	if k8sErrors.IsNotFound(err) {
+		return fmt.Errorf("IngressClass %q specified by ingressClassName does not exist", *className)
+	}
+
+	return err
+}
+
 // Merge object meta from the ingress template. Fall back to default values.
-func (s *Solver) mergeIngressObjectMetaWithIngressResourceTemplate(ingress *extv1beta1.Ingress, ingressTempl *cmacme.ACMEChallengeSolverHTTP01IngressTemplate) *extv1beta1.Ingress {
+func (s *Solver) mergeIngressObjectMetaWithIngressResourceTemplate(ingress *networkingv1beta1.Ingress, ingressTempl *cmacme.ACMEChallengeSolverHTTP01IngressTemplate) *networkingv1beta1.Ingress {
 	if ingressTempl == nil {
 		return ingress
 	}
@@ -204,7 +225,7 @@ func (s *Solver) mergeIngressObjectMetaWithIngressResourceTemplate(ingress *extv
 	return ingress
 }
 
-func (s *Solver) addChallengePathToIngress(ctx context.Context, ch *cmacme.Challenge, svcName string) (*extv1beta1.Ingress, error) {
+func (s *Solver) addChallengePathToIngress(ctx context.Context, ch *cmacme.Challenge, svcName string) (*networkingv1beta1.Ingress, error) {
 	httpDomainCfg, err := httpDomainCfgForChallenge(ch)
 	if err != nil {
 		return nil, err
@@ -221,7 +242,7 @@ func (s *Solver) addChallengePathToIngress(ctx context.Context, ch *cmacme.Chall
 	for _, rule := range ing.Spec.Rules {
 		if rule.Host == ch.Spec.DNSName {
 			if rule.HTTP == nil {
-				rule.HTTP = &extv1beta1.HTTPIngressRuleValue{}
+				rule.HTTP = &networkingv1beta1.HTTPIngressRuleValue{}
 			}
 			for i, p := range rule.HTTP.Paths {
 				// if an existing path exists on this rule for the challenge path,
@@ -233,24 +254,24 @@ func (s *Solver) addChallengePathToIngress(ctx context.Context, ch *cmacme.Chall
 						return ing, nil
 					}
 					rule.HTTP.Paths[i] = ingPathToAdd
-					return s.Client.ExtensionsV1beta1().Ingresses(ing.Namespace).Update(context.TODO(), ing, metav1.UpdateOptions{})
+					return s.ingressClient.Ingresses(ing.Namespace).Update(context.TODO(), ing, metav1.UpdateOptions{})
 				}
 			}
-			rule.HTTP.Paths = append([]extv1beta1.HTTPIngressPath{ingPathToAdd}, rule.HTTP.Paths...)
-			return s.Client.ExtensionsV1beta1().Ingresses(ing.Namespace).Update(context.TODO(), ing, metav1.UpdateOptions{})
+			rule.HTTP.Paths = append([]networkingv1beta1.HTTPIngressPath{ingPathToAdd}, rule.HTTP.Paths...)
+			return s.ingressClient.Ingresses(ing.Namespace).Update(context.TODO(), ing, metav1.UpdateOptions{})
 		}
 	}
 
 	// if one doesn't exist, create a new IngressRule
-	ing.Spec.Rules = append(ing.Spec.Rules, extv1beta1.IngressRule{
+	ing.Spec.Rules = append(ing.Spec.Rules, networkingv1beta1.IngressRule{
 		Host: ch.Spec.DNSName,
-		IngressRuleValue: extv1beta1.IngressRuleValue{
-			HTTP: &extv1beta1.HTTPIngressRuleValue{
-				Paths: []extv1beta1.HTTPIngressPath{ingPathToAdd},
+		IngressRuleValue: networkingv1beta1.IngressRuleValue{
+			HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+				Paths: []networkingv1beta1.HTTPIngressPath{ingPathToAdd},
 			},
 		},
 	})
-	return s.Client.ExtensionsV1beta1().Ingresses(ing.Namespace).Update(context.TODO(), ing, metav1.UpdateOptions{})
+	return s.ingressClient.Ingresses(ing.Namespace).Update(context.TODO(), ing, metav1.UpdateOptions{})
 }
 
 // cleanupIngresses will remove the rules added by cert-manager to an existing
@@ -277,7 +298,7 @@ func (s *Solver) cleanupIngresses(ctx context.Context, ch *cmacme.Challenge) err
 			log := logf.WithRelatedResource(log, ingress).V(logf.DebugLevel)
 
 			log.Info("deleting ingress resource")
-			err := s.Client.ExtensionsV1beta1().Ingresses(ingress.Namespace).Delete(context.TODO(), ingress.Name, metav1.DeleteOptions{})
+			err := s.ingressClient.Ingresses(ingress.Namespace).Delete(context.TODO(), ingress.Name, metav1.DeleteOptions{})
 			if err != nil {
 				log.Info("failed to delete ingress resource", "error", err)
 				errs = append(errs, err)
@@ -289,7 +310,7 @@ func (s *Solver) cleanupIngresses(ctx context.Context, ch *cmacme.Challenge) err
 	}
 
 	// otherwise, we need to remove any cert-manager added rules from the ingress resource
-	ing, err := s.Client.ExtensionsV1beta1().Ingresses(ch.Namespace).Get(context.TODO(), existingIngressName, metav1.GetOptions{})
+	ing, err := s.ingressClient.Ingresses(ch.Namespace).Get(context.TODO(), existingIngressName, metav1.GetOptions{})
 	if k8sErrors.IsNotFound(err) {
 		log.Error(err, "named ingress resource not found, skipping cleanup")
 		return nil
@@ -301,7 +322,7 @@ func (s *Solver) cleanupIngresses(ctx context.Context, ch *cmacme.Challenge) err
 
 	log.Info("attempting to clean up automatically added solver paths on ingress resource")
 	ingPathToDel := solverPathFn(ch.Spec.Token)
-	var ingRules []extv1beta1.IngressRule
+	var ingRules []networkingv1beta1.IngressRule
 	for _, rule := range ing.Spec.Rules {
 		// always retain rules that are not for the same DNSName
 		if rule.Host != ch.Spec.DNSName {
@@ -332,7 +353,7 @@ func (s *Solver) cleanupIngresses(ctx context.Context, ch *cmacme.Challenge) err
 
 	ing.Spec.Rules = ingRules
 
-	_, err = s.Client.ExtensionsV1beta1().Ingresses(ing.Namespace).Update(context.TODO(), ing, metav1.UpdateOptions{})
+	_, err = s.ingressClient.Ingresses(ing.Namespace).Update(context.TODO(), ing, metav1.UpdateOptions{})
 	if err != nil {
 		return err
 	}
@@ -343,11 +364,14 @@ func (s *Solver) cleanupIngresses(ctx context.Context, ch *cmacme.Challenge) err
 }
 
 // ingressPath returns the ingress HTTPIngressPath object needed to solve this
-// challenge.
-func ingressPath(token, serviceName string) extv1beta1.HTTPIngressPath {
-	return extv1beta1.HTTPIngressPath{
-		Path: solverPathFn(token),
-		Backend: extv1beta1.IngressBackend{
+// challenge. The path type is always set, as it is required by
+// networking.k8s.io/v1 Ingresses.
+func ingressPath(token, serviceName string) networkingv1beta1.HTTPIngressPath {
+	pathType := networkingv1beta1.PathTypeImplementationSpecific
+	return networkingv1beta1.HTTPIngressPath{
+		Path:     solverPathFn(token),
+		PathType: &pathType,
+		Backend: networkingv1beta1.IngressBackend{
 			ServiceName: serviceName,
 			ServicePort: intstr.FromInt(acmeSolverListenPort),
 		},
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// Traefik IngressRoute resources are accessed using the dynamic client so
+// that cert-manager does not depend on the Traefik API types.
+var ingressRouteGVR = schema.GroupVersionResource{Group: "traefik.containo.us", Version: "v1alpha1", Resource: "ingressroutes"}
+
+// ensureIngressRoute ensures the existing IngressRoute named in the
+// challenge's solver config has a route for the challenge path to the given
+// service.
+func (s *Solver) ensureIngressRoute(ctx context.Context, ch *cmacme.Challenge, svcName string) error {
+	name := ch.Spec.Solver.HTTP01.IngressRoute.Name
+	log := logf.WithRelatedResourceName(logf.FromContext(ctx).WithName("ensureIngressRoute"), name, ch.Namespace, "IngressRoute")
+
+	client := s.DynamicClient.Resource(ingressRouteGVR).Namespace(ch.Namespace)
+	ingressRoute, err := client.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	routes, _, err := unstructured.NestedSlice(ingressRoute.Object, "spec", "routes")
+	if err != nil {
+		return err
+	}
+
+	routeToAdd := ingressRouteRoute(ch.Spec.DNSName, ch.Spec.Token, svcName)
+	match := ingressRouteMatch(ch.Spec.DNSName, ch.Spec.Token)
+	found := false
+	for i, route := range routes {
+		if ingressRouteRouteMatch(route) != match {
+			continue
+		}
+		// ingressroute resource is already up to date
+		if reflect.DeepEqual(route, routeToAdd) {
+			return nil
+		}
+		routes[i] = routeToAdd
+		found = true
+		break
+	}
+	if !found {
+		routes = append([]interface{}{routeToAdd}, routes...)
+	}
+
+	log.Info("adding solver route to existing ingressroute resource")
+	if err := unstructured.SetNestedSlice(ingressRoute.Object, routes, "spec", "routes"); err != nil {
+		return err
+	}
+	_, err = client.Update(context.TODO(), ingressRoute, metav1.UpdateOptions{})
+	return err
+}
+
+// cleanupIngressRoute removes the route added to the existing IngressRoute
+// named in the challenge's solver config.
+func (s *Solver) cleanupIngressRoute(ctx context.Context, ch *cmacme.Challenge) error {
+	name := ch.Spec.Solver.HTTP01.IngressRoute.Name
+	log := logf.WithRelatedResourceName(logf.FromContext(ctx, "cleanupIngressRoute"), name, ch.Namespace, "IngressRoute")
+
+	client := s.DynamicClient.Resource(ingressRouteGVR).Namespace(ch.Namespace)
+	ingressRoute, err := client.Get(context.TODO(), name, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		log.Error(err, "named ingressroute resource not found, skipping cleanup")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	routes, _, err := unstructured.NestedSlice(ingressRoute.Object, "spec", "routes")
+	if err != nil {
+		return err
+	}
+
+	match := ingressRouteMatch(ch.Spec.DNSName, ch.Spec.Token)
+	var retained []interface{}
+	for _, route := range routes {
+		if ingressRouteRouteMatch(route) == match {
+			log.Info("deleting challenge solver route on ingressroute resource")
+			continue
+		}
+		retained = append(retained, route)
+	}
+	if len(retained) == len(routes) {
+		return nil
+	}
+
+	if err := unstructured.SetNestedSlice(ingressRoute.Object, retained, "spec", "routes"); err != nil {
+		return err
+	}
+	_, err = client.Update(context.TODO(), ingressRoute, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	log.Info("cleaned up all challenge solver routes on ingressroute resource")
+
+	return nil
+}
+
+// ingressRouteRoute returns the IngressRoute route needed to solve this
+// challenge.
+func ingressRouteRoute(dnsName, token, serviceName string) interface{} {
+	return map[string]interface{}{
+		"kind":  "Rule",
+		"match": ingressRouteMatch(dnsName, token),
+		"services": []interface{}{
+			map[string]interface{}{
+				"name": serviceName,
+				"port": int64(acmeSolverListenPort),
+			},
+		},
+	}
+}
+
+// ingressRouteMatch returns the Traefik rule matching requests for this
+// challenge. Traefik prioritises longer rules, so this takes precedence
+// over any other route for the same host.
+func ingressRouteMatch(dnsName, token string) string {
+	return fmt.Sprintf("Host(`%s`) && Path(`%s`)", dnsName, solverPathFn(token))
+}
+
+// ingressRouteRouteMatch returns the match rule of the given IngressRoute
+// route, or an empty string if it has none.
+func ingressRouteRouteMatch(route interface{}) string {
+	r, ok := route.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	match, _ := r["match"].(string)
+	return match
+}
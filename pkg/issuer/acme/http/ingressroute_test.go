@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+)
+
+func TestEnsureIngressRoute(t *testing.T) {
+	otherRoute := map[string]interface{}{
+		"kind":     "Rule",
+		"match":    "Host(`example.com`)",
+		"services": []interface{}{map[string]interface{}{"name": "app", "port": int64(80)}},
+	}
+
+	tests := map[string]struct {
+		routes         []interface{}
+		expectedRoutes []interface{}
+		expectUpdate   bool
+	}{
+		"should add the solver route to an existing ingressroute": {
+			routes:         []interface{}{otherRoute},
+			expectedRoutes: []interface{}{ingressRouteRoute("example.com", "token", "fakeservice"), otherRoute},
+			expectUpdate:   true,
+		},
+		"should not update an ingressroute that already has the solver route": {
+			routes:         []interface{}{otherRoute, ingressRouteRoute("example.com", "token", "fakeservice")},
+			expectedRoutes: []interface{}{otherRoute, ingressRouteRoute("example.com", "token", "fakeservice")},
+		},
+		"should replace a solver route for a different service": {
+			routes:         []interface{}{ingressRouteRoute("example.com", "token", "oldservice"), otherRoute},
+			expectedRoutes: []interface{}{ingressRouteRoute("example.com", "token", "fakeservice"), otherRoute},
+			expectUpdate:   true,
+		},
+		"should add a solver route if the existing one is for a different domain": {
+			routes:         []interface{}{ingressRouteRoute("other.example.com", "token", "fakeservice")},
+			expectedRoutes: []interface{}{ingressRouteRoute("example.com", "token", "fakeservice"), ingressRouteRoute("other.example.com", "token", "fakeservice")},
+			expectUpdate:   true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := buildIngressRouteChallenge()
+			s, b := buildDynamicFakeSolver(t, buildIngressRoute(test.routes))
+			defer b.Stop()
+
+			if err := s.ensureIngressRoute(context.TODO(), ch, "fakeservice"); err != nil {
+				t.Errorf("expected no error, but got: %v", err)
+			}
+
+			checkDynamicRoutes(t, b, ingressRouteGVR, "ingressroute", test.expectUpdate, test.expectedRoutes)
+		})
+	}
+}
+
+func TestCleanupIngressRoute(t *testing.T) {
+	otherRoute := map[string]interface{}{
+		"kind":     "Rule",
+		"match":    "Host(`example.com`)",
+		"services": []interface{}{map[string]interface{}{"name": "app", "port": int64(80)}},
+	}
+
+	tests := map[string]struct {
+		objects        []runtime.Object
+		expectedRoutes []interface{}
+		expectUpdate   bool
+	}{
+		"should remove the solver route from the ingressroute": {
+			objects:        []runtime.Object{buildIngressRoute([]interface{}{ingressRouteRoute("example.com", "token", "fakeservice"), otherRoute})},
+			expectedRoutes: []interface{}{otherRoute},
+			expectUpdate:   true,
+		},
+		"should not update an ingressroute without a solver route": {
+			objects:        []runtime.Object{buildIngressRoute([]interface{}{otherRoute})},
+			expectedRoutes: []interface{}{otherRoute},
+		},
+		"should not error if the ingressroute does not exist": {},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := buildIngressRouteChallenge()
+			s, b := buildDynamicFakeSolver(t, test.objects...)
+			defer b.Stop()
+
+			if err := s.cleanupIngressRoute(context.TODO(), ch); err != nil {
+				t.Errorf("expected no error, but got: %v", err)
+			}
+
+			if len(test.objects) > 0 {
+				checkDynamicRoutes(t, b, ingressRouteGVR, "ingressroute", test.expectUpdate, test.expectedRoutes)
+			}
+		})
+	}
+}
+
+func buildIngressRouteChallenge() *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{Namespace: defaultTestNamespace},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					IngressRoute: &cmacme.ACMEChallengeSolverHTTP01IngressRoute{
+						Name: "ingressroute",
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildIngressRoute(routes []interface{}) *unstructured.Unstructured {
+	ingressRoute := &unstructured.Unstructured{}
+	ingressRoute.SetAPIVersion("traefik.containo.us/v1alpha1")
+	ingressRoute.SetKind("IngressRoute")
+	ingressRoute.SetName("ingressroute")
+	ingressRoute.SetNamespace(defaultTestNamespace)
+	ingressRoute.Object["spec"] = map[string]interface{}{
+		"entryPoints": []interface{}{"web"},
+		"routes":      routes,
+	}
+	return ingressRoute
+}
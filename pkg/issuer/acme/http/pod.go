@@ -144,10 +144,8 @@ func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
 	pod := s.buildDefaultPod(ch)
 
 	// Override defaults if they have changed in the pod template.
-	if ch.Spec.Solver.HTTP01 != nil &&
-		ch.Spec.Solver.HTTP01.Ingress != nil {
-		pod = s.mergePodObjectMetaWithPodTemplate(pod,
-			ch.Spec.Solver.HTTP01.Ingress.PodTemplate)
+	if _, podTemplate, err := solverPodCfgForChallenge(ch); err == nil {
+		pod = s.mergePodObjectMetaWithPodTemplate(pod, podTemplate)
 	}
 
 	return pod
@@ -245,5 +243,81 @@ func (s *Solver) mergePodObjectMetaWithPodTemplate(pod *corev1.Pod, podTempl *cm
 		pod.Spec.Affinity = podTempl.Spec.Affinity
 	}
 
+	if len(podTempl.Spec.TopologySpreadConstraints) > 0 {
+		pod.Spec.TopologySpreadConstraints = podTempl.Spec.TopologySpreadConstraints
+	}
+
+	if podTempl.Spec.PriorityClassName != "" {
+		pod.Spec.PriorityClassName = podTempl.Spec.PriorityClassName
+	}
+
+	if podTempl.Spec.ServiceAccountName != "" {
+		pod.Spec.ServiceAccountName = podTempl.Spec.ServiceAccountName
+	}
+
+	if len(podTempl.Spec.ImagePullSecrets) > 0 {
+		pod.Spec.ImagePullSecrets = podTempl.Spec.ImagePullSecrets
+	}
+
+	if podTempl.Spec.SecurityContext != nil {
+		pod.Spec.SecurityContext = podTempl.Spec.SecurityContext
+	}
+
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if container.Name != "acmesolver" {
+			continue
+		}
+
+		if podTempl.Spec.ContainerSecurityContext != nil {
+			container.SecurityContext = podTempl.Spec.ContainerSecurityContext
+		}
+
+		// Individual requests and limits override the defaults so that
+		// users only need to specify the values they want to change.
+		if podTempl.Spec.Resources != nil {
+			container.Resources = mergeResources(container.Resources, *podTempl.Spec.Resources)
+		}
+	}
+
 	return pod
 }
+
+// mergeResources returns a copy of dst with all requests and limits in src
+// set on it.
+// If a request then exceeds its limit, whichever of the two was not set in
+// src is adjusted to match the other, so that overriding only a request or a
+// limit never produces a pod that is rejected by the API server.
+func mergeResources(dst, src corev1.ResourceRequirements) corev1.ResourceRequirements {
+	out := corev1.ResourceRequirements{
+		Requests: mergeResourceList(dst.Requests, src.Requests),
+		Limits:   mergeResourceList(dst.Limits, src.Limits),
+	}
+
+	for name, request := range out.Requests {
+		limit, ok := out.Limits[name]
+		if !ok || request.Cmp(limit) <= 0 {
+			continue
+		}
+		if _, ok := src.Limits[name]; ok {
+			out.Requests[name] = limit
+		} else {
+			out.Limits[name] = request
+		}
+	}
+
+	return out
+}
+
+// mergeResourceList returns a copy of dst with all values in src set on it.
+func mergeResourceList(dst, src corev1.ResourceList) corev1.ResourceList {
+	out := make(corev1.ResourceList, len(dst)+len(src))
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range src {
+		out[k] = v
+	}
+
+	return out
+}
@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
@@ -348,6 +350,90 @@ func TestMergePodObjectMetaWithPodTemplate(t *testing.T) {
 				}
 			},
 		},
+		"should apply pod spec overrides from template": {
+			Challenge: &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Solver: cmacme.ACMEChallengeSolver{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+								PodTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate{
+									Spec: cmacme.ACMEChallengeSolverHTTP01IngressPodSpec{
+										PriorityClassName:  "high",
+										ServiceAccountName: "solver",
+										ImagePullSecrets: []v1.LocalObjectReference{
+											{Name: "registry"},
+										},
+										TopologySpreadConstraints: []v1.TopologySpreadConstraint{
+											{
+												MaxSkew:           1,
+												TopologyKey:       "zone",
+												WhenUnsatisfiable: v1.ScheduleAnyway,
+											},
+										},
+										SecurityContext: &v1.PodSecurityContext{
+											RunAsNonRoot: boolPtr(true),
+										},
+										ContainerSecurityContext: &v1.SecurityContext{
+											AllowPrivilegeEscalation: boolPtr(false),
+										},
+										Resources: &v1.ResourceRequirements{
+											Limits: v1.ResourceList{
+												v1.ResourceMemory: resource.MustParse("32Mi"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			PreFn: func(t *testing.T, s *solverFixture) {
+				resultingPod := s.Solver.buildDefaultPod(s.Challenge)
+				resultingPod.Spec.NodeSelector = map[string]string{}
+				resultingPod.Spec.Tolerations = []v1.Toleration{}
+				resultingPod.Spec.PriorityClassName = "high"
+				resultingPod.Spec.ServiceAccountName = "solver"
+				resultingPod.Spec.ImagePullSecrets = []v1.LocalObjectReference{
+					{Name: "registry"},
+				}
+				resultingPod.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "zone",
+						WhenUnsatisfiable: v1.ScheduleAnyway,
+					},
+				}
+				resultingPod.Spec.SecurityContext = &v1.PodSecurityContext{
+					RunAsNonRoot: boolPtr(true),
+				}
+				resultingPod.Spec.Containers[0].SecurityContext = &v1.SecurityContext{
+					AllowPrivilegeEscalation: boolPtr(false),
+				}
+				resultingPod.Spec.Containers[0].Resources.Limits[v1.ResourceMemory] = resource.MustParse("32Mi")
+				s.testResources[createdPodKey] = resultingPod
+
+				s.Builder.Sync()
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				resultingPod := s.testResources[createdPodKey].(*v1.Pod)
+
+				resp, ok := args[0].(*v1.Pod)
+				if !ok {
+					t.Errorf("expected pod to be returned, but got %v", args[0])
+					return
+				}
+
+				// ignore pointer differences here
+				resultingPod.OwnerReferences = resp.OwnerReferences
+
+				if resp.String() != resultingPod.String() {
+					t.Errorf("unexpected pod generated from merge\nexp=%s\ngot=%s",
+						resultingPod, resp)
+				}
+			},
+		},
 		"should use default if nothing has changed in template": {
 			Challenge: &cmacme.Challenge{
 				Spec: cmacme.ChallengeSpec{
@@ -408,3 +494,76 @@ func TestMergePodObjectMetaWithPodTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeResources(t *testing.T) {
+	defaults := v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("10m"),
+			v1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("100m"),
+			v1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+	}
+
+	tests := map[string]struct {
+		src      v1.ResourceRequirements
+		expected v1.ResourceRequirements
+	}{
+		"should use defaults if nothing is overridden": {
+			expected: defaults,
+		},
+		"should override individual values": {
+			src: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("50m")},
+			},
+			expected: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("50m"),
+					v1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+				Limits: defaults.Limits,
+			},
+		},
+		"should raise the default limit to an overridden request": {
+			src: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("200m")},
+			},
+			expected: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("200m"),
+					v1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("200m"),
+					v1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+			},
+		},
+		"should lower the default request to an overridden limit": {
+			src: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("32Mi")},
+			},
+			expected: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("10m"),
+					v1.ResourceMemory: resource.MustParse("32Mi"),
+				},
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("100m"),
+					v1.ResourceMemory: resource.MustParse("32Mi"),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := mergeResources(defaults, test.src)
+			if !apiequality.Semantic.DeepEqual(got, test.expected) {
+				t.Errorf("unexpected resources\nexp=%v\ngot=%v", test.expected, got)
+			}
+		})
+	}
+}
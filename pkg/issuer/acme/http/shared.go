@@ -0,0 +1,389 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/adler32"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/jetstack/cert-manager/pkg/acme"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// In shared solver mode, the tokens and keys of all pending challenges in a
+// namespace are stored in a single ConfigMap. This ConfigMap is mounted into
+// long running solver Deployments, one for each distinct pod template and
+// service type used by the challenges in the namespace, which serve every
+// token in it. This avoids creating a pod and service per challenge.
+const (
+	// sharedSolverTokensName is the name of the ConfigMap containing the
+	// tokens of all pending challenges in a namespace.
+	sharedSolverTokensName = "cm-acme-http-solver-tokens"
+	// sharedSolverNamePrefix is the name prefix of the Deployments and
+	// Services of shared solvers.
+	sharedSolverNamePrefix = "cm-acme-http-solver-shared-"
+	// sharedSolverLabelKey is set to the name of the shared solver on its
+	// Deployment, Service and pods.
+	sharedSolverLabelKey = "acme.cert-manager.io/http01-shared-solver"
+	// sharedSolverTokenDir is the path the tokens ConfigMap is mounted at in
+	// shared solver pods.
+	sharedSolverTokenDir = "/var/run/acmesolver/tokens"
+)
+
+// sharedSolverName returns the name of the shared solver that should serve
+// the given challenge. Challenges with the same pod template and service
+// type share a solver.
+func sharedSolverName(ch *cmacme.Challenge) (string, error) {
+	serviceType, podTemplate, err := solverPodCfgForChallenge(ch)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := json.Marshal(struct {
+		ServiceType corev1.ServiceType                                  `json:"serviceType"`
+		PodTemplate *cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate `json:"podTemplate"`
+	}{serviceType, podTemplate})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%d", sharedSolverNamePrefix, adler32.Checksum(cfg)), nil
+}
+
+func sharedSolverLabels(name string) map[string]string {
+	return map[string]string{
+		solverIdentificationLabelKey: "true",
+		sharedSolverLabelKey:         name,
+	}
+}
+
+// presentShared ensures the challenge's token is served by a shared solver,
+// and that the solver is reachable through an ingress.
+func (s *Solver) presentShared(ctx context.Context, ch *cmacme.Challenge) error {
+	name, err := sharedSolverName(ch)
+	if err != nil {
+		return err
+	}
+
+	tokenErr := s.ensureSharedToken(ctx, ch)
+	_, deploymentErr := s.ensureSharedDeployment(ctx, ch, name)
+	svc, svcErr := s.ensureSharedService(ctx, ch, name)
+	if svcErr != nil {
+		return utilerrors.NewAggregate([]error{tokenErr, deploymentErr, svcErr})
+	}
+	routingErr := s.ensureRouting(ctx, ch, svc.Name)
+	return utilerrors.NewAggregate([]error{tokenErr, deploymentErr, routingErr})
+}
+
+// ensureSharedToken adds the challenge's token and key to the namespace's
+// tokens ConfigMap, creating it if required.
+func (s *Solver) ensureSharedToken(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx).WithName("ensureSharedToken")
+
+	cm, err := s.configMapLister.ConfigMaps(ch.Namespace).Get(sharedSolverTokensName)
+	if k8sErrors.IsNotFound(err) {
+		log.Info("creating HTTP01 shared solver tokens configmap")
+		_, err = s.Client.CoreV1().ConfigMaps(ch.Namespace).Create(context.TODO(), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sharedSolverTokensName,
+				Namespace: ch.Namespace,
+				Labels: map[string]string{
+					solverIdentificationLabelKey: "true",
+				},
+			},
+			Data: map[string]string{
+				ch.Spec.Token: ch.Spec.Key,
+			},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if key, ok := cm.Data[ch.Spec.Token]; ok && key == ch.Spec.Key {
+		return nil
+	}
+
+	log.V(logf.DebugLevel).Info("adding challenge token to HTTP01 shared solver tokens configmap")
+	cm = cm.DeepCopy()
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[ch.Spec.Token] = ch.Spec.Key
+	_, err = s.Client.CoreV1().ConfigMaps(ch.Namespace).Update(context.TODO(), cm, metav1.UpdateOptions{})
+	return err
+}
+
+// ensureSharedDeployment ensures the named shared solver Deployment exists
+// and is up to date, for example after the solver image has been changed.
+func (s *Solver) ensureSharedDeployment(ctx context.Context, ch *cmacme.Challenge, name string) (*appsv1.Deployment, error) {
+	log := logf.FromContext(ctx).WithName("ensureSharedDeployment")
+
+	desired := s.buildSharedDeployment(ch, name)
+	deployment, err := s.deploymentLister.Deployments(ch.Namespace).Get(name)
+	if k8sErrors.IsNotFound(err) {
+		log.Info("creating HTTP01 shared solver deployment", "name", name)
+		return s.Client.AppsV1().Deployments(ch.Namespace).Create(context.TODO(), desired, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log = logf.WithRelatedResource(log, deployment)
+	// the existing spec has been defaulted by the apiserver, so only compare
+	// the fields that are set on the desired spec
+	if apiequality.Semantic.DeepDerivative(desired.Spec, deployment.Spec) {
+		log.V(logf.DebugLevel).Info("found existing HTTP01 shared solver deployment")
+		return deployment, nil
+	}
+
+	log.Info("updating HTTP01 shared solver deployment")
+	deployment = deployment.DeepCopy()
+	deployment.Labels = desired.Labels
+	deployment.Spec = desired.Spec
+	return s.Client.AppsV1().Deployments(ch.Namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+}
+
+// buildSharedDeployment builds the named shared solver Deployment. Its pods
+// are built in the same way as those of per-challenge solvers, including
+// any pod template, but serve the tokens in the mounted tokens ConfigMap.
+func (s *Solver) buildSharedDeployment(ch *cmacme.Challenge, name string) *appsv1.Deployment {
+	pod := s.buildPod(ch)
+	solverLabels := sharedSolverLabels(name)
+
+	// replace the per-challenge labels with those of the shared solver
+	delete(pod.Labels, domainLabelKey)
+	delete(pod.Labels, tokenLabelKey)
+	for k, v := range solverLabels {
+		pod.Labels[k] = v
+	}
+
+	pod.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	container := &pod.Spec.Containers[0]
+	container.Args = []string{
+		fmt.Sprintf("--listen-port=%d", acmeSolverListenPort),
+		fmt.Sprintf("--token-dir=%s", sharedSolverTokenDir),
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      "tokens",
+		MountPath: sharedSolverTokenDir,
+		ReadOnly:  true,
+	})
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: "tokens",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: sharedSolverTokensName,
+				},
+			},
+		},
+	})
+
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ch.Namespace,
+			Labels:    solverLabels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: solverLabels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      pod.Labels,
+					Annotations: pod.Annotations,
+				},
+				Spec: pod.Spec,
+			},
+		},
+	}
+}
+
+// ensureSharedService ensures the Service for the named shared solver
+// exists.
+func (s *Solver) ensureSharedService(ctx context.Context, ch *cmacme.Challenge, name string) (*corev1.Service, error) {
+	log := logf.FromContext(ctx).WithName("ensureSharedService")
+
+	svc, err := s.serviceLister.Services(ch.Namespace).Get(name)
+	if err == nil {
+		logf.WithRelatedResource(log, svc).V(logf.DebugLevel).Info("found existing HTTP01 shared solver service")
+		return svc, nil
+	}
+	if !k8sErrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	svc, err = buildSharedService(ch, name)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("creating HTTP01 shared solver service", "name", name)
+	return s.Client.CoreV1().Services(ch.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
+}
+
+func buildSharedService(ch *cmacme.Challenge, name string) (*corev1.Service, error) {
+	svc, err := buildService(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	solverLabels := sharedSolverLabels(name)
+	svc.GenerateName = ""
+	svc.Name = name
+	svc.Labels = solverLabels
+	svc.OwnerReferences = nil
+	svc.Spec.Selector = solverLabels
+
+	return svc, nil
+}
+
+// cleanupShared removes the challenge's token from the tokens ConfigMap, and
+// deletes any shared solver Deployments and Services in the namespace that
+// are no longer used by a challenge. The ConfigMap itself is retained so
+// that it does not race with tokens being added for new challenges.
+func (s *Solver) cleanupShared(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupShared")
+
+	cm, err := s.configMapLister.ConfigMaps(ch.Namespace).Get(sharedSolverTokensName)
+	if k8sErrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cm.Data[ch.Spec.Token]; ok {
+		log.V(logf.DebugLevel).Info("removing challenge token from HTTP01 shared solver tokens configmap")
+		cm = cm.DeepCopy()
+		delete(cm.Data, ch.Spec.Token)
+		_, err = s.Client.CoreV1().ConfigMaps(ch.Namespace).Update(context.TODO(), cm, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	inUse, err := s.sharedSolversInUse(ch)
+	if err != nil {
+		return err
+	}
+
+	return s.cleanupSharedSolvers(ctx, ch.Namespace, inUse)
+}
+
+// sharedSolversInUse returns the names of the shared solvers that are still
+// required by challenges in the namespace of the given challenge, other than
+// the given challenge itself. A challenge requires its solver until it has
+// reached a final state or is being deleted, which is independent of whether
+// its token has been presented yet.
+func (s *Solver) sharedSolversInUse(ch *cmacme.Challenge) (sets.String, error) {
+	challenges, err := s.challengeLister.Challenges(ch.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	inUse := sets.NewString()
+	for _, other := range challenges {
+		if other.Name == ch.Name {
+			continue
+		}
+		if other.DeletionTimestamp != nil || acme.IsFinalState(other.Status.State) {
+			continue
+		}
+		if other.Spec.Solver.HTTP01 == nil || other.Spec.Solver.HTTP01.Ingress == nil {
+			continue
+		}
+		name, err := sharedSolverName(other)
+		if err != nil {
+			return nil, err
+		}
+		inUse.Insert(name)
+	}
+
+	return inUse, nil
+}
+
+// cleanupSharedSolvers deletes the shared solver Deployments and Services in
+// the given namespace that are not in use.
+func (s *Solver) cleanupSharedSolvers(ctx context.Context, namespace string, inUse sets.String) error {
+	log := logf.FromContext(ctx)
+
+	req, err := labels.NewRequirement(sharedSolverLabelKey, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	selector := labels.NewSelector().Add(*req)
+
+	var errs []error
+	deployments, err := s.deploymentLister.Deployments(namespace).List(selector)
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments {
+		if inUse.Has(deployment.Labels[sharedSolverLabelKey]) {
+			continue
+		}
+
+		log := logf.WithRelatedResource(log, deployment).V(logf.DebugLevel)
+		log.Info("deleting idle shared solver deployment")
+
+		err := s.Client.AppsV1().Deployments(namespace).Delete(context.TODO(), deployment.Name, metav1.DeleteOptions{})
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			log.Info("failed to delete deployment resource", "error", err)
+			errs = append(errs, err)
+		}
+	}
+
+	services, err := s.serviceLister.Services(namespace).List(selector)
+	if err != nil {
+		return err
+	}
+	for _, service := range services {
+		if inUse.Has(service.Labels[sharedSolverLabelKey]) {
+			continue
+		}
+
+		log := logf.WithRelatedResource(log, service).V(logf.DebugLevel)
+		log.Info("deleting idle shared solver service")
+
+		err := s.Client.CoreV1().Services(namespace).Delete(context.TODO(), service.Name, metav1.DeleteOptions{})
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			log.Info("failed to delete service resource", "error", err)
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
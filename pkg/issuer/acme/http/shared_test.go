@@ -0,0 +1,259 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/test"
+)
+
+func sharedTestChallenge(name, token, key string, serviceType corev1.ServiceType) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: defaultTestNamespace,
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: name + ".example.com",
+			Token:   token,
+			Key:     key,
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+						ServiceType: serviceType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func newSharedSolverFixture(t *testing.T) *solverFixture {
+	s := &solverFixture{
+		Builder: &test.Builder{
+			Context: &controller.Context{
+				RootContext: context.Background(),
+				ACMEOptions: controller.ACMEOptions{
+					HTTP01SolverShared: true,
+				},
+			},
+		},
+	}
+	s.Setup(t)
+	return s
+}
+
+func TestPresentShared(t *testing.T) {
+	s := newSharedSolverFixture(t)
+	defer s.Builder.Stop()
+
+	ch1 := sharedTestChallenge("one", "token1", "key1", "")
+	ch2 := sharedTestChallenge("two", "token2", "key2", "")
+	ch3 := sharedTestChallenge("three", "token3", "key3", "ClusterIP")
+
+	for _, ch := range []*cmacme.Challenge{ch1, ch2, ch3} {
+		if err := s.Solver.Present(context.Background(), nil, ch); err != nil {
+			t.Fatalf("unexpected error presenting challenge %q: %v", ch.Name, err)
+		}
+		s.Builder.Sync()
+	}
+
+	cm, err := s.Solver.configMapLister.ConfigMaps(defaultTestNamespace).Get(sharedSolverTokensName)
+	if err != nil {
+		t.Fatalf("error getting tokens configmap: %v", err)
+	}
+	expectedTokens := map[string]string{"token1": "key1", "token2": "key2", "token3": "key3"}
+	if !reflect.DeepEqual(cm.Data, expectedTokens) {
+		t.Errorf("unexpected tokens, exp=%v, got=%v", expectedTokens, cm.Data)
+	}
+
+	// challenges one and two share a solver, challenge three uses a
+	// different service type and so requires its own
+	deployments, err := s.Solver.deploymentLister.List(labels.Everything())
+	if err != nil {
+		t.Fatalf("error listing deployments: %v", err)
+	}
+	if len(deployments) != 2 {
+		t.Errorf("expected 2 shared solver deployments, got %d", len(deployments))
+	}
+
+	name, _ := sharedSolverName(ch1)
+	svc, err := s.Solver.serviceLister.Services(defaultTestNamespace).Get(name)
+	if err != nil {
+		t.Fatalf("error getting shared solver service: %v", err)
+	}
+	if !reflect.DeepEqual(svc.Spec.Selector, sharedSolverLabels(name)) {
+		t.Errorf("unexpected service selector: %v", svc.Spec.Selector)
+	}
+
+	deployment, err := s.Solver.deploymentLister.Deployments(defaultTestNamespace).Get(name)
+	if err != nil {
+		t.Fatalf("error getting shared solver deployment: %v", err)
+	}
+	podLabels := labels.Set(deployment.Spec.Template.Labels)
+	if !labels.SelectorFromSet(svc.Spec.Selector).Matches(podLabels) {
+		t.Errorf("service selector %v does not match pod labels %v", svc.Spec.Selector, podLabels)
+	}
+	if podLabels.Has(tokenLabelKey) || podLabels.Has(domainLabelKey) {
+		t.Errorf("shared solver pods should not have per-challenge labels: %v", podLabels)
+	}
+
+	ingresses, err := s.Solver.getIngressesForChallenge(context.Background(), ch1)
+	if err != nil {
+		t.Fatalf("error getting ingresses: %v", err)
+	}
+	if len(ingresses) != 1 || ingressServiceName(ingresses[0]) != name {
+		t.Errorf("expected one ingress for challenge pointing at service %q, got %v", name, ingresses)
+	}
+}
+
+// createSharedTestChallenges creates the given challenges so that they can
+// be found by the solver's challenge lister.
+func createSharedTestChallenges(t *testing.T, s *solverFixture, challenges ...*cmacme.Challenge) {
+	for _, ch := range challenges {
+		_, err := s.Builder.CMClient.AcmeV1alpha2().Challenges(ch.Namespace).Create(context.TODO(), ch, metav1.CreateOptions{})
+		if err != nil {
+			t.Fatalf("error creating challenge %q: %v", ch.Name, err)
+		}
+	}
+	s.Builder.Sync()
+}
+
+func TestPresentSharedUpdatesDeployment(t *testing.T) {
+	s := newSharedSolverFixture(t)
+	defer s.Builder.Stop()
+
+	ch := sharedTestChallenge("one", "token1", "key1", "")
+	s.Builder.Context.HTTP01SolverImage = "solver:v1"
+	if err := s.Solver.Present(context.Background(), nil, ch); err != nil {
+		t.Fatalf("unexpected error presenting challenge: %v", err)
+	}
+	s.Builder.Sync()
+
+	s.Builder.Context.HTTP01SolverImage = "solver:v2"
+	if err := s.Solver.Present(context.Background(), nil, ch); err != nil {
+		t.Fatalf("unexpected error presenting challenge: %v", err)
+	}
+	s.Builder.Sync()
+
+	name, _ := sharedSolverName(ch)
+	deployment, err := s.Solver.deploymentLister.Deployments(defaultTestNamespace).Get(name)
+	if err != nil {
+		t.Fatalf("error getting shared solver deployment: %v", err)
+	}
+	if image := deployment.Spec.Template.Spec.Containers[0].Image; image != "solver:v2" {
+		t.Errorf("expected shared solver deployment to be updated to image %q, got %q", "solver:v2", image)
+	}
+}
+
+func TestCleanUpShared(t *testing.T) {
+	s := newSharedSolverFixture(t)
+	defer s.Builder.Stop()
+
+	ch1 := sharedTestChallenge("one", "token1", "key1", "")
+	ch2 := sharedTestChallenge("two", "token2", "key2", "")
+	createSharedTestChallenges(t, s, ch1, ch2)
+	for _, ch := range []*cmacme.Challenge{ch1, ch2} {
+		if err := s.Solver.Present(context.Background(), nil, ch); err != nil {
+			t.Fatalf("unexpected error presenting challenge %q: %v", ch.Name, err)
+		}
+		s.Builder.Sync()
+	}
+
+	if err := s.Solver.CleanUp(context.Background(), nil, ch1); err != nil {
+		t.Fatalf("unexpected error cleaning up challenge: %v", err)
+	}
+	s.Builder.Sync()
+
+	cm, err := s.Solver.configMapLister.ConfigMaps(defaultTestNamespace).Get(sharedSolverTokensName)
+	if err != nil {
+		t.Fatalf("error getting tokens configmap: %v", err)
+	}
+	if !reflect.DeepEqual(cm.Data, map[string]string{"token2": "key2"}) {
+		t.Errorf("unexpected tokens after cleaning up one challenge: %v", cm.Data)
+	}
+	deployments, _ := s.Solver.deploymentLister.List(labels.Everything())
+	if len(deployments) != 1 {
+		t.Errorf("expected shared solver to be retained while challenges are pending, got %d deployments", len(deployments))
+	}
+
+	err = s.Builder.CMClient.AcmeV1alpha2().Challenges(ch1.Namespace).Delete(context.TODO(), ch1.Name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("error deleting challenge: %v", err)
+	}
+	s.Builder.Sync()
+
+	if err := s.Solver.CleanUp(context.Background(), nil, ch2); err != nil {
+		t.Fatalf("unexpected error cleaning up challenge: %v", err)
+	}
+	s.Builder.Sync()
+
+	deployments, _ = s.Solver.deploymentLister.List(labels.Everything())
+	if len(deployments) != 0 {
+		t.Errorf("expected idle shared solver deployments to be deleted, got %d", len(deployments))
+	}
+	services, _ := s.Solver.serviceLister.List(labels.Everything())
+	if len(services) != 0 {
+		t.Errorf("expected idle shared solver services to be deleted, got %d", len(services))
+	}
+}
+
+func TestCleanUpSharedRetainsSolversOfPendingChallenges(t *testing.T) {
+	s := newSharedSolverFixture(t)
+	defer s.Builder.Stop()
+
+	ch1 := sharedTestChallenge("one", "token1", "key1", "")
+	// challenge two uses the same solver and has not been presented yet, so
+	// the tokens configmap will be empty once challenge one is cleaned up
+	ch2 := sharedTestChallenge("two", "token2", "key2", "")
+	// challenge three uses a different solver and is valid, so its solver is
+	// no longer required
+	ch3 := sharedTestChallenge("three", "token3", "key3", "ClusterIP")
+	ch3.Status.State = cmacme.Valid
+	createSharedTestChallenges(t, s, ch1, ch2, ch3)
+
+	for _, ch := range []*cmacme.Challenge{ch1, ch3} {
+		if err := s.Solver.Present(context.Background(), nil, ch); err != nil {
+			t.Fatalf("unexpected error presenting challenge %q: %v", ch.Name, err)
+		}
+		s.Builder.Sync()
+	}
+
+	if err := s.Solver.CleanUp(context.Background(), nil, ch1); err != nil {
+		t.Fatalf("unexpected error cleaning up challenge: %v", err)
+	}
+	s.Builder.Sync()
+
+	name, _ := sharedSolverName(ch2)
+	deployments, _ := s.Solver.deploymentLister.List(labels.Everything())
+	if len(deployments) != 1 || deployments[0].Name != name {
+		t.Errorf("expected only shared solver deployment %q to be retained, got %v", name, deployments)
+	}
+	services, _ := s.Solver.serviceLister.List(labels.Everything())
+	if len(services) != 1 || services[0].Name != name {
+		t.Errorf("expected only shared solver service %q to be retained, got %v", name, services)
+	}
+}
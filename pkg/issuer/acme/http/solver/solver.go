@@ -19,8 +19,12 @@ package solver
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	logf "github.com/jetstack/cert-manager/pkg/logs"
@@ -32,16 +36,33 @@ type HTTP01Solver struct {
 	Domain string
 	Token  string
 	Key    string
+
+	// TokenDir is a directory containing one file per challenge token, named
+	// after the token and containing the key to respond with. If set, the
+	// solver serves every token in the directory and Domain, Token and Key
+	// are ignored. This is used by shared solvers, which serve all pending
+	// challenges in a namespace.
+	TokenDir string
 }
 
+// validToken matches the characters allowed in an ACME challenge token.
+var validToken = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 func (h *HTTP01Solver) Listen(ctx context.Context) error {
 	log := logf.FromContext(ctx)
-	log.Info("starting listener",
-		"expected_domain", h.Domain,
-		"expected_token", h.Token,
-		"expected_key", h.Key,
-		"listen_port", h.ListenPort,
-	)
+	if h.TokenDir != "" {
+		log.Info("starting listener",
+			"token_dir", h.TokenDir,
+			"listen_port", h.ListenPort,
+		)
+	} else {
+		log.Info("starting listener",
+			"expected_domain", h.Domain,
+			"expected_token", h.Token,
+			"expected_key", h.Key,
+			"listen_port", h.ListenPort,
+		)
+	}
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// extract vars from the request
@@ -69,6 +90,21 @@ func (h *HTTP01Solver) Listen(ctx context.Context) error {
 			return
 		}
 
+		if h.TokenDir != "" {
+			key, err := h.lookupKey(token)
+			if err != nil {
+				log.Info("failed to look up token", "error", err)
+				http.NotFound(w, r)
+				return
+			}
+
+			log.Info("got successful challenge request, writing key")
+			w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, key)
+			return
+		}
+
 		log.Info("comparing host", "expected_host", h.Domain)
 		if h.Domain != host {
 			log.Info("invalid host", "expected_host", h.Domain)
@@ -91,3 +127,22 @@ func (h *HTTP01Solver) Listen(ctx context.Context) error {
 	})
 	return http.ListenAndServe(fmt.Sprintf(":%d", h.ListenPort), handler)
 }
+
+// lookupKey reads the key for the given token from TokenDir. The directory
+// is read on every request so that tokens added after the solver started are
+// served.
+func (h *HTTP01Solver) lookupKey(token string) (string, error) {
+	if !validToken.MatchString(token) {
+		return "", fmt.Errorf("invalid token %q", token)
+	}
+
+	key, err := ioutil.ReadFile(filepath.Join(h.TokenDir, token))
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("unknown token %q", token)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return string(key), nil
+}
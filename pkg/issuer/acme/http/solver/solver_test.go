@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookupKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "acmesolver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "abc_DEF-123"), []byte("key"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "..data"), []byte("not-a-token"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &HTTP01Solver{TokenDir: dir}
+
+	key, err := h.lookupKey("abc_DEF-123")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if key != "key" {
+		t.Errorf("expected key %q, got %q", "key", key)
+	}
+
+	for _, token := range []string{"missing", "..data", "..", "a/b"} {
+		if _, err := h.lookupKey(token); err == nil {
+			t.Errorf("expected error looking up token %q", token)
+		}
+	}
+}
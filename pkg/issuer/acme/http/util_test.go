@@ -82,7 +82,10 @@ func (s *solverFixture) Finish(t *testing.T, args ...interface{}) {
 
 func buildFakeSolver(b *test.Builder) *Solver {
 	b.Init()
-	s := NewSolver(b.Context)
+	s, err := NewSolver(b.Context)
+	if err != nil {
+		b.T.Fatalf("error building solver: %v", err)
+	}
 	b.Start()
 	return s
 }
@@ -90,3 +93,7 @@ func buildFakeSolver(b *test.Builder) *Solver {
 func strPtr(s string) *string {
 	return &s
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -156,14 +156,23 @@ func (a *Acme) Setup(ctx context.Context) error {
 
 	// If the Host components of the server URL and the account URL match,
 	// and the cached email matches the registered email, then
-	// we skip re-checking the account status to save excess calls to the
-	// ACME api.
+	// we skip re-registering the account to save excess calls to the
+	// ACME api. As issuers are set up again periodically, we still check that
+	// the ACME server can be reached and the account is still valid.
 	if hasReadyCondition &&
 		a.issuer.GetStatus().ACMEStatus().URI != "" &&
 		parsedAccountURL.Host == parsedServerURL.Host &&
 		a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail == a.issuer.GetSpec().ACME.Email {
-		log.Info("skipping re-verifying ACME account as cached registration " +
-			"details look sufficient")
+		if err := verifyAccount(ctx, cl); err != nil {
+			s := messageAccountVerificationFailed + err.Error()
+			log.Error(err, "failed to verify cached ACME account registration")
+			a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorAccountVerificationFailed, s)
+			apiutil.SetIssuerCondition(a.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorAccountVerificationFailed, s)
+			return err
+		}
+
+		log.Info("skipping re-registering ACME account as cached registration " +
+			"details are still valid")
 		// ensure the cached client in the account registry is up to date
 		a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk)
 		return nil
@@ -321,19 +330,41 @@ func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, eabAcco
 	acc, err := cl.Register(ctx, acc, acmeapi.AcceptTOS)
 	// If the account already exists, fetch the Account object and return.
 	if err == acmeapi.ErrAccountAlreadyExists {
-		return cl.GetReg(ctx, "")
+		acc, err = cl.GetReg(ctx, "")
 	}
 	if err != nil {
 		return nil, err
 	}
-	// TODO: re-enable this check once this field is set by Pebble
-	// if acc.Status != acme.StatusValid {
-	// 	return nil, fmt.Errorf("acme account is not valid")
-	// }
+
+	if err := checkAccountStatus(acc); err != nil {
+		return nil, err
+	}
 
 	return acc, nil
 }
 
+// verifyAccount checks that the ACME server can be reached, and that the
+// account registered for the client's private key is still valid.
+func verifyAccount(ctx context.Context, cl client.Interface) error {
+	acc, err := cl.GetReg(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	return checkAccountStatus(acc)
+}
+
+// checkAccountStatus returns an error if the account has been deactivated or
+// revoked. Some ACME servers, such as Pebble, do not set the status of
+// accounts so an empty status is accepted.
+func checkAccountStatus(acc *acmeapi.Account) error {
+	if acc.Status != "" && acc.Status != acmeapi.StatusValid {
+		return fmt.Errorf("ACME account status is %q", acc.Status)
+	}
+
+	return nil
+}
+
 func (a *Acme) getEABKey(ns string) ([]byte, error) {
 	eab := a.issuer.GetSpec().ACME.ExternalAccountBinding.Key
 	sec, err := a.secretsClient.Secrets(ns).Get(context.TODO(), eab.Name, metav1.GetOptions{})
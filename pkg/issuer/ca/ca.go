@@ -22,6 +22,7 @@ import (
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/internal/pkcs11"
 	"github.com/jetstack/cert-manager/pkg/issuer"
 )
 
@@ -37,16 +38,20 @@ type CA struct {
 	// For Issuers, this will be the namespace of the Issuer.
 	// For ClusterIssuers, this will be the cluster resource namespace.
 	resourceNamespace string
+
+	// Used for testing to sign without a PKCS#11 token
+	pkcs11SignerBuilder pkcs11.SignerBuilder
 }
 
 func NewCA(ctx *controller.Context, issuer v1alpha2.GenericIssuer) (issuer.Interface, error) {
 	secretsLister := ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister()
 
 	return &CA{
-		Context:           ctx,
-		issuer:            issuer,
-		secretsLister:     secretsLister,
-		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
+		Context:             ctx,
+		issuer:              issuer,
+		secretsLister:       secretsLister,
+		resourceNamespace:   ctx.IssuerOptions.ResourceNamespace(issuer),
+		pkcs11SignerBuilder: pkcs11.SignerForKey,
 	}, nil
 }
 
@@ -18,12 +18,19 @@ package ca
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
 
 	"k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/internal/pkcs11"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util/kube"
 )
@@ -31,6 +38,7 @@ import (
 const (
 	errorGetKeyPair     = "ErrGetKeyPair"
 	errorInvalidKeyPair = "ErrInvalidKeyPair"
+	errorCAExpired      = "ErrCAExpired"
 
 	successKeyPairVerified = "KeyPairVerified"
 
@@ -43,7 +51,7 @@ const (
 func (c *CA) Setup(ctx context.Context) error {
 	log := logf.FromContext(ctx, "setup")
 
-	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	chain, err := kube.SecretTLSCertChain(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
 	if err != nil {
 		log.Error(err, "error getting signing CA TLS certificate")
 		s := messageErrorGetKeyPair + err.Error()
@@ -52,7 +60,17 @@ func (c *CA) Setup(ctx context.Context) error {
 		return err
 	}
 
-	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	cert := chain[0]
+	// Avoid updating the status if only the time zone of NotAfter differs
+	if status := caStatus(chain); !apiequality.Semantic.DeepEqual(status, c.issuer.GetStatus().CA) {
+		c.issuer.GetStatus().CA = status
+	}
+
+	if pkcs11Key := c.issuer.GetSpec().CA.PKCS11; pkcs11Key != nil {
+		err = c.verifyPKCS11Key(pkcs11Key, cert)
+	} else {
+		_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	}
 	if err != nil {
 		log.Error(err, "error getting signing CA private key")
 		s := messageErrorGetKeyPair + err.Error()
@@ -71,9 +89,71 @@ func (c *CA) Setup(ctx context.Context) error {
 		return nil
 	}
 
+	if err := checkValidity(c.Clock.Now(), chain); err != nil {
+		s := messageErrorInvalidKeyPair + err.Error()
+		log.Error(err, "signing CA is not valid")
+		c.Recorder.Event(c.issuer, v1.EventTypeWarning, errorCAExpired, s)
+		apiutil.SetIssuerCondition(c.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorCAExpired, s)
+		// Don't return an error here as the CA will not become valid until
+		// the secret is updated
+		return nil
+	}
+
 	log.Info("signing CA verified")
-	c.Recorder.Event(c.issuer, v1.EventTypeNormal, successKeyPairVerified, messageKeyPairVerified)
+	// Only record an Event when the issuer becomes ready, as the issuer is
+	// set up again periodically
+	if !apiutil.IssuerHasCondition(c.issuer, v1alpha2.IssuerCondition{
+		Type:   v1alpha2.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		c.Recorder.Event(c.issuer, v1.EventTypeNormal, successKeyPairVerified, messageKeyPairVerified)
+	}
 	apiutil.SetIssuerCondition(c.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionTrue, successKeyPairVerified, messageKeyPairVerified)
 
 	return nil
 }
+
+// verifyPKCS11Key checks that the private key in the PKCS#11 token can be
+// used to sign, and that it is the key of the CA certificate.
+func (c *CA) verifyPKCS11Key(key *v1alpha2.CAPKCS11Key, cert *x509.Certificate) error {
+	if !pkcs11.ModuleAllowed(key.ModulePath, c.IssuerOptions.PKCS11AllowedModules) {
+		return fmt.Errorf("PKCS#11 module %q is not allowed, it must be listed in the --pkcs11-allowed-modules flag of the controller", key.ModulePath)
+	}
+
+	signer, err := c.pkcs11SignerBuilder(c.resourceNamespace, c.secretsLister, key, cert.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	return pkcs11.VerifySigner(signer)
+}
+
+// checkValidity returns an error if any certificate in the chain has expired
+// or is not yet valid at the given time.
+func checkValidity(now time.Time, chain []*x509.Certificate) error {
+	for _, cert := range chain {
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate %q expired at %s", cert.Subject.String(), cert.NotAfter.Format(time.RFC3339))
+		}
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate %q is not valid until %s", cert.Subject.String(), cert.NotBefore.Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// caStatus returns the status of the CA with the given certificate chain.
+func caStatus(chain []*x509.Certificate) *v1alpha2.CAIssuerStatus {
+	var chainPEM []byte
+	for _, cert := range chain {
+		chainPEM = append(chainPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+
+	notAfter := metav1.NewTime(chain[0].NotAfter)
+	return &v1alpha2.CAIssuerStatus{
+		Subject:  chain[0].Subject.String(),
+		NotAfter: &notAfter,
+		Chain:    chainPEM,
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var fixedClockStart = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func mustCreateCASecret(t *testing.T, name string, notBefore, notAfter time.Time) *corev1.Secret {
+	pk, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM, err := pki.EncodeX509(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodePrivateKey(pk, v1alpha2.PKCS1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return gen.Secret(name,
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		}),
+	)
+}
+
+func TestSetup(t *testing.T) {
+	validSecret := mustCreateCASecret(t, "ca", fixedClockStart.Add(-time.Hour), fixedClockStart.Add(time.Hour))
+	expiredSecret := mustCreateCASecret(t, "ca", fixedClockStart.Add(-time.Hour*2), fixedClockStart.Add(-time.Hour))
+	notYetValidSecret := mustCreateCASecret(t, "ca", fixedClockStart.Add(time.Hour), fixedClockStart.Add(time.Hour*2))
+
+	baseIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerCA(v1alpha2.CAIssuer{SecretName: "ca"}),
+	)
+
+	tests := map[string]struct {
+		issuer *v1alpha2.Issuer
+		secret *corev1.Secret
+
+		expectedReady  cmmeta.ConditionStatus
+		expectedReason string
+		expectedEvents []string
+	}{
+		"should mark the issuer ready if the CA is valid": {
+			issuer:         baseIssuer,
+			secret:         validSecret,
+			expectedReady:  cmmeta.ConditionTrue,
+			expectedReason: successKeyPairVerified,
+			expectedEvents: []string{"Normal KeyPairVerified Signing CA verified"},
+		},
+		"should not record an event if the issuer is already ready": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.AddIssuerCondition(v1alpha2.IssuerCondition{
+					Type:   v1alpha2.IssuerConditionReady,
+					Status: cmmeta.ConditionTrue,
+				}),
+			),
+			secret:         validSecret,
+			expectedReady:  cmmeta.ConditionTrue,
+			expectedReason: successKeyPairVerified,
+		},
+		"should mark the issuer not ready if the CA has expired": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.AddIssuerCondition(v1alpha2.IssuerCondition{
+					Type:   v1alpha2.IssuerConditionReady,
+					Status: cmmeta.ConditionTrue,
+				}),
+			),
+			secret:         expiredSecret,
+			expectedReady:  cmmeta.ConditionFalse,
+			expectedReason: errorCAExpired,
+			expectedEvents: []string{`Warning ErrCAExpired Invalid signing key pair: certificate "CN=test-ca" expired at 2020-06-01T11:00:00Z`},
+		},
+		"should mark the issuer not ready if the CA is not yet valid": {
+			issuer:         baseIssuer,
+			secret:         notYetValidSecret,
+			expectedReady:  cmmeta.ConditionFalse,
+			expectedReason: errorCAExpired,
+			expectedEvents: []string{`Warning ErrCAExpired Invalid signing key pair: certificate "CN=test-ca" is not valid until 2020-06-01T13:00:00Z`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &caFixture{
+				Issuer: test.issuer.DeepCopy(),
+				Builder: &testpkg.Builder{
+					KubeObjects: []runtime.Object{test.secret},
+					Clock:       fakeclock.NewFakeClock(fixedClockStart),
+				},
+			}
+			s.Setup(t)
+			err := s.CA.Setup(s.Ctx)
+			if err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+			s.Finish(t)
+
+			var ready *v1alpha2.IssuerCondition
+			for i, c := range s.Issuer.GetStatus().Conditions {
+				if c.Type == v1alpha2.IssuerConditionReady {
+					ready = &s.Issuer.GetStatus().Conditions[i]
+				}
+			}
+			if ready == nil || ready.Status != test.expectedReady || ready.Reason != test.expectedReason {
+				t.Errorf("unexpected Ready condition, exp=%s/%s got=%+v", test.expectedReady, test.expectedReason, ready)
+			}
+
+			if !reflect.DeepEqual(s.Builder.Events(), test.expectedEvents) {
+				t.Errorf("unexpected events, exp=%v got=%v", test.expectedEvents, s.Builder.Events())
+			}
+
+			// The CA status should be published even if the CA has expired
+			caStatus := s.Issuer.GetStatus().CA
+			if caStatus == nil {
+				t.Fatalf("expected CA status to be set")
+			}
+			if caStatus.Subject != "CN=test-ca" {
+				t.Errorf("unexpected CA subject %q", caStatus.Subject)
+			}
+			if string(caStatus.Chain) != string(test.secret.Data[corev1.TLSCertKey]) {
+				t.Errorf("unexpected CA chain %q", caStatus.Chain)
+			}
+		})
+	}
+}
+
+func TestSetupPKCS11(t *testing.T) {
+	secret := mustCreateCASecret(t, "ca", fixedClockStart.Add(-time.Hour), fixedClockStart.Add(time.Hour))
+	caKey, err := pki.DecodePrivateKeyBytes(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := gen.Issuer("test-issuer",
+		gen.SetIssuerCA(v1alpha2.CAIssuer{
+			SecretName: "ca",
+			PKCS11: &v1alpha2.CAPKCS11Key{
+				ModulePath: "/usr/lib/softhsm/libsofthsm2.so",
+				TokenLabel: "cert-manager",
+				KeyLabel:   "ca-key",
+				PINSecretRef: cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "pin"},
+					Key:                  "pin",
+				},
+			},
+		}),
+	)
+
+	tests := map[string]struct {
+		allowedModules []string
+
+		expectedErr    bool
+		expectedReady  cmmeta.ConditionStatus
+		expectedReason string
+		expectedEvents []string
+	}{
+		"should mark the issuer ready if the PKCS#11 module is allowed": {
+			allowedModules: []string{"/usr/lib/other/libother.so", "/usr/lib/softhsm/libsofthsm2.so"},
+			expectedReady:  cmmeta.ConditionTrue,
+			expectedReason: successKeyPairVerified,
+			expectedEvents: []string{"Normal KeyPairVerified Signing CA verified"},
+		},
+		"should mark the issuer not ready if the PKCS#11 module is not allowed": {
+			allowedModules: []string{"/usr/lib/other/libother.so"},
+			expectedErr:    true,
+			expectedReady:  cmmeta.ConditionFalse,
+			expectedReason: errorGetKeyPair,
+			expectedEvents: []string{`Warning ErrGetKeyPair Error getting keypair for CA issuer: PKCS#11 module "/usr/lib/softhsm/libsofthsm2.so" is not allowed, it must be listed in the --pkcs11-allowed-modules flag of the controller`},
+		},
+		"should mark the issuer not ready if no PKCS#11 modules are allowed": {
+			expectedErr:    true,
+			expectedReady:  cmmeta.ConditionFalse,
+			expectedReason: errorGetKeyPair,
+			expectedEvents: []string{`Warning ErrGetKeyPair Error getting keypair for CA issuer: PKCS#11 module "/usr/lib/softhsm/libsofthsm2.so" is not allowed, it must be listed in the --pkcs11-allowed-modules flag of the controller`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &caFixture{
+				Issuer: issuer.DeepCopy(),
+				Builder: &testpkg.Builder{
+					Context: &controller.Context{
+						RootContext: context.Background(),
+						IssuerOptions: controller.IssuerOptions{
+							PKCS11AllowedModules: test.allowedModules,
+						},
+					},
+					KubeObjects: []runtime.Object{secret},
+					Clock:       fakeclock.NewFakeClock(fixedClockStart),
+				},
+				PreFn: func(t *testing.T, s *caFixture) {
+					s.CA.pkcs11SignerBuilder = func(string, corelisters.SecretLister, *v1alpha2.CAPKCS11Key, crypto.PublicKey) (crypto.Signer, error) {
+						return caKey, nil
+					}
+				},
+			}
+			s.Setup(t)
+			err := s.CA.Setup(s.Ctx)
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected no error but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected an error but got none")
+			}
+			s.Finish(t)
+
+			var ready *v1alpha2.IssuerCondition
+			for i, c := range s.Issuer.GetStatus().Conditions {
+				if c.Type == v1alpha2.IssuerConditionReady {
+					ready = &s.Issuer.GetStatus().Conditions[i]
+				}
+			}
+			if ready == nil || ready.Status != test.expectedReady || ready.Reason != test.expectedReason {
+				t.Errorf("unexpected Ready condition, exp=%s/%s got=%+v", test.expectedReady, test.expectedReason, ready)
+			}
+
+			if !reflect.DeepEqual(s.Builder.Events(), test.expectedEvents) {
+				t.Errorf("unexpected events, exp=%v got=%v", test.expectedEvents, s.Builder.Events())
+			}
+		})
+	}
+}
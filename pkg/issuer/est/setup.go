@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package est
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	successESTVerified = "ESTVerified"
+	messageESTVerified = "Fetched CA certificates from EST server"
+
+	errorESTInit    = "ErrInitClient"
+	errorESTCACerts = "ErrGetCACerts"
+
+	messageESTInitFailed    = "Failed to initialise EST client: "
+	messageESTCACertsFailed = "Failed to fetch CA certificates from EST server: "
+)
+
+// Setup verifies that the EST server is reachable with the issuer's
+// credentials by fetching its current CA certificates.
+func (e *EST) Setup(ctx context.Context) error {
+	client, err := e.clientBuilder(e.resourceNamespace, e.secretsLister, e.issuer)
+	if err != nil {
+		s := messageESTInitFailed + err.Error()
+		klog.V(4).Infof("%s: %s", e.issuer.GetObjectMeta().Name, s)
+		apiutil.SetIssuerCondition(e.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorESTInit, s)
+		return err
+	}
+
+	if _, err := client.CACerts(ctx); err != nil {
+		s := messageESTCACertsFailed + err.Error()
+		klog.V(4).Infof("%s: %s", e.issuer.GetObjectMeta().Name, s)
+		apiutil.SetIssuerCondition(e.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorESTCACerts, s)
+		return fmt.Errorf("error verifying EST server: %s", err.Error())
+	}
+
+	// If it does not already have a 'ready' condition, we'll also log an event
+	// to make it really clear to users that this Issuer is ready.
+	if !apiutil.IssuerHasCondition(e.issuer, v1alpha2.IssuerCondition{
+		Type:   v1alpha2.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		e.Recorder.Eventf(e.issuer, corev1.EventTypeNormal, "Ready", "Verified issuer with EST server")
+	}
+
+	klog.Info(messageESTVerified)
+	apiutil.SetIssuerCondition(e.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionTrue, successESTVerified, messageESTVerified)
+
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetescsr
+
+import (
+	"context"
+	"fmt"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	successReady = "IsReady"
+)
+
+// Setup marks the issuer as ready. Whether the signer exists and will sign
+// requests can only be determined by the CertificateSigningRequests that are
+// created for it.
+func (k *KubernetesCSR) Setup(ctx context.Context) error {
+	apiutil.SetIssuerCondition(k.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionTrue, successReady,
+		fmt.Sprintf("Requesting certificates from Kubernetes signer %q", k.issuer.GetSpec().KubernetesCSR.SignerName))
+	return nil
+}
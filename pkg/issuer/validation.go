@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// PolicyRecheckInterval is how often Certificates that do not comply with
+// the policy of their issuer are checked again.
+const PolicyRecheckInterval = time.Minute * 5
+
+// CertificateValidator is implemented by issuers that enforce a policy on the
+// Certificates they issue which can be checked before a CertificateRequest is
+// created, such as the zone policy of Venafi issuers.
+type CertificateValidator interface {
+	// ValidateCertificateForIssuer returns the ways in which the Certificate
+	// does not comply with the policy of the issuer. An error is returned if
+	// the policy could not be checked.
+	ValidateCertificateForIssuer(ctx context.Context, crt *cmapi.Certificate) (field.ErrorList, error)
+}
+
+// ValidateCertificateForIssuerFunc validates a Certificate against the policy
+// of the issuer it references.
+type ValidateCertificateForIssuerFunc func(ctx context.Context, crt *cmapi.Certificate) (field.ErrorList, error)
+
+var (
+	validatorTypes     = sets.NewString()
+	validatorTypesLock sync.RWMutex
+)
+
+// RegisterCertificateValidator records that issuers of the named type
+// implement CertificateValidator. Issuers of other types are not constructed
+// to validate Certificates.
+func RegisterCertificateValidator(name string) {
+	validatorTypesLock.Lock()
+	defer validatorTypesLock.Unlock()
+	validatorTypes.Insert(name)
+}
+
+func isCertificateValidator(name string) bool {
+	validatorTypesLock.RLock()
+	defer validatorTypesLock.RUnlock()
+	return validatorTypes.Has(name)
+}
+
+// NewCertificateValidator returns a ValidateCertificateForIssuerFunc that
+// validates Certificates using the implementation of the issuer they
+// reference, if it is a CertificateValidator.
+// Certificates referencing issuers outside of the cert-manager API group,
+// issuers that do not exist yet, or issuers of types that have not been
+// registered with RegisterCertificateValidator are not validated.
+func NewCertificateValidator(helper Helper, factory Factory) ValidateCertificateForIssuerFunc {
+	return func(ctx context.Context, crt *cmapi.Certificate) (field.ErrorList, error) {
+		if !(crt.Spec.IssuerRef.Group == "" || crt.Spec.IssuerRef.Group == certmanager.GroupName) {
+			return nil, nil
+		}
+
+		issuerObj, err := helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		issuerType, err := apiutil.NameForIssuer(issuerObj)
+		if err != nil {
+			return nil, err
+		}
+		if !isCertificateValidator(issuerType) {
+			return nil, nil
+		}
+
+		i, err := factory.IssuerFor(issuerObj)
+		if err != nil {
+			return nil, err
+		}
+
+		validator, ok := i.(CertificateValidator)
+		if !ok {
+			return nil, nil
+		}
+
+		return validator.ValidateCertificateForIssuer(ctx, crt)
+	}
+}
+
+// PolicyViolations returns the ways in which the Certificate violates the
+// policy of its issuer. Errors checking the policy are logged rather than
+// returned, as the issuer will still validate the request when it is sent.
+func PolicyViolations(ctx context.Context, validate ValidateCertificateForIssuerFunc, crt *cmapi.Certificate) field.ErrorList {
+	if validate == nil {
+		return nil
+	}
+
+	el, err := validate(ctx, crt)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to validate certificate against issuer policy")
+		return nil
+	}
+
+	return el
+}
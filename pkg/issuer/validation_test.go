@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+type fakeHelper func(ref cmmeta.ObjectReference, ns string) (v1alpha2.GenericIssuer, error)
+
+func (f fakeHelper) GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (v1alpha2.GenericIssuer, error) {
+	return f(ref, ns)
+}
+
+type fakeFactory func(v1alpha2.GenericIssuer) (Interface, error)
+
+func (f fakeFactory) IssuerFor(iss v1alpha2.GenericIssuer) (Interface, error) {
+	return f(iss)
+}
+
+type fakeIssuer struct{}
+
+func (fakeIssuer) Setup(context.Context) error { return nil }
+
+type fakeValidatingIssuer struct {
+	fakeIssuer
+	errs field.ErrorList
+}
+
+func (f fakeValidatingIssuer) ValidateCertificateForIssuer(context.Context, *v1alpha2.Certificate) (field.ErrorList, error) {
+	return f.errs, nil
+}
+
+func TestNewCertificateValidator(t *testing.T) {
+	RegisterCertificateValidator(apiutil.IssuerVenafi)
+
+	policyErrs := field.ErrorList{field.Invalid(field.NewPath("spec", "commonName"), "example.com", "not allowed")}
+	issuerFound := fakeHelper(func(cmmeta.ObjectReference, string) (v1alpha2.GenericIssuer, error) {
+		return gen.Issuer("issuer", gen.SetIssuerVenafi(v1alpha2.VenafiIssuer{})), nil
+	})
+
+	tests := map[string]struct {
+		crt     *v1alpha2.Certificate
+		helper  Helper
+		factory Factory
+
+		expectedErrs field.ErrorList
+		expectedErr  bool
+	}{
+		"should validate using an issuer that is a CertificateValidator": {
+			crt:     gen.Certificate("test", gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer"})),
+			helper:  issuerFound,
+			factory: fakeFactory(func(v1alpha2.GenericIssuer) (Interface, error) { return fakeValidatingIssuer{errs: policyErrs}, nil }),
+
+			expectedErrs: policyErrs,
+		},
+		"should not validate using an issuer that is not a CertificateValidator": {
+			crt:     gen.Certificate("test", gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer"})),
+			helper:  issuerFound,
+			factory: fakeFactory(func(v1alpha2.GenericIssuer) (Interface, error) { return fakeIssuer{}, nil }),
+		},
+		"should not construct issuers of types that are not registered as CertificateValidators": {
+			crt: gen.Certificate("test", gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer"})),
+			helper: fakeHelper(func(cmmeta.ObjectReference, string) (v1alpha2.GenericIssuer, error) {
+				return gen.Issuer("issuer", gen.SetIssuerCA(v1alpha2.CAIssuer{})), nil
+			}),
+			factory: fakeFactory(func(v1alpha2.GenericIssuer) (Interface, error) { return nil, errors.New("unexpected call") }),
+		},
+		"should not validate if the issuer does not exist": {
+			crt: gen.Certificate("test", gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer"})),
+			helper: fakeHelper(func(cmmeta.ObjectReference, string) (v1alpha2.GenericIssuer, error) {
+				return nil, apierrors.NewNotFound(v1alpha2.Resource("issuers"), "issuer")
+			}),
+		},
+		"should not validate Certificates for external issuers": {
+			crt: gen.Certificate("test", gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer", Group: "example.com"})),
+		},
+		"should return an error if the issuer cannot be constructed": {
+			crt:     gen.Certificate("test", gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer"})),
+			helper:  issuerFound,
+			factory: fakeFactory(func(v1alpha2.GenericIssuer) (Interface, error) { return nil, errors.New("not registered") }),
+
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs, err := NewCertificateValidator(test.helper, test.factory)(context.TODO(), test.crt)
+			if err != nil != test.expectedErr {
+				t.Fatalf("expected error=%t, got: %v", test.expectedErr, err)
+			}
+			if !reflect.DeepEqual(errs, test.expectedErrs) {
+				t.Errorf("unexpected policy errors, exp=%v got=%v", test.expectedErrs, errs)
+			}
+		})
+	}
+}
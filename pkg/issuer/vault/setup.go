@@ -20,7 +20,7 @@ import (
 	"context"
 	"fmt"
 
-	"k8s.io/klog"
+	"k8s.io/klog/v2"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
@@ -39,8 +39,9 @@ const (
 	messageVaultStatusVerificationFailed = "Vault is not initialized or is sealed"
 	messageVaultConfigRequired           = "Vault config cannot be empty"
 	messageServerAndPathRequired         = "Vault server and path are required fields"
-	messageAuthFieldsRequired            = "Vault tokenSecretRef, appRole, or kubernetes is required"
+	messageAuthFieldsRequired            = "Vault tokenSecretRef, appRole, kubernetes, tlsCert or jwt is required"
 	messageAuthFieldRequired             = "Multiple auth methods cannot be set on the same Vault issuer"
+	messageClientCertRequired            = "Vault clientCertSecretRef is required when using tlsCert auth"
 )
 
 func (v *Vault) Setup(ctx context.Context) error {
@@ -61,18 +62,25 @@ func (v *Vault) Setup(ctx context.Context) error {
 	tokenAuth := v.issuer.GetSpec().Vault.Auth.TokenSecretRef
 	appRoleAuth := v.issuer.GetSpec().Vault.Auth.AppRole
 	kubeAuth := v.issuer.GetSpec().Vault.Auth.Kubernetes
+	tlsCertAuth := v.issuer.GetSpec().Vault.Auth.TLSCert
+	jwtAuth := v.issuer.GetSpec().Vault.Auth.JWT
+
+	authMethods := 0
+	for _, set := range []bool{tokenAuth != nil, appRoleAuth != nil, kubeAuth != nil, tlsCertAuth != nil, jwtAuth != nil} {
+		if set {
+			authMethods++
+		}
+	}
 
 	// check if at least one auth method is specified.
-	if tokenAuth == nil && appRoleAuth == nil && kubeAuth == nil {
+	if authMethods == 0 {
 		klog.Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageAuthFieldsRequired)
 		apiutil.SetIssuerCondition(v.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageAuthFieldsRequired)
 		return nil
 	}
 
 	// check only one auth method set
-	if (tokenAuth != nil && appRoleAuth != nil) ||
-		(tokenAuth != nil && kubeAuth != nil) ||
-		(appRoleAuth != nil && kubeAuth != nil) {
+	if authMethods > 1 {
 		klog.Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageAuthFieldRequired)
 		apiutil.SetIssuerCondition(v.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageAuthFieldRequired)
 		return nil
@@ -99,7 +107,22 @@ func (v *Vault) Setup(ctx context.Context) error {
 		return nil
 	}
 
-	client, err := vaultinternal.New(v.resourceNamespace, v.secretsLister, v.issuer)
+	// check if all mandatory Vault JWT fields are set.
+	if jwtAuth != nil && (len(jwtAuth.ServiceAccountRef.Name) == 0 || len(jwtAuth.Role) == 0) {
+		klog.Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageAuthFieldRequired)
+		apiutil.SetIssuerCondition(v.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageAuthFieldRequired)
+		return nil
+	}
+
+	// check that a client certificate is configured for TLS certificate auth.
+	if tlsCertAuth != nil && v.issuer.GetSpec().Vault.ClientCertSecretRef == nil {
+		klog.Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageClientCertRequired)
+		apiutil.SetIssuerCondition(v.issuer, v1alpha2.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageClientCertRequired)
+		return nil
+	}
+
+	client, err := vaultinternal.New(v.resourceNamespace, v.secretsLister,
+		v.Client.CoreV1().ServiceAccounts(v.resourceNamespace).CreateToken, v.IssuerOptions.VaultTokenCache, v.issuer)
 	if err != nil {
 		s := messageVaultClientInitFailed + err.Error()
 		klog.V(4).Infof("%s: %s", v.issuer.GetObjectMeta().Name, s)
@@ -0,0 +1,264 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tokencache implements a cache of the tokens obtained by logging in
+// to Vault, shared between the components of cert-manager that use Vault
+// issuers.
+package tokencache
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"k8s.io/utils/clock"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/metrics"
+)
+
+const (
+	tokenRenewSelfPath  = "/v1/auth/token/renew-self"
+	tokenLookupSelfPath = "/v1/auth/token/lookup-self"
+
+	authOperationLogin = "login"
+	authOperationRenew = "renew"
+
+	authStatusSuccess = "success"
+	authStatusError   = "error"
+)
+
+// Client is the subset of the Vault client used to renew and look up tokens.
+type Client interface {
+	NewRequest(method, requestPath string) *vault.Request
+	RawRequest(r *vault.Request) (*vault.Response, error)
+	SetToken(v string)
+}
+
+// Cache caches the Vault tokens obtained by logging in to Vault, so that
+// a new token is not created every time an Issuer is used. Tokens are cached
+// per Issuer, renewed once two thirds of their TTL has passed, and obtained
+// again by logging in if they cannot be renewed.
+// Tokens read directly from a Secret using `tokenSecretRef` are not cached.
+// At most one token is cached per Issuer: the token is dropped when the
+// Issuer's Vault configuration changes, or when Remove is called after the
+// Issuer has been deleted.
+type Cache struct {
+	clock   clock.Clock
+	metrics *metrics.Metrics
+
+	lock sync.Mutex
+	// tokens holds the cached token of each Issuer, keyed by the Issuer's
+	// namespace and name.
+	tokens map[string]*cachedToken
+}
+
+type cachedToken struct {
+	// lock is held whilst logging in or renewing the token, so that concurrent
+	// users of the same Issuer do not all log in at once.
+	lock sync.Mutex
+
+	// specHash is the hash of the Vault configuration of the Issuer that the
+	// token was obtained for.
+	specHash string
+
+	token     string
+	renewable bool
+	// renewAt is the time after which the token should be renewed before use.
+	// It is zero for tokens that do not expire.
+	renewAt time.Time
+	// expiresAt is the time after which the token can no longer be used.
+	expiresAt time.Time
+}
+
+// New returns an empty Cache. The number of login and renewal requests made
+// are recorded using m, if it is not nil.
+func New(c clock.Clock, m *metrics.Metrics) *Cache {
+	return &Cache{
+		clock:   c,
+		metrics: m,
+		tokens:  make(map[string]*cachedToken),
+	}
+}
+
+// SetToken sets a valid token for the issuer on client. A cached token is
+// used if there is one, renewing it first if required. Otherwise login is
+// called to obtain a new token, which is then cached.
+func (c *Cache) SetToken(client Client, issuer v1alpha2.GenericIssuer, login func() (string, error)) error {
+	key := issuerKey(issuer)
+	specHash, err := vaultSpecHash(issuer)
+	if err != nil {
+		return err
+	}
+
+	entry := c.entry(key, specHash)
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+
+	now := c.clock.Now()
+	if entry.token != "" {
+		if entry.renewAt.IsZero() || now.Before(entry.renewAt) {
+			client.SetToken(entry.token)
+			return nil
+		}
+		if entry.renewable && now.Before(entry.expiresAt) {
+			client.SetToken(entry.token)
+			secret, err := c.renew(client)
+			if err == nil {
+				c.updateEntry(entry, entry.token, secret)
+				return nil
+			}
+		}
+	}
+
+	token, err := login()
+	c.observe(authOperationLogin, err)
+	if err != nil {
+		c.forget(key, entry)
+		return err
+	}
+	client.SetToken(token)
+
+	secret, err := c.lookup(client)
+	if err != nil {
+		// The token can still be used, but as its TTL is unknown it is not
+		// cached.
+		c.forget(key, entry)
+		return nil
+	}
+	c.updateEntry(entry, token, secret)
+
+	return nil
+}
+
+// Invalidate removes the cached token for the issuer, so that the next call
+// to SetToken will log in again.
+func (c *Cache) Invalidate(issuer v1alpha2.GenericIssuer) {
+	c.Remove(issuer.GetObjectMeta().Namespace, issuer.GetObjectMeta().Name)
+}
+
+// Remove removes the cached token of the Issuer with the given namespace and
+// name. It should be called once an Issuer has been deleted. The namespace
+// of ClusterIssuers is empty.
+func (c *Cache) Remove(namespace, name string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.tokens, namespace+"/"+name)
+}
+
+// entry returns the cache entry for the issuer with the given key. If the
+// cached entry was created for a different Vault configuration, it is
+// replaced with an empty entry.
+func (c *Cache) entry(key, specHash string) *cachedToken {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.tokens[key]
+	if !ok || entry.specHash != specHash {
+		entry = &cachedToken{specHash: specHash}
+		c.tokens[key] = entry
+	}
+	return entry
+}
+
+// forget removes entry from the cache, unless it has already been replaced.
+func (c *Cache) forget(key string, entry *cachedToken) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.tokens[key] == entry {
+		delete(c.tokens, key)
+	}
+}
+
+func (c *Cache) updateEntry(entry *cachedToken, token string, secret *vault.Secret) {
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		ttl = 0
+	}
+	renewable, err := secret.TokenIsRenewable()
+	if err != nil {
+		renewable = false
+	}
+
+	now := c.clock.Now()
+	entry.token = token
+	entry.renewable = renewable
+	if ttl > 0 {
+		entry.renewAt = now.Add(ttl * 2 / 3)
+		entry.expiresAt = now.Add(ttl)
+	} else {
+		entry.renewAt = time.Time{}
+		entry.expiresAt = time.Time{}
+	}
+}
+
+// renew renews the token currently set on client.
+func (c *Cache) renew(client Client) (*vault.Secret, error) {
+	secret, err := doTokenRequest(client, "POST", tokenRenewSelfPath)
+	c.observe(authOperationRenew, err)
+	return secret, err
+}
+
+// lookup returns the properties of the token currently set on client.
+func (c *Cache) lookup(client Client) (*vault.Secret, error) {
+	return doTokenRequest(client, "GET", tokenLookupSelfPath)
+}
+
+func (c *Cache) observe(operation string, err error) {
+	if c.metrics == nil {
+		return
+	}
+	status := authStatusSuccess
+	if err != nil {
+		status = authStatusError
+	}
+	c.metrics.IncrementVaultAuthRequestCount(operation, status)
+}
+
+func doTokenRequest(client Client, method, path string) (*vault.Secret, error) {
+	request := client.NewRequest(method, path)
+	resp, err := client.RawRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("error calling Vault server: %s", err.Error())
+	}
+
+	defer resp.Body.Close()
+	vaultResult := vault.Secret{}
+	if err := resp.DecodeJSON(&vaultResult); err != nil {
+		return nil, fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	return &vaultResult, nil
+}
+
+// issuerKey returns the key under which the token of the issuer is cached.
+func issuerKey(issuer v1alpha2.GenericIssuer) string {
+	meta := issuer.GetObjectMeta()
+	return meta.Namespace + "/" + meta.Name
+}
+
+// vaultSpecHash returns a hash of the issuer's Vault configuration, so that a
+// token is not reused after the issuer's authentication settings have changed.
+func vaultSpecHash(issuer v1alpha2.GenericIssuer) (string, error) {
+	spec, err := json.Marshal(issuer.GetSpec().Vault)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute Vault token cache key: %s", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(spec)), nil
+}
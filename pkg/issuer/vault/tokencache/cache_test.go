@@ -0,0 +1,270 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokencache
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+// fakeClient records the paths of the requests made to Vault, and responds
+// to them using responses, which is keyed by request path.
+type fakeClient struct {
+	responses map[string]string
+	errs      map[string]error
+
+	requests []string
+	token    string
+}
+
+func (c *fakeClient) NewRequest(method, requestPath string) *vault.Request {
+	return &vault.Request{Method: method, URL: &url.URL{Path: requestPath}}
+}
+
+func (c *fakeClient) RawRequest(r *vault.Request) (*vault.Response, error) {
+	c.requests = append(c.requests, r.URL.Path)
+	if err := c.errs[r.URL.Path]; err != nil {
+		return nil, err
+	}
+	return &vault.Response{
+		Response: &http.Response{
+			Body: ioutil.NopCloser(strings.NewReader(c.responses[r.URL.Path])),
+		},
+	}, nil
+}
+
+func (c *fakeClient) SetToken(v string) {
+	c.token = v
+}
+
+func TestCacheSetToken(t *testing.T) {
+	const (
+		lookupRenewable    = `{"data":{"ttl":300,"renewable":true}}`
+		lookupNotRenewable = `{"data":{"ttl":300,"renewable":false}}`
+		renewResponse      = `{"auth":{"client_token":"login-token","lease_duration":300,"renewable":true}}`
+	)
+
+	issuer := gen.Issuer("vault-issuer",
+		gen.SetIssuerVault(v1alpha2.VaultIssuer{
+			Server: "https://vault.example.com",
+			Path:   "pki/sign/example",
+			Auth: v1alpha2.VaultAuth{
+				AppRole: &v1alpha2.VaultAppRole{RoleId: "role"},
+			},
+		}),
+	)
+
+	tests := map[string]struct {
+		// responses and errs are returned by the fake Vault client, keyed by
+		// request path
+		responses map[string]string
+		errs      map[string]error
+		// loginErr is returned by the login func if set
+		loginErr error
+		// setup is called with the cache before the clock is advanced by
+		// elapsed and the token is set
+		setup   func(c *Cache)
+		elapsed time.Duration
+
+		expectedToken    string
+		expectedLogins   int
+		expectedRequests []string
+		expectedErr      bool
+	}{
+		"should log in and look up the token if nothing is cached": {
+			responses:        map[string]string{tokenLookupSelfPath: lookupRenewable},
+			expectedToken:    "login-token",
+			expectedLogins:   1,
+			expectedRequests: []string{tokenLookupSelfPath},
+		},
+		"should use a cached token that does not need renewing": {
+			responses: map[string]string{tokenLookupSelfPath: lookupRenewable},
+			setup: func(c *Cache) {
+				mustSetToken(t, c, issuer, &fakeClient{responses: map[string]string{tokenLookupSelfPath: lookupRenewable}})
+			},
+			elapsed:        time.Minute,
+			expectedToken:  "login-token",
+			expectedLogins: 0,
+		},
+		"should renew a cached token once two thirds of its TTL has passed": {
+			responses: map[string]string{tokenRenewSelfPath: renewResponse},
+			setup: func(c *Cache) {
+				mustSetToken(t, c, issuer, &fakeClient{responses: map[string]string{tokenLookupSelfPath: lookupRenewable}})
+			},
+			elapsed:          time.Second * 201,
+			expectedToken:    "login-token",
+			expectedLogins:   0,
+			expectedRequests: []string{tokenRenewSelfPath},
+		},
+		"should log in again if renewing the cached token fails": {
+			responses: map[string]string{tokenLookupSelfPath: lookupRenewable},
+			errs:      map[string]error{tokenRenewSelfPath: errors.New("permission denied")},
+			setup: func(c *Cache) {
+				mustSetToken(t, c, issuer, &fakeClient{responses: map[string]string{tokenLookupSelfPath: lookupRenewable}})
+			},
+			elapsed:          time.Second * 201,
+			expectedToken:    "login-token",
+			expectedLogins:   1,
+			expectedRequests: []string{tokenRenewSelfPath, tokenLookupSelfPath},
+		},
+		"should log in again if the cached token is not renewable": {
+			responses: map[string]string{tokenLookupSelfPath: lookupNotRenewable},
+			setup: func(c *Cache) {
+				mustSetToken(t, c, issuer, &fakeClient{responses: map[string]string{tokenLookupSelfPath: lookupNotRenewable}})
+			},
+			elapsed:          time.Second * 201,
+			expectedToken:    "login-token",
+			expectedLogins:   1,
+			expectedRequests: []string{tokenLookupSelfPath},
+		},
+		"should log in again if the cached token has been invalidated": {
+			responses: map[string]string{tokenLookupSelfPath: lookupRenewable},
+			setup: func(c *Cache) {
+				mustSetToken(t, c, issuer, &fakeClient{responses: map[string]string{tokenLookupSelfPath: lookupRenewable}})
+				c.Invalidate(issuer)
+			},
+			expectedToken:    "login-token",
+			expectedLogins:   1,
+			expectedRequests: []string{tokenLookupSelfPath},
+		},
+		"should log in again if the issuer has been removed": {
+			responses: map[string]string{tokenLookupSelfPath: lookupRenewable},
+			setup: func(c *Cache) {
+				mustSetToken(t, c, issuer, &fakeClient{responses: map[string]string{tokenLookupSelfPath: lookupRenewable}})
+				c.Remove(issuer.Namespace, issuer.Name)
+			},
+			expectedToken:    "login-token",
+			expectedLogins:   1,
+			expectedRequests: []string{tokenLookupSelfPath},
+		},
+		"should not use a token cached for a different Vault configuration": {
+			responses: map[string]string{tokenLookupSelfPath: lookupRenewable},
+			setup: func(c *Cache) {
+				other := issuer.DeepCopy()
+				other.Spec.Vault.Auth.AppRole.RoleId = "other-role"
+				mustSetToken(t, c, other, &fakeClient{responses: map[string]string{tokenLookupSelfPath: lookupRenewable}})
+			},
+			expectedToken:    "login-token",
+			expectedLogins:   1,
+			expectedRequests: []string{tokenLookupSelfPath},
+		},
+		"should return an error if logging in fails": {
+			loginErr:       errors.New("permission denied"),
+			expectedLogins: 1,
+			expectedErr:    true,
+		},
+		"should still use the token if looking it up fails": {
+			errs:             map[string]error{tokenLookupSelfPath: errors.New("permission denied")},
+			expectedToken:    "login-token",
+			expectedLogins:   1,
+			expectedRequests: []string{tokenLookupSelfPath},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			clock := fakeclock.NewFakeClock(time.Now())
+			c := New(clock, nil)
+			if test.setup != nil {
+				test.setup(c)
+			}
+			clock.Step(test.elapsed)
+
+			client := &fakeClient{responses: test.responses, errs: test.errs}
+			logins := 0
+			err := c.SetToken(client, issuer, func() (string, error) {
+				logins++
+				if test.loginErr != nil {
+					return "", test.loginErr
+				}
+				return "login-token", nil
+			})
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+
+			if client.token != test.expectedToken {
+				t.Errorf("unexpected token, exp=%q got=%q", test.expectedToken, client.token)
+			}
+			if logins != test.expectedLogins {
+				t.Errorf("unexpected number of logins, exp=%d got=%d", test.expectedLogins, logins)
+			}
+			if !reflect.DeepEqual(client.requests, test.expectedRequests) {
+				t.Errorf("unexpected requests, exp=%v got=%v", test.expectedRequests, client.requests)
+			}
+		})
+	}
+}
+
+func mustSetToken(t *testing.T, c *Cache, issuer v1alpha2.GenericIssuer, client *fakeClient) {
+	if err := c.SetToken(client, issuer, func() (string, error) { return "login-token", nil }); err != nil {
+		t.Fatalf("failed to set token: %v", err)
+	}
+}
+
+func TestCacheEvictsStaleTokens(t *testing.T) {
+	issuer := gen.Issuer("vault-issuer",
+		gen.SetIssuerVault(v1alpha2.VaultIssuer{
+			Auth: v1alpha2.VaultAuth{
+				AppRole: &v1alpha2.VaultAppRole{RoleId: "role"},
+			},
+		}),
+	)
+	issuer.Namespace = "default"
+	updated := issuer.DeepCopy()
+	updated.Spec.Vault.Auth.AppRole.RoleId = "other-role"
+	clusterIssuer := gen.ClusterIssuer("vault-issuer",
+		gen.SetIssuerVault(v1alpha2.VaultIssuer{
+			Auth: v1alpha2.VaultAuth{
+				AppRole: &v1alpha2.VaultAppRole{RoleId: "role"},
+			},
+		}),
+	)
+
+	c := New(fakeclock.NewFakeClock(time.Now()), nil)
+	client := &fakeClient{responses: map[string]string{tokenLookupSelfPath: `{"data":{"ttl":300}}`}}
+
+	mustSetToken(t, c, issuer, client)
+	mustSetToken(t, c, updated, client)
+	mustSetToken(t, c, clusterIssuer, client)
+	if len(c.tokens) != 2 {
+		t.Errorf("expected the token cached for the previous configuration of the issuer to be evicted, got %d cached tokens", len(c.tokens))
+	}
+
+	c.Remove(issuer.Namespace, issuer.Name)
+	if _, ok := c.tokens[issuerKey(updated)]; ok {
+		t.Errorf("expected the token of the removed issuer to be evicted")
+	}
+	if _, ok := c.tokens[issuerKey(clusterIssuer)]; !ok {
+		t.Errorf("expected the token of a ClusterIssuer with the same name to be kept")
+	}
+}
@@ -21,7 +21,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/klog"
+	"k8s.io/klog/v2"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
@@ -29,7 +29,7 @@ import (
 )
 
 func (v *Venafi) Setup(ctx context.Context) error {
-	client, err := v.clientBuilder(v.resourceNamespace, v.secretsLister, v.issuer)
+	client, err := v.clientBuilder(v.resourceNamespace, v.secretsLister, v.secretsClient, v.issuer)
 	if err != nil {
 		return err
 	}
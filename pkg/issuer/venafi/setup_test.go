@@ -20,6 +20,7 @@ import (
 	"errors"
 	"testing"
 
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
@@ -34,12 +35,12 @@ import (
 func TestSetup(t *testing.T) {
 	baseIssuer := gen.Issuer("test-issuer")
 
-	failingClientBuilder := func(string, corelisters.SecretLister,
+	failingClientBuilder := func(string, corelisters.SecretLister, corev1client.SecretsGetter,
 		cmapi.GenericIssuer) (internalvenafi.Interface, error) {
 		return nil, errors.New("this is an error")
 	}
 
-	failingPingClient := func(string, corelisters.SecretLister,
+	failingPingClient := func(string, corelisters.SecretLister, corev1client.SecretsGetter,
 		cmapi.GenericIssuer) (internalvenafi.Interface, error) {
 		return &internalvenafifake.Venafi{
 			PingFn: func() error {
@@ -48,7 +49,7 @@ func TestSetup(t *testing.T) {
 		}, nil
 	}
 
-	pingClient := func(string, corelisters.SecretLister,
+	pingClient := func(string, corelisters.SecretLister, corev1client.SecretsGetter,
 		cmapi.GenericIssuer) (internalvenafi.Interface, error) {
 		return &internalvenafifake.Venafi{
 			PingFn: func() error {
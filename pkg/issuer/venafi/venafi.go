@@ -17,6 +17,11 @@ limitations under the License.
 package venafi
 
 import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
@@ -39,6 +44,7 @@ type Venafi struct {
 	*controller.Context
 
 	secretsLister corelisters.SecretLister
+	secretsClient corev1client.SecretsGetter
 
 	// Namespace in which to read resources related to this Issuer from.
 	// For Issuers, this will be the namespace of the Issuer.
@@ -46,18 +52,53 @@ type Venafi struct {
 	resourceNamespace string
 
 	clientBuilder venafi.VenafiClientBuilder
+
+	policyChecker *venafi.PolicyChecker
+}
+
+var _ issuer.CertificateValidator = &Venafi{}
+
+var (
+	// policyCheckers are shared by all Venafi issuers created with the same
+	// controller context, as a new issuer is created each time one is used
+	// but zone configurations should be cached between uses.
+	policyCheckers     = make(map[*controller.Context]*venafi.PolicyChecker)
+	policyCheckersLock sync.Mutex
+)
+
+func policyCheckerFor(ctx *controller.Context, secretsLister corelisters.SecretLister, secretsClient corev1client.SecretsGetter) *venafi.PolicyChecker {
+	policyCheckersLock.Lock()
+	defer policyCheckersLock.Unlock()
+	checker, ok := policyCheckers[ctx]
+	if !ok {
+		checker = venafi.NewPolicyChecker(secretsLister, secretsClient, ctx.Clock)
+		policyCheckers[ctx] = checker
+	}
+	return checker
 }
 
 func NewVenafi(ctx *controller.Context, issuer cmapi.GenericIssuer) (issuer.Interface, error) {
+	secretsLister := ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister()
+	secretsClient := ctx.Client.CoreV1()
+
 	return &Venafi{
 		issuer:            issuer,
-		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		secretsLister:     secretsLister,
+		secretsClient:     secretsClient,
 		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
 		clientBuilder:     venafi.New,
+		policyChecker:     policyCheckerFor(ctx, secretsLister, secretsClient),
 		Context:           ctx,
 	}, nil
 }
 
+// ValidateCertificateForIssuer validates the Certificate against the policy
+// of the issuer's Venafi zone.
+func (v *Venafi) ValidateCertificateForIssuer(ctx context.Context, crt *cmapi.Certificate) (field.ErrorList, error) {
+	return v.policyChecker.ValidateCertificate(crt, v.issuer, v.resourceNamespace)
+}
+
 func init() {
 	issuer.RegisterIssuer(apiutil.IssuerVenafi, NewVenafi)
+	issuer.RegisterCertificateValidator(apiutil.IssuerVenafi)
 }
@@ -21,6 +21,7 @@ limitations under the License.
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // controller_sync_call_count{"controller"}
+// vault_auth_request_count{"operation", "status"}
 package metrics
 
 import (
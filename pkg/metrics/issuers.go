@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// UpdateIssuer will update the Issuer or ClusterIssuer metrics with its Ready
+// condition and, if known, the expiry of its CA certificate.
+func (m *Metrics) UpdateIssuer(kind string, iss cmapi.GenericIssuer) {
+	meta := iss.GetObjectMeta()
+	labels := prometheus.Labels{
+		"name":      meta.Name,
+		"namespace": meta.Namespace,
+		"kind":      kind,
+	}
+
+	m.updateIssuerReadyStatus(labels, iss)
+
+	status := iss.GetStatus()
+	if status.CA == nil || status.CA.NotAfter == nil {
+		// Don't expose an expiry time for issuers without a known CA, as a
+		// zero value would look like an expired CA
+		m.issuerCAExpiryTimeSeconds.Delete(labels)
+		return
+	}
+
+	m.issuerCAExpiryTimeSeconds.With(labels).Set(float64(status.CA.NotAfter.Unix()))
+}
+
+func (m *Metrics) updateIssuerReadyStatus(labels prometheus.Labels, iss cmapi.GenericIssuer) {
+	current := cmmeta.ConditionUnknown
+	for _, c := range iss.GetStatus().Conditions {
+		if c.Type == cmapi.IssuerConditionReady {
+			current = c.Status
+			break
+		}
+	}
+
+	for _, condition := range readyConditionStatuses {
+		value := 0.0
+
+		if current == condition {
+			value = 1.0
+		}
+
+		m.issuerReadyStatus.With(prometheus.Labels{
+			"name":      labels["name"],
+			"namespace": labels["namespace"],
+			"kind":      labels["kind"],
+			"condition": string(condition),
+		}).Set(value)
+	}
+}
+
+// RemoveIssuer will delete the metrics of the Issuer or ClusterIssuer with
+// the given name and namespace from continuing to be exposed.
+func (m *Metrics) RemoveIssuer(kind, namespace, name string) {
+	m.issuerCAExpiryTimeSeconds.DeleteLabelValues(name, namespace, kind)
+	for _, condition := range readyConditionStatuses {
+		m.issuerReadyStatus.DeleteLabelValues(name, namespace, kind, string(condition))
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logtesting "github.com/jetstack/cert-manager/pkg/logs/testing"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+const issuerCAExpiryMetadata = `
+	# HELP certmanager_issuer_ca_expiration_timestamp_seconds The date after which the CA certificate of the Issuer or ClusterIssuer expires. Expressed as a Unix Epoch Time.
+	# TYPE certmanager_issuer_ca_expiration_timestamp_seconds gauge
+`
+
+const issuerReadyMetadata = `
+	# HELP certmanager_issuer_ready_status The ready status of the Issuer or ClusterIssuer.
+	# TYPE certmanager_issuer_ready_status gauge
+`
+
+func TestIssuerMetrics(t *testing.T) {
+	type testT struct {
+		kind string
+		iss  cmapi.GenericIssuer
+
+		expectedExpiry, expectedReady string
+	}
+	tests := map[string]testT{
+		"issuer with CA expiry and ready status": {
+			kind: cmapi.IssuerKind,
+			iss: gen.Issuer("test-issuer",
+				gen.SetIssuerCAStatus(cmapi.CAIssuerStatus{
+					NotAfter: &metav1.Time{Time: time.Unix(2208988804, 0)},
+				}),
+				gen.AddIssuerCondition(cmapi.IssuerCondition{
+					Type:   cmapi.IssuerConditionReady,
+					Status: cmmeta.ConditionTrue,
+				}),
+			),
+			expectedExpiry: `
+	certmanager_issuer_ca_expiration_timestamp_seconds{kind="Issuer",name="test-issuer",namespace="default-unit-test-ns"} 2.208988804e+09
+`,
+			expectedReady: `
+	certmanager_issuer_ready_status{condition="False",kind="Issuer",name="test-issuer",namespace="default-unit-test-ns"} 0
+	certmanager_issuer_ready_status{condition="True",kind="Issuer",name="test-issuer",namespace="default-unit-test-ns"} 1
+	certmanager_issuer_ready_status{condition="Unknown",kind="Issuer",name="test-issuer",namespace="default-unit-test-ns"} 0
+`,
+		},
+		"cluster issuer without a CA should not expose an expiry": {
+			kind: cmapi.ClusterIssuerKind,
+			iss: gen.ClusterIssuer("test-issuer",
+				gen.AddIssuerCondition(cmapi.IssuerCondition{
+					Type:   cmapi.IssuerConditionReady,
+					Status: cmmeta.ConditionFalse,
+				}),
+			),
+			expectedReady: `
+	certmanager_issuer_ready_status{condition="False",kind="ClusterIssuer",name="test-issuer",namespace=""} 1
+	certmanager_issuer_ready_status{condition="True",kind="ClusterIssuer",name="test-issuer",namespace=""} 0
+	certmanager_issuer_ready_status{condition="Unknown",kind="ClusterIssuer",name="test-issuer",namespace=""} 0
+`,
+		},
+		"issuer with no status should give an Unknown status": {
+			kind: cmapi.IssuerKind,
+			iss:  gen.Issuer("test-issuer"),
+			expectedReady: `
+	certmanager_issuer_ready_status{condition="False",kind="Issuer",name="test-issuer",namespace="default-unit-test-ns"} 0
+	certmanager_issuer_ready_status{condition="True",kind="Issuer",name="test-issuer",namespace="default-unit-test-ns"} 0
+	certmanager_issuer_ready_status{condition="Unknown",kind="Issuer",name="test-issuer",namespace="default-unit-test-ns"} 1
+`,
+		},
+	}
+	for n, test := range tests {
+		t.Run(n, func(t *testing.T) {
+			m := New(logtesting.TestLogger{T: t})
+			m.UpdateIssuer(test.kind, test.iss)
+
+			if err := testutil.CollectAndCompare(m.issuerCAExpiryTimeSeconds,
+				strings.NewReader(issuerCAExpiryMetadata+test.expectedExpiry),
+				"certmanager_issuer_ca_expiration_timestamp_seconds",
+			); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
+
+			if err := testutil.CollectAndCompare(m.issuerReadyStatus,
+				strings.NewReader(issuerReadyMetadata+test.expectedReady),
+				"certmanager_issuer_ready_status",
+			); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
+		})
+	}
+}
+
+func TestRemoveIssuer(t *testing.T) {
+	m := New(logtesting.TestLogger{T: t})
+
+	iss := gen.Issuer("test-issuer",
+		gen.SetIssuerCAStatus(cmapi.CAIssuerStatus{
+			NotAfter: &metav1.Time{Time: time.Unix(100, 0)},
+		}),
+	)
+	m.UpdateIssuer(cmapi.IssuerKind, iss)
+
+	// An expired CA being removed from the status should remove its expiry
+	iss.Status.CA = nil
+	m.UpdateIssuer(cmapi.IssuerKind, iss)
+	if err := testutil.CollectAndCompare(m.issuerCAExpiryTimeSeconds,
+		strings.NewReader(issuerCAExpiryMetadata),
+		"certmanager_issuer_ca_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.RemoveIssuer(cmapi.IssuerKind, "default-unit-test-ns", "test-issuer")
+	if err := testutil.CollectAndCompare(m.issuerReadyStatus,
+		strings.NewReader(issuerReadyMetadata),
+		"certmanager_issuer_ready_status",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
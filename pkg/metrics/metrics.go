@@ -21,6 +21,9 @@ limitations under the License.
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // controller_sync_call_count{"controller"}
+// vault_auth_request_count{"operation", "status"}
+// issuer_ready_status{name, namespace, kind, condition}
+// issuer_ca_expiration_timestamp_seconds{name, namespace, kind}
 package metrics
 
 import (
@@ -57,6 +60,9 @@ type Metrics struct {
 	acmeClientRequestDurationSeconds *prometheus.SummaryVec
 	acmeClientRequestCount           *prometheus.CounterVec
 	controllerSyncCallCount          *prometheus.CounterVec
+	vaultAuthRequestCount            *prometheus.CounterVec
+	issuerReadyStatus                *prometheus.GaugeVec
+	issuerCAExpiryTimeSeconds        *prometheus.GaugeVec
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
@@ -114,6 +120,35 @@ func New(log logr.Logger) *Metrics {
 			},
 			[]string{"controller"},
 		)
+
+		// vaultAuthRequestCount is a Prometheus counter to collect the number
+		// of login and token renewal requests made to Vault.
+		vaultAuthRequestCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "vault_auth_request_count",
+				Help:      "The number of login and token renewal requests made to Vault.",
+			},
+			[]string{"operation", "status"},
+		)
+
+		issuerReadyStatus = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "issuer_ready_status",
+				Help:      "The ready status of the Issuer or ClusterIssuer.",
+			},
+			[]string{"name", "namespace", "kind", "condition"},
+		)
+
+		issuerCAExpiryTimeSeconds = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "issuer_ca_expiration_timestamp_seconds",
+				Help:      "The date after which the CA certificate of the Issuer or ClusterIssuer expires. Expressed as a Unix Epoch Time.",
+			},
+			[]string{"name", "namespace", "kind"},
+		)
 	)
 
 	// Create server and register Prometheus metrics handler
@@ -126,6 +161,9 @@ func New(log logr.Logger) *Metrics {
 		acmeClientRequestCount:           acmeClientRequestCount,
 		acmeClientRequestDurationSeconds: acmeClientRequestDurationSeconds,
 		controllerSyncCallCount:          controllerSyncCallCount,
+		vaultAuthRequestCount:            vaultAuthRequestCount,
+		issuerReadyStatus:                issuerReadyStatus,
+		issuerCAExpiryTimeSeconds:        issuerCAExpiryTimeSeconds,
 	}
 
 	return m
@@ -138,6 +176,9 @@ func (m *Metrics) Start(listenAddress string) (*http.Server, error) {
 	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
 	m.registry.MustRegister(m.acmeClientRequestCount)
 	m.registry.MustRegister(m.controllerSyncCallCount)
+	m.registry.MustRegister(m.vaultAuthRequestCount)
+	m.registry.MustRegister(m.issuerReadyStatus)
+	m.registry.MustRegister(m.issuerCAExpiryTimeSeconds)
 
 	router := mux.NewRouter()
 	router.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
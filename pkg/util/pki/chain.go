@@ -0,0 +1,59 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"crypto/x509"
+)
+
+// OrderCAChain returns the certificates in caCerts that form the chain of
+// issuers of cert, ordered from the issuer of cert upwards. Each CA
+// certificate is used at most once and certificates that are not part of
+// the chain are omitted. An empty chain is returned if the issuer of cert is
+// not in caCerts, or if cert is self-signed.
+func OrderCAChain(cert *x509.Certificate, caCerts []*x509.Certificate) []*x509.Certificate {
+	var chain []*x509.Certificate
+	used := make(map[int]bool)
+	current := cert
+	for !IsSelfSigned(current) {
+		next := -1
+		for i, ca := range caCerts {
+			if used[i] {
+				continue
+			}
+			if bytes.Equal(current.RawIssuer, ca.RawSubject) && current.CheckSignatureFrom(ca) == nil {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			break
+		}
+		used[next] = true
+		current = caCerts[next]
+		chain = append(chain, current)
+	}
+
+	return chain
+}
+
+// IsSelfSigned returns true if cert is issued by, and its signature can be
+// verified with, its own public key.
+func IsSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func mustCreateCert(t *testing.T, commonName string, isCA bool, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestOrderCAChain(t *testing.T) {
+	root, rootKey := mustCreateCert(t, "root", true, nil, nil)
+	intermediate, intermediateKey := mustCreateCert(t, "intermediate", true, root, rootKey)
+	leaf, _ := mustCreateCert(t, "leaf", false, intermediate, intermediateKey)
+	otherRoot, otherRootKey := mustCreateCert(t, "other-root", true, nil, nil)
+	otherIntermediate, _ := mustCreateCert(t, "other-intermediate", true, otherRoot, otherRootKey)
+	// A certificate with the same subject as the intermediate, signed by a
+	// different key, must not be used as the issuer of the leaf.
+	impostor, _ := mustCreateCert(t, "intermediate", true, otherRoot, otherRootKey)
+
+	tests := map[string]struct {
+		cert     *x509.Certificate
+		caCerts  []*x509.Certificate
+		expected []*x509.Certificate
+	}{
+		"orders an unordered chain from the issuer of the certificate upwards": {
+			cert:     leaf,
+			caCerts:  []*x509.Certificate{root, intermediate},
+			expected: []*x509.Certificate{intermediate, root},
+		},
+		"omits certificates that are not part of the chain": {
+			cert:     leaf,
+			caCerts:  []*x509.Certificate{otherIntermediate, root, otherRoot, intermediate},
+			expected: []*x509.Certificate{intermediate, root},
+		},
+		"does not use a certificate with a matching subject that did not sign the certificate": {
+			cert:     leaf,
+			caCerts:  []*x509.Certificate{impostor, intermediate, root},
+			expected: []*x509.Certificate{intermediate, root},
+		},
+		"returns a partial chain if the root is missing": {
+			cert:     leaf,
+			caCerts:  []*x509.Certificate{intermediate},
+			expected: []*x509.Certificate{intermediate},
+		},
+		"returns an empty chain if the issuer is missing": {
+			cert:     leaf,
+			caCerts:  []*x509.Certificate{root, otherRoot},
+			expected: nil,
+		},
+		"returns an empty chain for a self-signed certificate": {
+			cert:     root,
+			caCerts:  []*x509.Certificate{root, intermediate},
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chain := OrderCAChain(test.cert, test.caCerts)
+			if len(chain) != len(test.expected) {
+				t.Fatalf("expected chain of %d certificates, got %d", len(test.expected), len(chain))
+			}
+			for i := range chain {
+				if chain[i] != test.expected[i] {
+					t.Errorf("expected certificate %d to be %q, got %q", i, test.expected[i].Subject.CommonName, chain[i].Subject.CommonName)
+				}
+			}
+		})
+	}
+}
+
+func TestIsSelfSigned(t *testing.T) {
+	root, rootKey := mustCreateCert(t, "root", true, nil, nil)
+	intermediate, _ := mustCreateCert(t, "intermediate", true, root, rootKey)
+	// A certificate whose issuer and subject match, but that was signed by
+	// another key.
+	sameName, _ := mustCreateCert(t, "root", true, root, rootKey)
+
+	if !IsSelfSigned(root) {
+		t.Errorf("expected root certificate to be self-signed")
+	}
+	if IsSelfSigned(intermediate) {
+		t.Errorf("expected intermediate certificate not to be self-signed")
+	}
+	if IsSelfSigned(sameName) {
+		t.Errorf("expected certificate signed by another key not to be self-signed")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/internal/api/validation"
 	acmeinstall "github.com/jetstack/cert-manager/pkg/internal/apis/acme/install"
 	cminstall "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/install"
+	cmvalidation "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/validation"
 	metainstall "github.com/jetstack/cert-manager/pkg/internal/apis/meta/install"
 )
 
@@ -50,3 +51,10 @@ func init() {
 	cminstall.InstallValidation(ValidationRegistry)
 	acmeinstall.InstallValidation(ValidationRegistry)
 }
+
+// SetPKCS11AllowedModules sets the paths to the PKCS#11 modules that CA
+// issuers validated by ValidationRegistry may use. If empty, CA issuers may
+// not use PKCS#11 tokens.
+func SetPKCS11AllowedModules(modules []string) {
+	cmvalidation.SetPKCS11AllowedModules(modules)
+}
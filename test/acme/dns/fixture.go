@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/testing_frameworks/integration"
 
 	"github.com/jetstack/cert-manager/pkg/acme/webhook"
+	"github.com/jetstack/cert-manager/pkg/acme/webhook/conformance"
 )
 
 func init() {
@@ -70,6 +71,10 @@ type fixture struct {
 	kubectl      *integration.KubeCtl
 	setupLock    sync.Mutex
 
+	// suite is the conformance suite run by the fixture.
+	// It is constructed when a Run* method is called.
+	suite *conformance.Suite
+
 	pollInterval     time.Duration
 	propagationLimit time.Duration
 }
@@ -128,6 +133,25 @@ func (f *fixture) setup(t *testing.T) func() error {
 	f.kubectl = f.controlPlane.KubeCtl()
 	f.kubectl.Path = f.binariesPath + "/kubectl"
 
+	f.suite = &conformance.Suite{
+		Solver:    f.testSolver,
+		Clientset: f.clientset,
+		ApplyManifest: func(namespace, path string) error {
+			_, _, err := f.kubectl.Run("apply", "--namespace", namespace, "-f", path)
+			return err
+		},
+		ResolvedFQDN:            f.resolvedFQDN,
+		ResolvedZone:            f.resolvedZone,
+		AllowAmbientCredentials: f.allowAmbientCredentials,
+		Config:                  f.jsonConfig,
+		Strict:                  f.strictMode,
+		UseAuthoritative:        *f.useAuthoritative,
+		ManifestPath:            f.kubectlManifestsPath,
+		DNSServer:               f.testDNSServer,
+		PollInterval:            f.pollInterval,
+		PropagationLimit:        f.propagationLimit,
+	}
+
 	stopCh := make(chan struct{})
 	f.testSolver.Initialize(f.restConfig, stopCh)
 	return func() error {
@@ -140,22 +164,26 @@ func (f *fixture) setup(t *testing.T) func() error {
 // configuration
 func (f *fixture) RunConformance(t *testing.T) {
 	defer f.setup(t)()
-	t.Run("Conformance", func(t *testing.T) {
-		f.RunBasic(t)
-		f.RunExtended(t)
-	})
+	f.suite.RunConformance(testingT{t})
 }
 
 func (f *fixture) RunBasic(t *testing.T) {
 	defer f.setup(t)()
-	t.Run("Basic", func(t *testing.T) {
-		t.Run("PresentRecord", f.TestBasicPresentRecord)
-	})
+	f.suite.RunBasic(testingT{t})
 }
 
 func (f *fixture) RunExtended(t *testing.T) {
 	defer f.setup(t)()
-	t.Run("Extended", func(t *testing.T) {
-		t.Run("DeletingOneRecordRetainsOthers", f.TestExtendedDeletingOneRecordRetainsOthers)
+	f.suite.RunExtended(testingT{t})
+}
+
+// testingT adapts a *testing.T to the conformance.T interface.
+type testingT struct {
+	*testing.T
+}
+
+func (t testingT) Run(name string, fn func(conformance.T)) bool {
+	return t.T.Run(name, func(t *testing.T) {
+		fn(testingT{t})
 	})
 }
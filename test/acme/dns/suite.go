@@ -18,8 +18,6 @@ package dns
 
 import (
 	"testing"
-
-	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 // TestBasicPresentRecord will perform a basic validation that the Present
@@ -30,38 +28,7 @@ import (
 // If either Present or CleanUp fail to properly present and clean up the
 // challenge record, this test case will fail.
 func (f *fixture) TestBasicPresentRecord(t *testing.T) {
-	ns, cleanup := f.setupNamespace(t, "basic-present-record")
-	defer cleanup()
-	ch := f.buildChallengeRequest(t, ns)
-
-	t.Logf("Calling Present with ChallengeRequest: %#v", ch)
-	// present the record
-	if err := f.testSolver.Present(ch); err != nil {
-		t.Errorf("expected Present to not error, but got: %v", err)
-		return
-	}
-	defer f.testSolver.CleanUp(ch)
-
-	// wait until the record has propagated
-	if err := wait.PollUntil(f.getPollInterval(),
-		f.recordHasPropagatedCheck(ch.ResolvedFQDN, ch.Key),
-		closingStopCh(f.getPropagationLimit())); err != nil {
-		t.Errorf("error waiting for DNS record propagation: %v", err)
-		return
-	}
-
-	// clean up the presented record
-	if err := f.testSolver.CleanUp(ch); err != nil {
-		t.Errorf("expected CleanUp to not error, but got: %v", err)
-	}
-
-	// wait until the record has been deleted
-	if err := wait.PollUntil(f.getPollInterval(),
-		f.recordHasBeenDeletedCheck(ch.ResolvedFQDN, ch.Key),
-		closingStopCh(f.getPropagationLimit())); err != nil {
-		t.Errorf("error waiting for record to be deleted: %v", err)
-		return
-	}
+	f.suite.TestBasicPresentRecord(testingT{t})
 }
 
 // TestExtendedSupportsMultipleSameDomain validates that a DNS01 provider
@@ -69,54 +36,5 @@ func (f *fixture) TestBasicPresentRecord(t *testing.T) {
 // Adding a new record **must not** delete existing records with the same
 // record name from the DNS zone.
 func (f *fixture) TestExtendedDeletingOneRecordRetainsOthers(t *testing.T) {
-	if !f.strictMode {
-		t.Skip("skipping test as strict mode is disabled, see: https://github.com/jetstack/cert-manager/pull/1354")
-	}
-
-	ns, cleanup := f.setupNamespace(t, "extended-supports-multiple-same-domain")
-	defer cleanup()
-	ch := f.buildChallengeRequest(t, ns)
-	ch2 := f.buildChallengeRequest(t, ns)
-	ch2.Key = "anothertestingkey"
-
-	// present the first record
-	if err := f.testSolver.Present(ch); err != nil {
-		t.Errorf("expected Present to not error, but got: %v", err)
-		return
-	}
-	defer f.testSolver.CleanUp(ch)
-
-	// present the second record
-	if err := f.testSolver.Present(ch2); err != nil {
-		t.Errorf("expected Present to not error, but got: %v", err)
-		return
-	}
-	defer f.testSolver.CleanUp(ch2)
-
-	// wait until all records have propagated
-	if err := wait.PollUntil(f.getPollInterval(),
-		allConditions(
-			f.recordHasPropagatedCheck(ch.ResolvedFQDN, ch.Key),
-			f.recordHasPropagatedCheck(ch2.ResolvedFQDN, ch2.Key),
-		),
-		closingStopCh(f.getPropagationLimit())); err != nil {
-		t.Errorf("error waiting for DNS record propagation: %v", err)
-		return
-	}
-
-	// clean up the second record
-	if err := f.testSolver.CleanUp(ch2); err != nil {
-		t.Errorf("expected CleanUp to not error, but got: %v", err)
-	}
-
-	// wait until the second record has been deleted and the first one remains
-	if err := wait.PollUntil(f.getPollInterval(),
-		allConditions(
-			f.recordHasBeenDeletedCheck(ch2.ResolvedFQDN, ch2.Key),
-			f.recordHasPropagatedCheck(ch.ResolvedFQDN, ch.Key),
-		),
-		closingStopCh(f.getPropagationLimit())); err != nil {
-		t.Errorf("error waiting for DNS record propagation: %v", err)
-		return
-	}
+	f.suite.TestExtendedDeletingOneRecordRetainsOthers(testingT{t})
 }
@@ -31,13 +31,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type injectableTest struct {
-	makeInjectable func(namePrefix string) runtime.Object
-	getCAs         func(runtime.Object) [][]byte
+	makeInjectable func(namePrefix string) client.Object
+	getCAs         func(client.Object) [][]byte
 	subject        string
 	disabled       string
 }
@@ -50,7 +50,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 
 	injectorContext := func(subj string, test *injectableTest) {
 		Context("for "+subj+"s", func() {
-			var toCleanup runtime.Object
+			var toCleanup client.Object
 
 			BeforeEach(func() {
 				By("creating a self-signing issuer")
@@ -74,7 +74,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 				}
 				Expect(f.CRClient.Delete(context.Background(), toCleanup)).To(Succeed())
 			})
-			generalSetup := func(injectable runtime.Object) (runtime.Object, certmanager.Certificate, corev1.Secret) {
+			generalSetup := func(injectable client.Object) (client.Object, certmanager.Certificate, corev1.Secret) {
 				By("creating a " + subj + " pointing to a cert")
 				Expect(f.CRClient.Create(context.Background(), injectable)).To(Succeed())
 				toCleanup = injectable
@@ -103,7 +103,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 					expectedCAs[i] = caData
 				}
 				Eventually(func() ([][]byte, error) {
-					newInjectable := injectable.DeepCopyObject()
+					newInjectable := injectable.DeepCopyObject().(client.Object)
 					if err := f.CRClient.Get(context.Background(), types.NamespacedName{Name: injectable.(metav1.Object).GetName()}, newInjectable); err != nil {
 						return nil, err
 					}
@@ -135,7 +135,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 				By("expecting the CA data to remain in place")
 				expectedCAs := test.getCAs(injectable)
 				Consistently(func() ([][]byte, error) {
-					newInjectable := injectable.DeepCopyObject()
+					newInjectable := injectable.DeepCopyObject().(client.Object)
 					if err := f.CRClient.Get(context.Background(), types.NamespacedName{Name: injectable.(metav1.Object).GetName()}, newInjectable); err != nil {
 						return nil, err
 					}
@@ -169,7 +169,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 					expectedCAs[i] = caData
 				}
 				Eventually(func() ([][]byte, error) {
-					newInjectable := injectable.DeepCopyObject()
+					newInjectable := injectable.DeepCopyObject().(client.Object)
 					if err := f.CRClient.Get(context.Background(), types.NamespacedName{Name: injectable.(metav1.Object).GetName()}, newInjectable); err != nil {
 						return nil, err
 					}
@@ -192,7 +192,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 				By("expecting the CA data to remain in place")
 				expectedCAs := test.getCAs(injectable)
 				Consistently(func() ([][]byte, error) {
-					newInjectable := injectable.DeepCopyObject()
+					newInjectable := injectable.DeepCopyObject().(client.Object)
 					if err := f.CRClient.Get(context.Background(), types.NamespacedName{Name: injectable.(metav1.Object).GetName()}, newInjectable); err != nil {
 						return nil, err
 					}
@@ -223,7 +223,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 					expectedCAs[i] = caData
 				}
 				Eventually(func() ([][]byte, error) {
-					newInjectable := injectable.DeepCopyObject()
+					newInjectable := injectable.DeepCopyObject().(client.Object)
 					if err := f.CRClient.Get(context.Background(), types.NamespacedName{Name: injectable.(metav1.Object).GetName()}, newInjectable); err != nil {
 						return nil, err
 					}
@@ -294,7 +294,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 					expectedCAs[i] = nil
 				}
 				Consistently(func() ([][]byte, error) {
-					newInjectable := injectable.DeepCopyObject()
+					newInjectable := injectable.DeepCopyObject().(client.Object)
 					if err := f.CRClient.Get(context.Background(), types.NamespacedName{Name: injectable.(metav1.Object).GetName()}, newInjectable); err != nil {
 						return nil, err
 					}
@@ -305,7 +305,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 	}
 
 	injectorContext("validating webhook", &injectableTest{
-		makeInjectable: func(namePrefix string) runtime.Object {
+		makeInjectable: func(namePrefix string) client.Object {
 			someURL := "https://localhost:8675"
 			return &admissionreg.ValidatingWebhookConfiguration{
 				ObjectMeta: metav1.ObjectMeta{
@@ -333,7 +333,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 				},
 			}
 		},
-		getCAs: func(obj runtime.Object) [][]byte {
+		getCAs: func(obj client.Object) [][]byte {
 			hook := obj.(*admissionreg.ValidatingWebhookConfiguration)
 			res := make([][]byte, len(hook.Webhooks))
 			for i, webhook := range hook.Webhooks {
@@ -344,7 +344,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 	})
 
 	injectorContext("mutating webhook", &injectableTest{
-		makeInjectable: func(namePrefix string) runtime.Object {
+		makeInjectable: func(namePrefix string) client.Object {
 			someURL := "https://localhost:8675"
 			return &admissionreg.MutatingWebhookConfiguration{
 				ObjectMeta: metav1.ObjectMeta{
@@ -372,7 +372,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 				},
 			}
 		},
-		getCAs: func(obj runtime.Object) [][]byte {
+		getCAs: func(obj client.Object) [][]byte {
 			hook := obj.(*admissionreg.MutatingWebhookConfiguration)
 			res := make([][]byte, len(hook.Webhooks))
 			for i, webhook := range hook.Webhooks {
@@ -385,7 +385,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 	// TODO(directxman12): enable ConversionWebhook feature on the test infra,
 	// re-enable this.
 	injectorContext("conversion webhook", &injectableTest{
-		makeInjectable: func(namePrefix string) runtime.Object {
+		makeInjectable: func(namePrefix string) client.Object {
 			someURL := "https://localhost:8675"
 			return &apiext.CustomResourceDefinition{
 				ObjectMeta: metav1.ObjectMeta{
@@ -410,7 +410,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 				},
 			}
 		},
-		getCAs: func(obj runtime.Object) [][]byte {
+		getCAs: func(obj client.Object) [][]byte {
 			crd := obj.(*apiext.CustomResourceDefinition)
 			if crd.Spec.Conversion == nil || crd.Spec.Conversion.WebhookClientConfig == nil {
 				return nil
@@ -428,7 +428,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 	// https://github.com/jetstack/cert-manager/issues/2353
 	// https://github.com/jetstack/cert-manager/issues/2354
 	//injectorContext("api service", &injectableTest{
-	//	makeInjectable: func(namePrefix string) runtime.Object {
+	//	makeInjectable: func(namePrefix string) client.Object {
 	//		return &apireg.APIService{
 	//			ObjectMeta: metav1.ObjectMeta{
 	//				Name: "v1." + namePrefix + ".testing.cert-manager.io",
@@ -448,7 +448,7 @@ var _ = framework.CertManagerDescribe("CA Injector", func() {
 	//			},
 	//		}
 	//	},
-	//	getCAs: func(obj runtime.Object) [][]byte {
+	//	getCAs: func(obj client.Object) [][]byte {
 	//		apiSvc := obj.(*apireg.APIService)
 	//		return [][]byte{apiSvc.Spec.CABundle}
 	//	},
@@ -21,7 +21,6 @@ import (
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/jetstack/cert-manager/pkg/api"
@@ -33,8 +32,8 @@ import (
 
 func TestConversion(t *testing.T) {
 	tests := map[string]struct {
-		input  runtime.Object
-		output runtime.Object
+		input  client.Object
+		output client.Object
 	}{
 		"should convert Certificates from v1alpha2 to v1alpha3": {
 			input: &v1alpha2.Certificate{
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer/versioning"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 
 	webhooktesting "github.com/jetstack/cert-manager/cmd/webhook/app/testing"
@@ -163,11 +164,11 @@ func readCRDsAtPath(codec runtime.Codec, converter runtime.ObjectConvertor, path
 	return crds, nil
 }
 
-func crdsToRuntimeObjects(in []*v1beta1.CustomResourceDefinition) []runtime.Object {
-	out := make([]runtime.Object, len(in))
+func crdsToRuntimeObjects(in []*v1beta1.CustomResourceDefinition) []client.Object {
+	out := make([]client.Object, len(in))
 
 	for i, crd := range in {
-		out[i] = runtime.Object(crd)
+		out[i] = client.Object(crd)
 	}
 
 	return out
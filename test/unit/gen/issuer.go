@@ -89,8 +89,26 @@ func SetIssuerVenafi(a v1alpha2.VenafiIssuer) IssuerModifier {
 	}
 }
 
+func SetIssuerEST(a v1alpha2.ESTIssuer) IssuerModifier {
+	return func(iss v1alpha2.GenericIssuer) {
+		iss.GetSpec().EST = &a
+	}
+}
+
+func SetIssuerKubernetesCSR(a v1alpha2.KubernetesCSRIssuer) IssuerModifier {
+	return func(iss v1alpha2.GenericIssuer) {
+		iss.GetSpec().KubernetesCSR = &a
+	}
+}
+
 func AddIssuerCondition(c v1alpha2.IssuerCondition) IssuerModifier {
 	return func(iss v1alpha2.GenericIssuer) {
 		iss.GetStatus().Conditions = append(iss.GetStatus().Conditions, c)
 	}
 }
+
+func SetIssuerCAStatus(s v1alpha2.CAIssuerStatus) IssuerModifier {
+	return func(iss v1alpha2.GenericIssuer) {
+		iss.GetStatus().CA = &s
+	}
+}
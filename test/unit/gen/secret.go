@@ -48,3 +48,9 @@ func SetSecretAnnotations(an map[string]string) SecretModifier {
 		}
 	}
 }
+
+func SetSecretData(data map[string][]byte) SecretModifier {
+	return func(sec *corev1.Secret) {
+		sec.Data = data
+	}
+}